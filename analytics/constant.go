@@ -43,9 +43,11 @@ const (
 	PruningStrategySelected        Event = "pruning_strategy_selected"
 	ExistingGenesisReplaceSelected Event = "existing_genesis_replace_selected"
 	SyncMethodSelected             Event = "sync_method_selected"
+	SnapshotProviderSelected       Event = "snapshot_provider_selected"
 	CosmovisorAutoUpgradeSelected  Event = "cosmovisor_auto_upgrade_selected"
 	ExistingDataReplaceSelected    Event = "existing_data_replace_selected"
 	FeaturesEnabled                Event = "feature_enabled"
+	NodeRoleSelected               Event = "node_role_selected"
 
 	// Rollup Event
 	VmTypeSelected                        Event = "vm_type_selected"
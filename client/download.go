@@ -0,0 +1,630 @@
+package client
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	PartialFileSuffix    = ".partial"
+	PartialStateSuffix   = ".partial.json"
+	ChecksumFileSuffix   = ".sha256"
+	minParallelChunkSize = 8 << 20
+
+	// stateSaveInterval is how many bytes a segment downloads between saves of the partial state
+	stateSaveInterval = 64 << 20
+)
+
+// DownloadOptions tunes DownloadFileWithOptions. The zero value downloads over a single connection without limits.
+type DownloadOptions struct {
+	// Connections is the number of parallel range requests. Values below 2 download sequentially.
+	Connections int
+	// BandwidthLimit caps the combined download speed in bytes per second. Zero means unlimited.
+	BandwidthLimit int64
+	// SHA256 is the expected hex-encoded checksum of the file
+	SHA256 string
+	// FetchPublishedChecksum looks up <url>.sha256 when SHA256 is empty and verifies the file against it if published
+	FetchPublishedChecksum bool
+	// Resumed receives the number of bytes that were already downloaded by a previous attempt
+	Resumed *int64
+}
+
+// DownloadFileWithOptions downloads url to dest through a dest.partial file that survives failures.
+// A later call resumes the partial file with HTTP Range requests instead of starting over.
+func (c *HTTPClient) DownloadFileWithOptions(url, dest string, progress, totalSize *int64, opts DownloadOptions) error {
+	if opts.SHA256 == "" && opts.FetchPublishedChecksum {
+		checksum, err := c.FetchPublishedChecksum(url)
+		if err != nil {
+			return err
+		}
+		opts.SHA256 = checksum
+	}
+
+	ctx, cancel := c.requestContext()
+	defer cancel()
+	_, policy := currentNetwork()
+	d := &download{
+		ctx:      ctx,
+		policy:   policy,
+		url:      url,
+		partial:  dest + PartialFileSuffix,
+		state:    dest + PartialStateSuffix,
+		progress: progress,
+		limiter:  newRateLimiter(opts.BandwidthLimit),
+	}
+
+	// A partial file is only resumed for the URL it was started from
+	state := d.loadPartialState()
+	if state == nil || state.URL != url {
+		d.reset()
+		state = nil
+	}
+
+	var err error
+	if opts.Connections > 1 || state != nil {
+		err = d.parallel(opts.Connections, state, totalSize, opts.Resumed)
+	} else {
+		err = d.sequential(totalSize, opts.Resumed)
+	}
+	if err != nil {
+		return err
+	}
+
+	if opts.SHA256 != "" {
+		if err := verifySHA256(d.partial, opts.SHA256); err != nil {
+			// A corrupted partial file must not be resumed again
+			d.reset()
+			return err
+		}
+	}
+
+	if err := os.Rename(d.partial, dest); err != nil {
+		return fmt.Errorf("failed to move downloaded file into place: %w", err)
+	}
+	_ = os.Remove(d.state)
+	return nil
+}
+
+// FetchPublishedChecksum returns the SHA-256 published next to url as <url>.sha256, or an empty string if there is none
+func (c *HTTPClient) FetchPublishedChecksum(url string) (string, error) {
+	var body []byte
+	if path, ok := strings.CutPrefix(url, LocalFileURLPrefix); ok {
+		content, err := os.ReadFile(path + ChecksumFileSuffix)
+		if os.IsNotExist(err) {
+			return "", nil
+		}
+		if err != nil {
+			return "", fmt.Errorf("failed to read checksum: %w", err)
+		}
+		body = content
+	} else {
+		ctx, cancel := c.requestContext()
+		defer cancel()
+		resp, err := get(ctx, url+ChecksumFileSuffix)
+		if err != nil {
+			return "", fmt.Errorf("failed to connect to URL: %w", err)
+		}
+		defer resp.Body.Close()
+
+		switch resp.StatusCode {
+		case http.StatusOK:
+		case http.StatusNotFound:
+			return "", nil
+		default:
+			return "", fmt.Errorf("failed to fetch checksum: received status code %d", resp.StatusCode)
+		}
+
+		body, err = io.ReadAll(io.LimitReader(resp.Body, 4096))
+		if err != nil {
+			return "", fmt.Errorf("failed to read checksum: %w", err)
+		}
+	}
+
+	// Accept both a bare checksum and the `sha256sum` output format
+	fields := strings.Fields(string(body))
+	if len(fields) == 0 {
+		return "", nil
+	}
+	checksum := strings.ToLower(fields[0])
+	if decoded, err := hex.DecodeString(checksum); err != nil || len(decoded) != sha256.Size {
+		return "", fmt.Errorf("invalid checksum published at %s%s", url, ChecksumFileSuffix)
+	}
+	return checksum, nil
+}
+
+func verifySHA256(path, expected string) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("failed to open downloaded file: %w", err)
+	}
+	defer file.Close()
+
+	hash := sha256.New()
+	if _, err := io.Copy(hash, file); err != nil {
+		return fmt.Errorf("failed to hash downloaded file: %w", err)
+	}
+
+	return matchSHA256(hash.Sum(nil), expected)
+}
+
+func matchSHA256(sum []byte, expected string) error {
+	actual := hex.EncodeToString(sum)
+	if !strings.EqualFold(actual, strings.TrimSpace(expected)) {
+		return fmt.Errorf("checksum mismatch: expected %s, got %s", expected, actual)
+	}
+	return nil
+}
+
+type download struct {
+	ctx      context.Context
+	policy   RetryPolicy
+	url      string
+	partial  string
+	state    string
+	progress *int64
+	limiter  *rateLimiter
+
+	downloaded int64
+}
+
+func (d *download) addProgress(n int64) {
+	downloaded := atomic.AddInt64(&d.downloaded, n)
+	if d.progress != nil {
+		atomic.StoreInt64(d.progress, downloaded)
+	}
+}
+
+// sequential downloads over a single connection, resuming from the end of the partial file
+func (d *download) sequential(totalSize, resumed *int64) error {
+	var offset int64
+	if info, err := os.Stat(d.partial); err == nil {
+		offset = info.Size()
+	}
+	if resumed != nil {
+		atomic.StoreInt64(resumed, offset)
+	}
+
+	var file *os.File
+	defer func() {
+		if file != nil {
+			file.Close()
+		}
+	}()
+
+	retrying := false
+	for attempt := 1; ; attempt++ {
+		resp, err := d.get(offset, -1)
+		if err != nil {
+			// Only a download that was interrupted midway keeps trying to reconnect
+			if !retrying || attempt >= d.policy.MaxAttempts {
+				return err
+			}
+			if err := d.wait(attempt); err != nil {
+				return err
+			}
+			continue
+		}
+
+		rangeNotSatisfiable := resp.StatusCode == http.StatusRequestedRangeNotSatisfiable && offset > 0
+		if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusPartialContent && !rangeNotSatisfiable {
+			resp.Body.Close()
+			return fmt.Errorf("failed to download: received status code %d", resp.StatusCode)
+		}
+
+		// The file is only opened once the server answered, so a failed request leaves nothing behind
+		if file == nil {
+			if err := d.savePartialState(&partialState{URL: d.url}); err != nil {
+				resp.Body.Close()
+				return err
+			}
+			file, err = os.OpenFile(d.partial, os.O_CREATE|os.O_WRONLY, 0600)
+			if err != nil {
+				resp.Body.Close()
+				return fmt.Errorf("failed to create destination file: %w", err)
+			}
+		}
+
+		switch {
+		case rangeNotSatisfiable:
+			// The partial file is as long as or longer than the remote file, so it cannot be resumed
+			resp.Body.Close()
+			if err := file.Truncate(0); err != nil {
+				return fmt.Errorf("failed to reset destination file: %w", err)
+			}
+			offset = 0
+			if resumed != nil {
+				atomic.StoreInt64(resumed, 0)
+			}
+			continue
+		case resp.StatusCode == http.StatusOK && offset > 0:
+			// The server ignored the Range header, so start over
+			offset = 0
+			if resumed != nil {
+				atomic.StoreInt64(resumed, 0)
+			}
+			if err := file.Truncate(0); err != nil {
+				resp.Body.Close()
+				return fmt.Errorf("failed to reset destination file: %w", err)
+			}
+		}
+
+		if totalSize != nil {
+			size := offset + resp.ContentLength
+			if resp.ContentLength <= 0 {
+				size = 1
+			}
+			atomic.StoreInt64(totalSize, size)
+		}
+		atomic.StoreInt64(&d.downloaded, offset)
+		d.addProgress(0)
+
+		if _, err := file.Seek(offset, io.SeekStart); err != nil {
+			resp.Body.Close()
+			return fmt.Errorf("failed to seek destination file: %w", err)
+		}
+		written, err := d.copy(file, resp.Body)
+		resp.Body.Close()
+		offset += written
+		if err == nil {
+			return nil
+		}
+
+		// Stream failures are resumed from where they stopped; an attempt that made progress earns a fresh retry budget
+		retrying = true
+		if written > 0 {
+			attempt = 0
+		}
+		if attempt >= d.policy.MaxAttempts {
+			return fmt.Errorf("error during file download: %w", err)
+		}
+		if err := d.wait(attempt + 1); err != nil {
+			return err
+		}
+	}
+}
+
+// wait backs off after the given failed attempt, returning early when the download is cancelled
+func (d *download) wait(attempt int) error {
+	return sleepContext(d.ctx, d.policy.backoff(attempt, 0))
+}
+
+// copy streams body into w while honoring the bandwidth limit and updating the progress
+func (d *download) copy(w io.Writer, body io.Reader) (int64, error) {
+	buffer := make([]byte, downloadBufferSize)
+	var written int64
+	for {
+		n, err := body.Read(buffer)
+		if n > 0 {
+			d.limiter.wait(n)
+			if _, err := w.Write(buffer[:n]); err != nil {
+				return written, fmt.Errorf("failed to write to file: %w", err)
+			}
+			written += int64(n)
+			d.addProgress(int64(n))
+		}
+		if err == io.EOF {
+			return written, nil
+		}
+		if err != nil {
+			return written, err
+		}
+	}
+}
+
+// get requests the bytes from start to end inclusive. An end below zero requests everything after start.
+func (d *download) get(start, end int64) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(d.ctx, http.MethodGet, d.url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	if start > 0 || end >= 0 {
+		if end >= 0 {
+			req.Header.Set("Range", fmt.Sprintf("bytes=%d-%d", start, end))
+		} else {
+			req.Header.Set("Range", fmt.Sprintf("bytes=%d-", start))
+		}
+	}
+
+	resp, err := send(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to URL: %w", err)
+	}
+	return resp, nil
+}
+
+// rangeSegment is a part of the file fetched by its own connection. Next is the first byte not yet written.
+type rangeSegment struct {
+	Start int64 `json:"start"`
+	End   int64 `json:"end"`
+	Next  int64 `json:"next"`
+}
+
+// partialState is persisted next to the partial file to tell which URL it belongs to.
+// Parallel downloads also record their segments so that each one can be resumed.
+type partialState struct {
+	URL      string          `json:"url"`
+	Size     int64           `json:"size,omitempty"`
+	Segments []*rangeSegment `json:"segments,omitempty"`
+}
+
+// probeSize asks for the first byte to learn whether the server supports ranges and how large the file is
+func (d *download) probeSize() (int64, bool, error) {
+	resp, err := d.get(0, 0)
+	if err != nil {
+		return 0, false, err
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusPartialContent:
+		contentRange := resp.Header.Get("Content-Range")
+		slash := strings.LastIndex(contentRange, "/")
+		if slash < 0 {
+			return 0, false, nil
+		}
+		size, err := strconv.ParseInt(contentRange[slash+1:], 10, 64)
+		if err != nil {
+			return 0, false, nil
+		}
+		return size, true, nil
+	case http.StatusOK:
+		return resp.ContentLength, false, nil
+	default:
+		return 0, false, fmt.Errorf("failed to download: received status code %d", resp.StatusCode)
+	}
+}
+
+// parallel downloads the file in segments over several connections, falling back to a
+// sequential download when the server does not support ranges or the file is small
+func (d *download) parallel(connections int, state *partialState, totalSize, resumed *int64) error {
+	if state != nil && len(state.Segments) == 0 {
+		// Finish a download that was started over a single connection the same way
+		return d.sequential(totalSize, resumed)
+	}
+
+	size, supportsRanges, err := d.probeSize()
+	if err != nil {
+		return err
+	}
+
+	if state != nil && (state.Size != size || fileSize(d.partial) != size) {
+		// The remote file changed, so the partial file has holes that cannot be resumed
+		d.reset()
+		state = nil
+	}
+	if !supportsRanges || (state == nil && (connections < 2 || size < int64(connections)*minParallelChunkSize)) {
+		// Segments of an earlier parallel run cannot be continued sequentially either
+		if state != nil {
+			d.reset()
+		}
+		return d.sequential(totalSize, resumed)
+	}
+
+	file, err := os.OpenFile(d.partial, os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return fmt.Errorf("failed to create destination file: %w", err)
+	}
+	defer file.Close()
+
+	if state == nil {
+		state = newPartialState(d.url, size, connections)
+		if err := file.Truncate(size); err != nil {
+			return fmt.Errorf("failed to allocate destination file: %w", err)
+		}
+	}
+
+	var done int64
+	for _, segment := range state.Segments {
+		done += segment.Next - segment.Start
+	}
+	if resumed != nil {
+		atomic.StoreInt64(resumed, done)
+	}
+	if totalSize != nil {
+		atomic.StoreInt64(totalSize, size)
+	}
+	atomic.StoreInt64(&d.downloaded, done)
+	d.addProgress(0)
+
+	var mu sync.Mutex
+	save := func() error {
+		mu.Lock()
+		defer mu.Unlock()
+		return d.savePartialState(state)
+	}
+	if err := save(); err != nil {
+		return err
+	}
+
+	var wg sync.WaitGroup
+	errs := make([]error, len(state.Segments))
+	for i, segment := range state.Segments {
+		wg.Add(1)
+		go func(i int, segment *rangeSegment) {
+			defer wg.Done()
+			errs[i] = d.fetchSegment(file, segment, &mu, save)
+		}(i, segment)
+	}
+	wg.Wait()
+
+	if err := save(); err != nil {
+		return err
+	}
+	return errors.Join(errs...)
+}
+
+func (d *download) fetchSegment(file *os.File, segment *rangeSegment, mu *sync.Mutex, save func() error) error {
+	for attempt := 1; ; attempt++ {
+		mu.Lock()
+		next := segment.Next
+		mu.Unlock()
+		if next > segment.End {
+			return nil
+		}
+
+		resp, err := d.get(next, segment.End)
+		if err != nil {
+			if attempt >= d.policy.MaxAttempts {
+				return err
+			}
+			if err := d.wait(attempt); err != nil {
+				return err
+			}
+			continue
+		}
+		if resp.StatusCode != http.StatusPartialContent {
+			resp.Body.Close()
+			return fmt.Errorf("failed to download range %d-%d: received status code %d", next, segment.End, resp.StatusCode)
+		}
+
+		writer := &segmentWriter{file: file, segment: segment, mu: mu, save: save}
+		written, err := d.copy(writer, resp.Body)
+		resp.Body.Close()
+		if err == nil {
+			mu.Lock()
+			complete := segment.Next > segment.End
+			mu.Unlock()
+			if complete {
+				return nil
+			}
+			err = fmt.Errorf("connection closed before the range was complete")
+		}
+
+		if written > 0 {
+			attempt = 0
+		}
+		if attempt >= d.policy.MaxAttempts {
+			return fmt.Errorf("error during file download: %w", err)
+		}
+		if err := d.wait(attempt + 1); err != nil {
+			return err
+		}
+	}
+}
+
+// segmentWriter writes a segment at its own offset and periodically records how far it got
+type segmentWriter struct {
+	file      *os.File
+	segment   *rangeSegment
+	mu        *sync.Mutex
+	save      func() error
+	sinceSave int64
+}
+
+func (w *segmentWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	next := w.segment.Next
+	w.mu.Unlock()
+
+	if remaining := w.segment.End - next + 1; int64(len(p)) > remaining {
+		p = p[:remaining]
+	}
+	n, err := w.file.WriteAt(p, next)
+
+	w.mu.Lock()
+	w.segment.Next += int64(n)
+	w.mu.Unlock()
+	if err != nil {
+		return n, err
+	}
+
+	w.sinceSave += int64(n)
+	if w.sinceSave >= stateSaveInterval {
+		w.sinceSave = 0
+		if err := w.save(); err != nil {
+			return n, err
+		}
+	}
+	return n, nil
+}
+
+func newPartialState(url string, size int64, connections int) *partialState {
+	state := &partialState{URL: url, Size: size}
+	chunk := size / int64(connections)
+	for i := 0; i < connections; i++ {
+		start := int64(i) * chunk
+		end := start + chunk - 1
+		if i == connections-1 {
+			end = size - 1
+		}
+		state.Segments = append(state.Segments, &rangeSegment{Start: start, End: end, Next: start})
+	}
+	return state
+}
+
+func (d *download) loadPartialState() *partialState {
+	content, err := os.ReadFile(d.state)
+	if err != nil {
+		return nil
+	}
+
+	var state partialState
+	if err := json.Unmarshal(content, &state); err != nil {
+		return nil
+	}
+	return &state
+}
+
+func (d *download) savePartialState(state *partialState) error {
+	content, err := json.Marshal(state)
+	if err != nil {
+		return fmt.Errorf("failed to marshal download state: %w", err)
+	}
+	if err := os.WriteFile(d.state, content, 0600); err != nil {
+		return fmt.Errorf("failed to save download state: %w", err)
+	}
+	return nil
+}
+
+// reset discards the partial file together with its state
+func (d *download) reset() {
+	_ = os.Remove(d.state)
+	_ = os.Remove(d.partial)
+}
+
+func fileSize(path string) int64 {
+	info, err := os.Stat(path)
+	if err != nil {
+		return -1
+	}
+	return info.Size()
+}
+
+// rateLimiter keeps the combined throughput of all connections under a bytes per second limit
+type rateLimiter struct {
+	limit   int64
+	mu      sync.Mutex
+	started time.Time
+	total   int64
+}
+
+func newRateLimiter(limit int64) *rateLimiter {
+	return &rateLimiter{limit: limit, started: time.Now()}
+}
+
+func (l *rateLimiter) wait(n int) {
+	if l.limit <= 0 {
+		return
+	}
+
+	l.mu.Lock()
+	l.total += int64(n)
+	due := l.started.Add(time.Duration(float64(l.total) / float64(l.limit) * float64(time.Second)))
+	l.mu.Unlock()
+
+	if delay := time.Until(due); delay > 0 {
+		time.Sleep(delay)
+	}
+}
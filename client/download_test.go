@@ -0,0 +1,239 @@
+package client
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func testContent(size int) []byte {
+	return bytes.Repeat([]byte("0123456789abcdef"), size/16)
+}
+
+// newRangeServer serves content with Range support and counts the requests that asked for a range
+func newRangeServer(content []byte, rangeRequests *int64) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Range") != "" && rangeRequests != nil {
+			atomic.AddInt64(rangeRequests, 1)
+		}
+		http.ServeContent(w, r, "snapshot", time.Time{}, bytes.NewReader(content))
+	}))
+}
+
+func TestDownloadFileWithOptions_ResumesPartialFile(t *testing.T) {
+	content := testContent(1 << 16)
+	var rangeRequests int64
+	server := newRangeServer(content, &rangeRequests)
+	defer server.Close()
+
+	dest := filepath.Join(t.TempDir(), "snapshot")
+	writePartial(t, dest, server.URL, content[:1000])
+
+	var progress, total, resumed int64
+	err := NewHTTPClient().DownloadFileWithOptions(server.URL, dest, &progress, &total, DownloadOptions{Resumed: &resumed})
+	assert.NoError(t, err)
+
+	downloaded, err := os.ReadFile(dest)
+	assert.NoError(t, err)
+	assert.Equal(t, content, downloaded)
+	assert.Equal(t, int64(1000), resumed)
+	assert.Equal(t, int64(len(content)), total)
+	assert.Equal(t, int64(len(content)), progress)
+	assert.Equal(t, int64(1), rangeRequests)
+	assert.NoFileExists(t, dest+PartialFileSuffix)
+	assert.NoFileExists(t, dest+PartialStateSuffix)
+}
+
+func TestDownloadFileWithOptions_DiscardsPartialOfOtherURL(t *testing.T) {
+	content := testContent(1 << 16)
+	var rangeRequests int64
+	server := newRangeServer(content, &rangeRequests)
+	defer server.Close()
+
+	dest := filepath.Join(t.TempDir(), "snapshot")
+	writePartial(t, dest, "https://example.com/other-snapshot", []byte("unrelated bytes"))
+
+	var resumed int64
+	err := NewHTTPClient().DownloadFileWithOptions(server.URL, dest, nil, nil, DownloadOptions{Resumed: &resumed})
+	assert.NoError(t, err)
+
+	downloaded, err := os.ReadFile(dest)
+	assert.NoError(t, err)
+	assert.Equal(t, content, downloaded)
+	assert.Equal(t, int64(0), resumed)
+	assert.Equal(t, int64(0), rangeRequests)
+}
+
+func writePartial(t *testing.T, dest, url string, content []byte) {
+	state, err := json.Marshal(partialState{URL: url})
+	assert.NoError(t, err)
+	assert.NoError(t, os.WriteFile(dest+PartialStateSuffix, state, 0644))
+	assert.NoError(t, os.WriteFile(dest+PartialFileSuffix, content, 0644))
+}
+
+func TestDownloadFileWithOptions_ResumesInterruptedStream(t *testing.T) {
+	content := testContent(1 << 16)
+	var requests int64
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt64(&requests, 1) == 1 {
+			// Drop the connection halfway through the first response
+			w.Header().Set("Content-Length", "65536")
+			_, _ = w.Write(content[:len(content)/2])
+			w.(http.Flusher).Flush()
+			panic(http.ErrAbortHandler)
+		}
+		assert.Equal(t, "bytes=32768-", r.Header.Get("Range"))
+		http.ServeContent(w, r, "snapshot", time.Time{}, bytes.NewReader(content))
+	}))
+	defer server.Close()
+
+	dest := filepath.Join(t.TempDir(), "snapshot")
+	assert.NoError(t, NewHTTPClient().DownloadFile(server.URL, dest, nil, nil))
+
+	downloaded, err := os.ReadFile(dest)
+	assert.NoError(t, err)
+	assert.Equal(t, content, downloaded)
+	assert.Equal(t, int64(2), requests)
+}
+
+func TestDownloadFileWithOptions_Parallel(t *testing.T) {
+	content := testContent(2 * minParallelChunkSize)
+	var rangeRequests int64
+	server := newRangeServer(content, &rangeRequests)
+	defer server.Close()
+
+	dest := filepath.Join(t.TempDir(), "snapshot")
+	var progress, total int64
+	err := NewHTTPClient().DownloadFileWithOptions(server.URL, dest, &progress, &total, DownloadOptions{Connections: 2})
+	assert.NoError(t, err)
+
+	downloaded, err := os.ReadFile(dest)
+	assert.NoError(t, err)
+	assert.True(t, bytes.Equal(content, downloaded))
+	assert.Equal(t, int64(len(content)), total)
+	assert.Equal(t, int64(len(content)), progress)
+	// One probe plus one request per connection
+	assert.Equal(t, int64(3), rangeRequests)
+	assert.NoFileExists(t, dest+PartialStateSuffix)
+}
+
+func TestDownloadFileWithOptions_ResumesParallelSegments(t *testing.T) {
+	content := testContent(2 * minParallelChunkSize)
+	server := newRangeServer(content, nil)
+	defer server.Close()
+
+	dest := filepath.Join(t.TempDir(), "snapshot")
+	size := int64(len(content))
+	half := size / 2
+
+	// The first segment is complete and the second one stopped after 100 bytes
+	partial := make([]byte, size)
+	copy(partial[:half+100], content[:half+100])
+	assert.NoError(t, os.WriteFile(dest+PartialFileSuffix, partial, 0644))
+	state, err := json.Marshal(partialState{URL: server.URL, Size: size, Segments: []*rangeSegment{
+		{Start: 0, End: half - 1, Next: half},
+		{Start: half, End: size - 1, Next: half + 100},
+	}})
+	assert.NoError(t, err)
+	assert.NoError(t, os.WriteFile(dest+PartialStateSuffix, state, 0644))
+
+	var resumed int64
+	err = NewHTTPClient().DownloadFileWithOptions(server.URL, dest, nil, nil, DownloadOptions{Resumed: &resumed})
+	assert.NoError(t, err)
+
+	downloaded, err := os.ReadFile(dest)
+	assert.NoError(t, err)
+	assert.True(t, bytes.Equal(content, downloaded))
+	assert.Equal(t, half+100, resumed)
+}
+
+func TestDownloadFileWithOptions_Checksum(t *testing.T) {
+	content := testContent(4096)
+	sum := sha256.Sum256(content)
+	checksum := hex.EncodeToString(sum[:])
+
+	published := checksum
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, ChecksumFileSuffix) {
+			_, _ = w.Write([]byte(published + "  snapshot.tar.lz4\n"))
+			return
+		}
+		http.ServeContent(w, r, "snapshot", time.Time{}, bytes.NewReader(content))
+	}))
+	defer server.Close()
+
+	dest := filepath.Join(t.TempDir(), "snapshot")
+	httpClient := NewHTTPClient()
+	assert.NoError(t, httpClient.DownloadFileWithOptions(server.URL+"/snapshot.tar.lz4", dest, nil, nil, DownloadOptions{FetchPublishedChecksum: true}))
+
+	published = strings.Repeat("0", 64)
+	err := httpClient.DownloadFileWithOptions(server.URL+"/snapshot.tar.lz4", dest+"-bad", nil, nil, DownloadOptions{FetchPublishedChecksum: true})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "checksum mismatch")
+	assert.NoFileExists(t, dest+"-bad"+PartialFileSuffix)
+
+	err = httpClient.DownloadFileWithOptions(server.URL+"/snapshot.tar.lz4", dest+"-explicit", nil, nil, DownloadOptions{SHA256: checksum})
+	assert.NoError(t, err)
+}
+
+func TestFetchPublishedChecksum_Status(t *testing.T) {
+	status := http.StatusNotFound
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(status)
+	}))
+	defer server.Close()
+
+	httpClient := NewHTTPClient()
+	checksum, err := httpClient.FetchPublishedChecksum(server.URL + "/snapshot.tar.lz4")
+	assert.NoError(t, err, "a missing checksum file means there is no published checksum")
+	assert.Empty(t, checksum)
+
+	status = http.StatusForbidden
+	_, err = httpClient.FetchPublishedChecksum(server.URL + "/snapshot.tar.lz4")
+	assert.Error(t, err, "verification must not be skipped when the checksum cannot be fetched")
+}
+
+func TestDownloadFileWithOptions_PartialFileMode(t *testing.T) {
+	content := testContent(1 << 16)
+	var requests int64
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt64(&requests, 1) > 1 {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Length", "65536")
+		_, _ = w.Write(content[:len(content)/2])
+		w.(http.Flusher).Flush()
+		panic(http.ErrAbortHandler)
+	}))
+	defer server.Close()
+
+	dest := filepath.Join(t.TempDir(), "snapshot")
+	assert.Error(t, NewHTTPClient().DownloadFile(server.URL, dest, nil, nil))
+
+	info, err := os.Stat(dest + PartialFileSuffix)
+	assert.NoError(t, err)
+	assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+}
+
+func TestDownloadFileWithOptions_BandwidthLimit(t *testing.T) {
+	content := testContent(100_000)
+	server := newRangeServer(content, nil)
+	defer server.Close()
+
+	dest := filepath.Join(t.TempDir(), "snapshot")
+	started := time.Now()
+	assert.NoError(t, NewHTTPClient().DownloadFileWithOptions(server.URL, dest, nil, nil, DownloadOptions{BandwidthLimit: 500_000}))
+	assert.GreaterOrEqual(t, time.Since(started), 150*time.Millisecond)
+}
@@ -2,6 +2,7 @@ package client
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -13,26 +14,38 @@ import (
 )
 
 const (
-	maxRetries = 3
-	baseDelay  = 1 * time.Second
-
 	downloadBufferSize = 65536
 )
 
 // HTTPClient defines the logic for making HTTP requests.
-type HTTPClient struct{}
+// Requests are sent through the settings given to Configure and stop once the base context is done.
+type HTTPClient struct {
+	ctx context.Context
+}
 
 // NewHTTPClient creates and returns a new HTTPClient instance.
 func NewHTTPClient() *HTTPClient {
 	return &HTTPClient{}
 }
 
+// WithContext returns a client whose requests, retries and downloads also stop when ctx is done
+func (c *HTTPClient) WithContext(ctx context.Context) *HTTPClient {
+	return &HTTPClient{ctx: ctx}
+}
+
 // Get performs an HTTP GET request.
 // It can either unmarshal a JSON response into the provided result or return the raw response data directly.
 func (c *HTTPClient) Get(baseURL, additionalPath string, params map[string]string, result interface{}) ([]byte, error) {
 	fullURL := constructURL(baseURL, additionalPath, params)
 
-	body, err := c.getWithRetry(fullURL)
+	headers := map[string]string{}
+	// if baseURL is api.github.com
+	if strings.HasPrefix(fullURL, "https://api.github.com") && os.Getenv("GITHUB_TOKEN") != "" {
+		// add GITHUB_TOKEN to headers
+		headers["Authorization"] = "Bearer " + os.Getenv("GITHUB_TOKEN")
+	}
+
+	body, err := c.doWithRetry(http.MethodGet, fullURL, headers, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -46,53 +59,12 @@ func (c *HTTPClient) Get(baseURL, additionalPath string, params map[string]strin
 	return body, nil
 }
 
-// getWithRetry performs the HTTP GET request with retry logic, including exponential backoff.
-func (c *HTTPClient) getWithRetry(endpoint string) ([]byte, error) {
-	var lastErr error
-
-	req, err := http.NewRequest("GET", endpoint, nil)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
-	}
-
-	// if baseURL is api.github.com
-	if strings.HasPrefix(endpoint, "https://api.github.com") && os.Getenv("GITHUB_TOKEN") != "" {
-		// add GITHUB_TOKEN to headers
-		req.Header.Add("Authorization", "Bearer "+os.Getenv("GITHUB_TOKEN"))
-	}
-
-	for attempt := 1; attempt <= maxRetries; attempt++ {
-		resp, err := http.DefaultClient.Do(req)
-		if err != nil {
-			lastErr = fmt.Errorf("attempt %d: request error: %w", attempt, err)
-		} else {
-			if resp.StatusCode != http.StatusOK {
-				lastErr = fmt.Errorf("attempt %d: unexpected status code %d", attempt, resp.StatusCode)
-			} else {
-				body, err := io.ReadAll(resp.Body)
-				if err != nil {
-					lastErr = fmt.Errorf("attempt %d: failed to read response body: %w", attempt, err)
-				} else {
-					return body, nil
-				}
-			}
-			err = resp.Body.Close()
-			if err != nil {
-				return nil, err
-			}
-		}
-		time.Sleep(baseDelay * time.Duration(attempt))
-	}
-
-	return nil, fmt.Errorf("all %d attempts failed: %w", maxRetries, lastErr)
-}
-
 // Post performs an HTTP POST request.
 // It can either unmarshal a JSON response into the provided result or return the raw response data directly.
 func (c *HTTPClient) Post(baseURL, additionalPath string, headers map[string]string, body []byte, result interface{}) ([]byte, error) {
 	fullURL := constructURL(baseURL, additionalPath, map[string]string{})
 
-	response, err := c.postWithRetry(fullURL, headers, body)
+	response, err := c.doWithRetry(http.MethodPost, fullURL, headers, body)
 	if err != nil {
 		return nil, err
 	}
@@ -106,99 +78,87 @@ func (c *HTTPClient) Post(baseURL, additionalPath string, headers map[string]str
 	return response, nil
 }
 
-// postWithRetry performs the HTTP POST request with retry logic, including exponential backoff.
-func (c *HTTPClient) postWithRetry(endpoint string, headers map[string]string, body []byte) ([]byte, error) {
-	var lastErr error
+// doWithRetry sends the request until it succeeds, fails for good or runs out of attempts, backing off exponentially
+// with jitter in between. A Retry-After sent with 429 or 503 is honored.
+func (c *HTTPClient) doWithRetry(method, endpoint string, headers map[string]string, body []byte) ([]byte, error) {
+	ctx, cancel := c.requestContext()
+	defer cancel()
+	_, policy := currentNetwork()
 
-	for attempt := 1; attempt <= maxRetries; attempt++ {
-		req, err := http.NewRequest("POST", endpoint, bytes.NewBuffer(body))
-		if err != nil {
-			return nil, fmt.Errorf("failed to create request: %w", err)
+	var lastErr error
+	for attempt := 1; attempt <= policy.MaxAttempts; attempt++ {
+		response, retryAfter, retryable, err := c.attempt(ctx, policy, method, endpoint, headers, body)
+		if err == nil {
+			return response, nil
 		}
-
-		for key, value := range headers {
-			req.Header.Set(key, value)
+		if ctx.Err() != nil {
+			return nil, fmt.Errorf("request cancelled: %w", ctx.Err())
 		}
-
-		resp, err := http.DefaultClient.Do(req)
-		if err != nil {
-			lastErr = fmt.Errorf("attempt %d: request error: %w", attempt, err)
-		} else {
-			if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-				lastErr = fmt.Errorf("attempt %d: unexpected status code %d", attempt, resp.StatusCode)
-			} else {
-				body, err := io.ReadAll(resp.Body)
-				if err != nil {
-					lastErr = fmt.Errorf("attempt %d: failed to read response body: %w", attempt, err)
-				} else {
-					return body, nil
-				}
-			}
-			err = resp.Body.Close()
-			if err != nil {
-				return nil, fmt.Errorf("failed to close response body: %w", err)
+		lastErr = fmt.Errorf("attempt %d: %w", attempt, err)
+		if !retryable {
+			return nil, lastErr
+		}
+		if attempt < policy.MaxAttempts {
+			if err := sleepContext(ctx, policy.backoff(attempt, retryAfter)); err != nil {
+				return nil, err
 			}
 		}
-
-		time.Sleep(baseDelay * time.Duration(attempt))
 	}
 
-	return nil, fmt.Errorf("all %d attempts failed: %w", maxRetries, lastErr)
+	return nil, fmt.Errorf("all %d attempts failed: %w", policy.MaxAttempts, lastErr)
 }
 
-// DownloadFile downloads a file from the specified URL
-// and updates the current progress using the provided progress pointer.
-func (c *HTTPClient) DownloadFile(url string, dest string, progress, totalSize *int64) error {
-	resp, err := http.Get(url)
-	if err != nil {
-		return fmt.Errorf("failed to connect to URL: %w", err)
-	}
-	defer resp.Body.Close()
+// attempt sends the request once within the request timeout. It reports how long the server asked to wait
+// and whether sending the request again may succeed.
+func (c *HTTPClient) attempt(ctx context.Context, policy RetryPolicy, method, endpoint string, headers map[string]string, body []byte) ([]byte, time.Duration, bool, error) {
+	ctx, cancel := context.WithTimeout(ctx, policy.RequestTimeout)
+	defer cancel()
 
-	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("failed to download: received status code %d", resp.StatusCode)
+	req, err := http.NewRequestWithContext(ctx, method, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return nil, 0, false, fmt.Errorf("failed to create request: %w", err)
 	}
-
-	if totalSize != nil {
-		*totalSize = resp.ContentLength
-		if *totalSize <= 0 {
-			*totalSize = 1
-		}
+	for key, value := range headers {
+		req.Header.Set(key, value)
 	}
 
-	file, err := os.Create(dest)
+	resp, err := send(req)
 	if err != nil {
-		return fmt.Errorf("failed to create destination file: %w", err)
+		return nil, 0, true, fmt.Errorf("request error: %w", err)
 	}
-	defer file.Close()
-
-	buffer := make([]byte, downloadBufferSize)
-	var totalDownloaded int64
-	for {
-		n, err := resp.Body.Read(buffer)
-		if err != nil && err != io.EOF {
-			return fmt.Errorf("error during file download: %w", err)
-		}
-		if n == 0 {
-			break
-		}
+	defer resp.Body.Close()
 
-		if _, err := file.Write(buffer[:n]); err != nil {
-			return fmt.Errorf("failed to write to file: %w", err)
-		}
+	ok := resp.StatusCode >= 200 && resp.StatusCode < 300
+	if method == http.MethodGet {
+		ok = resp.StatusCode == http.StatusOK
+	}
+	if !ok {
+		retryAfter := parseRetryAfter(resp.Header.Get("Retry-After"), time.Now())
+		return nil, retryAfter, retryableStatus(resp.StatusCode), fmt.Errorf("unexpected status code %d", resp.StatusCode)
+	}
 
-		totalDownloaded += int64(n)
-		if progress != nil {
-			*progress = totalDownloaded
-		}
+	response, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, 0, true, fmt.Errorf("failed to read response body: %w", err)
 	}
+	return response, 0, false, nil
+}
 
-	return nil
+// DownloadFile downloads a file from the specified URL
+// and updates the current progress using the provided progress pointer.
+// Interrupted downloads are resumed, see DownloadFileWithOptions.
+func (c *HTTPClient) DownloadFile(url string, dest string, progress, totalSize *int64) error {
+	return c.DownloadFileWithOptions(url, dest, progress, totalSize, DownloadOptions{})
 }
 
 // DownloadAndValidateFile does the HTTPClient.DownloadFile but with additional validation
 func (c *HTTPClient) DownloadAndValidateFile(url string, dest string, progress, totalSize *int64, validateFn func(string) error) error {
-	if err := c.DownloadFile(url, dest, progress, totalSize); err != nil {
+	return c.DownloadAndValidateFileWithOptions(url, dest, progress, totalSize, validateFn, DownloadOptions{})
+}
+
+// DownloadAndValidateFileWithOptions does the HTTPClient.DownloadFileWithOptions but with additional validation
+func (c *HTTPClient) DownloadAndValidateFileWithOptions(url string, dest string, progress, totalSize *int64, validateFn func(string) error, opts DownloadOptions) error {
+	if err := c.DownloadFileWithOptions(url, dest, progress, totalSize, opts); err != nil {
 		return err
 	}
 
@@ -0,0 +1,194 @@
+package client
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// NetworkOptions routes every request weave makes. The zero value connects directly, honoring the standard proxy
+// environment variables and the system certificate pool.
+type NetworkOptions struct {
+	// Mirrors rewrites URLs starting with a key to start with its value instead. Keys match case-insensitively,
+	// since the config file stores them in lowercase, and the longest matching key wins.
+	Mirrors map[string]string
+	// ProxyURL is the proxy every request goes through, in place of HTTP_PROXY, HTTPS_PROXY and NO_PROXY
+	ProxyURL string
+	// CABundlePath is a PEM file of certificates trusted in addition to the system pool
+	CABundlePath string
+	// Retry is how requests are timed out and retried
+	Retry RetryPolicy
+}
+
+var (
+	networkMu     sync.RWMutex
+	networkClient *http.Client
+	networkPolicy RetryPolicy
+)
+
+func init() {
+	networkClient, networkPolicy, _ = newNetwork(NetworkOptions{})
+}
+
+// Configure applies opts to all HTTP clients of the package, including the ones already created
+func Configure(opts NetworkOptions) error {
+	httpClient, policy, err := newNetwork(opts)
+	if err != nil {
+		return err
+	}
+
+	networkMu.Lock()
+	defer networkMu.Unlock()
+	networkClient, networkPolicy = httpClient, policy
+	return nil
+}
+
+func newNetwork(opts NetworkOptions) (*http.Client, RetryPolicy, error) {
+	policy := opts.Retry.withDefaults()
+	base := http.DefaultTransport.(*http.Transport).Clone()
+	base.ResponseHeaderTimeout = policy.RequestTimeout
+	if opts.ProxyURL != "" {
+		proxyURL, err := url.Parse(opts.ProxyURL)
+		if err != nil || proxyURL.Scheme == "" || proxyURL.Host == "" {
+			return nil, RetryPolicy{}, fmt.Errorf("invalid proxy URL %q", opts.ProxyURL)
+		}
+		base.Proxy = http.ProxyURL(proxyURL)
+	}
+	if opts.CABundlePath != "" {
+		pool, err := loadCABundle(opts.CABundlePath)
+		if err != nil {
+			return nil, RetryPolicy{}, err
+		}
+		base.TLSClientConfig = &tls.Config{RootCAs: pool, MinVersion: tls.VersionTLS12}
+	}
+
+	transport := &mirrorTransport{base: base}
+	for from, to := range opts.Mirrors {
+		if from == "" || to == "" {
+			return nil, RetryPolicy{}, fmt.Errorf("invalid mirror %q=%q", from, to)
+		}
+		transport.mirrors = append(transport.mirrors, mirror{from: from, to: to})
+	}
+	sort.Slice(transport.mirrors, func(i, j int) bool { return len(transport.mirrors[i].from) > len(transport.mirrors[j].from) })
+	return &http.Client{Transport: transport}, policy, nil
+}
+
+// Do sends req through the configured mirrors, proxy and certificates. It is not retried.
+// The request is cancelled when its own context or the one given to SetBaseContext is done.
+func (c *HTTPClient) Do(req *http.Request) (*http.Response, error) {
+	return send(req)
+}
+
+// RewriteURL returns the URL a request to rawURL is sent to once the configured mirrors are applied
+func RewriteURL(rawURL string) string {
+	networkMu.RLock()
+	defer networkMu.RUnlock()
+	return networkClient.Transport.(*mirrorTransport).rewrite(rawURL)
+}
+
+func currentNetwork() (*http.Client, RetryPolicy) {
+	networkMu.RLock()
+	defer networkMu.RUnlock()
+	return networkClient, networkPolicy
+}
+
+func send(req *http.Request) (*http.Response, error) {
+	ctx, cancel := context.WithCancel(req.Context())
+	stop := context.AfterFunc(baseContext(), cancel)
+	release := func() {
+		stop()
+		cancel()
+	}
+
+	httpClient, _ := currentNetwork()
+	resp, err := httpClient.Do(req.WithContext(ctx))
+	if err != nil {
+		release()
+		return nil, err
+	}
+	resp.Body = &releasingBody{ReadCloser: resp.Body, release: release}
+	return resp, nil
+}
+
+func get(ctx context.Context, rawURL string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, rawURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	return send(req)
+}
+
+// releasingBody releases the context of its request once it is closed
+type releasingBody struct {
+	io.ReadCloser
+	release func()
+}
+
+func (b *releasingBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.release()
+	return err
+}
+
+func loadCABundle(path string) (*x509.CertPool, error) {
+	bundle, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read CA bundle: %w", err)
+	}
+	pool, err := x509.SystemCertPool()
+	if err != nil {
+		pool = x509.NewCertPool()
+	}
+	if !pool.AppendCertsFromPEM(bundle) {
+		return nil, fmt.Errorf("no PEM certificates found in CA bundle %s", path)
+	}
+	return pool, nil
+}
+
+type mirror struct {
+	from string
+	to   string
+}
+
+// mirrorTransport rewrites requests, redirects included, before handing them to base
+type mirrorTransport struct {
+	base    http.RoundTripper
+	mirrors []mirror
+}
+
+func (t *mirrorTransport) rewrite(rawURL string) string {
+	for _, m := range t.mirrors {
+		if len(rawURL) >= len(m.from) && strings.EqualFold(rawURL[:len(m.from)], m.from) {
+			return m.to + rawURL[len(m.from):]
+		}
+	}
+	return rawURL
+}
+
+func (t *mirrorTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	rewritten := t.rewrite(req.URL.String())
+	if rewritten == req.URL.String() {
+		return t.base.RoundTrip(req)
+	}
+
+	target, err := url.Parse(rewritten)
+	if err != nil {
+		return nil, fmt.Errorf("invalid mirror URL %s: %w", rewritten, err)
+	}
+	mirrored := req.Clone(req.Context())
+	mirrored.URL = target
+	mirrored.Host = ""
+	if target.Host != req.URL.Host {
+		// Credentials such as GITHUB_TOKEN are meant for the original host only
+		mirrored.Header.Del("Authorization")
+	}
+	return t.base.RoundTrip(mirrored)
+}
@@ -0,0 +1,84 @@
+package client
+
+import (
+	"context"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConfigure_Mirrors(t *testing.T) {
+	var gotPath, gotAuth string
+	mirror := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath, gotAuth = r.URL.Path, r.Header.Get("Authorization")
+		_, _ = w.Write([]byte(`{"tag_name":"v1.0.0"}`))
+	}))
+	defer mirror.Close()
+
+	assert.NoError(t, Configure(NetworkOptions{Mirrors: map[string]string{
+		"https://api.github.com":                    mirror.URL + "/github",
+		"https://api.github.com/repos/initia-labs/": mirror.URL + "/initia/",
+	}}))
+	t.Cleanup(func() { _ = Configure(NetworkOptions{}) })
+
+	assert.Equal(t, mirror.URL+"/initia/initia/releases", RewriteURL("https://API.github.com/repos/initia-labs/initia/releases"))
+	assert.Equal(t, mirror.URL+"/github/repos/cosmos/cosmos-sdk", RewriteURL("https://api.github.com/repos/cosmos/cosmos-sdk"))
+	assert.Equal(t, "https://registry.initia.xyz/chains.json", RewriteURL("https://registry.initia.xyz/chains.json"))
+
+	t.Setenv("GITHUB_TOKEN", "secret")
+	var result map[string]string
+	_, err := NewHTTPClient().Get("https://api.github.com/repos/initia-labs/initia/releases/latest", "", nil, &result)
+	assert.NoError(t, err)
+	assert.Equal(t, "v1.0.0", result["tag_name"])
+	assert.Equal(t, "/initia/initia/releases/latest", gotPath)
+	assert.Empty(t, gotAuth, "the GitHub token must not be sent to a mirror")
+
+	dest := filepath.Join(t.TempDir(), "initia.tar.gz")
+	assert.NoError(t, NewHTTPClient().DownloadFile("https://api.github.com/download/initia.tar.gz", dest, nil, nil))
+	assert.Equal(t, "/github/download/initia.tar.gz", gotPath)
+}
+
+func TestConfigure_Proxy(t *testing.T) {
+	var proxied string
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		proxied = r.URL.String()
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer proxy.Close()
+
+	assert.NoError(t, Configure(NetworkOptions{ProxyURL: proxy.URL}))
+	t.Cleanup(func() { _ = Configure(NetworkOptions{}) })
+
+	_, err := NewHTTPClient().Get("http://registry.example.com/chains.json", "", nil, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "http://registry.example.com/chains.json", proxied)
+
+	assert.Error(t, Configure(NetworkOptions{ProxyURL: "proxy.example.com"}))
+}
+
+func TestConfigure_CABundle(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+	t.Cleanup(func() { _ = Configure(NetworkOptions{}) })
+
+	_, err := get(context.Background(), server.URL)
+	assert.Error(t, err, "the test server certificate is not trusted by default")
+
+	bundle := filepath.Join(t.TempDir(), "ca.pem")
+	assert.NoError(t, os.WriteFile(bundle, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw}), 0o644))
+	assert.NoError(t, Configure(NetworkOptions{CABundlePath: bundle}))
+	resp, err := get(context.Background(), server.URL)
+	assert.NoError(t, err)
+	resp.Body.Close()
+
+	invalid := filepath.Join(t.TempDir(), "invalid.pem")
+	assert.NoError(t, os.WriteFile(invalid, []byte("not a certificate"), 0o644))
+	assert.Error(t, Configure(NetworkOptions{CABundlePath: invalid}))
+}
@@ -0,0 +1,138 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// RetryPolicy controls how requests are timed out and retried. Zero fields take the value of DefaultRetryPolicy.
+type RetryPolicy struct {
+	// MaxAttempts is the number of times a request is sent before giving up
+	MaxAttempts int
+	// BaseDelay is the backoff after the first failure. It doubles with every attempt, with full jitter.
+	BaseDelay time.Duration
+	// MaxDelay caps the backoff, including the wait a server asks for with Retry-After
+	MaxDelay time.Duration
+	// RequestTimeout is the deadline of an API request, and how long a download waits for the response headers
+	RequestTimeout time.Duration
+}
+
+// DefaultRetryPolicy is used for the fields a RetryPolicy leaves unset
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts:    3,
+	BaseDelay:      1 * time.Second,
+	MaxDelay:       30 * time.Second,
+	RequestTimeout: 30 * time.Second,
+}
+
+func (p RetryPolicy) withDefaults() RetryPolicy {
+	if p.MaxAttempts <= 0 {
+		p.MaxAttempts = DefaultRetryPolicy.MaxAttempts
+	}
+	if p.BaseDelay <= 0 {
+		p.BaseDelay = DefaultRetryPolicy.BaseDelay
+	}
+	if p.MaxDelay <= 0 {
+		p.MaxDelay = DefaultRetryPolicy.MaxDelay
+	}
+	if p.RequestTimeout <= 0 {
+		p.RequestTimeout = DefaultRetryPolicy.RequestTimeout
+	}
+	return p
+}
+
+// backoff returns how long to wait after the given failed attempt. A Retry-After from the server is honored up to MaxDelay.
+func (p RetryPolicy) backoff(attempt int, retryAfter time.Duration) time.Duration {
+	ceiling := p.MaxDelay
+	if attempt < 31 {
+		ceiling = min(p.BaseDelay<<(attempt-1), p.MaxDelay)
+	}
+	delay := time.Duration(rand.Int64N(int64(ceiling) + 1))
+	return min(max(delay, retryAfter), p.MaxDelay)
+}
+
+var (
+	sessionMu     sync.Mutex
+	sessionCtx    context.Context
+	sessionCancel context.CancelFunc
+)
+
+func init() {
+	SetBaseContext(context.Background())
+}
+
+// SetBaseContext makes every request of the package stop when ctx is done, such as when the command is interrupted
+func SetBaseContext(ctx context.Context) {
+	sessionMu.Lock()
+	defer sessionMu.Unlock()
+	sessionCtx, sessionCancel = context.WithCancel(ctx)
+}
+
+// CancelRequests aborts the requests in flight and the retries waiting to be sent, for when the user quits a screen
+// whose work runs in the background. Later requests fail as well.
+func CancelRequests() {
+	sessionMu.Lock()
+	defer sessionMu.Unlock()
+	sessionCancel()
+}
+
+func baseContext() context.Context {
+	sessionMu.Lock()
+	defer sessionMu.Unlock()
+	return sessionCtx
+}
+
+// requestContext returns a context that is done when either the client context or the base context is
+func (c *HTTPClient) requestContext() (context.Context, context.CancelFunc) {
+	base := baseContext()
+	if c.ctx == nil {
+		return context.WithCancel(base)
+	}
+	ctx, cancel := context.WithCancel(c.ctx)
+	stop := context.AfterFunc(base, cancel)
+	return ctx, func() {
+		stop()
+		cancel()
+	}
+}
+
+// sleepContext waits for d, returning early with an error when ctx is done
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("request cancelled: %w", ctx.Err())
+	}
+}
+
+// retryableStatus reports whether a request that got code may succeed when sent again
+func retryableStatus(code int) bool {
+	switch code {
+	case http.StatusRequestTimeout, http.StatusTooManyRequests:
+		return true
+	default:
+		return code >= http.StatusInternalServerError && code != http.StatusNotImplemented
+	}
+}
+
+// parseRetryAfter reads a Retry-After header given either in seconds or as an HTTP date
+func parseRetryAfter(value string, now time.Time) time.Duration {
+	if value == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		return max(time.Duration(seconds)*time.Second, 0)
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		return max(date.Sub(now), 0)
+	}
+	return 0
+}
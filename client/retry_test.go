@@ -0,0 +1,128 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// configureRetry applies policy for the duration of the test
+func configureRetry(t *testing.T, policy RetryPolicy) {
+	assert.NoError(t, Configure(NetworkOptions{Retry: policy}))
+	t.Cleanup(func() { _ = Configure(NetworkOptions{}) })
+}
+
+func TestRetryPolicy_Backoff(t *testing.T) {
+	policy := RetryPolicy{BaseDelay: time.Second, MaxDelay: 5 * time.Second}
+	for attempt := 1; attempt <= 40; attempt++ {
+		delay := policy.backoff(attempt, 0)
+		assert.LessOrEqual(t, delay, min(time.Second<<min(attempt-1, 10), 5*time.Second))
+		assert.GreaterOrEqual(t, delay, time.Duration(0))
+	}
+	assert.Equal(t, 3*time.Second, policy.backoff(1, 3*time.Second))
+	assert.Equal(t, 5*time.Second, policy.backoff(1, time.Minute))
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	assert.Equal(t, 7*time.Second, parseRetryAfter("7", now))
+	assert.Equal(t, 90*time.Second, parseRetryAfter(now.Add(90*time.Second).Format(http.TimeFormat), now))
+	assert.Equal(t, time.Duration(0), parseRetryAfter(now.Add(-time.Minute).Format(http.TimeFormat), now))
+	assert.Equal(t, time.Duration(0), parseRetryAfter("soon", now))
+	assert.Equal(t, time.Duration(0), parseRetryAfter("", now))
+}
+
+func TestHTTPClient_Get_RetryAfter(t *testing.T) {
+	var requests int32
+	var retriedAt time.Time
+	started := time.Now()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&requests, 1) == 1 {
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		retriedAt = time.Now()
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+	configureRetry(t, RetryPolicy{BaseDelay: time.Millisecond, MaxDelay: 5 * time.Second})
+
+	_, err := NewHTTPClient().Get(server.URL, "", nil, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&requests))
+	assert.GreaterOrEqual(t, retriedAt.Sub(started), time.Second)
+}
+
+func TestHTTPClient_Get_NotRetried(t *testing.T) {
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+	configureRetry(t, RetryPolicy{MaxAttempts: 5, BaseDelay: time.Millisecond})
+
+	_, err := NewHTTPClient().Get(server.URL, "", nil, nil)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "unexpected status code 404")
+	assert.Equal(t, int32(1), atomic.LoadInt32(&requests))
+}
+
+func TestHTTPClient_Get_RequestTimeout(t *testing.T) {
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		select {
+		case <-r.Context().Done():
+		case <-time.After(5 * time.Second):
+		}
+	}))
+	defer server.Close()
+	configureRetry(t, RetryPolicy{MaxAttempts: 2, BaseDelay: time.Millisecond, RequestTimeout: 100 * time.Millisecond})
+
+	started := time.Now()
+	_, err := NewHTTPClient().Get(server.URL, "", nil, nil)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "all 2 attempts failed")
+	assert.Equal(t, int32(2), atomic.LoadInt32(&requests))
+	assert.Less(t, time.Since(started), 2*time.Second)
+}
+
+func TestHTTPClient_WithContext_Cancel(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+	configureRetry(t, RetryPolicy{MaxAttempts: 10, BaseDelay: time.Minute, MaxDelay: time.Minute})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(200*time.Millisecond, cancel)
+	started := time.Now()
+	_, err := NewHTTPClient().WithContext(ctx).Get(server.URL, "", nil, nil)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "request cancelled")
+	assert.Less(t, time.Since(started), 5*time.Second)
+}
+
+func TestCancelRequests(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+	configureRetry(t, RetryPolicy{MaxAttempts: 10, BaseDelay: time.Minute, MaxDelay: time.Minute})
+	t.Cleanup(func() { SetBaseContext(context.Background()) })
+
+	time.AfterFunc(200*time.Millisecond, CancelRequests)
+	_, err := NewHTTPClient().Get(server.URL, "", nil, nil)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "request cancelled")
+
+	_, err = NewHTTPClient().Get(server.URL, "", nil, nil)
+	assert.Error(t, err, "requests after the cancellation fail as well")
+}
@@ -0,0 +1,175 @@
+package client
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"hash"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"sync/atomic"
+)
+
+// LocalFileURLPrefix marks a URL that StreamFileWithOptions reads from the local filesystem instead of over HTTP
+const LocalFileURLPrefix = "file://"
+
+// StreamFileWithOptions downloads url and hands the body to consume as it arrives, without writing it to disk.
+// A url starting with LocalFileURLPrefix is read from the local filesystem.
+// A connection that drops midway is resumed with a Range request from the last byte read, but a stream cannot
+// be resumed by a later call, so opts.Connections and opts.Resumed are ignored.
+// The checksum, if any, is verified once consume returns, after reading whatever consume left unread. Since consume
+// sees the data before it is verified, it must stage what it writes and only commit it once this returns nil.
+func (c *HTTPClient) StreamFileWithOptions(url string, progress, totalSize *int64, opts DownloadOptions, consume func(io.Reader) error) error {
+	if opts.SHA256 == "" && opts.FetchPublishedChecksum {
+		checksum, err := c.FetchPublishedChecksum(url)
+		if err != nil {
+			return err
+		}
+		opts.SHA256 = checksum
+	}
+
+	ctx, cancel := c.requestContext()
+	defer cancel()
+	_, policy := currentNetwork()
+	s := &streamReader{
+		download: &download{
+			ctx:      ctx,
+			policy:   policy,
+			url:      url,
+			progress: progress,
+			limiter:  newRateLimiter(opts.BandwidthLimit),
+		},
+		hash:      sha256.New(),
+		totalSize: totalSize,
+	}
+	defer s.close()
+
+	// Connect before consuming so that an unreachable URL is reported without touching the consumer
+	if err := s.connect(); err != nil {
+		return err
+	}
+	if err := consume(s); err != nil {
+		return err
+	}
+	if _, err := io.Copy(io.Discard, s); err != nil {
+		return err
+	}
+
+	if opts.SHA256 != "" {
+		return matchSHA256(s.hash.Sum(nil), opts.SHA256)
+	}
+	return nil
+}
+
+// streamReader reads a download over a single connection, reconnecting from its offset when the stream breaks
+type streamReader struct {
+	*download
+	body      io.ReadCloser
+	offset    int64
+	hash      hash.Hash
+	totalSize *int64
+	failures  int
+}
+
+func (s *streamReader) connect() error {
+	if path, ok := strings.CutPrefix(s.url, LocalFileURLPrefix); ok {
+		return s.open(path)
+	}
+
+	resp, err := s.get(s.offset, -1)
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case s.offset == 0 && (resp.StatusCode == http.StatusOK || resp.StatusCode == http.StatusPartialContent):
+		if s.totalSize != nil {
+			size := resp.ContentLength
+			if size <= 0 {
+				size = 1
+			}
+			atomic.StoreInt64(s.totalSize, size)
+		}
+	case s.offset > 0 && resp.StatusCode == http.StatusPartialContent:
+	case s.offset > 0 && resp.StatusCode == http.StatusOK:
+		// The bytes already consumed cannot be taken back, so the stream can only continue from a range
+		resp.Body.Close()
+		return fmt.Errorf("failed to resume download: the server does not support range requests")
+	default:
+		resp.Body.Close()
+		return fmt.Errorf("failed to download: received status code %d", resp.StatusCode)
+	}
+
+	s.body = resp.Body
+	return nil
+}
+
+func (s *streamReader) open(path string) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("failed to open file: %w", err)
+	}
+	if s.offset == 0 && s.totalSize != nil {
+		if info, err := file.Stat(); err == nil {
+			atomic.StoreInt64(s.totalSize, info.Size())
+		}
+	}
+	if _, err := file.Seek(s.offset, io.SeekStart); err != nil {
+		file.Close()
+		return fmt.Errorf("failed to seek file: %w", err)
+	}
+
+	s.body = file
+	return nil
+}
+
+func (s *streamReader) Read(p []byte) (int, error) {
+	for {
+		if s.body == nil {
+			if err := s.connect(); err != nil {
+				if s.failures >= s.policy.MaxAttempts {
+					return 0, err
+				}
+				s.failures++
+				if err := s.wait(s.failures); err != nil {
+					return 0, err
+				}
+				continue
+			}
+		}
+
+		n, err := s.body.Read(p)
+		if n > 0 {
+			s.limiter.wait(n)
+			s.hash.Write(p[:n])
+			s.offset += int64(n)
+			s.addProgress(int64(n))
+		}
+		if err == nil || err == io.EOF {
+			return n, err
+		}
+
+		// Reconnect on the next read; a read that made progress earns a fresh retry budget
+		s.body.Close()
+		s.body = nil
+		if n > 0 {
+			s.failures = 0
+			return n, nil
+		}
+		if s.failures >= s.policy.MaxAttempts {
+			return 0, fmt.Errorf("error during file download: %w", err)
+		}
+		s.failures++
+		if err := s.wait(s.failures); err != nil {
+			return 0, err
+		}
+	}
+}
+
+func (s *streamReader) close() {
+	if s.body != nil {
+		s.body.Close()
+		s.body = nil
+	}
+}
@@ -0,0 +1,112 @@
+package client
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStreamFileWithOptions(t *testing.T) {
+	content := testContent(1 << 16)
+	server := newRangeServer(content, nil)
+	defer server.Close()
+
+	var progress, total int64
+	var streamed []byte
+	err := NewHTTPClient().StreamFileWithOptions(server.URL, &progress, &total, DownloadOptions{}, func(r io.Reader) error {
+		var err error
+		streamed, err = io.ReadAll(r)
+		return err
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, content, streamed)
+	assert.Equal(t, int64(len(content)), total)
+	assert.Equal(t, int64(len(content)), progress)
+}
+
+func TestStreamFileWithOptions_ResumesInterruptedStream(t *testing.T) {
+	content := testContent(1 << 16)
+	var requests int64
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt64(&requests, 1) == 1 {
+			// Drop the connection halfway through the first response
+			w.Header().Set("Content-Length", "65536")
+			_, _ = w.Write(content[:len(content)/2])
+			w.(http.Flusher).Flush()
+			panic(http.ErrAbortHandler)
+		}
+		assert.Equal(t, "bytes=32768-", r.Header.Get("Range"))
+		http.ServeContent(w, r, "snapshot", time.Time{}, bytes.NewReader(content))
+	}))
+	defer server.Close()
+
+	var streamed []byte
+	err := NewHTTPClient().StreamFileWithOptions(server.URL, nil, nil, DownloadOptions{}, func(r io.Reader) error {
+		var err error
+		streamed, err = io.ReadAll(r)
+		return err
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, content, streamed)
+	assert.Equal(t, int64(2), requests)
+}
+
+func TestStreamFileWithOptions_Checksum(t *testing.T) {
+	content := testContent(4096)
+	sum := sha256.Sum256(content)
+	server := newRangeServer(content, nil)
+	defer server.Close()
+
+	// The consumer stops early, so the checksum has to cover the bytes it left unread as well
+	consume := func(r io.Reader) error {
+		_, err := io.ReadFull(r, make([]byte, 100))
+		return err
+	}
+
+	httpClient := NewHTTPClient()
+	assert.NoError(t, httpClient.StreamFileWithOptions(server.URL, nil, nil, DownloadOptions{SHA256: hex.EncodeToString(sum[:])}, consume))
+
+	err := httpClient.StreamFileWithOptions(server.URL, nil, nil, DownloadOptions{SHA256: strings.Repeat("0", 64)}, consume)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "checksum mismatch")
+}
+
+func TestStreamFileWithOptions_InvalidURL(t *testing.T) {
+	called := false
+	err := NewHTTPClient().StreamFileWithOptions("http://invalid.invalid", nil, nil, DownloadOptions{}, func(r io.Reader) error {
+		called = true
+		return nil
+	})
+	assert.Error(t, err)
+	assert.False(t, called)
+}
+
+func TestStreamFileWithOptions_LocalFile(t *testing.T) {
+	content := testContent(4096)
+	path := filepath.Join(t.TempDir(), "snapshot.tar.lz4")
+	assert.NoError(t, os.WriteFile(path, content, 0o644))
+	sum := sha256.Sum256(content)
+	assert.NoError(t, os.WriteFile(path+ChecksumFileSuffix, []byte(hex.EncodeToString(sum[:])), 0o644))
+
+	var total int64
+	var streamed []byte
+	err := NewHTTPClient().StreamFileWithOptions(LocalFileURLPrefix+path, nil, &total, DownloadOptions{FetchPublishedChecksum: true}, func(r io.Reader) error {
+		var err error
+		streamed, err = io.ReadAll(r)
+		return err
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, content, streamed)
+	assert.Equal(t, int64(len(content)), total)
+}
@@ -0,0 +1,268 @@
+package client
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/pem"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"strings"
+
+	"golang.org/x/crypto/blake2b"
+)
+
+const (
+	CosignSignatureSuffix   = ".sig"
+	MinisignSignatureSuffix = ".minisig"
+
+	maxChecksumFileSize = 1 << 20
+)
+
+// releaseChecksumFiles are the checksum files looked up next to a release asset, after <asset>.sha256
+var releaseChecksumFiles = []string{"checksums.txt", "SHA256SUMS", "sha256sums.txt"}
+
+// VerifyOptions configures VerifyRelease. The zero value requires a published checksum and no signature.
+type VerifyOptions struct {
+	// InsecureSkipVerify accepts a release without any check
+	InsecureSkipVerify bool
+	// CosignPublicKeyPath is a PEM-encoded ECDSA public key the checksum file must be signed with, as by `cosign sign-blob --key`
+	CosignPublicKeyPath string
+	// MinisignPublicKey is the minisign public key the checksum file must be signed with
+	MinisignPublicKey string
+	// ExpectedSHA256 is a checksum the release was announced with from a trusted source, such as a governance upgrade
+	// plan. The file must always match it, and it stands in for the published checksum unless a signature is required.
+	ExpectedSHA256 string
+}
+
+// VerifyRelease checks the file at dest, downloaded from the release asset url, against the checksum the release publishes
+// as <url>.sha256 or in a checksum file next to it. When a public key is configured, the checksum file must also carry a
+// valid signature. A release without a checksum is rejected.
+func (c *HTTPClient) VerifyRelease(url, dest string, opts VerifyOptions) error {
+	if opts.ExpectedSHA256 != "" {
+		expected, err := validChecksum(opts.ExpectedSHA256)
+		if err != nil {
+			return err
+		}
+		if err := verifySHA256(dest, expected); err != nil {
+			return fmt.Errorf("%s does not match its expected checksum: %w", url, err)
+		}
+		if opts.CosignPublicKeyPath == "" && opts.MinisignPublicKey == "" {
+			return nil
+		}
+	}
+	if opts.InsecureSkipVerify {
+		return nil
+	}
+
+	checksumURL, checksumFile, checksum, err := c.findReleaseChecksum(url)
+	if err != nil {
+		return err
+	}
+	if err := verifySHA256(dest, checksum); err != nil {
+		return fmt.Errorf("%s does not match %s: %w", url, checksumURL, err)
+	}
+
+	if opts.CosignPublicKeyPath != "" {
+		signature, found, err := c.fetchReleaseFile(checksumURL + CosignSignatureSuffix)
+		if err != nil {
+			return err
+		}
+		if !found {
+			return fmt.Errorf("no cosign signature published at %s%s", checksumURL, CosignSignatureSuffix)
+		}
+		if err := verifyCosignSignature(opts.CosignPublicKeyPath, checksumFile, signature); err != nil {
+			return fmt.Errorf("invalid cosign signature of %s: %w", checksumURL, err)
+		}
+	}
+	if opts.MinisignPublicKey != "" {
+		signature, found, err := c.fetchReleaseFile(checksumURL + MinisignSignatureSuffix)
+		if err != nil {
+			return err
+		}
+		if !found {
+			return fmt.Errorf("no minisign signature published at %s%s", checksumURL, MinisignSignatureSuffix)
+		}
+		if err := verifyMinisignSignature(opts.MinisignPublicKey, checksumFile, signature); err != nil {
+			return fmt.Errorf("invalid minisign signature of %s: %w", checksumURL, err)
+		}
+	}
+	return nil
+}
+
+// findReleaseChecksum returns the URL and content of the checksum file published for url, and the checksum it lists for url
+func (c *HTTPClient) findReleaseChecksum(assetURL string) (string, []byte, string, error) {
+	parsed, err := url.Parse(assetURL)
+	if err != nil {
+		return "", nil, "", fmt.Errorf("invalid release URL %s: %w", assetURL, err)
+	}
+	assetName := path.Base(parsed.Path)
+
+	// Work on the escaped form, since tags such as cosmovisor%2Fv1.7.0 contain an escaped slash
+	parsed.RawQuery, parsed.Fragment = "", ""
+	releaseDir := parsed.String()
+	releaseDir = releaseDir[:strings.LastIndex(releaseDir, "/")+1]
+
+	candidates := []string{assetURL + ChecksumFileSuffix}
+	for _, name := range releaseChecksumFiles {
+		candidates = append(candidates, releaseDir+name)
+	}
+
+	for _, checksumURL := range candidates {
+		content, found, err := c.fetchReleaseFile(checksumURL)
+		if err != nil {
+			return "", nil, "", err
+		}
+		if !found {
+			continue
+		}
+		name := assetName
+		if checksumURL == assetURL+ChecksumFileSuffix {
+			// The checksum file of the asset itself may name it differently, or not at all
+			name = ""
+		}
+		checksum, err := parseChecksumFile(content, name)
+		if err != nil {
+			return "", nil, "", fmt.Errorf("invalid checksum file %s: %w", checksumURL, err)
+		}
+		return checksumURL, content, checksum, nil
+	}
+	return "", nil, "", fmt.Errorf("no checksum is published for %s", assetURL)
+}
+
+// parseChecksumFile returns the checksum of assetName from a file in the `sha256sum` output format.
+// With an empty assetName, the first checksum of the file is returned.
+func parseChecksumFile(content []byte, assetName string) (string, error) {
+	for _, line := range strings.Split(string(content), "\n") {
+		fields := strings.Fields(line)
+		switch {
+		case len(fields) == 0:
+			continue
+		case assetName == "":
+			return validChecksum(fields[0])
+		case len(fields) == 2 && path.Base(strings.TrimPrefix(fields[1], "*")) == assetName:
+			return validChecksum(fields[0])
+		}
+	}
+	if assetName == "" {
+		return "", fmt.Errorf("no checksum found")
+	}
+	return "", fmt.Errorf("%s is not listed", assetName)
+}
+
+func validChecksum(checksum string) (string, error) {
+	checksum = strings.ToLower(checksum)
+	if decoded, err := hex.DecodeString(checksum); err != nil || len(decoded) != sha256.Size {
+		return "", fmt.Errorf("invalid SHA-256 checksum %q", checksum)
+	}
+	return checksum, nil
+}
+
+// fetchReleaseFile downloads a small file published with a release, reporting false if it does not exist
+func (c *HTTPClient) fetchReleaseFile(fileURL string) ([]byte, bool, error) {
+	ctx, cancel := c.requestContext()
+	defer cancel()
+	resp, err := get(ctx, fileURL)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to connect to URL: %w", err)
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusNotFound:
+		return nil, false, nil
+	case resp.StatusCode != http.StatusOK:
+		return nil, false, fmt.Errorf("failed to fetch %s: unexpected status code %d", fileURL, resp.StatusCode)
+	}
+	content, err := io.ReadAll(io.LimitReader(resp.Body, maxChecksumFileSize))
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to read %s: %w", fileURL, err)
+	}
+	return content, true, nil
+}
+
+// verifyCosignSignature checks a base64-encoded ECDSA signature over the SHA-256 of message, the format of `cosign sign-blob --key`
+func verifyCosignSignature(publicKeyPath string, message, signature []byte) error {
+	keyPEM, err := os.ReadFile(publicKeyPath)
+	if err != nil {
+		return fmt.Errorf("failed to read cosign public key: %w", err)
+	}
+	block, _ := pem.Decode(keyPEM)
+	if block == nil {
+		return fmt.Errorf("%s is not a PEM-encoded public key", publicKeyPath)
+	}
+	key, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return fmt.Errorf("failed to parse cosign public key: %w", err)
+	}
+	ecdsaKey, ok := key.(*ecdsa.PublicKey)
+	if !ok {
+		return fmt.Errorf("cosign public key is not an ECDSA key")
+	}
+
+	decoded, err := base64.StdEncoding.DecodeString(strings.TrimSpace(string(signature)))
+	if err != nil {
+		return fmt.Errorf("failed to decode signature: %w", err)
+	}
+	digest := sha256.Sum256(message)
+	if !ecdsa.VerifyASN1(ecdsaKey, digest[:], decoded) {
+		return fmt.Errorf("signature does not match")
+	}
+	return nil
+}
+
+// verifyMinisignSignature checks a minisign signature, including the signature over its trusted comment
+func verifyMinisignSignature(publicKey string, message, signature []byte) error {
+	// The public key may be given with its untrusted comment line, as in a .pub file
+	keyLines := strings.Split(strings.TrimSpace(publicKey), "\n")
+	key, err := base64.StdEncoding.DecodeString(strings.TrimSpace(keyLines[len(keyLines)-1]))
+	if err != nil || len(key) != 2+8+ed25519.PublicKeySize || string(key[:2]) != "Ed" {
+		return fmt.Errorf("invalid minisign public key")
+	}
+	keyId, edKey := key[2:10], ed25519.PublicKey(key[10:])
+
+	lines := strings.Split(strings.TrimSpace(string(signature)), "\n")
+	if len(lines) != 4 {
+		return fmt.Errorf("invalid minisign signature file")
+	}
+	sig, err := base64.StdEncoding.DecodeString(strings.TrimSpace(lines[1]))
+	if err != nil || len(sig) != 2+8+ed25519.SignatureSize {
+		return fmt.Errorf("invalid minisign signature")
+	}
+	trustedComment, found := strings.CutPrefix(strings.TrimRight(lines[2], "\r"), "trusted comment: ")
+	if !found {
+		return fmt.Errorf("invalid minisign trusted comment")
+	}
+	globalSig, err := base64.StdEncoding.DecodeString(strings.TrimSpace(lines[3]))
+	if err != nil || len(globalSig) != ed25519.SignatureSize {
+		return fmt.Errorf("invalid minisign trusted comment signature")
+	}
+
+	if !bytes.Equal(sig[2:10], keyId) {
+		return fmt.Errorf("signed with key %X instead of %X", sig[2:10], keyId)
+	}
+	switch string(sig[:2]) {
+	case "Ed":
+	case "ED":
+		// Signatures of minisign 0.10 and later are over the BLAKE2b-512 of the file
+		digest := blake2b.Sum512(message)
+		message = digest[:]
+	default:
+		return fmt.Errorf("unsupported minisign signature algorithm %q", sig[:2])
+	}
+	if !ed25519.Verify(edKey, message, sig[10:]) {
+		return fmt.Errorf("signature does not match")
+	}
+	if !ed25519.Verify(edKey, append(append([]byte{}, sig[10:]...), trustedComment...), globalSig) {
+		return fmt.Errorf("trusted comment signature does not match")
+	}
+	return nil
+}
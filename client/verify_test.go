@@ -0,0 +1,182 @@
+package client
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/pem"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/crypto/blake2b"
+)
+
+const testAsset = "initia_v1.0.0_Linux_x86_64.tar.gz"
+
+// newReleaseServer serves files by their base name and answers 404 for anything else
+func newReleaseServer(files map[string][]byte) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		content, ok := files[filepath.Base(r.URL.Path)]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = w.Write(content)
+	}))
+}
+
+func writeDownloaded(t *testing.T, content []byte) string {
+	dest := filepath.Join(t.TempDir(), testAsset)
+	assert.NoError(t, os.WriteFile(dest, content, 0o644))
+	return dest
+}
+
+func checksumLine(content []byte, name string) string {
+	sum := sha256.Sum256(content)
+	return fmt.Sprintf("%s  %s\n", hex.EncodeToString(sum[:]), name)
+}
+
+func TestVerifyRelease_Checksum(t *testing.T) {
+	content := []byte("release tarball")
+	checksums := []byte(checksumLine([]byte("other"), "initia_v1.0.0_Darwin_arm64.tar.gz") + checksumLine(content, testAsset))
+	server := newReleaseServer(map[string][]byte{"checksums.txt": checksums})
+	defer server.Close()
+	assetURL := server.URL + "/download/v1.0.0/" + testAsset
+	httpClient := NewHTTPClient()
+
+	assert.NoError(t, httpClient.VerifyRelease(assetURL, writeDownloaded(t, content), VerifyOptions{}))
+
+	err := httpClient.VerifyRelease(assetURL, writeDownloaded(t, []byte("tampered")), VerifyOptions{})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "checksum mismatch")
+
+	err = httpClient.VerifyRelease(server.URL+"/download/v1.0.0/minievm.tar.gz", writeDownloaded(t, content), VerifyOptions{})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "is not listed")
+}
+
+func TestVerifyRelease_PrefersAssetChecksum(t *testing.T) {
+	content := []byte("release tarball")
+	server := newReleaseServer(map[string][]byte{
+		testAsset + ChecksumFileSuffix: []byte(checksumLine(content, testAsset)),
+		"checksums.txt":                []byte(checksumLine([]byte("stale"), testAsset)),
+	})
+	defer server.Close()
+
+	assert.NoError(t, NewHTTPClient().VerifyRelease(server.URL+"/download/v1.0.0/"+testAsset, writeDownloaded(t, content), VerifyOptions{}))
+}
+
+func TestVerifyRelease_NoChecksum(t *testing.T) {
+	server := newReleaseServer(map[string][]byte{})
+	defer server.Close()
+	assetURL := server.URL + "/download/v1.0.0/" + testAsset
+	dest := writeDownloaded(t, []byte("release tarball"))
+
+	err := NewHTTPClient().VerifyRelease(assetURL, dest, VerifyOptions{})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "no checksum is published")
+
+	assert.NoError(t, NewHTTPClient().VerifyRelease(assetURL, dest, VerifyOptions{InsecureSkipVerify: true}))
+}
+
+func TestVerifyRelease_ExpectedChecksum(t *testing.T) {
+	server := newReleaseServer(map[string][]byte{})
+	defer server.Close()
+	assetURL := server.URL + "/download/v1.0.0/" + testAsset
+	content := []byte("release tarball")
+	sum := sha256.Sum256(content)
+	expected := hex.EncodeToString(sum[:])
+
+	// The expected checksum stands in for the one the release does not publish
+	assert.NoError(t, NewHTTPClient().VerifyRelease(assetURL, writeDownloaded(t, content), VerifyOptions{ExpectedSHA256: expected}))
+
+	// and is enforced even when verification is skipped
+	err := NewHTTPClient().VerifyRelease(assetURL, writeDownloaded(t, []byte("tampered")), VerifyOptions{ExpectedSHA256: expected, InsecureSkipVerify: true})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "checksum mismatch")
+
+	err = NewHTTPClient().VerifyRelease(assetURL, writeDownloaded(t, content), VerifyOptions{ExpectedSHA256: "abcd"})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid SHA-256 checksum")
+}
+
+func TestVerifyRelease_Cosign(t *testing.T) {
+	privateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+	publicKey, err := x509.MarshalPKIXPublicKey(&privateKey.PublicKey)
+	assert.NoError(t, err)
+	keyPath := filepath.Join(t.TempDir(), "cosign.pub")
+	assert.NoError(t, os.WriteFile(keyPath, pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: publicKey}), 0o644))
+
+	content := []byte("release tarball")
+	checksums := []byte(checksumLine(content, testAsset))
+	digest := sha256.Sum256(checksums)
+	signature, err := ecdsa.SignASN1(rand.Reader, privateKey, digest[:])
+	assert.NoError(t, err)
+	files := map[string][]byte{"checksums.txt": checksums}
+	server := newReleaseServer(files)
+	defer server.Close()
+	assetURL := server.URL + "/download/v1.0.0/" + testAsset
+	opts := VerifyOptions{CosignPublicKeyPath: keyPath}
+
+	err = NewHTTPClient().VerifyRelease(assetURL, writeDownloaded(t, content), opts)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "no cosign signature")
+
+	files["checksums.txt"+CosignSignatureSuffix] = []byte(base64.StdEncoding.EncodeToString(signature))
+	assert.NoError(t, NewHTTPClient().VerifyRelease(assetURL, writeDownloaded(t, content), opts))
+
+	files["checksums.txt"] = []byte(checksumLine([]byte("tampered"), testAsset))
+	err = NewHTTPClient().VerifyRelease(assetURL, writeDownloaded(t, []byte("tampered")), opts)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid cosign signature")
+}
+
+// minisign produces a public key and a prehashed signature of message in the minisign formats
+func minisign(t *testing.T, message []byte, trustedComment string) (string, []byte) {
+	publicKey, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	assert.NoError(t, err)
+	keyId := []byte{1, 2, 3, 4, 5, 6, 7, 8}
+
+	digest := blake2b.Sum512(message)
+	sig := append(append([]byte("ED"), keyId...), ed25519.Sign(privateKey, digest[:])...)
+	globalSig := ed25519.Sign(privateKey, append(append([]byte{}, sig[10:]...), trustedComment...))
+	signature := fmt.Sprintf("untrusted comment: signature from minisign secret key\n%s\ntrusted comment: %s\n%s\n",
+		base64.StdEncoding.EncodeToString(sig), trustedComment, base64.StdEncoding.EncodeToString(globalSig))
+
+	key := append(append([]byte("Ed"), keyId...), publicKey...)
+	return "untrusted comment: minisign public key\n" + base64.StdEncoding.EncodeToString(key), []byte(signature)
+}
+
+func TestVerifyRelease_Minisign(t *testing.T) {
+	content := []byte("release tarball")
+	checksums := []byte(checksumLine(content, testAsset))
+	publicKey, signature := minisign(t, checksums, "timestamp:1700000000")
+	files := map[string][]byte{"checksums.txt": checksums, "checksums.txt" + MinisignSignatureSuffix: signature}
+	server := newReleaseServer(files)
+	defer server.Close()
+	assetURL := server.URL + "/download/v1.0.0/" + testAsset
+
+	assert.NoError(t, NewHTTPClient().VerifyRelease(assetURL, writeDownloaded(t, content), VerifyOptions{MinisignPublicKey: publicKey}))
+
+	otherKey, _ := minisign(t, checksums, "timestamp:1700000000")
+	err := NewHTTPClient().VerifyRelease(assetURL, writeDownloaded(t, content), VerifyOptions{MinisignPublicKey: otherKey})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid minisign signature")
+
+	files["checksums.txt"+MinisignSignatureSuffix] = bytes.Replace(signature, []byte("timestamp:1700000000"), []byte("timestamp:1800000000"), 1)
+	err = NewHTTPClient().VerifyRelease(assetURL, writeDownloaded(t, content), VerifyOptions{MinisignPublicKey: publicKey})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "trusted comment signature does not match")
+}
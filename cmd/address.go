@@ -0,0 +1,199 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/charmbracelet/x/term"
+	"github.com/spf13/cobra"
+
+	"github.com/initia-labs/weave/crypto"
+)
+
+var defaultConvertTargets = []string{"hex", "init", "celestia"}
+
+func AddressCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:                        "address",
+		Short:                      "Convert addresses between formats and derive addresses from a mnemonic",
+		DisableFlagParsing:         true,
+		SuggestionsMinimumDistance: 2,
+	}
+
+	cmd.AddCommand(
+		addressConvertCommand(),
+		addressDeriveCommand(),
+	)
+
+	return cmd
+}
+
+type convertedAddress struct {
+	Format  string `json:"format"`
+	Address string `json:"address"`
+}
+
+func addressConvertCommand() *cobra.Command {
+	convertCmd := &cobra.Command{
+		Use:   "convert <address>",
+		Short: "Convert an address between its 0x hex and Bech32 forms",
+		Long: "Convert an address between its 0x hex and Bech32 forms.\n\n" +
+			"The address can be given as 0x hex or Bech32 with any prefix. Without --to, the hex, init and celestia forms are shown.\n" +
+			"Example: weave address convert init1jvk3gadm45cxxg4g8y3c64h7thy3s36yat0ezy --to hex",
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			targets, _ := cmd.Flags().GetStringSlice(FlagTo)
+			if len(targets) == 0 {
+				targets = defaultConvertTargets
+			}
+
+			results := make([]convertedAddress, 0, len(targets))
+			for _, target := range targets {
+				converted, err := crypto.ConvertAddress(args[0], target)
+				if err != nil {
+					return fmt.Errorf("failed to convert %s to %s: %v", args[0], target, err)
+				}
+				results = append(results, convertedAddress{Format: target, Address: converted})
+			}
+
+			if asJSON, _ := cmd.Flags().GetBool(FlagJSON); asJSON {
+				return printJSON(results)
+			}
+
+			if len(results) == 1 {
+				fmt.Println(results[0].Address)
+				return nil
+			}
+			w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+			for _, result := range results {
+				fmt.Fprintf(w, "%s\t%s\n", result.Format, result.Address)
+			}
+			return w.Flush()
+		},
+	}
+
+	convertCmd.Flags().StringSlice(FlagTo, nil, "Target format: hex, init, celestia or any Bech32 prefix. Can be repeated or comma separated")
+	convertCmd.Flags().Bool(FlagJSON, false, "Output in JSON format")
+
+	return convertCmd
+}
+
+type derivedAddress struct {
+	CoinType   int               `json:"coin_type"`
+	HDPath     string            `json:"hd_path"`
+	HexAddress string            `json:"hex_address"`
+	Addresses  map[string]string `json:"addresses"`
+}
+
+func addressDeriveCommand() *cobra.Command {
+	deriveCmd := &cobra.Command{
+		Use:   "derive",
+		Short: "Derive the addresses of a mnemonic read from stdin",
+		Long: "Derive the addresses of a mnemonic read from stdin.\n\n" +
+			"Without --coin-type, addresses are derived for both coin type 60 (EVM) and 118 (Cosmos), " +
+			"which helps to find out which coin type an address was derived with.\n" +
+			"Example: echo \"$MNEMONIC\" | weave address derive --coin-type 60 --account 0 --index 1",
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			coinType, _ := cmd.Flags().GetInt(FlagCoinType)
+			account, _ := cmd.Flags().GetInt(FlagAccount)
+			index, _ := cmd.Flags().GetInt(FlagIndex)
+			prefixes, _ := cmd.Flags().GetStringSlice(FlagPrefix)
+			if account < 0 || index < 0 {
+				return fmt.Errorf("account and index must not be negative")
+			}
+
+			coinTypes := []int{60, 118}
+			if cmd.Flags().Changed(FlagCoinType) {
+				if coinType != 60 && coinType != 118 {
+					return fmt.Errorf("unsupported coin type %d: expected 60 or 118", coinType)
+				}
+				coinTypes = []int{coinType}
+			}
+
+			mnemonic, err := readMnemonicFromStdin()
+			if err != nil {
+				return err
+			}
+
+			results := make([]derivedAddress, 0, len(coinTypes))
+			for _, ct := range coinTypes {
+				addressBytes, err := crypto.MnemonicToAddressBytes(mnemonic, ct, account, index)
+				if err != nil {
+					return err
+				}
+
+				result := derivedAddress{
+					CoinType:   ct,
+					HDPath:     crypto.HDPath(ct, account, index),
+					HexAddress: fmt.Sprintf("0x%x", addressBytes),
+					Addresses:  make(map[string]string),
+				}
+				for _, prefix := range prefixes {
+					if result.Addresses[prefix], err = crypto.BytesToBech32(prefix, addressBytes); err != nil {
+						return err
+					}
+				}
+				results = append(results, result)
+			}
+
+			if asJSON, _ := cmd.Flags().GetBool(FlagJSON); asJSON {
+				return printJSON(results)
+			}
+
+			w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+			fmt.Fprintln(w, "COIN TYPE\tHD PATH\tHEX ADDRESS\tADDRESS")
+			for _, result := range results {
+				for _, prefix := range prefixes {
+					fmt.Fprintf(w, "%d\t%s\t%s\t%s\n", result.CoinType, result.HDPath, result.HexAddress, result.Addresses[prefix])
+				}
+			}
+			return w.Flush()
+		},
+	}
+
+	deriveCmd.Flags().Int(FlagCoinType, 0, "BIP44 coin type to derive with, 60 (EVM) or 118 (Cosmos). Both are derived if not set")
+	deriveCmd.Flags().Int(FlagAccount, 0, "BIP44 account number")
+	deriveCmd.Flags().Int(FlagIndex, 0, "BIP44 address index")
+	deriveCmd.Flags().StringSlice(FlagPrefix, []string{"init"}, "Bech32 prefixes to encode the derived address with")
+	deriveCmd.Flags().Bool(FlagJSON, false, "Output in JSON format")
+
+	return deriveCmd
+}
+
+// readMnemonicFromStdin reads the mnemonic without echoing it when stdin is a terminal
+func readMnemonicFromStdin() (string, error) {
+	var mnemonic string
+	if term.IsTerminal(os.Stdin.Fd()) {
+		input, err := readPassphrase("Enter mnemonic: ")
+		if err != nil {
+			return "", err
+		}
+		mnemonic = input
+	} else {
+		input, err := io.ReadAll(os.Stdin)
+		if err != nil {
+			return "", fmt.Errorf("failed to read mnemonic from stdin: %v", err)
+		}
+		mnemonic = string(input)
+	}
+
+	mnemonic = strings.Join(strings.Fields(mnemonic), " ")
+	if !crypto.IsMnemonicValid(mnemonic) {
+		return "", fmt.Errorf("invalid mnemonic")
+	}
+	return mnemonic, nil
+}
+
+func printJSON(v interface{}) error {
+	output, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal output: %v", err)
+	}
+	fmt.Println(string(output))
+	return nil
+}
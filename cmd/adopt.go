@@ -0,0 +1,223 @@
+package cmd
+
+import (
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/initia-labs/weave/cosmosutils"
+	weaveio "github.com/initia-labs/weave/io"
+	"github.com/initia-labs/weave/models/initia"
+	"github.com/initia-labs/weave/service"
+)
+
+func initiaAdoptCommand() *cobra.Command {
+	shortDescription := "Manage an existing Initia full node home with weave"
+	adoptCmd := &cobra.Command{
+		Use:   "adopt",
+		Short: shortDescription,
+		Long: fmt.Sprintf("%s.\n\nThe chain ID is read from the genesis file and the initiad version from the binary the node runs, "+
+			"found in the cosmovisor directory of the home, in PATH or given with --%s. The matching initiad release and cosmovisor are "+
+			"installed and the weave service is created for the home. Neither the configuration nor the data of the node is changed.\n\n%s",
+			shortDescription, FlagBinary, L1NodeHelperText),
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			home, chainId, err := adoptableHome(cmd)
+			if err != nil {
+				return err
+			}
+			noAutoUpgrade, _ := cmd.Flags().GetBool(FlagNoAutoUpgrade)
+			serviceCommand := service.UpgradableInitia
+			if noAutoUpgrade {
+				serviceCommand = service.NonUpgradableInitia
+			}
+			s, err := adoptableService(cmd, serviceCommand)
+			if err != nil {
+				return err
+			}
+
+			version, err := detectAdoptedVersion(cmd, home, "initiad")
+			if err != nil {
+				return err
+			}
+			fmt.Printf("Detected initiad %s on %s.\n", version, chainId)
+
+			url, err := cosmosutils.GetInitiaBinaryURL(version)
+			if err != nil {
+				return err
+			}
+			binaryPath, err := cosmosutils.GetInitiaBinaryPath(version)
+			if err != nil {
+				return err
+			}
+			if err = cosmosutils.InstallInitiaBinary(version, url, binaryPath); err != nil {
+				return fmt.Errorf("failed to install initiad %s: %w", version, err)
+			}
+			cosmovisorPath, err := cosmosutils.InstallCosmovisor(initia.CosmovisorVersion)
+			if err != nil {
+				return fmt.Errorf("failed to install cosmovisor: %w", err)
+			}
+
+			if !weaveio.FileOrFolderExists(filepath.Join(home, "cosmovisor")) {
+				initCmd := exec.Command(cosmovisorPath, "init", binaryPath)
+				initCmd.Env = append(initCmd.Env, "DAEMON_NAME=initiad", "DAEMON_HOME="+home)
+				if output, err := initCmd.CombinedOutput(); err != nil {
+					return fmt.Errorf("failed to run cosmovisor init: %v (output: %s)", err, string(output))
+				}
+			}
+			if err = weaveio.CopyDirectory(filepath.Dir(binaryPath), cosmosutils.CosmovisorLibraryPath(home)); err != nil {
+				return fmt.Errorf("failed to copy the initiad libraries: %w", err)
+			}
+
+			if err = s.Create(fmt.Sprintf("cosmovisor@%s", initia.CosmovisorVersion), home); err != nil {
+				return fmt.Errorf("failed to create service: %w", err)
+			}
+			printAdopted("initia", home)
+			return nil
+		},
+	}
+
+	addAdoptFlags(adoptCmd, "initiad")
+	adoptCmd.Flags().Bool(FlagNoAutoUpgrade, false, "Do not let cosmovisor download and switch to upgrade binaries on its own")
+
+	return adoptCmd
+}
+
+func minitiaAdoptCommand() *cobra.Command {
+	shortDescription := "Manage an existing rollup full node home with weave"
+	adoptCmd := &cobra.Command{
+		Use:   "adopt",
+		Short: shortDescription,
+		Long: fmt.Sprintf("%s.\n\nThe chain ID is read from the genesis file, and the minitiad version and VM from the binary the node runs, "+
+			"found in PATH or given with --%s. The matching minitiad release is installed and the weave service is created for the home. "+
+			"Neither the configuration nor the data of the node is changed.\n\n%s", shortDescription, FlagBinary, RollupHelperText),
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			home, chainId, err := adoptableHome(cmd)
+			if err != nil {
+				return err
+			}
+			s, err := adoptableService(cmd, service.Minitia)
+			if err != nil {
+				return err
+			}
+
+			version, err := detectAdoptedVersion(cmd, home, "minitiad")
+			if err != nil {
+				return err
+			}
+			vm, _ := cmd.Flags().GetString(FlagVm)
+			if vm != "" {
+				if err = validateVMFlag(vm); err != nil {
+					return err
+				}
+			} else {
+				binaryPath, err := adoptedBinary(cmd, home, "minitiad")
+				if err != nil {
+					return err
+				}
+				if vm, err = cosmosutils.DetectMinitiaVM(binaryPath, home); err != nil {
+					return fmt.Errorf("%w: specify it with --%s", err, FlagVm)
+				}
+			}
+			fmt.Printf("Detected mini%s %s on %s.\n", vm, version, chainId)
+
+			url, err := cosmosutils.GetMinitiadBinaryURL(vm, version)
+			if err != nil {
+				return err
+			}
+			if _, err = cosmosutils.EnsureMinitiadBinary(vm, version, url); err != nil {
+				return fmt.Errorf("failed to install minitiad %s: %w", version, err)
+			}
+
+			if err = s.Create(fmt.Sprintf("mini%s@%s", vm, version), home); err != nil {
+				return fmt.Errorf("failed to create service: %w", err)
+			}
+			printAdopted("rollup", home)
+			return nil
+		},
+	}
+
+	addAdoptFlags(adoptCmd, "minitiad")
+	adoptCmd.Flags().String(FlagVm, "", fmt.Sprintf("VM of the rollup, detected when not given. Valid options are: %s", strings.Join(validVMOptions, ", ")))
+
+	return adoptCmd
+}
+
+func addAdoptFlags(cmd *cobra.Command, binaryName string) {
+	cmd.Flags().String(FlagHome, "", "Home directory of the existing node")
+	cmd.Flags().String(FlagBinary, "", fmt.Sprintf("Path to the %s binary the node runs, used to detect its version", binaryName))
+	cmd.Flags().BoolP(FlagForce, "f", false, "Replace the weave service if one already exists")
+	_ = cmd.MarkFlagRequired(FlagHome)
+}
+
+// adoptableHome returns the absolute path of the --home directory and its chain ID, after checking it holds a node configuration
+func adoptableHome(cmd *cobra.Command) (string, string, error) {
+	home, _ := cmd.Flags().GetString(FlagHome)
+	home, err := filepath.Abs(home)
+	if err != nil {
+		return "", "", fmt.Errorf("invalid --%s: %w", FlagHome, err)
+	}
+	for _, file := range []string{"config.toml", "app.toml", "genesis.json"} {
+		if !weaveio.FileOrFolderExists(filepath.Join(home, "config", file)) {
+			return "", "", fmt.Errorf("%s is not a node home directory: config/%s not found", home, file)
+		}
+	}
+	chainId, err := readGenesisChainId(home)
+	if err != nil {
+		return "", "", err
+	}
+	return home, chainId, nil
+}
+
+// adoptableService returns the service of commandName, refusing to replace one that exists unless --force is set
+func adoptableService(cmd *cobra.Command, commandName service.CommandName) (service.Service, error) {
+	s, err := service.NewService(commandName, "")
+	if err != nil {
+		return nil, err
+	}
+	force, _ := cmd.Flags().GetBool(FlagForce)
+	serviceFile, err := s.GetServiceFile()
+	if err != nil {
+		return nil, err
+	}
+	if weaveio.FileOrFolderExists(serviceFile) && !force {
+		_, existingHome, err := s.GetServiceBinaryAndHome()
+		if err != nil {
+			existingHome = serviceFile
+		}
+		return nil, fmt.Errorf("weave already manages a node at %s. Use --%s to replace its service", existingHome, FlagForce)
+	}
+	return s, nil
+}
+
+func adoptedBinary(cmd *cobra.Command, home, binaryName string) (string, error) {
+	binaryPath, _ := cmd.Flags().GetString(FlagBinary)
+	if binaryPath != "" {
+		if !weaveio.FileOrFolderExists(binaryPath) {
+			return "", fmt.Errorf("%s not found", binaryPath)
+		}
+		return binaryPath, nil
+	}
+	return cosmosutils.FindNodeBinary(home, binaryName)
+}
+
+func detectAdoptedVersion(cmd *cobra.Command, home, binaryName string) (string, error) {
+	binaryPath, err := adoptedBinary(cmd, home, binaryName)
+	if err != nil {
+		return "", fmt.Errorf("%w: specify it with --%s", err, FlagBinary)
+	}
+	version, err := cosmosutils.DetectBinaryVersion(binaryPath)
+	if err != nil {
+		return "", fmt.Errorf("failed to detect the version of %s: %w", binaryPath, err)
+	}
+	return version, nil
+}
+
+func printAdopted(command, home string) {
+	fmt.Printf("weave now manages the node at %s.\n", home)
+	fmt.Printf("Stop the process that runs the node today, then run `weave %s start`.\n", command)
+}
@@ -0,0 +1,339 @@
+package cmd
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/initia-labs/weave/client"
+	"github.com/initia-labs/weave/common"
+	"github.com/initia-labs/weave/config"
+	"github.com/initia-labs/weave/cosmosutils"
+	weaveio "github.com/initia-labs/weave/io"
+	"github.com/initia-labs/weave/models/initia"
+	"github.com/initia-labs/weave/models/minitia"
+	"github.com/initia-labs/weave/registry"
+)
+
+const (
+	bundleComponentInitia   = "initia"
+	bundleComponentOPInit   = "opinit"
+	bundleComponentCelestia = "celestia"
+
+	bundleComponentMinitiaPrefix = "minitia-"
+)
+
+// validBundleComponents are the components `weave bundle create` can download
+var validBundleComponents = []string{
+	bundleComponentInitia,
+	bundleComponentMinitiaPrefix + "evm",
+	bundleComponentMinitiaPrefix + "move",
+	bundleComponentMinitiaPrefix + "wasm",
+	bundleComponentOPInit,
+	bundleComponentCelestia,
+}
+
+func BundleCommand() *cobra.Command {
+	shortDescription := "Carry the weave-managed binaries to machines without access to GitHub"
+	bundleCmd := &cobra.Command{
+		Use:   "bundle",
+		Short: shortDescription,
+		Long: fmt.Sprintf("%s.\n\nA bundle holds the binaries, the release listings and the registry snapshots that weave fetches "+
+			"during init and launch, so that those flows run offline once the bundle is installed.", shortDescription),
+	}
+
+	bundleCmd.AddCommand(
+		bundleCreateCommand(),
+		bundleInstallCommand(),
+	)
+
+	return bundleCmd
+}
+
+func bundleCreateCommand() *cobra.Command {
+	shortDescription := "Download binaries and registry snapshots into a bundle"
+	createCmd := &cobra.Command{
+		Use:   "create",
+		Short: shortDescription,
+		Long: fmt.Sprintf("%s.\n\nThe binaries are downloaded into the weave data directory of this machine and archived together with "+
+			"the registry snapshots and a manifest. The bundle is for the OS and architecture of this machine. "+
+			"Components take the latest stable release unless a version is given with --%s, such as initia=v1.0.0. "+
+			"The celestia version is required. Rollup launches download the celestia version Celestia mainnet runs and only "+
+			"fall back to the bundled one when it cannot be queried, so bundle the version the network runs.", shortDescription, FlagVersions),
+		Example: "  weave bundle create --components initia,minitia-evm,opinit --versions initia=v1.0.0",
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			components, _ := cmd.Flags().GetStringSlice(FlagComponents)
+			versionFlags, _ := cmd.Flags().GetStringSlice(FlagVersions)
+			outputPath, _ := cmd.Flags().GetString(FlagOutput)
+
+			versions, err := parseBundleVersions(versionFlags, components)
+			if err != nil {
+				return err
+			}
+			if outputPath == "" {
+				outputPath = fmt.Sprintf("weave_bundle_%s.tar.gz", strings.ReplaceAll(weaveio.BundlePlatform(), "/", "_"))
+			}
+
+			userHome, err := os.UserHomeDir()
+			if err != nil {
+				return fmt.Errorf("failed to get user home directory: %v", err)
+			}
+			dataDir := filepath.Join(userHome, common.WeaveDataDirectory)
+
+			manifest := weaveio.BundleManifest{Platform: weaveio.BundlePlatform(), CreatedAt: time.Now().UTC()}
+			releaseVersions := make(map[string][]string)
+			for _, component := range components {
+				fmt.Printf("Downloading %s...\n", component)
+				bundled, releaseAPI, err := prepareBundleComponent(component, versions[component], dataDir)
+				if err != nil {
+					return fmt.Errorf("failed to download %s: %w", component, err)
+				}
+				manifest.Components = append(manifest.Components, bundled...)
+				if releaseAPI != "" {
+					releaseVersions[releaseAPI] = append(releaseVersions[releaseAPI], bundled[0].Version)
+				}
+			}
+
+			cache := make(map[string][]byte)
+			for releaseAPI, bundledVersions := range releaseVersions {
+				listing, err := cosmosutils.ReleaseListing(releaseAPI, bundledVersions)
+				if err != nil {
+					return fmt.Errorf("failed to fetch the releases at %s: %w", releaseAPI, err)
+				}
+				cache[releaseAPI] = listing
+			}
+			fmt.Println("Downloading the registry snapshots...")
+			httpClient := client.NewHTTPClient()
+			for _, endpoint := range registry.RegistryEndpoints() {
+				snapshot, err := httpClient.Get(endpoint, "", nil, nil)
+				if err != nil {
+					return fmt.Errorf("failed to fetch %s: %w", endpoint, err)
+				}
+				cache[endpoint] = snapshot
+			}
+
+			fmt.Printf("Archiving into %s...\n", outputPath)
+			if err = writeBundle(outputPath, dataDir, manifest, cache); err != nil {
+				return fmt.Errorf("failed to create bundle: %w", err)
+			}
+			fmt.Printf("Created bundle for %s: %s\n", manifest.Platform, outputPath)
+			printBundleComponents(manifest.Components)
+			return nil
+		},
+	}
+
+	createCmd.Flags().StringSlice(FlagComponents, nil, fmt.Sprintf("Components to include. Valid options are: %s", strings.Join(validBundleComponents, ", ")))
+	createCmd.Flags().StringSlice(FlagVersions, nil, "Versions of the components as <component>=<version>")
+	createCmd.Flags().String(FlagOutput, "", "Path to write the bundle to. Defaults to weave_bundle_<os>_<arch>.tar.gz in the current directory")
+	_ = createCmd.MarkFlagRequired(FlagComponents)
+
+	return createCmd
+}
+
+func bundleInstallCommand() *cobra.Command {
+	shortDescription := "Install the binaries and registry snapshots of a bundle"
+	installCmd := &cobra.Command{
+		Use:   "install <file>",
+		Short: shortDescription,
+		Long: fmt.Sprintf("%s.\n\nThe bundle is unpacked into the weave data directory, replacing the binaries of the same versions. "+
+			"It is verified against <file>.sha256 when it exists. Afterwards weave falls back to the bundled release listings "+
+			"and registry snapshots whenever GitHub or the registry cannot be reached.", shortDescription),
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			bundlePath, err := filepath.Abs(args[0])
+			if err != nil {
+				return err
+			}
+			userHome, err := os.UserHomeDir()
+			if err != nil {
+				return fmt.Errorf("failed to get user home directory: %v", err)
+			}
+
+			// Verify the whole file before anything is moved into the data directory
+			err = client.NewHTTPClient().StreamFileWithOptions(client.LocalFileURLPrefix+bundlePath, nil, nil, client.DownloadOptions{FetchPublishedChecksum: true}, func(r io.Reader) error {
+				_, err := io.Copy(io.Discard, r)
+				return err
+			})
+			if err != nil {
+				return fmt.Errorf("failed to verify bundle: %w", err)
+			}
+
+			file, err := os.Open(bundlePath)
+			if err != nil {
+				return err
+			}
+			defer file.Close()
+			manifest, err := weaveio.InstallBundle(file, filepath.Join(userHome, common.WeaveDataDirectory))
+			if err != nil {
+				return fmt.Errorf("failed to install bundle: %w", err)
+			}
+
+			fmt.Printf("Installed bundle created at %s.\n", manifest.CreatedAt.Format(time.RFC3339))
+			printBundleComponents(manifest.Components)
+			return nil
+		},
+	}
+
+	return installCmd
+}
+
+// parseBundleVersions maps each component to the version given for it in versionFlags
+func parseBundleVersions(versionFlags, components []string) (map[string]string, error) {
+	selected := make(map[string]bool)
+	for _, component := range components {
+		if !isValidBundleComponent(component) {
+			return nil, fmt.Errorf("invalid component %q. Valid options are: %s", component, strings.Join(validBundleComponents, ", "))
+		}
+		if selected[component] {
+			return nil, fmt.Errorf("component %s is given more than once", component)
+		}
+		selected[component] = true
+	}
+
+	versions := make(map[string]string)
+	for _, versionFlag := range versionFlags {
+		component, version, found := strings.Cut(versionFlag, "=")
+		if !found || version == "" {
+			return nil, fmt.Errorf("invalid --%s %q, expected <component>=<version>", FlagVersions, versionFlag)
+		}
+		if !selected[component] {
+			return nil, fmt.Errorf("--%s names %s, which is not in --%s", FlagVersions, component, FlagComponents)
+		}
+		versions[component] = version
+	}
+	if selected[bundleComponentCelestia] && versions[bundleComponentCelestia] == "" {
+		return nil, fmt.Errorf("the celestia version must be given with --%s celestia=<version>", FlagVersions)
+	}
+	return versions, nil
+}
+
+func isValidBundleComponent(component string) bool {
+	for _, valid := range validBundleComponents {
+		if component == valid {
+			return true
+		}
+	}
+	return false
+}
+
+// prepareBundleComponent makes sure component is in dataDir and returns the directories to bundle for it,
+// with the release API the version was picked from
+func prepareBundleComponent(component, version, dataDir string) ([]weaveio.BundleComponent, string, error) {
+	switch {
+	case component == bundleComponentInitia:
+		version, url, err := cosmosutils.GetReleaseDownloadURL(cosmosutils.InitiaReleaseAPI, version)
+		if err != nil {
+			return nil, "", err
+		}
+		binaryPath, err := cosmosutils.GetInitiaBinaryPath(version)
+		if err != nil {
+			return nil, "", err
+		}
+		if err = cosmosutils.InstallInitiaBinary(version, url, binaryPath); err != nil {
+			return nil, "", err
+		}
+		if _, err = cosmosutils.InstallCosmovisor(initia.CosmovisorVersion); err != nil {
+			return nil, "", err
+		}
+		return []weaveio.BundleComponent{
+			{Name: component, Version: version, Path: fmt.Sprintf("initia@%s", version)},
+			{Name: "cosmovisor", Version: initia.CosmovisorVersion, Path: fmt.Sprintf("cosmovisor@%s", initia.CosmovisorVersion)},
+		}, cosmosutils.InitiaReleaseAPI, nil
+
+	case strings.HasPrefix(component, bundleComponentMinitiaPrefix):
+		vm := strings.TrimPrefix(component, bundleComponentMinitiaPrefix)
+		releaseAPI := cosmosutils.MinitiaReleaseAPI(vm)
+		version, url, err := cosmosutils.GetReleaseDownloadURL(releaseAPI, version)
+		if err != nil {
+			return nil, "", err
+		}
+		if _, err = cosmosutils.EnsureMinitiadBinary(vm, version, url); err != nil {
+			return nil, "", err
+		}
+		return []weaveio.BundleComponent{{Name: component, Version: version, Path: fmt.Sprintf("mini%s@%s", vm, version)}}, releaseAPI, nil
+
+	case component == bundleComponentOPInit:
+		version, url, err := cosmosutils.GetReleaseDownloadURL(cosmosutils.OPInitBotsReleaseAPI, version)
+		if err != nil {
+			return nil, "", err
+		}
+		path := fmt.Sprintf("opinitd@%s", version)
+		if err = downloadBundleBinary(url, dataDir, path, common.OPinitAppName); err != nil {
+			return nil, "", err
+		}
+		return []weaveio.BundleComponent{{Name: component, Version: version, Path: path}}, cosmosutils.OPInitBotsReleaseAPI, nil
+
+	case component == bundleComponentCelestia:
+		// The Celestia data directory and release URL both use the version without the v prefix
+		version = strings.TrimPrefix(version, "v")
+		url, err := minitia.GetCelestiaBinaryURL(version)
+		if err != nil {
+			return nil, "", err
+		}
+		path := fmt.Sprintf("celestia@%s", version)
+		if err = downloadBundleBinary(url, dataDir, path, minitia.CelestiaAppName); err != nil {
+			return nil, "", err
+		}
+		return []weaveio.BundleComponent{{Name: component, Version: version, Path: path}}, "", nil
+
+	default:
+		return nil, "", fmt.Errorf("invalid component %q", component)
+	}
+}
+
+// downloadBundleBinary extracts the release at url into dataDir/path unless binaryName is already there
+func downloadBundleBinary(url, dataDir, path, binaryName string) error {
+	extractedPath := filepath.Join(dataDir, path)
+	binaryPath := filepath.Join(extractedPath, binaryName)
+	if weaveio.FileOrFolderExists(binaryPath) {
+		return nil
+	}
+	if err := os.MkdirAll(extractedPath, os.ModePerm); err != nil {
+		return fmt.Errorf("failed to create %s: %w", extractedPath, err)
+	}
+	if err := weaveio.DownloadAndExtractTarGz(url, filepath.Join(dataDir, binaryName+".tar.gz"), extractedPath, config.GetReleaseVerifyOptions()); err != nil {
+		return fmt.Errorf("failed to download and extract binary: %w", err)
+	}
+	return os.Chmod(binaryPath, 0o755)
+}
+
+func writeBundle(outputPath, dataDir string, manifest weaveio.BundleManifest, cache map[string][]byte) (err error) {
+	file, err := os.OpenFile(outputPath, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0o644)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if closeErr := file.Close(); err == nil && closeErr != nil {
+			err = closeErr
+		}
+		if err != nil {
+			_ = os.Remove(outputPath)
+			_ = os.Remove(outputPath + client.ChecksumFileSuffix)
+		}
+	}()
+
+	hash := sha256.New()
+	if err = weaveio.CreateBundle(io.MultiWriter(file, hash), dataDir, manifest, cache); err != nil {
+		return err
+	}
+
+	checksum := fmt.Sprintf("%s  %s\n", hex.EncodeToString(hash.Sum(nil)), filepath.Base(outputPath))
+	return os.WriteFile(outputPath+client.ChecksumFileSuffix, []byte(checksum), 0o644)
+}
+
+func printBundleComponents(components []weaveio.BundleComponent) {
+	sorted := append([]weaveio.BundleComponent{}, components...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Name < sorted[j].Name })
+	for _, component := range sorted {
+		fmt.Printf("  %s %s\n", component.Name, component.Version)
+	}
+}
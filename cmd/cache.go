@@ -0,0 +1,244 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/charmbracelet/x/term"
+	"github.com/spf13/cobra"
+
+	"github.com/initia-labs/weave/common"
+	"github.com/initia-labs/weave/config"
+	weaveio "github.com/initia-labs/weave/io"
+	"github.com/initia-labs/weave/service"
+	"github.com/initia-labs/weave/ui"
+)
+
+// cacheServices are the services that run a binary out of the weave data directory
+var cacheServices = []service.CommandName{
+	service.UpgradableInitia,
+	service.NonUpgradableInitia,
+	service.Minitia,
+	service.OPinitExecutor,
+	service.OPinitChallenger,
+}
+
+func CacheCommand() *cobra.Command {
+	shortDescription := "Inspect and clean up the downloaded binaries and temp files of weave"
+	cacheCmd := &cobra.Command{
+		Use:   "cache",
+		Short: shortDescription,
+		Long: fmt.Sprintf("%s.\n\nEvery initiad, minitiad, opinitd and celestia-appd version weave installs is kept under ~/%s, "+
+			"together with release tarballs, partial downloads and temp files left by rollup launches.", shortDescription, common.WeaveDataDirectory),
+	}
+
+	cacheCmd.AddCommand(
+		cacheListCommand(),
+		cachePruneCommand(),
+	)
+
+	return cacheCmd
+}
+
+func cacheListCommand() *cobra.Command {
+	shortDescription := "List the artifacts in the weave data directory"
+	listCmd := &cobra.Command{
+		Use:   "list",
+		Short: shortDescription,
+		Long: fmt.Sprintf("%s.\n\nEach artifact is shown with its size, version and the services whose systemd or launchd unit runs it.",
+			shortDescription),
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			artifacts, err := listCacheArtifacts()
+			if err != nil {
+				return err
+			}
+			if asJSON, _ := cmd.Flags().GetBool(FlagJSON); asJSON {
+				if artifacts == nil {
+					artifacts = []weaveio.DataArtifact{}
+				}
+				return printJSON(artifacts)
+			}
+			if len(artifacts) == 0 {
+				fmt.Println("The weave data directory is empty.")
+				return nil
+			}
+			return printCacheArtifacts(artifacts, true)
+		},
+	}
+
+	listCmd.Flags().Bool(FlagJSON, false, "Output in JSON format")
+
+	return listCmd
+}
+
+func cachePruneCommand() *cobra.Command {
+	shortDescription := "Remove unused binaries and stale temp files from the weave data directory"
+	pruneCmd := &cobra.Command{
+		Use:   "prune",
+		Short: shortDescription,
+		Long: fmt.Sprintf("%s.\n\nA binary version is removed unless a systemd or launchd unit runs it. Binaries, tarballs, partial "+
+			"downloads and temp files are removed once they are older than --%s, so that an install in progress is not disturbed. "+
+			"The rollup launch config holds the mnemonics of the system keys and is only removed with --%s. "+
+			"The bundle cache is kept for offline installs.", shortDescription, FlagOlderThan, FlagIncludeLaunchConfig),
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			olderThan, _ := cmd.Flags().GetDuration(FlagOlderThan)
+			includeLaunchConfig, _ := cmd.Flags().GetBool(FlagIncludeLaunchConfig)
+			dryRun, _ := cmd.Flags().GetBool(FlagDryRun)
+			yes, _ := cmd.Flags().GetBool(FlagYes)
+			if olderThan < 0 {
+				return fmt.Errorf("--%s must not be negative", FlagOlderThan)
+			}
+
+			artifacts, err := listCacheArtifacts()
+			if err != nil {
+				return err
+			}
+			candidates := weaveio.PruneCandidates(artifacts, weaveio.DataPruneOptions{
+				StaleAfter:          olderThan,
+				IncludeLaunchConfig: includeLaunchConfig,
+			}, time.Now())
+			if len(candidates) == 0 {
+				fmt.Println("Nothing to prune.")
+				return nil
+			}
+
+			var total int64
+			for _, artifact := range candidates {
+				total += artifact.Size
+			}
+			if err := printCacheArtifacts(candidates, false); err != nil {
+				return err
+			}
+			fmt.Printf("\n%d artifacts, %s\n", len(candidates), ui.ByteCountSI(total))
+			if dryRun {
+				return nil
+			}
+			if !yes {
+				if !term.IsTerminal(os.Stdin.Fd()) {
+					return fmt.Errorf("use --%s to prune in a non-interactive session", FlagYes)
+				}
+				if ok, err := askYesNo("Remove these artifacts?"); err != nil || !ok {
+					return err
+				}
+			}
+
+			for _, artifact := range candidates {
+				if err := os.RemoveAll(artifact.Path); err != nil {
+					return fmt.Errorf("failed to remove %s: %w", artifact.Path, err)
+				}
+			}
+			fmt.Printf("Freed %s.\n", ui.ByteCountSI(total))
+			return nil
+		},
+	}
+
+	pruneCmd.Flags().Duration(FlagOlderThan, 24*time.Hour, "Only remove binaries, tarballs, partial downloads and temp files older than this")
+	pruneCmd.Flags().Bool(FlagIncludeLaunchConfig, false, "Also remove the rollup launch config, which holds the system key mnemonics")
+	pruneCmd.Flags().Bool(FlagDryRun, false, "Show what would be removed without removing anything")
+	pruneCmd.Flags().Bool(FlagYes, false, "Remove without asking for confirmation")
+
+	return pruneCmd
+}
+
+// listCacheArtifacts lists the weave data directory and marks the artifacts the installed services run
+func listCacheArtifacts() ([]weaveio.DataArtifact, error) {
+	userHome, err := os.UserHomeDir()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user home directory: %v", err)
+	}
+	dataDir := filepath.Join(userHome, common.WeaveDataDirectory)
+
+	artifacts, err := weaveio.ListDataArtifacts(dataDir)
+	if err != nil {
+		return nil, err
+	}
+	usedBy, err := cacheArtifactsInUse(dataDir)
+	if err != nil {
+		return nil, err
+	}
+	for i := range artifacts {
+		artifacts[i].UsedBy = usedBy[artifacts[i].Name]
+	}
+	return artifacts, nil
+}
+
+// cacheArtifactsInUse maps the data directory entries that service units point at to the services.
+// The data directory is shared by all profiles, so the services of every profile are checked.
+// A unit that cannot be read is an error, since its binary could otherwise be pruned.
+func cacheArtifactsInUse(dataDir string) (map[string][]string, error) {
+	resolvedDataDir, err := filepath.EvalSymlinks(dataDir)
+	if err != nil {
+		resolvedDataDir = dataDir
+	}
+	profiles, err := config.ListProfiles()
+	if err != nil {
+		return nil, err
+	}
+
+	usedBy := make(map[string][]string)
+	for _, profile := range profiles {
+		for _, commandName := range cacheServices {
+			s, err := service.NewServiceForProfile(commandName, "", profile.Name)
+			if err != nil {
+				return nil, err
+			}
+			serviceFile, err := s.GetServiceFile()
+			if err != nil {
+				return nil, fmt.Errorf("failed to get the %s service file: %w", commandName, err)
+			}
+			if !weaveio.FileOrFolderExists(serviceFile) {
+				continue
+			}
+			binaryPath, _, err := s.GetServiceBinaryAndHome()
+			if err != nil {
+				return nil, fmt.Errorf("failed to read the binary of the %s service from %s: %w", commandName, serviceFile, err)
+			}
+
+			user := string(commandName) + config.ProfileServiceSuffix(profile.Name)
+			paths := []string{binaryPath}
+			if resolved, err := filepath.EvalSymlinks(binaryPath); err == nil {
+				paths = append(paths, resolved)
+			}
+			for _, path := range paths {
+				for _, dir := range []string{dataDir, resolvedDataDir} {
+					if name, ok := weaveio.DataArtifactOf(dir, path); ok && !slices.Contains(usedBy[name], user) {
+						usedBy[name] = append(usedBy[name], user)
+					}
+				}
+			}
+		}
+	}
+	return usedBy, nil
+}
+
+func printCacheArtifacts(artifacts []weaveio.DataArtifact, showUsage bool) error {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	if showUsage {
+		fmt.Fprintln(w, "NAME\tKIND\tVERSION\tSIZE\tMODIFIED\tUSED BY")
+	} else {
+		fmt.Fprintln(w, "NAME\tKIND\tVERSION\tSIZE\tMODIFIED")
+	}
+	for _, artifact := range artifacts {
+		version := artifact.Version
+		if version == "" {
+			version = "-"
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s", artifact.Name, artifact.Kind, version, ui.ByteCountSI(artifact.Size), artifact.ModTime.Format(time.DateTime))
+		if showUsage {
+			usedBy := "-"
+			if len(artifact.UsedBy) > 0 {
+				usedBy = strings.Join(artifact.UsedBy, ", ")
+			}
+			fmt.Fprintf(w, "\t%s", usedBy)
+		}
+		fmt.Fprintln(w)
+	}
+	return w.Flush()
+}
@@ -0,0 +1,179 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	"github.com/initia-labs/weave/common"
+	"github.com/initia-labs/weave/config"
+)
+
+func ConfigCommand() *cobra.Command {
+	shortDescription := "Inspect and edit the settings of weave"
+	configCmd := &cobra.Command{
+		Use:   "config",
+		Short: shortDescription,
+		Long: fmt.Sprintf("%s.\n\nSettings are stored in ~/%s and addressed by their dotted key, such as %s. "+
+			"Mnemonics, passphrases and their encrypted forms are never shown.", shortDescription, common.WeaveConfigFile, config.ProxyKey),
+	}
+
+	configCmd.AddCommand(
+		configListCommand(),
+		configGetCommand(),
+		configSetCommand(),
+		configUnsetCommand(),
+	)
+
+	return configCmd
+}
+
+func configListCommand() *cobra.Command {
+	shortDescription := "List the known settings and every value in the config file"
+	listCmd := &cobra.Command{
+		Use:   "list",
+		Short: shortDescription,
+		Long:  fmt.Sprintf("%s.\n\nSettings that are not set show as - and take their default value.", shortDescription),
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			entries := config.ListConfig()
+			if asJSON, _ := cmd.Flags().GetBool(FlagJSON); asJSON {
+				return printJSON(entries)
+			}
+			return printConfigEntries(entries)
+		},
+	}
+
+	listCmd.Flags().Bool(FlagJSON, false, "Output in JSON format")
+
+	return listCmd
+}
+
+func configGetCommand() *cobra.Command {
+	shortDescription := "Show the value of a setting"
+	getCmd := &cobra.Command{
+		Use:   "get <key>",
+		Short: shortDescription,
+		Long: fmt.Sprintf("%s.\n\nA section such as common.network shows every value under it. "+
+			"Nothing is printed for a known setting that is not set.", shortDescription),
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			key := strings.ToLower(args[0])
+			entries := config.ListConfig()
+			asJSON, _ := cmd.Flags().GetBool(FlagJSON)
+
+			if value, ok := entries[key]; ok {
+				if asJSON {
+					return printJSON(value)
+				}
+				fmt.Println(formatConfigValue(value, ""))
+				return nil
+			}
+
+			section := make(map[string]interface{})
+			for entryKey, value := range entries {
+				if strings.HasPrefix(entryKey, key+".") && value != nil {
+					section[entryKey] = value
+				}
+			}
+			if len(section) == 0 {
+				return fmt.Errorf("%s is not set, run `weave config list` to see the known settings", key)
+			}
+			if asJSON {
+				return printJSON(section)
+			}
+			return printConfigEntries(section)
+		},
+	}
+
+	getCmd.Flags().Bool(FlagJSON, false, "Output in JSON format")
+
+	return getCmd
+}
+
+func configSetCommand() *cobra.Command {
+	shortDescription := "Change the value of a setting"
+	var settings strings.Builder
+	for _, setting := range config.Settings {
+		if setting.ManagedBy != "" {
+			continue
+		}
+		settings.WriteString(fmt.Sprintf("  %s (%s)\n      %s\n", setting.Key, setting.Type, setting.Description))
+	}
+
+	setCmd := &cobra.Command{
+		Use:   "set <key> <value>",
+		Short: shortDescription,
+		Long: fmt.Sprintf("%s.\n\nThe value is checked against the type of the setting before it is saved. "+
+			"Mirrors are given as comma-separated prefix=mirror pairs, and replace the ones already set.\n\n"+
+			"Known settings:\n%s", shortDescription, settings.String()),
+		Example: fmt.Sprintf("  weave config set %s 5\n  weave config set %s https://api.github.com=https://github-mirror.example.com",
+			config.RetryMaxAttemptsKey, config.MirrorsKey),
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := config.SetSetting(args[0], args[1]); err != nil {
+				return err
+			}
+			fmt.Printf("Set %s.\n", strings.ToLower(args[0]))
+			return nil
+		},
+	}
+
+	return setCmd
+}
+
+func configUnsetCommand() *cobra.Command {
+	shortDescription := "Remove a setting from the config file so that its default applies again"
+	unsetCmd := &cobra.Command{
+		Use:   "unset <key>",
+		Short: shortDescription,
+		Long: fmt.Sprintf("%s.\n\nKeys weave does not know about, such as the ones left by an older version, can be removed as well. "+
+			"The gas station is changed through the gas-station commands instead.", shortDescription),
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := config.UnsetSetting(args[0]); err != nil {
+				return err
+			}
+			fmt.Printf("Unset %s.\n", strings.ToLower(args[0]))
+			return nil
+		},
+	}
+
+	return unsetCmd
+}
+
+func printConfigEntries(entries map[string]interface{}) error {
+	keys := make([]string, 0, len(entries))
+	for key := range entries {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "KEY\tVALUE")
+	for _, key := range keys {
+		fmt.Fprintf(w, "%s\t%s\n", key, formatConfigValue(entries[key], "-"))
+	}
+	return w.Flush()
+}
+
+// formatConfigValue prints maps, such as the mirrors, as the comma-separated pairs they are set with
+func formatConfigValue(value interface{}, unset string) string {
+	switch v := value.(type) {
+	case nil:
+		return unset
+	case map[string]interface{}:
+		pairs := make([]string, 0, len(v))
+		for key, nested := range v {
+			pairs = append(pairs, fmt.Sprintf("%s=%v", key, nested))
+		}
+		sort.Strings(pairs)
+		return strings.Join(pairs, ",")
+	default:
+		return fmt.Sprint(v)
+	}
+}
@@ -11,8 +11,66 @@ const (
 	FlagOPInitHome  = "opinit-dir"
 
 	FlagPollingInterval = "polling-interval"
+	FlagInterval        = "interval"
+	FlagWatch           = "watch"
 
 	FlagWithConfig      = "with-config"
 	FlagKeyFile         = "key-file"
 	FlagGenerateKeyFile = "generate-key-file"
+
+	FlagOutput   = "output"
+	FlagJSON     = "json"
+	FlagBalances = "balances"
+
+	FlagTo       = "to"
+	FlagPrefix   = "prefix"
+	FlagCoinType = "coin-type"
+	FlagAccount  = "account"
+	FlagIndex    = "index"
+
+	FlagPubKey = "pubkey"
+
+	FlagFormat       = "format"
+	FlagKeepAddrBook = "keep-addrbook"
+
+	FlagSeed      = "seed"
+	FlagSkipCheck = "skip-check"
+	FlagRestart   = "restart"
+
+	FlagNoStage          = "no-stage"
+	FlagIncludeProposals = "include-proposals"
+
+	FlagKeyName                 = "key-name"
+	FlagRecover                 = "recover"
+	FlagAmount                  = "amount"
+	FlagNoFund                  = "no-fund"
+	FlagYes                     = "yes"
+	FlagMoniker                 = "moniker"
+	FlagIdentity                = "identity"
+	FlagWebsite                 = "website"
+	FlagSecurityContact         = "security-contact"
+	FlagDetails                 = "details"
+	FlagCommissionRate          = "commission-rate"
+	FlagCommissionMaxRate       = "commission-max-rate"
+	FlagCommissionMaxChangeRate = "commission-max-change-rate"
+
+	FlagHome                 = "home"
+	FlagWarnMissedBlocks     = "warn-missed-blocks"
+	FlagCriticalMissedBlocks = "critical-missed-blocks"
+	FlagLogFile              = "log-file"
+	FlagWebhook              = "webhook"
+
+	FlagBinary        = "binary"
+	FlagNoAutoUpgrade = "no-auto-upgrade"
+
+	FlagComponents = "components"
+	FlagVersions   = "versions"
+
+	FlagInsecureSkipVerify = "insecure-skip-verify"
+
+	FlagOlderThan           = "older-than"
+	FlagIncludeLaunchConfig = "include-launch-config"
+	FlagDryRun              = "dry-run"
+
+	FlagProfile = "profile"
 )
@@ -12,9 +12,11 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/initia-labs/weave/analytics"
+	"github.com/initia-labs/weave/client"
 	"github.com/initia-labs/weave/config"
 	weavecontext "github.com/initia-labs/weave/context"
 	"github.com/initia-labs/weave/cosmosutils"
+	"github.com/initia-labs/weave/crypto"
 	"github.com/initia-labs/weave/models"
 	"github.com/initia-labs/weave/registry"
 )
@@ -83,7 +85,7 @@ func fetchInitiaRegistryAssetList(chainType registry.ChainType) (*AssetList, err
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
 
-	resp, err := http.DefaultClient.Do(req)
+	resp, err := client.NewHTTPClient().Do(req)
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch asset list: %w", err)
 	}
@@ -166,6 +168,10 @@ func GasStationCommand() *cobra.Command {
 	cmd.AddCommand(
 		gasStationSetupCommand(),
 		gasStationShowCommand(),
+		gasStationLockCommand(),
+		gasStationUnlockCommand(),
+		gasStationChangePassphraseCommand(),
+		gasStationSetSignerCommand(),
 	)
 
 	return cmd
@@ -179,6 +185,9 @@ func gasStationSetupCommand() *cobra.Command {
 		Long:  fmt.Sprintf("%s.\n\n%s", shortDescription, GasStationHelperText),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			analytics.TrackRunEvent(cmd, args, analytics.SetupGasStationFeature, analytics.NewEmptyEvent())
+			if err := ensureGasStationSetupPassphrase(); err != nil {
+				return err
+			}
 			ctx := weavecontext.NewAppContext(models.NewExistingCheckerState())
 			if finalModel, err := tea.NewProgram(models.NewGasStationMethodSelect(ctx), tea.WithAltScreen()).Run(); err != nil {
 				return err
@@ -235,7 +244,7 @@ func getMaxWidth(coinGroups ...*cosmosutils.Coins) int {
 }
 
 func showGasStationBalance() error {
-	gasStationKey, err := config.GetGasStationKey()
+	gasStationKey, err := config.GetGasStationPublicKey()
 	if err != nil {
 		return err
 	}
@@ -309,3 +318,156 @@ func gasStationShowCommand() *cobra.Command {
 
 	return showCmd
 }
+
+func gasStationLockCommand() *cobra.Command {
+	shortDescription := "Encrypt the Gas Station mnemonic with a passphrase"
+	lockCmd := &cobra.Command{
+		Use:   "lock",
+		Short: shortDescription,
+		Long: fmt.Sprintf("%s.\n\nThe passphrase is read from %s if set, otherwise you will be prompted for it. "+
+			"Once locked, commands that need to sign with the Gas Station will ask for the passphrase.\n\n%s",
+			shortDescription, config.PassphraseEnvVar, GasStationHelperText),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if config.IsFirstTimeSetup() {
+				fmt.Println("Please setup Gas Station first, by running `weave gas-station setup`")
+				return nil
+			}
+			if config.IsGasStationKeyLocked() {
+				fmt.Println("Gas Station key is already locked. Use `weave gas-station change-passphrase` to rotate the passphrase.")
+				return nil
+			}
+
+			passphrase := config.GetGasStationPassphrase()
+			if passphrase == "" {
+				var err error
+				passphrase, err = readNewPassphrase()
+				if err != nil {
+					return err
+				}
+			}
+
+			if err := config.LockGasStationKey(passphrase); err != nil {
+				return err
+			}
+			fmt.Println("🔒 Gas Station mnemonic is now encrypted at rest.")
+			return nil
+		},
+	}
+
+	return lockCmd
+}
+
+func gasStationUnlockCommand() *cobra.Command {
+	shortDescription := "Decrypt the Gas Station mnemonic and store it in plaintext"
+	unlockCmd := &cobra.Command{
+		Use:   "unlock",
+		Short: shortDescription,
+		Long:  fmt.Sprintf("%s.\n\n%s", shortDescription, GasStationHelperText),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if !config.IsGasStationKeyLocked() {
+				fmt.Println("Gas Station key is not locked.")
+				return nil
+			}
+
+			passphrase := config.GetGasStationPassphrase()
+			if passphrase == "" {
+				var err error
+				passphrase, err = readPassphrase("Enter gas station passphrase: ")
+				if err != nil {
+					return err
+				}
+			}
+
+			if err := config.UnlockGasStationKey(passphrase); err != nil {
+				return err
+			}
+			fmt.Println("🔓 Gas Station mnemonic is now stored in plaintext. Run `weave gas-station lock` to encrypt it again.")
+			return nil
+		},
+	}
+
+	return unlockCmd
+}
+
+func gasStationChangePassphraseCommand() *cobra.Command {
+	shortDescription := "Change the passphrase protecting the Gas Station mnemonic"
+	changeCmd := &cobra.Command{
+		Use:   "change-passphrase",
+		Short: shortDescription,
+		Long:  fmt.Sprintf("%s.\n\n%s", shortDescription, GasStationHelperText),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if !config.IsGasStationKeyLocked() {
+				fmt.Println("Gas Station key is not locked. Use `weave gas-station lock` to set a passphrase.")
+				return nil
+			}
+
+			currentPassphrase := config.GetGasStationPassphrase()
+			if currentPassphrase == "" {
+				var err error
+				currentPassphrase, err = readPassphrase("Enter current passphrase: ")
+				if err != nil {
+					return err
+				}
+			}
+
+			newPassphrase, err := readNewPassphrase()
+			if err != nil {
+				return err
+			}
+
+			if err := config.ChangeGasStationPassphrase(currentPassphrase, newPassphrase); err != nil {
+				return err
+			}
+			fmt.Println("🔒 Gas Station passphrase has been changed.")
+			return nil
+		},
+	}
+
+	return changeCmd
+}
+
+func gasStationSetSignerCommand() *cobra.Command {
+	shortDescription := "Use an external command to sign Gas Station transactions"
+	setSignerCmd := &cobra.Command{
+		Use:   "set-signer --pubkey <public-key> -- <command> [args...]",
+		Short: shortDescription,
+		Long: fmt.Sprintf("%s.\n\n"+
+			"The private key stays with the command, e.g. a bridge to your KMS, and weave never stores a mnemonic. "+
+			"For every transaction the command receives a JSON request on stdin with the chain_id, account_number, sequence, "+
+			"address, key_type, pub_key and the base64-encoded SIGN_MODE_DIRECT sign_bytes. "+
+			"It must print the base64-encoded 64-byte signature (R || S) on stdout.\n\n%s",
+			shortDescription, GasStationHelperText),
+		Args: cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			pubKey, _ := cmd.Flags().GetString(FlagPubKey)
+			coinType, _ := cmd.Flags().GetInt(FlagCoinType)
+			force, _ := cmd.Flags().GetBool(FlagForce)
+
+			if !config.IsFirstTimeSetup() && !force {
+				return fmt.Errorf("gas station key already exists, use --%s to replace it", FlagForce)
+			}
+
+			gasKey, err := config.NewExternalSignerGasStationKey(crypto.ExternalSignerConfig{
+				Command: args,
+				PubKey:  pubKey,
+			}, coinType)
+			if err != nil {
+				return err
+			}
+			if err := config.SaveGasStationKey(gasKey); err != nil {
+				return err
+			}
+
+			fmt.Printf("🔑 Gas Station transactions are now signed by `%s`.\n", strings.Join(args, " "))
+			fmt.Printf("\n⛽️ Initia Address: %s\n⛽️ Celestia Address: %s\n", gasKey.InitiaAddress, gasKey.CelestiaAddress)
+			return nil
+		},
+	}
+
+	setSignerCmd.Flags().String(FlagPubKey, "", "Hex or base64 encoded compressed secp256k1 public key of the external signer")
+	setSignerCmd.Flags().Int(FlagCoinType, 60, "Coin type of the Initia address, 60 (EVM) or 118 (Cosmos)")
+	setSignerCmd.Flags().Bool(FlagForce, false, "Replace an existing Gas Station key")
+	_ = setSignerCmd.MarkFlagRequired(FlagPubKey)
+
+	return setSignerCmd
+}
@@ -20,6 +20,9 @@ func InitCommand() *cobra.Command {
 		RunE: func(cmd *cobra.Command, args []string) error {
 			analytics.TrackEvent(analytics.RunEvent, analytics.NewEmptyEvent().Add(analytics.CommandEventKey, cmd.CommandPath()))
 			if config.IsFirstTimeSetup() {
+				if err := ensureGasStationSetupPassphrase(); err != nil {
+					return err
+				}
 				ctx := weavecontext.NewAppContext(models.NewExistingCheckerState())
 
 				// Capture both the final model and the error from Run()
@@ -13,6 +13,7 @@ import (
 	weavecontext "github.com/initia-labs/weave/context"
 	"github.com/initia-labs/weave/models/initia"
 	"github.com/initia-labs/weave/service"
+	"github.com/initia-labs/weave/types"
 )
 
 func InitiaCommand() *cobra.Command {
@@ -27,10 +28,16 @@ func InitiaCommand() *cobra.Command {
 
 	cmd.AddCommand(
 		initiaInitCommand(),
+		initiaAdoptCommand(),
 		initiaStartCommand(),
 		initiaStopCommand(),
 		initiaRestartCommand(),
 		initiaLogCommand(),
+		initiaStatusCommand(),
+		initiaPeersCommand(),
+		initiaUpgradeCommand(),
+		initiaValidatorCommand(),
+		snapshotCommand(service.UpgradableInitia, "Initia full node", L1NodeHelperText),
 	)
 
 	return cmd
@@ -41,15 +48,31 @@ func initiaInitCommand() *cobra.Command {
 	initCmd := &cobra.Command{
 		Use:   "init",
 		Short: shortDescription,
-		Long:  fmt.Sprintf("%s.\n\n%s", shortDescription, L1NodeHelperText),
+		Long: fmt.Sprintf("%s.\n\nThe node role (%s) selects a preset of config.toml and app.toml settings, which can be reviewed and edited before it is applied. "+
+			"Use --%s with a JSON file such as {\"role\": \"sentry\", \"mempool_size\": 8000} to start from a preset of your own.\n\n%s",
+			shortDescription, types.JoinNodeRoles(), FlagWithConfig, L1NodeHelperText),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			analytics.TrackRunEvent(cmd, args, analytics.SetupL1NodeFeature, analytics.NewEmptyEvent())
+			configPath, _ := cmd.Flags().GetString(FlagWithConfig)
+			analytics.TrackRunEvent(cmd, args, analytics.SetupL1NodeFeature, analytics.NewEmptyEvent().Add(analytics.WithConfigKey, configPath != ""))
 			initiaHome, err := cmd.Flags().GetString(FlagInitiaHome)
 			if err != nil {
 				return err
 			}
 
-			ctx := weavecontext.NewAppContext(initia.NewRunL1NodeState())
+			state := initia.NewRunL1NodeState()
+			if configPath != "" {
+				data, err := os.ReadFile(configPath)
+				if err != nil {
+					return fmt.Errorf("failed to read node preset %s: %w", configPath, err)
+				}
+				preset, err := types.LoadNodePreset(data)
+				if err != nil {
+					return err
+				}
+				state.WithNodePreset(preset)
+			}
+
+			ctx := weavecontext.NewAppContext(state)
 			ctx = weavecontext.SetInitiaHome(ctx, initiaHome)
 			model, err := initia.NewRunL1NodeNetworkSelect(ctx)
 			if err != nil {
@@ -71,6 +94,7 @@ func initiaInitCommand() *cobra.Command {
 	}
 
 	initCmd.Flags().String(FlagInitiaHome, filepath.Join(homeDir, common.InitiaDirectory), "The Initia application home directory")
+	initCmd.Flags().String(FlagWithConfig, "", "Path to a JSON node preset with a role and the settings to override, used instead of selecting the node role")
 
 	return initCmd
 }
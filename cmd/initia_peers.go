@@ -0,0 +1,381 @@
+package cmd
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/charmbracelet/x/term"
+	"github.com/spf13/cobra"
+
+	"github.com/initia-labs/weave/common"
+	"github.com/initia-labs/weave/config"
+	"github.com/initia-labs/weave/cosmosutils"
+	"github.com/initia-labs/weave/registry"
+	"github.com/initia-labs/weave/service"
+)
+
+const (
+	// peerDialTimeout bounds the reachability check of a peer before it is added
+	peerDialTimeout = 5 * time.Second
+	defaultP2PPort  = "26656"
+)
+
+type configuredPeers struct {
+	Seeds           []string        `json:"seeds"`
+	PersistentPeers []string        `json:"persistent_peers"`
+	Connected       []connectedPeer `json:"connected"`
+}
+
+type connectedPeer struct {
+	NodeID    string `json:"node_id"`
+	Moniker   string `json:"moniker"`
+	Address   string `json:"address"`
+	Direction string `json:"direction"`
+}
+
+func initiaPeersCommand() *cobra.Command {
+	shortDescription := "Manage the peers and address book of the Initia full node"
+	peersCmd := &cobra.Command{
+		Use:   "peers",
+		Short: shortDescription,
+		Long:  fmt.Sprintf("%s.\n\n%s", shortDescription, L1NodeHelperText),
+	}
+
+	peersCmd.AddCommand(
+		initiaPeersListCommand(),
+		initiaPeersAddCommand(),
+		initiaPeersRemoveCommand(),
+		initiaPeersRefreshCommand(),
+	)
+
+	return peersCmd
+}
+
+func initiaPeersListCommand() *cobra.Command {
+	shortDescription := "List the configured seeds and persistent peers and the connected peers"
+	listCmd := &cobra.Command{
+		Use:     "list",
+		Short:   shortDescription,
+		Long:    fmt.Sprintf("%s.\n\n%s", shortDescription, L1NodeHelperText),
+		Args:    cobra.NoArgs,
+		PreRunE: isInitiated(service.UpgradableInitia),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			asJSON, _ := cmd.Flags().GetBool(FlagJSON)
+			_, home, err := initiaServiceAndHome()
+			if err != nil {
+				return err
+			}
+
+			configPath := filepath.Join(home, "config", "config.toml")
+			seeds, err := config.GetTomlValue(configPath, "p2p.seeds")
+			if err != nil {
+				return err
+			}
+			persistentPeers, err := config.GetTomlValue(configPath, "p2p.persistent_peers")
+			if err != nil {
+				return err
+			}
+			peers := configuredPeers{
+				Seeds:           common.SplitPeers(seeds),
+				PersistentPeers: common.SplitPeers(persistentPeers),
+				Connected:       []connectedPeer{},
+			}
+
+			// The connected peers are only known while the node is running
+			var netInfoErr error
+			rpc, err := localRPCAddress(home)
+			if err == nil {
+				var netInfo *cosmosutils.NetInfoResponse
+				if netInfo, netInfoErr = cosmosutils.QueryNetInfo(rpc); netInfoErr == nil {
+					for _, peer := range netInfo.Result.Peers {
+						direction := "inbound"
+						if peer.IsOutbound {
+							direction = "outbound"
+						}
+						peers.Connected = append(peers.Connected, connectedPeer{
+							NodeID:    peer.NodeInfo.Id,
+							Moniker:   peer.NodeInfo.Moniker,
+							Address:   peer.RemoteIP,
+							Direction: direction,
+						})
+					}
+				}
+			} else {
+				netInfoErr = err
+			}
+
+			if asJSON {
+				return printJSON(peers)
+			}
+
+			w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+			fmt.Fprintf(w, "SEEDS (%d)\n", len(peers.Seeds))
+			for _, seed := range peers.Seeds {
+				fmt.Fprintf(w, "  %s\n", seed)
+			}
+			fmt.Fprintf(w, "\nPERSISTENT PEERS (%d)\n", len(peers.PersistentPeers))
+			for _, peer := range peers.PersistentPeers {
+				fmt.Fprintf(w, "  %s\n", peer)
+			}
+			if netInfoErr != nil {
+				fmt.Fprintf(w, "\nCONNECTED PEERS\n  could not reach the node: %v\n", netInfoErr)
+				return w.Flush()
+			}
+			fmt.Fprintf(w, "\nCONNECTED PEERS (%d)\n", len(peers.Connected))
+			if len(peers.Connected) > 0 {
+				fmt.Fprintln(w, "  NODE ID\tMONIKER\tADDRESS\tDIRECTION")
+			}
+			for _, peer := range peers.Connected {
+				fmt.Fprintf(w, "  %s\t%s\t%s\t%s\n", peer.NodeID, peer.Moniker, peer.Address, peer.Direction)
+			}
+			return w.Flush()
+		},
+	}
+
+	listCmd.Flags().Bool(FlagJSON, false, "Output in JSON format")
+
+	return listCmd
+}
+
+func initiaPeersAddCommand() *cobra.Command {
+	shortDescription := "Add persistent peers or seeds to the Initia full node config"
+	addCmd := &cobra.Command{
+		Use:   "add <node-id@host:port>...",
+		Short: shortDescription,
+		Long: fmt.Sprintf("%s.\n\nEach peer is checked to accept TCP connections before it is added, unless --%s is given. "+
+			"A peer whose node ID is already configured has its address replaced.\n\n%s", shortDescription, FlagSkipCheck, L1NodeHelperText),
+		Args:    cobra.MinimumNArgs(1),
+		PreRunE: isInitiated(service.UpgradableInitia),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			asSeed, _ := cmd.Flags().GetBool(FlagSeed)
+			skipCheck, _ := cmd.Flags().GetBool(FlagSkipCheck)
+
+			if err := common.ValidatePeerOrSeed(strings.Join(args, ",")); err != nil {
+				return err
+			}
+			if !skipCheck {
+				var unreachable []string
+				for _, peer := range args {
+					fmt.Printf("Checking %s...\n", peer)
+					if err := checkPeerReachable(peer); err != nil {
+						unreachable = append(unreachable, fmt.Sprintf("- %s: %v", peer, err))
+					}
+				}
+				if len(unreachable) > 0 {
+					return fmt.Errorf("some peers are not reachable, use --%s to add them anyway:\n%s", FlagSkipCheck, strings.Join(unreachable, "\n"))
+				}
+			}
+
+			s, home, err := initiaServiceAndHome()
+			if err != nil {
+				return err
+			}
+			configPath, key := peersConfigKey(home, asSeed)
+			existing, err := config.GetTomlValue(configPath, key)
+			if err != nil {
+				return err
+			}
+			if err = config.UpdateTomlValue(configPath, key, common.AddPeers(existing, args)); err != nil {
+				return fmt.Errorf("failed to update %s: %w", key, err)
+			}
+
+			fmt.Printf("Added %d peer(s) to %s.\n", len(args), key)
+			return offerRestart(cmd, s)
+		},
+	}
+
+	addCmd.Flags().Bool(FlagSeed, false, "Add the peers as seeds instead of persistent peers")
+	addCmd.Flags().Bool(FlagSkipCheck, false, "Add the peers without checking that they are reachable")
+	addCmd.Flags().Bool(FlagRestart, false, "Restart the Initia full node service to apply the change without asking")
+
+	return addCmd
+}
+
+func initiaPeersRemoveCommand() *cobra.Command {
+	shortDescription := "Remove persistent peers or seeds from the Initia full node config"
+	removeCmd := &cobra.Command{
+		Use:     "remove <node-id>...",
+		Short:   shortDescription,
+		Long:    fmt.Sprintf("%s.\n\nPeers can be given by node ID or as node-id@host:port.\n\n%s", shortDescription, L1NodeHelperText),
+		Args:    cobra.MinimumNArgs(1),
+		PreRunE: isInitiated(service.UpgradableInitia),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			asSeed, _ := cmd.Flags().GetBool(FlagSeed)
+
+			s, home, err := initiaServiceAndHome()
+			if err != nil {
+				return err
+			}
+			configPath, key := peersConfigKey(home, asSeed)
+			existing, err := config.GetTomlValue(configPath, key)
+			if err != nil {
+				return err
+			}
+			remaining, removed := common.RemovePeers(existing, args)
+			if len(removed) == 0 {
+				return fmt.Errorf("none of the given peers are in %s", key)
+			}
+			if err = config.UpdateTomlValue(configPath, key, remaining); err != nil {
+				return fmt.Errorf("failed to update %s: %w", key, err)
+			}
+
+			fmt.Printf("Removed from %s:\n  %s\n", key, strings.Join(removed, "\n  "))
+			return offerRestart(cmd, s)
+		},
+	}
+
+	removeCmd.Flags().Bool(FlagSeed, false, "Remove seeds instead of persistent peers")
+	removeCmd.Flags().Bool(FlagRestart, false, "Restart the Initia full node service to apply the change without asking")
+
+	return removeCmd
+}
+
+func initiaPeersRefreshCommand() *cobra.Command {
+	shortDescription := "Replace the address book of the Initia full node with a fresh one from the snapshot provider"
+	refreshCmd := &cobra.Command{
+		Use:   "refresh",
+		Short: shortDescription,
+		Long: fmt.Sprintf("%s.\n\nThe node writes its own address book when it stops, so a running service is stopped while the "+
+			"address book is replaced and started again afterwards. The provider is the one configured in %s, Polkachu by default.\n\n%s",
+			shortDescription, config.SnapshotProviderTypeKey, L1NodeHelperText),
+		Args:    cobra.NoArgs,
+		PreRunE: isInitiated(service.UpgradableInitia),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			s, home, err := initiaServiceAndHome()
+			if err != nil {
+				return err
+			}
+			chainId, err := readGenesisChainId(home)
+			if err != nil {
+				return err
+			}
+			chainType, err := initiaChainType(chainId)
+			if err != nil {
+				return err
+			}
+			providerType, location := config.GetSnapshotProvider()
+			provider, err := cosmosutils.NewSnapshotProvider(providerType, location, chainId, chainType)
+			if err != nil {
+				return err
+			}
+
+			// Download next to the address book first, so that a failed download leaves the node untouched
+			addrBookPath := filepath.Join(home, "config", "addrbook.json")
+			downloadPath := addrBookPath + ".download"
+			defer os.Remove(downloadPath)
+			fmt.Printf("Downloading the address book from %s...\n", provider.Name())
+			if err = provider.DownloadAddrBook(downloadPath); err != nil {
+				return fmt.Errorf("failed to download the address book: %w", err)
+			}
+
+			running, err := stopRunningService(s, "Initia full node")
+			if err != nil {
+				return err
+			}
+			replaceErr := os.Rename(downloadPath, addrBookPath)
+			if running {
+				fmt.Println("Starting the Initia full node service...")
+				if err = s.Start(); err != nil {
+					return fmt.Errorf("failed to start the Initia full node service: %w", err)
+				}
+			}
+			if replaceErr != nil {
+				return fmt.Errorf("failed to replace the address book: %w", replaceErr)
+			}
+
+			fmt.Printf("Refreshed the address book at %s.\n", addrBookPath)
+			if !running {
+				fmt.Println("The Initia full node service is not running, the new address book applies on its next start.")
+			}
+			return nil
+		},
+	}
+
+	return refreshCmd
+}
+
+func initiaServiceAndHome() (service.Service, string, error) {
+	s, err := service.NewService(service.UpgradableInitia, "")
+	if err != nil {
+		return nil, "", err
+	}
+	_, home, err := s.GetServiceBinaryAndHome()
+	if err != nil {
+		return nil, "", fmt.Errorf("could not determine the Initia home directory: %w", err)
+	}
+	return s, home, nil
+}
+
+func peersConfigKey(home string, asSeed bool) (string, string) {
+	configPath := filepath.Join(home, "config", "config.toml")
+	if asSeed {
+		return configPath, "p2p.seeds"
+	}
+	return configPath, "p2p.persistent_peers"
+}
+
+// initiaChainType returns the registry network of chainId
+func initiaChainType(chainId string) (registry.ChainType, error) {
+	for _, chainType := range []registry.ChainType{registry.InitiaL1Mainnet, registry.InitiaL1Testnet} {
+		chainRegistry, err := registry.GetChainRegistry(chainType)
+		if err == nil && chainRegistry.GetChainId() == chainId {
+			return chainType, nil
+		}
+	}
+	return 0, fmt.Errorf("chain %s is not an Initia network in the registry", chainId)
+}
+
+func checkPeerReachable(peer string) error {
+	_, address, found := strings.Cut(strings.TrimSpace(peer), "@")
+	if !found {
+		return errors.New("missing address")
+	}
+	if _, _, err := net.SplitHostPort(address); err != nil {
+		address = net.JoinHostPort(address, defaultP2PPort)
+	}
+
+	conn, err := net.DialTimeout("tcp", address, peerDialTimeout)
+	if err != nil {
+		return err
+	}
+	return conn.Close()
+}
+
+// offerRestart restarts the service when --restart is given, asks when running in a terminal, and otherwise explains how to apply the change
+func offerRestart(cmd *cobra.Command, s service.Service) error {
+	restart, _ := cmd.Flags().GetBool(FlagRestart)
+	if !restart && term.IsTerminal(os.Stdin.Fd()) {
+		var err error
+		if restart, err = askYesNo("Restart the Initia full node service now to apply the change?"); err != nil {
+			return err
+		}
+	}
+
+	if !restart {
+		fmt.Println("The change takes effect after `weave initia restart`.")
+		return nil
+	}
+	if err := s.Restart(); err != nil {
+		return fmt.Errorf("failed to restart the Initia full node service: %w", err)
+	}
+	fmt.Println("Restarted the Initia full node service.")
+	return nil
+}
+
+// askYesNo prompts on the terminal and defaults to no
+func askYesNo(question string) (bool, error) {
+	fmt.Printf("%s [y/N]: ", question)
+	answer, err := bufio.NewReader(os.Stdin).ReadString('\n')
+	if err != nil {
+		return false, fmt.Errorf("failed to read answer: %v", err)
+	}
+	answer = strings.TrimSpace(answer)
+	return strings.EqualFold(answer, "y") || strings.EqualFold(answer, "yes"), nil
+}
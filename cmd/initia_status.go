@@ -0,0 +1,255 @@
+package cmd
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/initia-labs/weave/cosmosutils"
+	weaveio "github.com/initia-labs/weave/io"
+	"github.com/initia-labs/weave/registry"
+	"github.com/initia-labs/weave/service"
+	"github.com/initia-labs/weave/ui"
+)
+
+// statusSampleInterval is how long a single status check waits between samples to measure the sync speed
+const statusSampleInterval = 3 * time.Second
+
+type initiaNodeStatus struct {
+	ChainId           string                   `json:"chain_id"`
+	Moniker           string                   `json:"moniker"`
+	AppVersion        string                   `json:"app_version"`
+	Height            int64                    `json:"height"`
+	NetworkHeight     int64                    `json:"network_height,omitempty"`
+	CatchingUp        bool                     `json:"catching_up"`
+	LatestBlockTime   string                   `json:"latest_block_time"`
+	BlocksPerSecond   float64                  `json:"blocks_per_second,omitempty"`
+	ETASeconds        int64                    `json:"eta_seconds,omitempty"`
+	Peers             int                      `json:"peers"`
+	CurrentUpgrade    string                   `json:"cosmovisor_current_upgrade,omitempty"`
+	NextUpgrade       *cosmosutils.UpgradePlan `json:"next_upgrade,omitempty"`
+	NextUpgradeStaged bool                     `json:"next_upgrade_binary_staged"`
+	DataDiskUsage     int64                    `json:"data_disk_usage_bytes"`
+
+	hasETA       bool
+	upgradeKnown bool
+}
+
+func initiaStatusCommand() *cobra.Command {
+	shortDescription := "Show the sync and health status of the Initia full node"
+	statusCmd := &cobra.Command{
+		Use:   "status",
+		Short: shortDescription,
+		Long: fmt.Sprintf("%s.\n\nThe local node is compared against the network height reported by the registry RPCs. "+
+			"Use --%s to refresh the status until interrupted.\n\n%s", shortDescription, FlagWatch, L1NodeHelperText),
+		PreRunE: isInitiated(service.UpgradableInitia),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			watch, _ := cmd.Flags().GetBool(FlagWatch)
+			interval, _ := cmd.Flags().GetDuration(FlagInterval)
+			asJSON, _ := cmd.Flags().GetBool(FlagJSON)
+			if interval <= 0 {
+				return fmt.Errorf("--%s must be positive", FlagInterval)
+			}
+
+			_, home, err := initiaServiceAndHome()
+			if err != nil {
+				return err
+			}
+			rpc, err := localRPCAddress(home)
+			if err != nil {
+				return fmt.Errorf("could not determine the Initia RPC address: %w", err)
+			}
+
+			collector := &nodeStatusCollector{home: home, rpc: rpc}
+			if !watch {
+				status, err := collector.collect()
+				if err != nil {
+					return err
+				}
+				// A second sample is needed to tell how fast the node is syncing
+				if status.CatchingUp {
+					time.Sleep(statusSampleInterval)
+					if status, err = collector.collect(); err != nil {
+						return err
+					}
+				}
+				return printNodeStatus(status, asJSON)
+			}
+
+			for {
+				status, err := collector.collect()
+				if !asJSON {
+					// Clear the screen so that the status is redrawn in place
+					fmt.Print("\033[H\033[2J")
+					fmt.Printf("Refreshing every %s, press Ctrl+C to exit.\n\n", interval)
+				}
+				if err != nil {
+					fmt.Println(err)
+				} else if err = printNodeStatus(status, asJSON); err != nil {
+					return err
+				}
+				time.Sleep(interval)
+			}
+		},
+	}
+
+	statusCmd.Flags().BoolP(FlagWatch, "w", false, "Keep refreshing the status")
+	statusCmd.Flags().Duration(FlagInterval, 5*time.Second, "How often the status is refreshed in watch mode")
+	statusCmd.Flags().Bool(FlagJSON, false, "Output in JSON format")
+
+	return statusCmd
+}
+
+// nodeStatusCollector queries the node status and keeps the previous sample to measure the sync speed
+type nodeStatusCollector struct {
+	home string
+	rpc  string
+
+	networkResolved bool
+	networkRpcs     []string
+	networkLcds     []string
+
+	previous *cosmosutils.SyncSample
+}
+
+func (c *nodeStatusCollector) collect() (*initiaNodeStatus, error) {
+	nodeStatus, err := cosmosutils.QueryNodeStatus(c.rpc)
+	if err != nil {
+		return nil, fmt.Errorf("could not reach the Initia full node at %s, start it with `weave initia start`: %w", c.rpc, err)
+	}
+
+	status := &initiaNodeStatus{
+		ChainId:         nodeStatus.Result.NodeInfo.Network,
+		Moniker:         nodeStatus.Result.NodeInfo.Moniker,
+		CatchingUp:      nodeStatus.Result.SyncInfo.CatchingUp,
+		LatestBlockTime: nodeStatus.Result.SyncInfo.LatestBlockTime,
+	}
+	status.Height, _ = strconv.ParseInt(nodeStatus.Result.SyncInfo.LatestBlockHeight, 10, 64)
+	sampledAt := time.Now()
+
+	if info, err := cosmosutils.QueryABCIInfo(c.rpc); err == nil {
+		status.AppVersion = info.Result.Response.Version
+	}
+	if netInfo, err := cosmosutils.QueryNetInfo(c.rpc); err == nil {
+		status.Peers, _ = strconv.Atoi(netInfo.Result.NPeers)
+	}
+
+	c.resolveNetwork(status.ChainId)
+	if len(c.networkRpcs) > 0 {
+		status.NetworkHeight, _ = cosmosutils.GetNetworkHeight(c.networkRpcs)
+	}
+	if len(c.networkLcds) > 0 {
+		plan, err := cosmosutils.QueryUpgradePlan(c.networkLcds)
+		status.upgradeKnown = err == nil
+		if err == nil && plan != nil {
+			status.NextUpgrade = plan
+			status.NextUpgradeStaged = weaveio.FileOrFolderExists(cosmosutils.CosmovisorUpgradeBinaryPath(c.home, plan.Name, "initiad"))
+		}
+	}
+	status.CurrentUpgrade, _ = cosmosutils.GetCosmovisorCurrentUpgrade(c.home)
+	status.DataDiskUsage, _ = directorySize(filepath.Join(c.home, "data"))
+
+	sample := cosmosutils.SyncSample{Time: sampledAt, Height: status.Height, NetworkHeight: status.NetworkHeight}
+	if c.previous != nil {
+		var eta time.Duration
+		status.BlocksPerSecond, eta, status.hasETA = cosmosutils.EstimateSync(*c.previous, sample)
+		status.ETASeconds = int64(eta.Seconds())
+	}
+	c.previous = &sample
+
+	return status, nil
+}
+
+// resolveNetwork looks up the registry RPCs and LCDs of chainId once. A chain that is not in the registry is compared against nothing.
+func (c *nodeStatusCollector) resolveNetwork(chainId string) {
+	if c.networkResolved {
+		return
+	}
+	c.networkResolved = true
+
+	chainType, err := initiaChainType(chainId)
+	if err != nil {
+		return
+	}
+	chainRegistry, err := registry.GetChainRegistry(chainType)
+	if err != nil {
+		return
+	}
+	c.networkRpcs, _ = chainRegistry.GetActiveRpcs()
+	c.networkLcds, _ = chainRegistry.GetActiveLcds()
+}
+
+func printNodeStatus(status *initiaNodeStatus, asJSON bool) error {
+	if asJSON {
+		return printJSON(status)
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(w, "Chain ID\t%s\n", status.ChainId)
+	fmt.Fprintf(w, "Moniker\t%s\n", status.Moniker)
+	fmt.Fprintf(w, "App version\t%s\n", valueOrUnknown(status.AppVersion))
+	if status.NetworkHeight > 0 {
+		fmt.Fprintf(w, "Height\t%d / %d (%d behind)\n", status.Height, status.NetworkHeight, max(status.NetworkHeight-status.Height, 0))
+	} else {
+		fmt.Fprintf(w, "Height\t%d (network height unknown)\n", status.Height)
+	}
+	fmt.Fprintf(w, "Latest block time\t%s\n", status.LatestBlockTime)
+	fmt.Fprintf(w, "Catching up\t%t\n", status.CatchingUp)
+	if status.BlocksPerSecond > 0 {
+		fmt.Fprintf(w, "Sync speed\t%.1f blocks/s\n", status.BlocksPerSecond)
+	}
+	if status.CatchingUp {
+		if status.hasETA {
+			fmt.Fprintf(w, "ETA\t%s\n", (time.Duration(status.ETASeconds) * time.Second).String())
+		} else {
+			fmt.Fprintf(w, "ETA\tunknown\n")
+		}
+	}
+	fmt.Fprintf(w, "Peers\t%d\n", status.Peers)
+	fmt.Fprintf(w, "Cosmovisor current\t%s\n", valueOrUnknown(status.CurrentUpgrade))
+	if status.NextUpgrade != nil {
+		staged := "binary not staged"
+		if status.NextUpgradeStaged {
+			staged = "binary staged"
+		}
+		fmt.Fprintf(w, "Next upgrade\t%s at height %s (%s)\n", status.NextUpgrade.Name, status.NextUpgrade.Height, staged)
+	} else if status.upgradeKnown {
+		fmt.Fprintf(w, "Next upgrade\tnone scheduled\n")
+	} else {
+		fmt.Fprintf(w, "Next upgrade\tunknown\n")
+	}
+	fmt.Fprintf(w, "Data disk usage\t%s\n", ui.ByteCountSI(status.DataDiskUsage))
+	return w.Flush()
+}
+
+func valueOrUnknown(value string) string {
+	if value == "" {
+		return "unknown"
+	}
+	return value
+}
+
+// directorySize returns the total size of the regular files under path
+func directorySize(path string) (int64, error) {
+	var size int64
+	err := filepath.WalkDir(path, func(_ string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if entry.Type().IsRegular() {
+			info, err := entry.Info()
+			if err != nil {
+				return err
+			}
+			size += info.Size()
+		}
+		return nil
+	})
+	return size, err
+}
@@ -0,0 +1,200 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/initia-labs/weave/common"
+	"github.com/initia-labs/weave/cosmosutils"
+	"github.com/initia-labs/weave/registry"
+	"github.com/initia-labs/weave/service"
+)
+
+// blockTimeWindow is the number of recent blocks the average block time for the upgrade countdown is measured over
+const blockTimeWindow = 1000
+
+func initiaUpgradeCommand() *cobra.Command {
+	shortDescription := "Prepare the Initia full node for governance upgrades"
+	upgradeCmd := &cobra.Command{
+		Use:   "upgrade",
+		Short: shortDescription,
+		Long:  fmt.Sprintf("%s.\n\n%s", shortDescription, L1NodeHelperText),
+	}
+
+	upgradeCmd.AddCommand(initiaUpgradePlanCommand())
+
+	return upgradeCmd
+}
+
+func initiaUpgradePlanCommand() *cobra.Command {
+	shortDescription := "Show pending software upgrades and pre-stage their binaries for cosmovisor"
+	planCmd := &cobra.Command{
+		Use:   "plan",
+		Short: shortDescription,
+		Long: fmt.Sprintf("%s.\n\nThe scheduled upgrade plan and the upgrade proposals in their voting period are queried from the L1. "+
+			"The initiad release of the scheduled upgrade is downloaded into cosmovisor/upgrades/<name>/bin and verified to report "+
+			"the expected version, so that cosmovisor does not have to download it at the upgrade height. "+
+			"Its shared libraries stay next to it and are only loaded by the node once cosmovisor switches to the upgrade. "+
+			"A tarball listed in the upgrade plan must match the checksum the plan gives for it. "+
+			"This works whether or not the node downloads upgrade binaries on its own.\n\n%s", shortDescription, L1NodeHelperText),
+		Args:    cobra.NoArgs,
+		PreRunE: isInitiated(service.UpgradableInitia),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			noStage, _ := cmd.Flags().GetBool(FlagNoStage)
+			includeProposals, _ := cmd.Flags().GetBool(FlagIncludeProposals)
+
+			s, home, err := initiaServiceAndHome()
+			if err != nil {
+				return err
+			}
+			chainId, err := readGenesisChainId(home)
+			if err != nil {
+				return err
+			}
+			chainType, err := initiaChainType(chainId)
+			if err != nil {
+				return err
+			}
+			chainRegistry, err := registry.GetChainRegistry(chainType)
+			if err != nil {
+				return err
+			}
+			lcds, err := chainRegistry.GetActiveLcds()
+			if err != nil {
+				return err
+			}
+
+			var plans []cosmosutils.UpgradePlan
+			plan, err := cosmosutils.QueryUpgradePlan(lcds)
+			if err != nil {
+				return err
+			}
+			if plan != nil {
+				fmt.Printf("Scheduled upgrade %s at height %s.\n", plan.Name, plan.Height)
+				plans = append(plans, *plan)
+			} else {
+				fmt.Println("No software upgrade is scheduled.")
+			}
+
+			proposals, err := cosmosutils.QueryUpgradeProposals(lcds)
+			if err != nil {
+				fmt.Printf("Could not query upgrade proposals: %v\n", err)
+			}
+			for _, proposal := range proposals {
+				fmt.Printf("Proposal #%s %q would upgrade to %s at height %s, voting ends at %s.\n",
+					proposal.Id, proposal.Title, proposal.Plan.Name, proposal.Plan.Height, proposal.VotingEndsAt)
+				if includeProposals {
+					plans = append(plans, proposal.Plan)
+				}
+			}
+			if len(plans) == 0 {
+				return nil
+			}
+
+			fmt.Println()
+			printUpgradeCountdown(chainRegistry, plans)
+			if noStage {
+				return nil
+			}
+
+			releases, err := cosmosutils.ListBinaryReleases(cosmosutils.InitiaReleaseAPI)
+			if err != nil {
+				return err
+			}
+			fmt.Println()
+			for _, plan := range plans {
+				release, err := cosmosutils.UpgradeDownloadURL(plan, releases)
+				if err != nil {
+					return err
+				}
+				fmt.Printf("Staging %s from %s...\n", plan.Name, release.URL)
+				binaryPath, err := cosmosutils.StageCosmovisorUpgrade(home, plan.Name, "initiad", release)
+				if err != nil {
+					return fmt.Errorf("failed to stage upgrade %s: %w", plan.Name, err)
+				}
+				fmt.Printf("Staged %s at %s.\n", plan.Name, binaryPath)
+			}
+			if err := refreshUpgradeLibraryPath(s, home); err != nil {
+				return err
+			}
+			fmt.Println("\nCosmovisor switches to the staged binary at the upgrade height. " +
+				"If the node does not restart after upgrades on its own, run `weave initia restart` once it halts.")
+			return nil
+		},
+	}
+
+	planCmd.Flags().Bool(FlagNoStage, false, "Only show the pending upgrades without downloading their binaries")
+	planCmd.Flags().Bool(FlagIncludeProposals, false, "Also stage the binaries of upgrade proposals that are still being voted on")
+
+	return planCmd
+}
+
+// refreshUpgradeLibraryPath rewrites a node service created before the service loaded the shared libraries from the bin
+// directory of the current upgrade, since it would keep loading the libraries of the genesis binary after the switch
+func refreshUpgradeLibraryPath(s service.Service, home string) error {
+	serviceFile, err := s.GetServiceFile()
+	if err != nil {
+		return err
+	}
+	content, err := os.ReadFile(serviceFile)
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", serviceFile, err)
+	}
+	if strings.Contains(string(content), filepath.Join("cosmovisor", "current", "bin")) {
+		return nil
+	}
+
+	binaryPath, _, err := s.GetServiceBinaryAndHome()
+	if err != nil {
+		return err
+	}
+	userHome, err := os.UserHomeDir()
+	if err != nil {
+		return fmt.Errorf("failed to get user home directory: %w", err)
+	}
+	binaryVersion, err := filepath.Rel(filepath.Join(userHome, common.WeaveDataDirectory), filepath.Dir(binaryPath))
+	if err != nil {
+		return fmt.Errorf("failed to locate the cosmovisor of %s: %w", serviceFile, err)
+	}
+	if err := s.Create(binaryVersion, home); err != nil {
+		return fmt.Errorf("failed to update the node service: %w", err)
+	}
+	fmt.Println("Updated the node service to load the shared libraries of the upgrade cosmovisor switches to. " +
+		"The change applies on the next start of the node.")
+	return nil
+}
+
+// printUpgradeCountdown estimates when each plan is reached from the recent average block time
+func printUpgradeCountdown(chainRegistry *registry.ChainRegistry, plans []cosmosutils.UpgradePlan) {
+	rpc, err := chainRegistry.GetFirstActiveRpc()
+	if err != nil {
+		fmt.Printf("Could not estimate the upgrade times: %v\n", err)
+		return
+	}
+	blockTime, latestHeight, err := cosmosutils.GetAverageBlockTime(rpc, blockTimeWindow)
+	if err != nil {
+		fmt.Printf("Could not estimate the upgrade times: %v\n", err)
+		return
+	}
+
+	for _, plan := range plans {
+		height, err := strconv.ParseInt(plan.Height, 10, 64)
+		if err != nil {
+			continue
+		}
+		remaining := height - latestHeight
+		if remaining <= 0 {
+			fmt.Printf("%s: upgrade height %d has been reached.\n", plan.Name, height)
+			continue
+		}
+		eta := time.Duration(remaining) * blockTime
+		fmt.Printf("%s: %d blocks to go, about %s at %s per block (around %s).\n",
+			plan.Name, remaining, eta.Round(time.Minute), blockTime.Round(time.Millisecond), time.Now().Add(eta).Format("2006-01-02 15:04 MST"))
+	}
+}
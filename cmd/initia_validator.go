@@ -0,0 +1,593 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/x/term"
+	"github.com/spf13/cobra"
+
+	"github.com/initia-labs/weave/common"
+	"github.com/initia-labs/weave/config"
+	"github.com/initia-labs/weave/cosmosutils"
+	"github.com/initia-labs/weave/crypto"
+	weaveio "github.com/initia-labs/weave/io"
+	"github.com/initia-labs/weave/models/initia"
+	"github.com/initia-labs/weave/registry"
+	"github.com/initia-labs/weave/service"
+)
+
+const (
+	defaultOperatorKeyName = "validator"
+
+	// validatorFeeReserve is how much of the gas denom the operator keeps on top of the self-delegation to pay for fees
+	validatorFeeReserve = 1_000_000
+
+	// syncTolerance is how many blocks behind the network the node may be to count as synced
+	syncTolerance = 5
+)
+
+func initiaValidatorCommand() *cobra.Command {
+	shortDescription := "Run the Initia full node as a validator"
+	validatorCmd := &cobra.Command{
+		Use:   "validator",
+		Short: shortDescription,
+		Long: fmt.Sprintf("%s.\n\nThe operator key is kept in config/%s of the Initia home directory, encrypted with a passphrase. "+
+			"Transactions are broadcast through the local node.\n\n%s", shortDescription, cosmosutils.OperatorKeyFileName, L1NodeHelperText),
+	}
+
+	validatorCmd.AddCommand(
+		initiaValidatorCreateCommand(),
+		initiaValidatorEditCommand(),
+		initiaValidatorUnjailCommand(),
+		initiaValidatorMonitorCommand(),
+	)
+
+	return validatorCmd
+}
+
+// validatorEnv holds what every validator transaction needs
+type validatorEnv struct {
+	home      string
+	chainId   string
+	rpc       string
+	lcds      []string
+	gasPrices string
+	executor  *cosmosutils.InitiadTxExecutor
+}
+
+func newValidatorEnv() (*validatorEnv, error) {
+	_, home, err := initiaServiceAndHome()
+	if err != nil {
+		return nil, err
+	}
+	chainId, err := readGenesisChainId(home)
+	if err != nil {
+		return nil, err
+	}
+	chainType, err := initiaChainType(chainId)
+	if err != nil {
+		return nil, err
+	}
+	chainRegistry, err := registry.GetChainRegistry(chainType)
+	if err != nil {
+		return nil, err
+	}
+	lcds, err := chainRegistry.GetActiveLcds()
+	if err != nil {
+		return nil, err
+	}
+	gasPrices, err := chainRegistry.GetMinGasPriceByDenom(initia.DefaultGasPriceDenom)
+	if err != nil {
+		return nil, err
+	}
+	rpc, err := localRPCAddress(home)
+	if err != nil {
+		return nil, fmt.Errorf("could not determine the Initia RPC address: %w", err)
+	}
+
+	var executor *cosmosutils.InitiadTxExecutor
+	for _, lcd := range lcds {
+		if executor, err = cosmosutils.NewInitiadTxExecutor(lcd); err == nil {
+			break
+		}
+	}
+	if executor == nil {
+		return nil, fmt.Errorf("failed to prepare initiad: %w", err)
+	}
+
+	return &validatorEnv{
+		home:      home,
+		chainId:   chainId,
+		rpc:       rpc,
+		lcds:      lcds,
+		gasPrices: gasPrices,
+		executor:  executor,
+	}, nil
+}
+
+func initiaValidatorCreateCommand() *cobra.Command {
+	shortDescription := "Turn the Initia full node into a validator"
+	createCmd := &cobra.Command{
+		Use:   "create",
+		Short: shortDescription,
+		Long: fmt.Sprintf("%s.\n\nThe operator key is generated, or imported with --%s, and the command waits until the node has caught up with the network. "+
+			"The operator is funded from the gas station with the self-delegation and some INIT for fees, "+
+			"priv_validator_key.json is backed up and MsgCreateValidator is broadcast.\n\n%s", shortDescription, FlagRecover, L1NodeHelperText),
+		Args:    cobra.NoArgs,
+		PreRunE: isInitiated(service.UpgradableInitia),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			keyName, _ := cmd.Flags().GetString(FlagKeyName)
+			recoverKey, _ := cmd.Flags().GetBool(FlagRecover)
+			amountStr, _ := cmd.Flags().GetString(FlagAmount)
+			noFund, _ := cmd.Flags().GetBool(FlagNoFund)
+			yes, _ := cmd.Flags().GetBool(FlagYes)
+
+			selfDelegation, err := cosmosutils.ParseCoin(amountStr)
+			if err != nil {
+				return fmt.Errorf("invalid --%s: %w", FlagAmount, err)
+			}
+			commission, err := validatorCommissionFromFlags(cmd)
+			if err != nil {
+				return err
+			}
+
+			env, err := newValidatorEnv()
+			if err != nil {
+				return err
+			}
+			description := validatorDescriptionFromFlags(cmd)
+			if description.Moniker == "" {
+				description.Moniker, _ = config.GetTomlValue(filepath.Join(env.home, "config", "config.toml"), "moniker")
+			}
+
+			operator, err := ensureOperatorKey(env, keyName, recoverKey)
+			if err != nil {
+				return err
+			}
+			valoper, err := operator.ValoperAddress()
+			if err != nil {
+				return err
+			}
+
+			if err := waitForNodeSync(env.home, env.rpc); err != nil {
+				return err
+			}
+			validator, err := env.executor.QueryValidator(valoper, env.rpc)
+			if err != nil {
+				return err
+			}
+			if validator != nil {
+				return fmt.Errorf("%s is already a validator, use `weave initia validator edit` to change it", valoper)
+			}
+
+			// The fee reserve is only added when the self-delegation is in the gas denom, which is the only denom the gas station funds
+			funding := big.NewInt(0)
+			if selfDelegation.Denom == initia.DefaultGasPriceDenom {
+				balances, err := cosmosutils.QueryBankBalances(env.lcds, operator.Address)
+				if err != nil {
+					return fmt.Errorf("failed to query the operator balance: %w", err)
+				}
+				funding = cosmosutils.ValidatorFunding(*balances, selfDelegation, big.NewInt(validatorFeeReserve))
+				if funding.Sign() > 0 && noFund {
+					return fmt.Errorf("%s needs %s%s more to self-delegate %s and pay for fees", operator.Address, funding, selfDelegation.Denom, amountStr)
+				}
+			} else if !noFund {
+				return fmt.Errorf("the gas station only funds %s self-delegations, fund %s yourself and use --%s", initia.DefaultGasPriceDenom, operator.Address, FlagNoFund)
+			}
+
+			fmt.Printf("\nOperator         %s\nValidator        %s\nMoniker          %s\nSelf-delegation  %s\nCommission       %s (max %s, max change %s)\n",
+				operator.Address, valoper, description.Moniker, amountStr, commission.Rate, commission.MaxRate, commission.MaxChangeRate)
+			if funding.Sign() > 0 {
+				fmt.Printf("Gas station      sends %s%s to the operator\n", funding, selfDelegation.Denom)
+			}
+			fmt.Println()
+			if !yes {
+				if !term.IsTerminal(os.Stdin.Fd()) {
+					return fmt.Errorf("use --%s to create the validator in a non-interactive session", FlagYes)
+				}
+				if ok, err := askYesNo("Create this validator?"); err != nil || !ok {
+					return err
+				}
+			}
+
+			if funding.Sign() > 0 {
+				if err := fundOperator(env, operator.Address, fmt.Sprintf("%s%s", funding, selfDelegation.Denom)); err != nil {
+					return err
+				}
+			}
+
+			backupPath, err := backupPrivValidatorKey(env.home, env.chainId)
+			if err != nil {
+				return err
+			}
+			fmt.Printf("Backed up priv_validator_key.json to %s.\n", backupPath)
+			fmt.Println("Keep this backup offline. Never run two nodes with the same priv_validator_key.json at the same time: " +
+				"the validator would double sign and be tombstoned, which slashes its stake and jails it permanently.")
+
+			consensusPubKey, err := env.executor.ConsensusPubKey(env.home)
+			if err != nil {
+				return err
+			}
+			validatorJSON, err := cosmosutils.CreateValidatorJSON(consensusPubKey, amountStr, description, commission)
+			if err != nil {
+				return err
+			}
+			res, err := env.executor.BroadcastCreateValidator(env.home, operator, validatorJSON, env.gasPrices, env.rpc, env.chainId)
+			if err != nil {
+				return err
+			}
+			fmt.Printf("\nCreated validator %s in tx %s.\n", valoper, res.TxHash)
+			return nil
+		},
+	}
+
+	createCmd.Flags().String(FlagKeyName, defaultOperatorKeyName, "Name of the operator key in the operator key file of the Initia home directory")
+	createCmd.Flags().Bool(FlagRecover, false, "Import the operator key from a mnemonic instead of generating one")
+	createCmd.Flags().String(FlagAmount, "", "Amount to self-delegate, ex. 1000000uinit")
+	createCmd.Flags().Bool(FlagNoFund, false, "Do not fund the operator from the gas station")
+	createCmd.Flags().BoolP(FlagYes, "y", false, "Skip the confirmation prompt")
+	addValidatorDescriptionFlags(createCmd)
+	createCmd.Flags().String(FlagCommissionRate, "0.10", "Commission rate")
+	createCmd.Flags().String(FlagCommissionMaxRate, "0.20", "Maximum commission rate, which can never be changed")
+	createCmd.Flags().String(FlagCommissionMaxChangeRate, "0.01", "Maximum daily commission rate change, which can never be changed")
+	_ = createCmd.MarkFlagRequired(FlagAmount)
+
+	return createCmd
+}
+
+func initiaValidatorEditCommand() *cobra.Command {
+	shortDescription := "Edit the description and commission rate of the validator"
+	editCmd := &cobra.Command{
+		Use:     "edit",
+		Short:   shortDescription,
+		Long:    fmt.Sprintf("%s.\n\nOnly the fields given as flags are changed.\n\n%s", shortDescription, L1NodeHelperText),
+		Args:    cobra.NoArgs,
+		PreRunE: isInitiated(service.UpgradableInitia),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			keyName, _ := cmd.Flags().GetString(FlagKeyName)
+			description := validatorDescriptionFromFlags(cmd)
+			commissionRate, _ := cmd.Flags().GetString(FlagCommissionRate)
+			if description == (cosmosutils.ValidatorDescription{}) && commissionRate == "" {
+				return fmt.Errorf("nothing to edit: set at least one of the description or commission flags")
+			}
+			if commissionRate != "" {
+				if err := common.ValidateDecFromStr(commissionRate); err != nil {
+					return fmt.Errorf("invalid --%s: %w", FlagCommissionRate, err)
+				}
+			}
+
+			env, err := newValidatorEnv()
+			if err != nil {
+				return err
+			}
+			operator, err := loadOperatorKey(env, keyName)
+			if err != nil {
+				return err
+			}
+			res, err := env.executor.BroadcastEditValidator(env.home, operator, description, commissionRate, env.gasPrices, env.rpc, env.chainId)
+			if err != nil {
+				return err
+			}
+			fmt.Printf("Edited the validator in tx %s.\n", res.TxHash)
+			return nil
+		},
+	}
+
+	editCmd.Flags().String(FlagKeyName, defaultOperatorKeyName, "Name of the operator key in the operator key file of the Initia home directory")
+	addValidatorDescriptionFlags(editCmd)
+	editCmd.Flags().String(FlagCommissionRate, "", "New commission rate")
+
+	return editCmd
+}
+
+func initiaValidatorUnjailCommand() *cobra.Command {
+	shortDescription := "Unjail the validator after downtime"
+	unjailCmd := &cobra.Command{
+		Use:     "unjail",
+		Short:   shortDescription,
+		Long:    fmt.Sprintf("%s.\n\nMake sure the node is synced and signing again before unjailing, or it is jailed again.\n\n%s", shortDescription, L1NodeHelperText),
+		Args:    cobra.NoArgs,
+		PreRunE: isInitiated(service.UpgradableInitia),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			keyName, _ := cmd.Flags().GetString(FlagKeyName)
+
+			env, err := newValidatorEnv()
+			if err != nil {
+				return err
+			}
+			operator, err := loadOperatorKey(env, keyName)
+			if err != nil {
+				return err
+			}
+			valoper, err := operator.ValoperAddress()
+			if err != nil {
+				return err
+			}
+
+			if err := waitForNodeSync(env.home, env.rpc); err != nil {
+				return err
+			}
+			validator, err := env.executor.QueryValidator(valoper, env.rpc)
+			if err != nil {
+				return err
+			}
+			if validator == nil {
+				return fmt.Errorf("%s is not a validator", valoper)
+			}
+			if !validator.Jailed {
+				fmt.Printf("Validator %s is not jailed.\n", valoper)
+				return nil
+			}
+			res, err := env.executor.BroadcastUnjail(env.home, operator, env.gasPrices, env.rpc, env.chainId)
+			if err != nil {
+				return err
+			}
+			fmt.Printf("Unjailed validator %s in tx %s.\n", valoper, res.TxHash)
+			return nil
+		},
+	}
+
+	unjailCmd.Flags().String(FlagKeyName, defaultOperatorKeyName, "Name of the operator key in the operator key file of the Initia home directory")
+
+	return unjailCmd
+}
+
+func addValidatorDescriptionFlags(cmd *cobra.Command) {
+	cmd.Flags().String(FlagMoniker, "", "Validator name, defaults to the node moniker on create")
+	cmd.Flags().String(FlagIdentity, "", "Identity signature, ex. a Keybase key")
+	cmd.Flags().String(FlagWebsite, "", "Website of the validator")
+	cmd.Flags().String(FlagSecurityContact, "", "Security contact email")
+	cmd.Flags().String(FlagDetails, "", "Description of the validator")
+}
+
+func validatorDescriptionFromFlags(cmd *cobra.Command) cosmosutils.ValidatorDescription {
+	var description cosmosutils.ValidatorDescription
+	description.Moniker, _ = cmd.Flags().GetString(FlagMoniker)
+	description.Identity, _ = cmd.Flags().GetString(FlagIdentity)
+	description.Website, _ = cmd.Flags().GetString(FlagWebsite)
+	description.SecurityContact, _ = cmd.Flags().GetString(FlagSecurityContact)
+	description.Details, _ = cmd.Flags().GetString(FlagDetails)
+	return description
+}
+
+func validatorCommissionFromFlags(cmd *cobra.Command) (cosmosutils.ValidatorCommission, error) {
+	var commission cosmosutils.ValidatorCommission
+	commission.Rate, _ = cmd.Flags().GetString(FlagCommissionRate)
+	commission.MaxRate, _ = cmd.Flags().GetString(FlagCommissionMaxRate)
+	commission.MaxChangeRate, _ = cmd.Flags().GetString(FlagCommissionMaxChangeRate)
+	for _, rate := range []struct{ flag, value string }{
+		{FlagCommissionRate, commission.Rate},
+		{FlagCommissionMaxRate, commission.MaxRate},
+		{FlagCommissionMaxChangeRate, commission.MaxChangeRate},
+	} {
+		if err := common.ValidateDecFromStr(rate.value); err != nil {
+			return commission, fmt.Errorf("invalid --%s: %w", rate.flag, err)
+		}
+	}
+	return commission, nil
+}
+
+// findOperatorKey returns the operator key keyName from the operator key file, or from the test keyring of the node home
+// where older versions of weave kept it, and false if it is in neither
+func findOperatorKey(env *validatorEnv, keyName string) (cosmosutils.OperatorKey, bool, error) {
+	keyFilePath := cosmosutils.OperatorKeyFilePath(env.home)
+	if weaveio.FileOrFolderExists(keyFilePath) {
+		addresses, _, err := weaveio.LoadKeyFileAddresses(keyFilePath)
+		if err != nil {
+			return cosmosutils.OperatorKey{}, false, err
+		}
+		if _, found := addresses[keyName]; found {
+			keyFile, err := loadOperatorKeyFile(keyFilePath)
+			if err != nil {
+				return cosmosutils.OperatorKey{}, false, err
+			}
+			key, err := cosmosutils.NewOperatorKey(keyName, keyFile.GetMnemonic(keyName))
+			return key, true, err
+		}
+	}
+
+	if !env.executor.OperatorKeyExists(env.home, keyName) {
+		return cosmosutils.OperatorKey{}, false, nil
+	}
+	info, err := env.executor.ShowOperatorKey(env.home, keyName)
+	if err != nil {
+		return cosmosutils.OperatorKey{}, false, err
+	}
+	fmt.Printf("Operator key %s is stored unencrypted in the test keyring of %s. "+
+		"Move its mnemonic into %s with `initiad keys delete %s --keyring-backend test --home %s` and `weave initia validator create --%s` "+
+		"once you no longer need it there.\n", keyName, env.home, keyFilePath, keyName, env.home, FlagRecover)
+	return cosmosutils.OperatorKey{Name: keyName, Address: info.Address}, true, nil
+}
+
+// loadOperatorKey returns the operator key keyName, which must exist
+func loadOperatorKey(env *validatorEnv, keyName string) (cosmosutils.OperatorKey, error) {
+	key, found, err := findOperatorKey(env, keyName)
+	if err != nil {
+		return cosmosutils.OperatorKey{}, err
+	}
+	if !found {
+		return cosmosutils.OperatorKey{}, fmt.Errorf("operator key %s not found in %s", keyName, env.home)
+	}
+	return key, nil
+}
+
+// loadOperatorKeyFile resolves the passphrase of the operator key file and decrypts it, or returns an empty key file if there is none yet
+func loadOperatorKeyFile(keyFilePath string) (weaveio.KeyFile, error) {
+	if err := ensureKeyFilePassphrase(keyFilePath); err != nil {
+		return nil, err
+	}
+	keyFile := weaveio.NewKeyFile()
+	if weaveio.FileOrFolderExists(keyFilePath) {
+		if err := keyFile.Load(keyFilePath, config.GetKeyFilePassphrase(keyFilePath)); err != nil {
+			return nil, err
+		}
+	}
+	return keyFile, nil
+}
+
+// ensureOperatorKey returns the operator key, generating or importing it into the encrypted operator key file when it does not exist yet
+func ensureOperatorKey(env *validatorEnv, keyName string, recoverKey bool) (cosmosutils.OperatorKey, error) {
+	key, found, err := findOperatorKey(env, keyName)
+	if err != nil {
+		return cosmosutils.OperatorKey{}, err
+	}
+	if found {
+		if recoverKey {
+			return cosmosutils.OperatorKey{}, fmt.Errorf("operator key %s already exists in %s", keyName, env.home)
+		}
+		return key, nil
+	}
+
+	var mnemonic string
+	if recoverKey {
+		mnemonic, err = readMnemonic()
+	} else {
+		mnemonic, err = crypto.GenerateMnemonic()
+	}
+	if err != nil {
+		return cosmosutils.OperatorKey{}, err
+	}
+	if key, err = cosmosutils.NewOperatorKey(keyName, mnemonic); err != nil {
+		return cosmosutils.OperatorKey{}, err
+	}
+
+	keyFilePath := cosmosutils.OperatorKeyFilePath(env.home)
+	keyFile, err := loadOperatorKeyFile(keyFilePath)
+	if err != nil {
+		return cosmosutils.OperatorKey{}, err
+	}
+	keyFile.AddKey(keyName, weaveio.NewKey(key.Address, mnemonic, crypto.CosmosAddressType))
+	if err = keyFile.Write(keyFilePath, config.GetKeyFilePassphrase(keyFilePath)); err != nil {
+		return cosmosutils.OperatorKey{}, fmt.Errorf("failed to write %s: %w", keyFilePath, err)
+	}
+
+	if recoverKey {
+		fmt.Printf("Imported operator key %s with address %s into %s.\n", keyName, key.Address, keyFilePath)
+		return key, nil
+	}
+	fmt.Printf("Generated operator key %s with address %s. Its mnemonic is encrypted in %s.\n", keyName, key.Address, keyFilePath)
+	if err = offerOperatorMnemonicDisplay(mnemonic, keyFilePath); err != nil {
+		return cosmosutils.OperatorKey{}, err
+	}
+	return key, nil
+}
+
+// offerOperatorMnemonicDisplay shows a generated mnemonic only when asked to on a terminal, so that it does not end up in logs
+func offerOperatorMnemonicDisplay(mnemonic, keyFilePath string) error {
+	if term.IsTerminal(os.Stdin.Fd()) && term.IsTerminal(os.Stdout.Fd()) {
+		show, err := askYesNo("Show the mnemonic now to write it down?")
+		if err != nil {
+			return err
+		}
+		if show {
+			fmt.Printf("\n%s\n\n", mnemonic)
+			fmt.Println("Write down the mnemonic above and keep it safe. It is the only way to recover the operator key.")
+			return nil
+		}
+	}
+	fmt.Printf("Back up the mnemonic with `weave keys decrypt %s --%s <file>`. It is the only way to recover the operator key.\n", keyFilePath, FlagOutput)
+	return nil
+}
+
+// readMnemonic reads the mnemonic without echoing it, or a line from stdin when it is piped
+func readMnemonic() (string, error) {
+	var mnemonic string
+	if term.IsTerminal(os.Stdin.Fd()) {
+		fmt.Print("Enter the operator key mnemonic: ")
+		input, err := term.ReadPassword(os.Stdin.Fd())
+		fmt.Println()
+		if err != nil {
+			return "", fmt.Errorf("failed to read mnemonic: %v", err)
+		}
+		mnemonic = string(input)
+	} else {
+		input, err := bufio.NewReader(os.Stdin).ReadString('\n')
+		if err != nil && input == "" {
+			return "", fmt.Errorf("failed to read mnemonic: %v", err)
+		}
+		mnemonic = input
+	}
+
+	mnemonic = strings.TrimSpace(mnemonic)
+	if err := common.ValidateMnemonic(mnemonic); err != nil {
+		return "", err
+	}
+	return mnemonic, nil
+}
+
+// waitForNodeSync blocks until the node has caught up with the network
+func waitForNodeSync(home, rpc string) error {
+	collector := &nodeStatusCollector{home: home, rpc: rpc}
+	for {
+		status, err := collector.collect()
+		if err != nil {
+			return err
+		}
+		synced := !status.CatchingUp && (status.NetworkHeight == 0 || status.NetworkHeight-status.Height <= syncTolerance)
+		if synced {
+			fmt.Printf("The node is synced at height %d.\n", status.Height)
+			return nil
+		}
+
+		progress := fmt.Sprintf("Waiting for the node to sync: height %d", status.Height)
+		if status.NetworkHeight > 0 {
+			progress += fmt.Sprintf(" / %d", status.NetworkHeight)
+		}
+		if status.hasETA {
+			progress += fmt.Sprintf(", about %s left", time.Duration(status.ETASeconds)*time.Second)
+		}
+		fmt.Println(progress)
+		time.Sleep(10 * time.Second)
+	}
+}
+
+func fundOperator(env *validatorEnv, address, amount string) error {
+	if err := unlockGasStationKey(); err != nil {
+		return err
+	}
+	gasStationKey, err := config.GetGasStationKey()
+	if err != nil {
+		return fmt.Errorf("failed to get gas station key: %w", err)
+	}
+	signer, err := gasStationKey.InitiaSigner()
+	if err != nil {
+		return fmt.Errorf("failed to get gas station signer: %w", err)
+	}
+
+	fmt.Printf("Sending %s from the gas station to %s...\n", amount, address)
+	res, err := env.executor.BroadcastMsgSend(signer, address, amount, env.gasPrices, env.rpc, env.chainId)
+	if err != nil {
+		return fmt.Errorf("failed to fund the operator: %w", err)
+	}
+	fmt.Printf("Funded the operator in tx %s.\n", res.TxHash)
+	return nil
+}
+
+// backupPrivValidatorKey copies priv_validator_key.json into the weave data directory
+func backupPrivValidatorKey(home, chainId string) (string, error) {
+	userHome, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to get user home directory: %v", err)
+	}
+	backupDir := filepath.Join(userHome, common.WeaveDataDirectory, "validator_backups")
+	if err := os.MkdirAll(backupDir, 0700); err != nil {
+		return "", fmt.Errorf("failed to create backup directory: %w", err)
+	}
+
+	keyPath := filepath.Join(home, "config", "priv_validator_key.json")
+	backupPath := filepath.Join(backupDir, fmt.Sprintf("%s_priv_validator_key_%s.json", chainId, time.Now().UTC().Format("20060102T150405Z")))
+	data, err := os.ReadFile(keyPath)
+	if err != nil {
+		return "", fmt.Errorf("failed to read %s: %w", keyPath, err)
+	}
+	if err := os.WriteFile(backupPath, data, 0600); err != nil {
+		return "", fmt.Errorf("failed to back up %s: %w", keyPath, err)
+	}
+	return backupPath, nil
+}
@@ -0,0 +1,384 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/initia-labs/weave/client"
+	"github.com/initia-labs/weave/common"
+	"github.com/initia-labs/weave/config"
+	"github.com/initia-labs/weave/cosmosutils"
+	"github.com/initia-labs/weave/registry"
+	"github.com/initia-labs/weave/service"
+)
+
+const (
+	defaultMonitorInterval         = time.Minute
+	defaultWarnMissedBlocks        = 50
+	defaultCriticalMissedBlocks    = 200
+	defaultValidatorMonitorLogFile = "validator_monitor.log"
+)
+
+func initiaValidatorMonitorCommand() *cobra.Command {
+	shortDescription := "Watch the validator for missed blocks, jailing and tombstoning"
+	monitorCmd := &cobra.Command{
+		Use:   "monitor",
+		Short: shortDescription,
+		Long: fmt.Sprintf("%s.\n\nThe signing info of the consensus address in priv_validator_key.json is queried from the L1 LCDs. "+
+			"Alerts are printed, appended to a log file and optionally posted as JSON to a webhook.\n\n%s", shortDescription, L1NodeHelperText),
+	}
+
+	monitorCmd.AddCommand(
+		initiaValidatorMonitorRunCommand(),
+		initiaValidatorMonitorStartCommand(),
+		initiaValidatorMonitorStopCommand(),
+		initiaValidatorMonitorLogCommand(),
+	)
+
+	return monitorCmd
+}
+
+func addValidatorMonitorFlags(cmd *cobra.Command) {
+	cmd.Flags().Duration(FlagInterval, defaultMonitorInterval, "How often the signing info is queried")
+	cmd.Flags().Int64(FlagWarnMissedBlocks, defaultWarnMissedBlocks, "Missed blocks in the signing window at which a warning is raised")
+	cmd.Flags().Int64(FlagCriticalMissedBlocks, defaultCriticalMissedBlocks, "Missed blocks in the signing window at which a critical alert is raised")
+	cmd.Flags().String(FlagLogFile, "", "File alerts are appended to (default ~/.weave/log/validator_monitor.log)")
+	cmd.Flags().String(FlagWebhook, "", "URL alerts are posted to as JSON (default for run: the webhook saved by start)")
+}
+
+// validatorMonitorOptions holds the monitor flags, so that start can hand them to the service unchanged, apart from the
+// webhook that start saves to the config file
+type validatorMonitorOptions struct {
+	interval   time.Duration
+	thresholds cosmosutils.MissedBlockThresholds
+	logFile    string
+	webhook    string
+}
+
+func validatorMonitorOptionsFromFlags(cmd *cobra.Command) (*validatorMonitorOptions, error) {
+	interval, _ := cmd.Flags().GetDuration(FlagInterval)
+	if interval <= 0 {
+		return nil, fmt.Errorf("--%s must be positive", FlagInterval)
+	}
+	warning, _ := cmd.Flags().GetInt64(FlagWarnMissedBlocks)
+	critical, _ := cmd.Flags().GetInt64(FlagCriticalMissedBlocks)
+	thresholds := cosmosutils.MissedBlockThresholds{Warning: warning, Critical: critical}
+	if err := thresholds.Validate(); err != nil {
+		return nil, err
+	}
+
+	logFile, _ := cmd.Flags().GetString(FlagLogFile)
+	if logFile == "" {
+		userHome, err := os.UserHomeDir()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get user home directory: %v", err)
+		}
+		logFile = filepath.Join(userHome, common.WeaveLogDirectory, defaultValidatorMonitorLogFile)
+	}
+	logFile, err := filepath.Abs(logFile)
+	if err != nil {
+		return nil, fmt.Errorf("invalid --%s: %v", FlagLogFile, err)
+	}
+
+	webhook, _ := cmd.Flags().GetString(FlagWebhook)
+	if !cmd.Flags().Changed(FlagWebhook) && cmd.Name() == "run" {
+		// The service is not given the webhook on its command line, where any user could read it
+		webhook, _ = config.GetConfig(config.ValidatorMonitorWebhookKey).(string)
+	}
+	if webhook != "" && !strings.HasPrefix(webhook, "http://") && !strings.HasPrefix(webhook, "https://") {
+		return nil, fmt.Errorf("--%s must be an http or https URL", FlagWebhook)
+	}
+
+	return &validatorMonitorOptions{
+		interval:   interval,
+		thresholds: thresholds,
+		logFile:    logFile,
+		webhook:    webhook,
+	}, nil
+}
+
+func (o *validatorMonitorOptions) args() []string {
+	return []string{
+		"--" + FlagInterval, o.interval.String(),
+		"--" + FlagWarnMissedBlocks, strconv.FormatInt(o.thresholds.Warning, 10),
+		"--" + FlagCriticalMissedBlocks, strconv.FormatInt(o.thresholds.Critical, 10),
+		"--" + FlagLogFile, o.logFile,
+	}
+}
+
+func initiaValidatorMonitorRunCommand() *cobra.Command {
+	shortDescription := "Run the validator monitor in the foreground"
+	runCmd := &cobra.Command{
+		Use:   "run",
+		Short: shortDescription,
+		Long: fmt.Sprintf("%s.\n\nThis is what the validator monitor service runs. "+
+			"Use `weave initia validator monitor start` to keep it running in the background.\n\n%s", shortDescription, L1NodeHelperText),
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			options, err := validatorMonitorOptionsFromFlags(cmd)
+			if err != nil {
+				return err
+			}
+			home, _ := cmd.Flags().GetString(FlagHome)
+			if home == "" {
+				if _, home, err = initiaServiceAndHome(); err != nil {
+					return err
+				}
+			}
+
+			sinks := []alertSink{stdoutAlertSink{}, fileAlertSink{path: options.logFile}}
+			if options.webhook != "" {
+				sinks = append(sinks, webhookAlertSink{url: options.webhook})
+			}
+
+			ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt, syscall.SIGTERM)
+			defer stop()
+			// Queries in flight are abandoned on shutdown instead of running out their retries
+			client.SetBaseContext(ctx)
+			return runValidatorMonitor(ctx, home, options, sinks)
+		},
+	}
+
+	runCmd.Flags().String(FlagHome, "", "Initia home directory (default: the home of the Initia full node service)")
+	addValidatorMonitorFlags(runCmd)
+
+	return runCmd
+}
+
+func initiaValidatorMonitorStartCommand() *cobra.Command {
+	shortDescription := "Start the validator monitor service"
+	startCmd := &cobra.Command{
+		Use:   "start",
+		Short: shortDescription,
+		Long: fmt.Sprintf("%s.\n\nThe service runs `weave initia validator monitor run` with the given flags "+
+			"and is restarted if it fails. The webhook is saved to the config file of the profile, which only you can read, "+
+			"instead of the service definition.\n\n%s", shortDescription, L1NodeHelperText),
+		Args:    cobra.NoArgs,
+		PreRunE: isInitiated(service.UpgradableInitia),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			options, err := validatorMonitorOptionsFromFlags(cmd)
+			if err != nil {
+				return err
+			}
+			_, home, err := initiaServiceAndHome()
+			if err != nil {
+				return err
+			}
+			consensusAddress, err := cosmosutils.ConsensusAddress(home)
+			if err != nil {
+				return err
+			}
+
+			s, err := service.NewService(service.ValidatorMonitor, "")
+			if err != nil {
+				return err
+			}
+			if options.webhook != "" {
+				err = config.SetConfig(config.ValidatorMonitorWebhookKey, options.webhook)
+			} else if config.GetConfig(config.ValidatorMonitorWebhookKey) != nil {
+				err = config.UnsetConfig(config.ValidatorMonitorWebhookKey)
+			}
+			if err != nil {
+				return fmt.Errorf("failed to save the webhook: %w", err)
+			}
+			if err = s.Create("", home); err != nil {
+				return fmt.Errorf("failed to create the validator monitor service: %w", err)
+			}
+			if err = s.Start(options.args()...); err != nil {
+				return err
+			}
+			fmt.Printf("Started the validator monitor for %s. Alerts are appended to %s.\n", consensusAddress, options.logFile)
+			fmt.Println("Run `weave initia validator monitor log` to see its output.")
+			return nil
+		},
+	}
+
+	addValidatorMonitorFlags(startCmd)
+
+	return startCmd
+}
+
+func initiaValidatorMonitorStopCommand() *cobra.Command {
+	shortDescription := "Stop the validator monitor service"
+	stopCmd := &cobra.Command{
+		Use:     "stop",
+		Short:   shortDescription,
+		Long:    fmt.Sprintf("%s.\n\n%s", shortDescription, L1NodeHelperText),
+		Args:    cobra.NoArgs,
+		PreRunE: isInitiated(service.ValidatorMonitor),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			s, err := service.NewService(service.ValidatorMonitor, "")
+			if err != nil {
+				return err
+			}
+			if err = s.Stop(); err != nil {
+				return err
+			}
+			fmt.Println("Stopped the validator monitor service.")
+			return nil
+		},
+	}
+
+	return stopCmd
+}
+
+func initiaValidatorMonitorLogCommand() *cobra.Command {
+	shortDescription := "Stream the logs of the validator monitor service"
+	logCmd := &cobra.Command{
+		Use:     "log",
+		Short:   shortDescription,
+		Long:    fmt.Sprintf("%s.\n\n%s", shortDescription, L1NodeHelperText),
+		Args:    cobra.NoArgs,
+		PreRunE: isInitiated(service.ValidatorMonitor),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			n, err := cmd.Flags().GetInt(FlagN)
+			if err != nil {
+				return err
+			}
+
+			s, err := service.NewService(service.ValidatorMonitor, "")
+			if err != nil {
+				return err
+			}
+			return s.Log(n)
+		},
+	}
+
+	logCmd.Flags().IntP(FlagN, FlagN, 100, "previous log lines to show")
+
+	return logCmd
+}
+
+// runValidatorMonitor polls the signing info of the validator of home until ctx is done
+func runValidatorMonitor(ctx context.Context, home string, options *validatorMonitorOptions, sinks []alertSink) error {
+	consensusAddress, err := cosmosutils.ConsensusAddress(home)
+	if err != nil {
+		return err
+	}
+	chainId, err := readGenesisChainId(home)
+	if err != nil {
+		return err
+	}
+	chainType, err := initiaChainType(chainId)
+	if err != nil {
+		return err
+	}
+	chainRegistry, err := registry.GetChainRegistry(chainType)
+	if err != nil {
+		return err
+	}
+	lcds, err := chainRegistry.GetActiveLcds()
+	if err != nil {
+		return err
+	}
+
+	// Without the params the alerts only lack the allowance of the signing window
+	var params cosmosutils.SlashingParams
+	if queried, err := cosmosutils.QuerySlashingParams(lcds); err != nil {
+		fmt.Fprintf(os.Stderr, "Could not query the slashing params: %v\n", err)
+	} else {
+		params = *queried
+	}
+
+	fmt.Printf("Monitoring %s on %s every %s (warning at %d, critical at %d missed blocks).\n",
+		consensusAddress, chainId, options.interval, options.thresholds.Warning, options.thresholds.Critical)
+
+	var previous *cosmosutils.SigningInfo
+	ticker := time.NewTicker(options.interval)
+	defer ticker.Stop()
+	for {
+		info, err := cosmosutils.QuerySigningInfo(lcds, consensusAddress)
+		if err != nil {
+			// The slashing module only knows validators that have been in the active set
+			fmt.Fprintf(os.Stderr, "Could not query the signing info of %s: %v\n", consensusAddress, err)
+		} else {
+			info.Address = consensusAddress
+			for _, alert := range cosmosutils.EvaluateSigningInfo(previous, *info, params, options.thresholds, time.Now()) {
+				for _, sink := range sinks {
+					if err := sink.Send(alert); err != nil {
+						fmt.Fprintf(os.Stderr, "Could not deliver the alert to the %s: %v\n", sink.Name(), err)
+					}
+				}
+			}
+			previous = info
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+type alertSink interface {
+	Name() string
+	Send(alert cosmosutils.ValidatorAlert) error
+}
+
+func formatAlert(alert cosmosutils.ValidatorAlert) string {
+	return fmt.Sprintf("%s [%s] %s: %s", alert.Time.UTC().Format(time.RFC3339), strings.ToUpper(string(alert.Level)), alert.ConsensusAddress, alert.Message)
+}
+
+type stdoutAlertSink struct{}
+
+func (stdoutAlertSink) Name() string {
+	return "standard output"
+}
+
+func (stdoutAlertSink) Send(alert cosmosutils.ValidatorAlert) error {
+	_, err := fmt.Println(formatAlert(alert))
+	return err
+}
+
+type fileAlertSink struct {
+	path string
+}
+
+func (s fileAlertSink) Name() string {
+	return fmt.Sprintf("log file %s", s.path)
+}
+
+func (s fileAlertSink) Send(alert cosmosutils.ValidatorAlert) error {
+	if err := os.MkdirAll(filepath.Dir(s.path), 0755); err != nil {
+		return err
+	}
+	file, err := os.OpenFile(s.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	_, err = fmt.Fprintln(file, formatAlert(alert))
+	return err
+}
+
+type webhookAlertSink struct {
+	url string
+}
+
+func (s webhookAlertSink) Name() string {
+	return "webhook"
+}
+
+func (s webhookAlertSink) Send(alert cosmosutils.ValidatorAlert) error {
+	payload := struct {
+		cosmosutils.ValidatorAlert
+		Text string `json:"text"`
+	}{alert, formatAlert(alert)}
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+	_, err = client.NewHTTPClient().Post(s.url, "", map[string]string{"Content-Type": "application/json"}, body, nil)
+	return err
+}
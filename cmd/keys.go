@@ -0,0 +1,311 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	"github.com/initia-labs/weave/common"
+	"github.com/initia-labs/weave/config"
+	"github.com/initia-labs/weave/cosmosutils"
+	weaveio "github.com/initia-labs/weave/io"
+)
+
+func KeysCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:                        "keys",
+		Short:                      "Inspect the keys managed by Weave and manage encrypted key files",
+		DisableFlagParsing:         true,
+		SuggestionsMinimumDistance: 2,
+	}
+
+	cmd.AddCommand(
+		keysListCommand(),
+		keysShowCommand(),
+		keysExportCommand(),
+		keysEncryptCommand(),
+		keysDecryptCommand(),
+	)
+
+	return cmd
+}
+
+// addKeyLocationFlags registers the directories the key inventory is collected from
+func addKeyLocationFlags(cmd *cobra.Command) {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		panic(fmt.Errorf("cannot get user home directory: %v", err))
+	}
+
+	cmd.Flags().String(FlagMinitiaHome, filepath.Join(homeDir, common.MinitiaDirectory), "Rollup application directory to read the system keys from")
+	cmd.Flags().String(FlagOPInitHome, filepath.Join(homeDir, common.OPinitDirectory), "OPInit bots home directory to read the bot keys from")
+}
+
+func loadManagedKeys(cmd *cobra.Command) ([]managedKey, error) {
+	userHome, err := os.UserHomeDir()
+	if err != nil {
+		return nil, fmt.Errorf("error getting user home directory: %v", err)
+	}
+	minitiaHome, _ := cmd.Flags().GetString(FlagMinitiaHome)
+	opInitHome, _ := cmd.Flags().GetString(FlagOPInitHome)
+
+	return collectManagedKeys(userHome, minitiaHome, opInitHome)
+}
+
+func printManagedKeys(keys []managedKey, asJSON bool) error {
+	if asJSON {
+		if keys == nil {
+			keys = []managedKey{}
+		}
+		return printJSON(keys)
+	}
+
+	if len(keys) == 0 {
+		fmt.Println("No keys found. Setup the Gas Station with `weave gas-station setup` to get started.")
+		return nil
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "NAME\tROLE\tCHAIN\tADDRESS\tHEX ADDRESS\tCOIN TYPE")
+	for _, key := range keys {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%d\n", key.Name, key.Role, key.Chain, key.Address, key.HexAddress, key.CoinType)
+		for chainId, coins := range key.Balances {
+			fmt.Fprintf(w, "\t\t%s\t%s\t\t\n", chainId, renderCoinsInline(coins))
+		}
+	}
+	return w.Flush()
+}
+
+func renderCoinsInline(coins *cosmosutils.Coins) string {
+	if coins == nil || len(*coins) == 0 {
+		return cosmosutils.NoBalancesText
+	}
+
+	parts := make([]string, 0, len(*coins))
+	for _, coin := range *coins {
+		parts = append(parts, coin.Amount+coin.Denom)
+	}
+	return strings.Join(parts, ", ")
+}
+
+func keysListCommand() *cobra.Command {
+	listCmd := &cobra.Command{
+		Use:   "list",
+		Short: "List every key managed by Weave with its role, chain and addresses",
+		Long: "List every key managed by Weave with its role, chain and addresses.\n\n" +
+			"Keys are collected from the Gas Station in the Weave config, the rollup system keys in artifacts/config.json, " +
+			"the OPinit bot key file and keyring, and the relayer config.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			keys, err := loadManagedKeys(cmd)
+			if err != nil {
+				return err
+			}
+
+			if withBalances, _ := cmd.Flags().GetBool(FlagBalances); withBalances {
+				fillBalances(keys)
+			}
+
+			asJSON, _ := cmd.Flags().GetBool(FlagJSON)
+			return printManagedKeys(keys, asJSON)
+		},
+	}
+
+	addKeyLocationFlags(listCmd)
+	listCmd.Flags().Bool(FlagBalances, false, "Query the live balances of every key")
+	listCmd.Flags().Bool(FlagJSON, false, "Output in JSON format")
+
+	return listCmd
+}
+
+func keysShowCommand() *cobra.Command {
+	showCmd := &cobra.Command{
+		Use:   "show <name>",
+		Short: "Show the addresses and balances of a single key",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			keys, err := loadManagedKeys(cmd)
+			if err != nil {
+				return err
+			}
+
+			keys, err = filterManagedKeys(keys, args[0])
+			if err != nil {
+				return err
+			}
+
+			if withBalances, _ := cmd.Flags().GetBool(FlagBalances); withBalances {
+				fillBalances(keys)
+			}
+
+			asJSON, _ := cmd.Flags().GetBool(FlagJSON)
+			if err = printManagedKeys(keys, asJSON); err != nil {
+				return err
+			}
+			if !asJSON {
+				fmt.Printf("\nSource: %s\n", keys[0].Source)
+			}
+			return nil
+		},
+	}
+
+	addKeyLocationFlags(showCmd)
+	showCmd.Flags().Bool(FlagBalances, false, "Query the live balances of the key")
+	showCmd.Flags().Bool(FlagJSON, false, "Output in JSON format")
+
+	return showCmd
+}
+
+func keysExportCommand() *cobra.Command {
+	exportCmd := &cobra.Command{
+		Use:   "export <name>",
+		Short: "Print the mnemonic of a single key",
+		Long: "Print the mnemonic of a single key.\n\n" +
+			"Anyone with the mnemonic has full control over the funds of the key. " +
+			"You will be asked to type the key name to confirm unless --force is given.",
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			name := args[0]
+			keys, err := loadManagedKeys(cmd)
+			if err != nil {
+				return err
+			}
+
+			keys, err = filterManagedKeys(keys, name)
+			if err != nil {
+				return err
+			}
+
+			if force, _ := cmd.Flags().GetBool(FlagForce); !force {
+				fmt.Printf("⚠️  The mnemonic of %s gives full control over its funds. Type the key name to confirm: ", name)
+				confirmation, err := bufio.NewReader(os.Stdin).ReadString('\n')
+				if err != nil {
+					return fmt.Errorf("failed to read confirmation: %v", err)
+				}
+				if strings.TrimSpace(confirmation) != name {
+					return fmt.Errorf("confirmation does not match, aborting export")
+				}
+			}
+
+			mnemonic, err := keys[0].mnemonic()
+			if err != nil {
+				return err
+			}
+			fmt.Println(mnemonic)
+			return nil
+		},
+	}
+
+	addKeyLocationFlags(exportCmd)
+	exportCmd.Flags().BoolP(FlagForce, "f", false, "Skip the confirmation prompt")
+
+	return exportCmd
+}
+
+func keysEncryptCommand() *cobra.Command {
+	encryptCmd := &cobra.Command{
+		Use:   "encrypt <key-file>",
+		Short: "Encrypt a plaintext key file with a passphrase",
+		Long: fmt.Sprintf("Encrypt a plaintext key file with a passphrase.\n\n"+
+			"The passphrase is read from %s if set, otherwise you will be prompted for it. "+
+			"The key file is encrypted in place unless --%s is given.", config.PassphraseEnvVar, FlagOutput),
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			keyFilePath := args[0]
+			outputPath, _ := cmd.Flags().GetString(FlagOutput)
+			if outputPath == "" {
+				outputPath = keyFilePath
+			}
+
+			if !weaveio.FileOrFolderExists(keyFilePath) {
+				return fmt.Errorf("key file is missing at path: %s", keyFilePath)
+			}
+			encrypted, err := weaveio.IsEncryptedKeyFile(keyFilePath)
+			if err != nil {
+				return err
+			}
+			if encrypted {
+				fmt.Printf("%s is already encrypted.\n", keyFilePath)
+				return nil
+			}
+
+			keyFile := weaveio.NewKeyFile()
+			if err = keyFile.Load(keyFilePath, ""); err != nil {
+				return err
+			}
+
+			passphrase := config.GetKeyFilePassphrase(outputPath)
+			if passphrase == "" {
+				if passphrase, err = readNewPassphrase(); err != nil {
+					return err
+				}
+			}
+
+			if err = keyFile.Write(outputPath, passphrase); err != nil {
+				return fmt.Errorf("error writing to file: %w", err)
+			}
+			fmt.Printf("🔒 Encrypted key file saved at %s\n", outputPath)
+			return nil
+		},
+	}
+
+	encryptCmd.Flags().String(FlagOutput, "", "Path to write the encrypted key file to. Defaults to encrypting in place")
+
+	return encryptCmd
+}
+
+func keysDecryptCommand() *cobra.Command {
+	decryptCmd := &cobra.Command{
+		Use:   "decrypt <key-file>",
+		Short: "Export an encrypted key file as plaintext",
+		Long: fmt.Sprintf("Export an encrypted key file as plaintext.\n\n"+
+			"The passphrase is read from %s if set, otherwise you will be prompted for it. "+
+			"The plaintext key file contains every mnemonic unprotected, so keep it somewhere safe.", config.PassphraseEnvVar),
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			keyFilePath := args[0]
+			outputPath, _ := cmd.Flags().GetString(FlagOutput)
+			if outputPath == "" {
+				outputPath = keyFilePath
+			}
+
+			if !weaveio.FileOrFolderExists(keyFilePath) {
+				return fmt.Errorf("key file is missing at path: %s", keyFilePath)
+			}
+			encrypted, err := weaveio.IsEncryptedKeyFile(keyFilePath)
+			if err != nil {
+				return err
+			}
+			if !encrypted {
+				fmt.Printf("%s is not encrypted.\n", keyFilePath)
+				return nil
+			}
+
+			passphrase := config.GetKeyFilePassphrase(keyFilePath)
+			if passphrase == "" {
+				if passphrase, err = readPassphrase(fmt.Sprintf("Enter passphrase for %s: ", keyFilePath)); err != nil {
+					return err
+				}
+			}
+
+			keyFile := weaveio.NewKeyFile()
+			if err = keyFile.Load(keyFilePath, passphrase); err != nil {
+				return err
+			}
+
+			if err = keyFile.Export(outputPath); err != nil {
+				return fmt.Errorf("error writing to file: %w", err)
+			}
+			fmt.Printf("🔓 Plaintext key file exported to %s\n", outputPath)
+			return nil
+		},
+	}
+
+	decryptCmd.Flags().String(FlagOutput, "", "Path to write the plaintext key file to. Defaults to decrypting in place")
+
+	return decryptCmd
+}
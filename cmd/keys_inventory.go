@@ -0,0 +1,489 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/initia-labs/weave/common"
+	"github.com/initia-labs/weave/config"
+	"github.com/initia-labs/weave/cosmosutils"
+	"github.com/initia-labs/weave/crypto"
+	weaveio "github.com/initia-labs/weave/io"
+	"github.com/initia-labs/weave/models/opinit_bots"
+	"github.com/initia-labs/weave/registry"
+	"github.com/initia-labs/weave/service"
+	"github.com/initia-labs/weave/types"
+)
+
+const (
+	initiaNetwork   = "initia"
+	celestiaNetwork = "celestia"
+)
+
+// managedKey describes a single address of a key managed by weave, wherever the key is stored
+type managedKey struct {
+	Name       string                        `json:"name"`
+	Role       string                        `json:"role"`
+	Chain      string                        `json:"chain"`
+	Address    string                        `json:"address"`
+	HexAddress string                        `json:"hex_address"`
+	CoinType   int                           `json:"coin_type"`
+	Source     string                        `json:"source"`
+	Balances   map[string]*cosmosutils.Coins `json:"balances,omitempty"`
+
+	// mnemonic is resolved lazily so that listing keys never decrypts more than needed
+	mnemonic func() (string, error)
+}
+
+func newManagedKey(name, role, chain, address string, coinType int, source string, mnemonic func() (string, error)) managedKey {
+	hexAddress, err := crypto.Bech32ToHex(address)
+	if err != nil {
+		hexAddress = ""
+	}
+
+	return managedKey{
+		Name:       name,
+		Role:       role,
+		Chain:      chain,
+		Address:    address,
+		HexAddress: hexAddress,
+		CoinType:   coinType,
+		Source:     source,
+		mnemonic:   mnemonic,
+	}
+}
+
+func staticMnemonic(mnemonic string) func() (string, error) {
+	return func() (string, error) {
+		return mnemonic, nil
+	}
+}
+
+// networkFromAddress guesses the network family of an address from its Bech32 prefix
+func networkFromAddress(address string) string {
+	if strings.HasPrefix(address, celestiaNetwork) {
+		return celestiaNetwork
+	}
+	return initiaNetwork
+}
+
+// collectManagedKeys gathers the gas station, rollup system, OPinit bot and relayer keys
+func collectManagedKeys(userHome, minitiaHome, opInitHome string) ([]managedKey, error) {
+	var keys []managedKey
+
+	collectors := []func() ([]managedKey, error){
+		collectGasStationKeys,
+		func() ([]managedKey, error) { return collectRollupSystemKeys(minitiaHome) },
+		func() ([]managedKey, error) { return collectOPInitKeys(opInitHome) },
+		func() ([]managedKey, error) { return collectRelayerKeys(userHome, rollupCoinTypes(minitiaHome)) },
+	}
+	for _, collect := range collectors {
+		collected, err := collect()
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, collected...)
+	}
+
+	return keys, nil
+}
+
+func collectGasStationKeys() ([]managedKey, error) {
+	if config.IsFirstTimeSetup() {
+		return nil, nil
+	}
+
+	gasStationKey, err := config.GetGasStationPublicKey()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load gas station key: %v", err)
+	}
+
+	mnemonic := func() (string, error) {
+		if err := unlockGasStationKey(); err != nil {
+			return "", err
+		}
+		key, err := config.GetGasStationKey()
+		if err != nil {
+			return "", err
+		}
+		return key.Mnemonic, nil
+	}
+
+	source := "weave config"
+	if config.IsGasStationKeyLocked() {
+		source = "weave config (encrypted)"
+	}
+	if gasStationKey.ExternalSigner != nil {
+		source = "external signer"
+		mnemonic = func() (string, error) {
+			return "", fmt.Errorf("gas station key is held by an external signer and has no mnemonic")
+		}
+	}
+
+	coinType := 118
+	if gasStationKey.CoinType != nil {
+		coinType = *gasStationKey.CoinType
+	}
+
+	return []managedKey{
+		newManagedKey("gas-station", "gas station", initiaNetwork, gasStationKey.InitiaAddress, coinType, source, mnemonic),
+		newManagedKey("gas-station", "gas station", celestiaNetwork, gasStationKey.CelestiaAddress, 118, source, mnemonic),
+	}, nil
+}
+
+// loadMinitiaConfig reads the artifacts config of the rollup launched under minitiaHome, if any
+func loadMinitiaConfig(minitiaHome string) (*types.MinitiaConfig, string, error) {
+	configPath := filepath.Join(minitiaHome, common.MinitiaArtifactsConfigJson)
+	if !weaveio.FileOrFolderExists(configPath) {
+		return nil, configPath, nil
+	}
+
+	data, err := os.ReadFile(configPath)
+	if err != nil {
+		return nil, configPath, fmt.Errorf("failed to read %s: %v", configPath, err)
+	}
+
+	var minitiaConfig types.MinitiaConfig
+	if err := json.Unmarshal(data, &minitiaConfig); err != nil {
+		return nil, configPath, fmt.Errorf("failed to unmarshal %s: %v", configPath, err)
+	}
+	if err := minitiaConfig.SystemKeys.ValidateDerivation(); err != nil {
+		return nil, configPath, fmt.Errorf("%s: %w", configPath, err)
+	}
+	return &minitiaConfig, configPath, nil
+}
+
+// rollupCoinType returns the coin type of the rollup accounts under minitiaHome, which is 60 on minievm
+func rollupCoinType(minitiaHome string) int {
+	if vm, err := cosmosutils.DetectMinitiaVMFromGenesisFile(minitiaHome); err == nil && vm == "evm" {
+		return 60
+	}
+	return 118
+}
+
+// rollupCoinTypes maps the chain ID of the local rollup to the coin type of its accounts
+func rollupCoinTypes(minitiaHome string) map[string]int {
+	minitiaConfig, _, err := loadMinitiaConfig(minitiaHome)
+	if err != nil || minitiaConfig == nil || minitiaConfig.L2Config == nil || minitiaConfig.L2Config.ChainID == "" {
+		return nil
+	}
+	return map[string]int{minitiaConfig.L2Config.ChainID: rollupCoinType(minitiaHome)}
+}
+
+func collectRollupSystemKeys(minitiaHome string) ([]managedKey, error) {
+	minitiaConfig, configPath, err := loadMinitiaConfig(minitiaHome)
+	if err != nil {
+		return nil, err
+	}
+	if minitiaConfig == nil || minitiaConfig.SystemKeys == nil {
+		return nil, nil
+	}
+
+	var l1ChainId, l2ChainId string
+	if minitiaConfig.L1Config != nil {
+		l1ChainId = minitiaConfig.L1Config.ChainID
+	}
+	if minitiaConfig.L2Config != nil {
+		l2ChainId = minitiaConfig.L2Config.ChainID
+	}
+
+	l2CoinType := rollupCoinType(minitiaHome)
+	systemKeys := minitiaConfig.SystemKeys
+	accounts := []struct {
+		role    string
+		account *types.SystemAccount
+	}{
+		{"validator", systemKeys.Validator},
+		{"bridge_executor", systemKeys.BridgeExecutor},
+		{"output_submitter", systemKeys.OutputSubmitter},
+		{"batch_submitter", systemKeys.BatchSubmitter},
+		{"challenger", systemKeys.Challenger},
+	}
+
+	var keys []managedKey
+	for _, a := range accounts {
+		if a.account == nil {
+			continue
+		}
+
+		name := fmt.Sprintf("rollup.%s", a.role)
+		role := fmt.Sprintf("rollup %s", strings.ReplaceAll(a.role, "_", " "))
+		mnemonic := staticMnemonic(a.account.Mnemonic)
+		if a.account.L1Address != "" {
+			keys = append(keys, newManagedKey(name, role, l1ChainId, a.account.L1Address, 118, configPath, mnemonic))
+		}
+		if a.account.L2Address != "" {
+			keys = append(keys, newManagedKey(name, role, l2ChainId, a.account.L2Address, l2CoinType, configPath, mnemonic))
+		}
+		if a.account.DAAddress != "" {
+			daChain := l1ChainId
+			if networkFromAddress(a.account.DAAddress) == celestiaNetwork {
+				daChain = celestiaNetwork
+			}
+			keys = append(keys, newManagedKey(name, role, daChain, a.account.DAAddress, 118, configPath, mnemonic))
+		}
+	}
+
+	return keys, nil
+}
+
+func collectOPInitKeys(opInitHome string) ([]managedKey, error) {
+	keyFilePath := filepath.Join(opInitHome, common.OPinitKeyFileJson)
+	addresses := make(map[string]string)
+	if weaveio.FileOrFolderExists(keyFilePath) {
+		stored, ok, err := weaveio.LoadKeyFileAddresses(keyFilePath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load OPinit key file: %v", err)
+		}
+		if ok {
+			addresses = stored
+		} else {
+			// Key files encrypted before the addresses were stored alongside can only be listed once decrypted
+			keyFile, err := loadOPInitKeyFile(keyFilePath)
+			if err != nil {
+				return nil, err
+			}
+			for name, key := range keyFile {
+				addresses[name] = key.Address
+			}
+		}
+	}
+
+	// The mnemonics are decrypted at most once, and only when one of them is asked for
+	loadKeyFile := sync.OnceValues(func() (weaveio.KeyFile, error) {
+		return loadOPInitKeyFile(keyFilePath)
+	})
+
+	names := make([]string, 0, len(addresses))
+	for name := range addresses {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var keys []managedKey
+	for _, name := range names {
+		address := addresses[name]
+		mnemonic := func() (string, error) {
+			keyFile, err := loadKeyFile()
+			if err != nil {
+				return "", err
+			}
+			key, ok := keyFile[name]
+			if !ok {
+				return "", fmt.Errorf("key %s not found in %s", name, keyFilePath)
+			}
+			return key.Mnemonic, nil
+		}
+
+		role := fmt.Sprintf("opinit %s", strings.ReplaceAll(strings.TrimPrefix(name, "weave_"), "_", " "))
+		keys = append(keys, newManagedKey("opinit."+name, role, networkFromAddress(address), address, 118, keyFilePath, mnemonic))
+	}
+
+	// Keys that only live in the weave-dummy keyring can still be listed through the installed opinitd binary
+	binaryPath, ok := findOPInitBinary()
+	if !ok {
+		return keys, nil
+	}
+	for _, name := range []string{
+		opinit_bots.BridgeExecutorKeyName,
+		opinit_bots.OutputSubmitterKeyName,
+		opinit_bots.BatchSubmitterKeyName,
+		opinit_bots.OracleBridgeExecutorKeyName,
+		opinit_bots.ChallengerKeyName,
+	} {
+		if _, ok := addresses[name]; ok {
+			continue
+		}
+		address, err := cosmosutils.OPInitGetAddressForKey(binaryPath, name, opInitHome)
+		if err != nil {
+			continue
+		}
+
+		role := fmt.Sprintf("opinit %s", strings.ReplaceAll(strings.TrimPrefix(name, "weave_"), "_", " "))
+		keys = append(keys, newManagedKey("opinit."+name, role, networkFromAddress(address), address, 118, "weave-dummy keyring", func() (string, error) {
+			return "", fmt.Errorf("the mnemonic of %s is only stored in the weave-dummy keyring under %s", name, opInitHome)
+		}))
+	}
+
+	return keys, nil
+}
+
+// loadOPInitKeyFile decrypts the OPinit key file, prompting for its passphrase when needed
+func loadOPInitKeyFile(keyFilePath string) (weaveio.KeyFile, error) {
+	keyFile := weaveio.NewKeyFile()
+	encrypted, err := weaveio.IsEncryptedKeyFile(keyFilePath)
+	if err != nil {
+		return nil, err
+	}
+	if encrypted {
+		if err := ensureKeyFilePassphrase(keyFilePath); err != nil {
+			return nil, err
+		}
+	}
+	if err := keyFile.Load(keyFilePath, config.GetKeyFilePassphrase(keyFilePath)); err != nil {
+		return nil, fmt.Errorf("failed to load OPinit key file: %v", err)
+	}
+	return keyFile, nil
+}
+
+// findOPInitBinary locates the opinitd binary used by an installed OPinit bot service
+func findOPInitBinary() (string, bool) {
+	for _, commandName := range []service.CommandName{service.OPinitExecutor, service.OPinitChallenger} {
+		srv, err := service.NewService(commandName, "")
+		if err != nil {
+			continue
+		}
+		binaryPath, _, err := srv.GetServiceBinaryAndHome()
+		if err == nil && weaveio.FileOrFolderExists(binaryPath) {
+			return binaryPath, true
+		}
+	}
+	return "", false
+}
+
+type rapidRelayerConfig struct {
+	Chains []struct {
+		Bech32Prefix string `json:"bech32Prefix"`
+		ChainID      string `json:"chainId"`
+		Wallets      []struct {
+			Key struct {
+				Type       string `json:"type"`
+				PrivateKey string `json:"privateKey"`
+			} `json:"key"`
+		} `json:"wallets"`
+	} `json:"chains"`
+}
+
+// collectRelayerKeys lists the relayer wallets. Chains missing from coinTypes use coin type 118.
+func collectRelayerKeys(userHome string, coinTypes map[string]int) ([]managedKey, error) {
+	configPath := filepath.Join(userHome, common.RelayerConfigPath)
+	if !weaveio.FileOrFolderExists(configPath) {
+		return nil, nil
+	}
+
+	data, err := os.ReadFile(configPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %v", configPath, err)
+	}
+
+	var relayerConfig rapidRelayerConfig
+	if err := json.Unmarshal(data, &relayerConfig); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal %s: %v", configPath, err)
+	}
+
+	var keys []managedKey
+	for _, chain := range relayerConfig.Chains {
+		for i, wallet := range chain.Wallets {
+			name := fmt.Sprintf("relayer.%s", chain.ChainID)
+			if i > 0 {
+				name = fmt.Sprintf("%s.%d", name, i)
+			}
+
+			coinType, ok := coinTypes[chain.ChainID]
+			if !ok {
+				coinType = 118
+			}
+
+			var address string
+			var mnemonic func() (string, error)
+			switch wallet.Key.Type {
+			case "mnemonic":
+				address, err = crypto.MnemonicToBech32AddressWithCoinType(chain.Bech32Prefix, wallet.Key.PrivateKey, coinType)
+				mnemonic = staticMnemonic(wallet.Key.PrivateKey)
+			case "raw":
+				// Keys imported with a custom derivation are stored as raw private keys
+				address, err = crypto.PrivateKeyHexToBech32Address(chain.Bech32Prefix, wallet.Key.PrivateKey, coinType)
+				mnemonic = func() (string, error) {
+					return "", fmt.Errorf("%s is stored as a raw private key in %s and has no mnemonic", name, configPath)
+				}
+			default:
+				continue
+			}
+			if err != nil {
+				return nil, fmt.Errorf("failed to derive relayer address for %s: %v", chain.ChainID, err)
+			}
+
+			keys = append(keys, newManagedKey(name, "relayer", chain.ChainID, address, coinType, configPath, mnemonic))
+		}
+	}
+
+	return keys, nil
+}
+
+// filterManagedKeys returns the entries belonging to the named key
+func filterManagedKeys(keys []managedKey, name string) ([]managedKey, error) {
+	var filtered []managedKey
+	for _, key := range keys {
+		if key.Name == name {
+			filtered = append(filtered, key)
+		}
+	}
+	if len(filtered) == 0 {
+		return nil, fmt.Errorf("key %s not found, run `weave keys list` to see the available keys", name)
+	}
+	return filtered, nil
+}
+
+// fillBalances queries the balances of every key on the chains it belongs to.
+// Keys only known by network family are queried on both testnet and mainnet.
+func fillBalances(keys []managedKey) {
+	for i := range keys {
+		balances := make(map[string]*cosmosutils.Coins)
+		for _, chainType := range chainTypesForKey(keys[i].Chain) {
+			chainRegistry, err := registry.GetChainRegistry(chainType)
+			if err != nil {
+				continue
+			}
+			coins, err := getBalance(chainType, keys[i].Address)
+			if err != nil {
+				continue
+			}
+			balances[chainRegistry.GetChainId()] = coins
+		}
+
+		if len(balances) == 0 {
+			if coins, err := getL2Balance(keys[i].Chain, keys[i].Address); err == nil {
+				balances[keys[i].Chain] = coins
+			}
+		}
+		keys[i].Balances = balances
+	}
+}
+
+func chainTypesForKey(chain string) []registry.ChainType {
+	switch chain {
+	case initiaNetwork:
+		return []registry.ChainType{registry.InitiaL1Testnet, registry.InitiaL1Mainnet}
+	case celestiaNetwork:
+		return []registry.ChainType{registry.CelestiaTestnet, registry.CelestiaMainnet}
+	}
+
+	for _, chainType := range []registry.ChainType{registry.InitiaL1Testnet, registry.InitiaL1Mainnet, registry.CelestiaTestnet, registry.CelestiaMainnet} {
+		chainRegistry, err := registry.GetChainRegistry(chainType)
+		if err == nil && chainRegistry.GetChainId() == chain {
+			return []registry.ChainType{chainType}
+		}
+	}
+	return nil
+}
+
+func getL2Balance(chainId, address string) (*cosmosutils.Coins, error) {
+	for _, chainType := range []registry.ChainType{registry.InitiaL1Testnet, registry.InitiaL1Mainnet} {
+		l2Registry, err := registry.GetL2Registry(chainType, chainId)
+		if err != nil {
+			continue
+		}
+		activeLcds, err := l2Registry.GetActiveLcds()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get active lcd for %s: %v", chainId, err)
+		}
+		return cosmosutils.QueryBankBalances(activeLcds, address)
+	}
+	return nil, errors.New("chain not found in registry")
+}
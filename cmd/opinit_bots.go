@@ -3,6 +3,7 @@ package cmd
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
 	"os/exec"
@@ -13,6 +14,7 @@ import (
 
 	"github.com/initia-labs/weave/analytics"
 	"github.com/initia-labs/weave/common"
+	"github.com/initia-labs/weave/config"
 	weavecontext "github.com/initia-labs/weave/context"
 	"github.com/initia-labs/weave/cosmosutils"
 	weaveio "github.com/initia-labs/weave/io"
@@ -115,6 +117,10 @@ func OPInitBotsKeysSetupCommand() *cobra.Command {
 				return fmt.Errorf("error getting user home directory: %v", err)
 			}
 
+			if err = ensureKeyFilePassphrase(filepath.Join(opInitHome, common.OPinitKeyFileJson)); err != nil {
+				return err
+			}
+
 			_, err = RunOPInit(opinit_bots.NewSetupBotCheckbox, HomeConfig{
 				MinitiaHome: minitiaHome,
 				OPInitHome:  opInitHome,
@@ -178,7 +184,10 @@ func handleWithConfig(cmd *cobra.Command, userHome, opInitHome, configPath, keyF
 		if err != nil {
 			return fmt.Errorf("error generating keyfile: %v", err)
 		}
-		err = keyFile.Write(keyPath)
+		if err = ensureKeyFilePassphrase(keyPath); err != nil {
+			return err
+		}
+		err = keyFile.Write(keyPath, config.GetKeyFilePassphrase(keyPath))
 		if err != nil {
 			return fmt.Errorf("error writing to file: %w", err)
 		}
@@ -207,15 +216,23 @@ func handleWithConfig(cmd *cobra.Command, userHome, opInitHome, configPath, keyF
 	return initializeBotWithConfig(cmd, fileData, keyFile, opInitHome, userHome, botName)
 }
 
-// readAndUnmarshalKeyFile read and unmarshal the key file into the KeyFile struct
+// readAndUnmarshalKeyFile read and unmarshal the key file into the KeyFile struct, prompting for the passphrase if it is encrypted
 func readAndUnmarshalKeyFile(keyFilePath string) (weaveio.KeyFile, error) {
 	fileData, err := os.ReadFile(keyFilePath)
 	if err != nil {
 		return nil, err
 	}
 
-	keyFile := weaveio.KeyFile{}
-	err = json.Unmarshal(fileData, &keyFile)
+	keyFile := weaveio.NewKeyFile()
+	err = keyFile.Unmarshal(fileData, config.GetKeyFilePassphrase(keyFilePath))
+	if errors.Is(err, weaveio.ErrKeyFilePassphraseRequired) {
+		passphrase, promptErr := readPassphrase(fmt.Sprintf("Enter passphrase for %s: ", keyFilePath))
+		if promptErr != nil {
+			return nil, promptErr
+		}
+		config.SetKeyFilePassphrase(keyFilePath, passphrase)
+		err = keyFile.Unmarshal(fileData, passphrase)
+	}
 	return keyFile, err
 }
 
@@ -327,6 +344,10 @@ func OPInitBotsInitCommand() *cobra.Command {
 				return handleWithConfig(cmd, userHome, opInitHome, configPath, keyFilePath, args, force, isGenerateKeyFile)
 			}
 
+			if err = ensureKeyFilePassphrase(filepath.Join(opInitHome, common.OPinitKeyFileJson)); err != nil {
+				return err
+			}
+
 			_, err = RunOPInit(rootProgram, HomeConfig{
 				MinitiaHome: minitiaHome,
 				OPInitHome:  opInitHome,
@@ -345,7 +366,7 @@ func OPInitBotsInitCommand() *cobra.Command {
 	initCmd.Flags().String(FlagMinitiaHome, filepath.Join(homeDir, common.MinitiaDirectory), "Rollup application directory to fetch artifacts from if existed")
 	initCmd.Flags().String(FlagOPInitHome, filepath.Join(homeDir, common.OPinitDirectory), "OPInit bots home directory")
 	initCmd.Flags().String(FlagWithConfig, "", "Bypass the interactive setup and initialize the bot by providing a path to a config file. Either --key-file or --generate-key-file has to be specified")
-	initCmd.Flags().String(FlagKeyFile, "", "Path to key-file.json. Cannot be specified together with --generate-key-file")
+	initCmd.Flags().String(FlagKeyFile, "", "Path to key-file.json, either encrypted or plaintext. Cannot be specified together with --generate-key-file")
 	initCmd.Flags().BoolP(FlagForce, "f", false, "Force the setup by deleting the existing .opinit directory if it exists")
 	initCmd.Flags().BoolP(FlagGenerateKeyFile, "", false, "Use this flag to generate the bot keys. Cannot be specified together with --key-file")
 
@@ -0,0 +1,154 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/charmbracelet/x/term"
+
+	"github.com/initia-labs/weave/config"
+	weaveio "github.com/initia-labs/weave/io"
+)
+
+// readPassphrase reads a passphrase from the terminal without echoing it
+func readPassphrase(prompt string) (string, error) {
+	if !term.IsTerminal(os.Stdin.Fd()) {
+		return "", fmt.Errorf("cannot prompt for passphrase in a non-interactive session, set %s instead", config.PassphraseEnvVar)
+	}
+
+	fmt.Print(prompt)
+	passphrase, err := term.ReadPassword(os.Stdin.Fd())
+	fmt.Println()
+	if err != nil {
+		return "", fmt.Errorf("failed to read passphrase: %v", err)
+	}
+
+	return string(passphrase), nil
+}
+
+// readNewPassphrase prompts for a new passphrase twice and ensures both entries match
+func readNewPassphrase() (string, error) {
+	passphrase, err := readPassphrase("Enter new passphrase: ")
+	if err != nil {
+		return "", err
+	}
+	if passphrase == "" {
+		return "", fmt.Errorf("passphrase cannot be empty")
+	}
+
+	confirmation, err := readPassphrase("Confirm new passphrase: ")
+	if err != nil {
+		return "", err
+	}
+	if passphrase != confirmation {
+		return "", fmt.Errorf("passphrases do not match")
+	}
+
+	return passphrase, nil
+}
+
+// unlockGasStationKey makes sure an encrypted gas station key can be decrypted for the rest of the command.
+// It must run before any TUI program starts, since the prompt needs the terminal.
+func unlockGasStationKey() error {
+	if !config.IsGasStationKeyLocked() {
+		return offerGasStationEncryption()
+	}
+
+	if config.GetGasStationPassphrase() == "" {
+		passphrase, err := readPassphrase("Enter gas station passphrase: ")
+		if err != nil {
+			return err
+		}
+		config.SetGasStationPassphrase(passphrase)
+	}
+
+	_, err := config.GetGasStationKey()
+	return err
+}
+
+// offerGasStationEncryption asks once whether a gas station mnemonic stored in plaintext, such as one saved by an older
+// version of weave, should be encrypted now. Non-interactive sessions are never asked.
+func offerGasStationEncryption() error {
+	if !config.ShouldOfferGasStationEncryption() || !term.IsTerminal(os.Stdin.Fd()) {
+		return nil
+	}
+
+	encrypt, err := askYesNo("The Gas Station mnemonic is stored in plaintext in the weave config. Encrypt it with a passphrase now?")
+	if err != nil {
+		return err
+	}
+	if err := config.MarkGasStationEncryptionOffered(); err != nil {
+		return err
+	}
+	if !encrypt {
+		fmt.Println("You can encrypt it later with `weave gas-station lock`.")
+		return nil
+	}
+
+	passphrase := config.GetGasStationPassphrase()
+	if passphrase == "" {
+		passphrase, err = readNewPassphrase()
+		if err != nil {
+			return err
+		}
+	}
+	if err := config.LockGasStationKey(passphrase); err != nil {
+		return err
+	}
+	config.SetGasStationPassphrase(passphrase)
+	fmt.Println("🔒 Gas Station mnemonic is now encrypted at rest.")
+	return nil
+}
+
+// ensureGasStationSetupPassphrase resolves the passphrase the mnemonic saved by a gas station setup is encrypted with.
+// It must run before the setup program starts. A locked key being replaced keeps its passphrase, so that setting up
+// the gas station again never stores the mnemonic in plaintext.
+func ensureGasStationSetupPassphrase() error {
+	if config.IsGasStationKeyLocked() {
+		return unlockGasStationKey()
+	}
+	if config.GetGasStationPassphrase() != "" {
+		return nil
+	}
+
+	fmt.Println("The Gas Station mnemonic is encrypted at rest. Choose a passphrase to protect it.")
+	passphrase, err := readNewPassphrase()
+	if err != nil {
+		return err
+	}
+	config.SetGasStationPassphrase(passphrase)
+	return nil
+}
+
+// ensureKeyFilePassphrase resolves the passphrase protecting the key file at keyFilePath.
+// An existing encrypted file is checked against the passphrase so that a typo fails early.
+func ensureKeyFilePassphrase(keyFilePath string) error {
+	encrypted := false
+	if weaveio.FileOrFolderExists(keyFilePath) {
+		var err error
+		encrypted, err = weaveio.IsEncryptedKeyFile(keyFilePath)
+		if err != nil {
+			return err
+		}
+	}
+
+	if config.GetKeyFilePassphrase(keyFilePath) == "" {
+		var passphrase string
+		var err error
+		if encrypted {
+			passphrase, err = readPassphrase(fmt.Sprintf("Enter passphrase for %s: ", keyFilePath))
+		} else {
+			fmt.Printf("Mnemonics in %s are encrypted at rest. Choose a passphrase to protect them.\n", keyFilePath)
+			passphrase, err = readNewPassphrase()
+		}
+		if err != nil {
+			return err
+		}
+		config.SetKeyFilePassphrase(keyFilePath, passphrase)
+	}
+
+	if encrypted {
+		return weaveio.NewKeyFile().Load(keyFilePath, config.GetKeyFilePassphrase(keyFilePath))
+	}
+	return nil
+}
@@ -0,0 +1,162 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	"github.com/initia-labs/weave/common"
+	"github.com/initia-labs/weave/config"
+)
+
+func ProfileCommand() *cobra.Command {
+	shortDescription := "Manage profiles to operate several environments from the same machine"
+	profileCmd := &cobra.Command{
+		Use:   "profile",
+		Short: shortDescription,
+		Long: fmt.Sprintf("%s.\n\nA profile has its own config file, with its own gas station and network settings, its own default "+
+			"--%s, --%s and --%s, and service names that end with the profile name, so that a testnet and a mainnet deployment "+
+			"can run side by side. Run a single command with another profile using --%s or %s.",
+			shortDescription, FlagInitiaHome, FlagMinitiaHome, FlagOPInitHome, FlagProfile, config.ProfileEnvVar),
+	}
+
+	profileCmd.AddCommand(
+		profileCreateCommand(),
+		profileUseCommand(),
+		profileListCommand(),
+	)
+
+	return profileCmd
+}
+
+func profileCreateCommand() *cobra.Command {
+	shortDescription := "Create a profile"
+	createCmd := &cobra.Command{
+		Use:   "create <name>",
+		Short: shortDescription,
+		Long: fmt.Sprintf("%s.\n\nThe profile starts with an empty config under ~/%s/<name>. Home directories that are not given "+
+			"default to the usual ones with the profile name appended, such as ~/%s-<name>.",
+			shortDescription, common.WeaveProfilesDirectory, common.InitiaDirectory),
+		Example: "  weave profile create testnet\n  weave --profile testnet gas-station setup",
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			initiaHome, _ := cmd.Flags().GetString(FlagInitiaHome)
+			minitiaHome, _ := cmd.Flags().GetString(FlagMinitiaHome)
+			opinitHome, _ := cmd.Flags().GetString(FlagOPInitHome)
+			if err := config.CreateProfile(config.Profile{
+				Name:        args[0],
+				InitiaHome:  initiaHome,
+				MinitiaHome: minitiaHome,
+				OPinitHome:  opinitHome,
+			}); err != nil {
+				return err
+			}
+
+			fmt.Printf("Created profile %s. Switch to it with `weave profile use %s`, or run a single command with `weave --%s %s`.\n",
+				args[0], args[0], FlagProfile, args[0])
+			return nil
+		},
+	}
+
+	createCmd.Flags().String(FlagInitiaHome, "", "The Initia application home directory of the profile")
+	createCmd.Flags().String(FlagMinitiaHome, "", "The rollup application home directory of the profile")
+	createCmd.Flags().String(FlagOPInitHome, "", "The OPinit bots home directory of the profile")
+
+	return createCmd
+}
+
+func profileUseCommand() *cobra.Command {
+	shortDescription := "Select the profile of the commands run without --profile"
+	useCmd := &cobra.Command{
+		Use:   "use <name>",
+		Short: shortDescription,
+		Long: fmt.Sprintf("%s.\n\n%s takes precedence over the selected profile. Use %s to go back to ~/%s.",
+			shortDescription, config.ProfileEnvVar, config.DefaultProfile, common.WeaveConfigFile),
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := config.UseProfile(args[0]); err != nil {
+				return err
+			}
+			fmt.Printf("Switched to profile %s.\n", args[0])
+			return nil
+		},
+	}
+
+	return useCmd
+}
+
+func profileListCommand() *cobra.Command {
+	shortDescription := "List the profiles"
+	listCmd := &cobra.Command{
+		Use:   "list",
+		Short: shortDescription,
+		Long:  fmt.Sprintf("%s.\n\nThe profile the command runs with is marked with *.", shortDescription),
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			profiles, err := config.ListProfiles()
+			if err != nil {
+				return err
+			}
+			if asJSON, _ := cmd.Flags().GetBool(FlagJSON); asJSON {
+				return printJSON(profiles)
+			}
+
+			w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+			fmt.Fprintln(w, "\tNAME\tINITIA DIR\tMINITIA DIR\tOPINIT DIR\tCONFIG")
+			for _, profile := range profiles {
+				active := ""
+				if profile.Active {
+					active = "*"
+				}
+				fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n", active, profile.Name, profile.InitiaHome, profile.MinitiaHome, profile.OPinitHome, profile.ConfigPath)
+			}
+			return w.Flush()
+		},
+	}
+
+	listCmd.Flags().Bool(FlagJSON, false, "Output in JSON format")
+
+	return listCmd
+}
+
+// useProfile resolves the profile of cmd and activates it. The profile commands fall back to the default profile
+// when the selected one no longer exists, so that another one can be selected.
+func useProfile(cmd *cobra.Command) error {
+	flagValue, _ := cmd.Flags().GetString(FlagProfile)
+	profile, err := config.ResolveProfile(flagValue)
+	if err != nil {
+		if !cmd.HasParent() || cmd.Parent().Name() != "profile" {
+			return err
+		}
+		profile = config.DefaultProfile
+	}
+	return config.SetProfile(profile)
+}
+
+// applyProfileHomes makes the home directory flags that are not given default to the ones of the active profile
+func applyProfileHomes(cmd *cobra.Command) error {
+	if cmd.HasParent() && cmd.Parent().Name() == "profile" {
+		return nil
+	}
+	for flagName, home := range map[string]func() (string, error){
+		FlagInitiaHome:  config.GetInitiaHome,
+		FlagMinitiaHome: config.GetMinitiaHome,
+		FlagOPInitHome:  config.GetOPinitHome,
+	} {
+		flag := cmd.Flags().Lookup(flagName)
+		if flag == nil || flag.Changed {
+			continue
+		}
+		path, err := home()
+		if err != nil {
+			return err
+		}
+		if err := flag.Value.Set(path); err != nil {
+			return fmt.Errorf("failed to set --%s: %w", flagName, err)
+		}
+		flag.DefValue = path
+	}
+	return nil
+}
@@ -51,6 +51,9 @@ func relayerInitCommand() *cobra.Command {
 			ctx = weavecontext.SetMinitiaHome(ctx, minitiaHome)
 
 			if config.IsFirstTimeSetup() {
+				if err := ensureGasStationSetupPassphrase(); err != nil {
+					return err
+				}
 				checkerCtx := weavecontext.NewAppContext(models.NewExistingCheckerState())
 				if finalModel, err := tea.NewProgram(models.NewGasStationMethodSelect(checkerCtx), tea.WithAltScreen()).Run(); err != nil {
 					return err
@@ -62,6 +65,10 @@ func relayerInitCommand() *cobra.Command {
 				}
 			}
 
+			if err := unlockGasStationKey(); err != nil {
+				return err
+			}
+
 			model, err := relayer.NewRollupSelect(ctx)
 			if err != nil {
 				return err
@@ -41,10 +41,12 @@ func MinitiaCommand() *cobra.Command {
 
 	cmd.AddCommand(
 		minitiaLaunchCommand(),
+		minitiaAdoptCommand(),
 		minitiaStartCommand(),
 		minitiaStopCommand(),
 		minitiaRestartCommand(),
 		minitiaLogCommand(),
+		snapshotCommand(service.Minitia, "rollup full node", RollupHelperText),
 		minitiaIndexerCommand(),
 	)
 
@@ -72,6 +74,9 @@ func loadAndParseMinitiaConfig(path string) (*types.MinitiaConfig, error) {
 	if err = decoder.Decode(&minitiaConfig); err != nil {
 		return nil, err
 	}
+	if err = minitiaConfig.SystemKeys.ValidateDerivation(); err != nil {
+		return nil, err
+	}
 
 	return &minitiaConfig, nil
 }
@@ -155,6 +160,9 @@ func minitiaLaunchCommand() *cobra.Command {
 			ctx = weavecontext.SetMinitiaHome(ctx, minitiaHome)
 			ctx = weavecontext.SetOPInitHome(ctx, opinitHome)
 			if config.IsFirstTimeSetup() {
+				if err := ensureGasStationSetupPassphrase(); err != nil {
+					return err
+				}
 				checkerCtx := weavecontext.NewAppContext(models.NewExistingCheckerState())
 				checkerCtx = weavecontext.SetMinitiaHome(checkerCtx, minitiaHome)
 				checkerCtx = weavecontext.SetOPInitHome(checkerCtx, opinitHome)
@@ -168,6 +176,10 @@ func minitiaLaunchCommand() *cobra.Command {
 				}
 			}
 
+			if err := unlockGasStationKey(); err != nil {
+				return err
+			}
+
 			if finalModel, err := tea.NewProgram(minitia.NewExistingMinitiaChecker(ctx), tea.WithAltScreen()).Run(); err != nil {
 				return err
 			} else {
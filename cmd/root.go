@@ -3,12 +3,15 @@ package cmd
 import (
 	"context"
 	"fmt"
-	"os/exec"
+	"os"
+	"os/signal"
+	"syscall"
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 
 	"github.com/initia-labs/weave/analytics"
+	"github.com/initia-labs/weave/client"
 	"github.com/initia-labs/weave/config"
 )
 
@@ -21,21 +24,23 @@ func Execute() error {
 		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
 			viper.AutomaticEnv()
 			viper.SetEnvPrefix("weave")
+			if err := useProfile(cmd); err != nil {
+				return err
+			}
 			if err := config.InitializeConfig(); err != nil {
 				return err
 			}
-			analytics.Initialize(Version)
-
-			// Skip LZ4 check for certain commands that don't need it
-			if cmd.Name() != "version" && cmd.Name() != "analytics" {
-				if _, err := exec.LookPath("lz4"); err != nil {
-					return fmt.Errorf("lz4 is not installed. Please install it first:\n" +
-						"- For macOS: Run 'brew install lz4'\n" +
-						"- For Ubuntu/Debian: Run 'apt-get install lz4'\n" +
-						"- For other Linux distributions: Use your package manager to install lz4")
-				}
+			if err := applyProfileHomes(cmd); err != nil {
+				return err
 			}
-
+			if err := config.ConfigureNetwork(); err != nil {
+				return err
+			}
+			client.SetBaseContext(cmd.Context())
+			if viper.GetBool(config.InsecureSkipVerifyKey) {
+				fmt.Fprintln(os.Stderr, "Warning: downloaded binaries are not verified against their published checksums.")
+			}
+			analytics.Initialize(Version)
 			return nil
 		},
 		PersistentPostRunE: func(cmd *cobra.Command, args []string) error {
@@ -45,6 +50,12 @@ func Execute() error {
 		},
 	}
 
+	rootCmd.PersistentFlags().String(FlagProfile, "", fmt.Sprintf("Profile to run the command with, instead of %s or the selected one", config.ProfileEnvVar))
+	rootCmd.PersistentFlags().Bool(FlagInsecureSkipVerify, false, "Install downloaded binaries without verifying their checksums and signatures")
+	if err := viper.BindPFlag(config.InsecureSkipVerifyKey, rootCmd.PersistentFlags().Lookup(FlagInsecureSkipVerify)); err != nil {
+		return err
+	}
+
 	rootCmd.AddCommand(
 		InitCommand(),
 		InitiaCommand(),
@@ -55,7 +66,21 @@ func Execute() error {
 		OPInitBotsCommand(),
 		RelayerCommand(),
 		AnalyticsCommand(),
+		KeysCommand(),
+		AddressCommand(),
+		BundleCommand(),
+		CacheCommand(),
+		ConfigCommand(),
+		ProfileCommand(),
 	)
 
-	return rootCmd.ExecuteContext(context.Background())
+	// Cancel requests in flight on the first interrupt, and let a second one terminate weave as before
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	go func() {
+		<-ctx.Done()
+		stop()
+	}()
+
+	return rootCmd.ExecuteContext(ctx)
 }
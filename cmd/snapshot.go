@@ -0,0 +1,384 @@
+package cmd
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/url"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/initia-labs/weave/client"
+	"github.com/initia-labs/weave/config"
+	"github.com/initia-labs/weave/cosmosutils"
+	weaveio "github.com/initia-labs/weave/io"
+	"github.com/initia-labs/weave/service"
+)
+
+// snapshotDataPaths are archived relative to the node home. wasm only exists on Wasm chains.
+var snapshotDataPaths = []string{"data", "wasm"}
+
+func snapshotCommand(commandName service.CommandName, nodeName, helperText string) *cobra.Command {
+	shortDescription := fmt.Sprintf("Create and restore snapshots of the %s data", nodeName)
+	snapshotCmd := &cobra.Command{
+		Use:   "snapshot",
+		Short: shortDescription,
+		Long:  fmt.Sprintf("%s.\n\n%s", shortDescription, helperText),
+	}
+
+	snapshotCmd.AddCommand(
+		snapshotCreateCommand(commandName, nodeName, helperText),
+		snapshotRestoreCommand(commandName, nodeName, helperText),
+	)
+
+	return snapshotCmd
+}
+
+func snapshotCreateCommand(commandName service.CommandName, nodeName, helperText string) *cobra.Command {
+	shortDescription := fmt.Sprintf("Archive the %s data into a compressed snapshot", nodeName)
+	createCmd := &cobra.Command{
+		Use:   "create",
+		Short: shortDescription,
+		Long: fmt.Sprintf("%s.\n\nA running service is stopped while the data directory is archived and started again afterwards. "+
+			"The snapshot includes a manifest with the chain ID, height and app version, and its SHA-256 is written next to it.\n\n%s", shortDescription, helperText),
+		PreRunE: isInitiated(commandName),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			outputPath, _ := cmd.Flags().GetString(FlagOutput)
+			format, _ := cmd.Flags().GetString(FlagFormat)
+			keepAddrBook, _ := cmd.Flags().GetBool(FlagKeepAddrBook)
+			if format != weaveio.SnapshotFormatZstd && format != weaveio.SnapshotFormatLz4 {
+				return fmt.Errorf("invalid value for --%s. Valid options are: %s, %s", FlagFormat, weaveio.SnapshotFormatZstd, weaveio.SnapshotFormatLz4)
+			}
+
+			s, err := service.NewService(commandName, "")
+			if err != nil {
+				return err
+			}
+			_, home, err := s.GetServiceBinaryAndHome()
+			if err != nil {
+				return fmt.Errorf("could not determine the %s home directory: %w", nodeName, err)
+			}
+
+			// The height and app version can only be read while the node is still running
+			manifest, err := snapshotManifest(home)
+			if err != nil {
+				return err
+			}
+			if manifest.Height == 0 {
+				fmt.Printf("Could not query the %s, so the snapshot manifest will not include the height and app version.\n", nodeName)
+			}
+
+			if outputPath == "" {
+				if manifest.Height > 0 {
+					outputPath = fmt.Sprintf("%s_%d.tar.%s", manifest.ChainId, manifest.Height, format)
+				} else {
+					outputPath = fmt.Sprintf("%s_%s.tar.%s", manifest.ChainId, manifest.CreatedAt.Format("20060102150405"), format)
+				}
+			}
+
+			paths := []string{}
+			for _, path := range snapshotDataPaths {
+				if weaveio.FileOrFolderExists(filepath.Join(home, path)) {
+					paths = append(paths, path)
+				}
+			}
+			if keepAddrBook {
+				paths = append(paths, filepath.Join("config", "addrbook.json"))
+			}
+
+			running, err := stopRunningService(s, nodeName)
+			if err != nil {
+				return err
+			}
+
+			fmt.Printf("Archiving %s into %s...\n", home, outputPath)
+			createErr := writeSnapshot(outputPath, home, format, paths, manifest)
+
+			if running {
+				fmt.Printf("Starting the %s service...\n", nodeName)
+				if err = s.Start(); err != nil {
+					if createErr != nil {
+						return fmt.Errorf("failed to create snapshot: %w (the %s service also failed to start: %v)", createErr, nodeName, err)
+					}
+					return fmt.Errorf("snapshot was created at %s but the %s service failed to start: %w", outputPath, nodeName, err)
+				}
+			}
+			if createErr != nil {
+				return fmt.Errorf("failed to create snapshot: %w", createErr)
+			}
+
+			if manifest.Height > 0 {
+				fmt.Printf("Created snapshot of %s at height %d: %s\n", manifest.ChainId, manifest.Height, outputPath)
+			} else {
+				fmt.Printf("Created snapshot of %s: %s\n", manifest.ChainId, outputPath)
+			}
+			return nil
+		},
+	}
+
+	createCmd.Flags().String(FlagOutput, "", "Path to write the snapshot to. Defaults to <chain-id>_<height>.tar.<format> in the current directory")
+	createCmd.Flags().String(FlagFormat, weaveio.SnapshotFormatZstd, fmt.Sprintf("Compression format. Valid options are: %s, %s", weaveio.SnapshotFormatZstd, weaveio.SnapshotFormatLz4))
+	createCmd.Flags().Bool(FlagKeepAddrBook, false, "Include the address book in the snapshot")
+
+	return createCmd
+}
+
+func snapshotRestoreCommand(commandName service.CommandName, nodeName, helperText string) *cobra.Command {
+	shortDescription := fmt.Sprintf("Restore the %s data from a snapshot file", nodeName)
+	restoreCmd := &cobra.Command{
+		Use:   "restore <file>",
+		Short: shortDescription,
+		Long: fmt.Sprintf("%s.\n\nA running service is stopped, the data and wasm directories are replaced with the ones of the snapshot "+
+			"and the service is started again. The snapshot is extracted and verified next to the data directory before anything is replaced. "+
+			"The signing state in data/priv_validator_state.json is kept. "+
+			"Snapshots in the .tar.lz4, .tar.zst and .tar.gz formats are supported and verified against <file>.sha256 when it exists.\n\n%s", shortDescription, helperText),
+		Args:    cobra.ExactArgs(1),
+		PreRunE: isInitiated(commandName),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			force, _ := cmd.Flags().GetBool(FlagForce)
+			snapshotPath, err := filepath.Abs(args[0])
+			if err != nil {
+				return err
+			}
+
+			s, err := service.NewService(commandName, "")
+			if err != nil {
+				return err
+			}
+			_, home, err := s.GetServiceBinaryAndHome()
+			if err != nil {
+				return fmt.Errorf("could not determine the %s home directory: %w", nodeName, err)
+			}
+
+			manifest, err := readSnapshotManifest(snapshotPath)
+			if err != nil {
+				return err
+			}
+			if manifest != nil && !force {
+				chainId, err := readGenesisChainId(home)
+				if err != nil {
+					return err
+				}
+				if manifest.ChainId != chainId {
+					return fmt.Errorf("snapshot is of chain %s but the %s runs %s, use --%s to restore it anyway", manifest.ChainId, nodeName, chainId, FlagForce)
+				}
+			}
+
+			running, err := stopRunningService(s, nodeName)
+			if err != nil {
+				return err
+			}
+
+			fmt.Printf("Restoring %s into %s...\n", snapshotPath, home)
+			err = replaceDataDirectories(home, func(stagingDir string) error {
+				return client.NewHTTPClient().StreamFileWithOptions(client.LocalFileURLPrefix+snapshotPath, nil, nil, client.DownloadOptions{FetchPublishedChecksum: true}, func(r io.Reader) error {
+					return weaveio.ExtractSnapshot(r, stagingDir)
+				})
+			})
+			if err != nil {
+				if running {
+					return fmt.Errorf("failed to restore snapshot, the %s service was left stopped: %w", nodeName, err)
+				}
+				return fmt.Errorf("failed to restore snapshot: %w", err)
+			}
+
+			if running {
+				fmt.Printf("Starting the %s service...\n", nodeName)
+				if err = s.Start(); err != nil {
+					return fmt.Errorf("snapshot was restored but the %s service failed to start: %w", nodeName, err)
+				}
+			}
+
+			if manifest != nil && manifest.Height > 0 {
+				fmt.Printf("Restored snapshot of %s at height %d.\n", manifest.ChainId, manifest.Height)
+			} else {
+				fmt.Println("Restored snapshot.")
+			}
+			return nil
+		},
+	}
+
+	restoreCmd.Flags().BoolP(FlagForce, "f", false, "Restore even if the snapshot manifest is of another chain")
+
+	return restoreCmd
+}
+
+// snapshotManifest describes the node at home. The height and app version are left empty if its RPC cannot be reached.
+func snapshotManifest(home string) (weaveio.SnapshotManifest, error) {
+	manifest := weaveio.SnapshotManifest{CreatedAt: time.Now().UTC()}
+
+	rpc, err := localRPCAddress(home)
+	if err == nil {
+		if status, err := cosmosutils.QueryNodeStatus(rpc); err == nil {
+			manifest.ChainId = status.Result.NodeInfo.Network
+		}
+		if info, err := cosmosutils.QueryABCIInfo(rpc); err == nil {
+			manifest.AppVersion = info.Result.Response.Version
+			manifest.Height, _ = strconv.ParseInt(info.Result.Response.LastBlockHeight, 10, 64)
+		}
+	}
+
+	if manifest.ChainId == "" {
+		chainId, err := readGenesisChainId(home)
+		if err != nil {
+			return manifest, err
+		}
+		manifest.ChainId = chainId
+	}
+	return manifest, nil
+}
+
+// localRPCAddress returns the address the node at home serves its CometBFT RPC on
+func localRPCAddress(home string) (string, error) {
+	laddr, err := config.GetTomlValue(filepath.Join(home, "config", "config.toml"), "rpc.laddr")
+	if err != nil {
+		return "", err
+	}
+
+	u, err := url.Parse(laddr)
+	if err != nil {
+		return "", fmt.Errorf("invalid rpc laddr %s: %w", laddr, err)
+	}
+	host := u.Hostname()
+	if host == "" || host == "0.0.0.0" || host == "::" {
+		host = "127.0.0.1"
+	}
+	return fmt.Sprintf("http://%s", net.JoinHostPort(host, u.Port())), nil
+}
+
+func readGenesisChainId(home string) (string, error) {
+	genesisPath := filepath.Join(home, "config", "genesis.json")
+	data, err := os.ReadFile(genesisPath)
+	if err != nil {
+		return "", fmt.Errorf("failed to read genesis file: %w", err)
+	}
+
+	var genesis struct {
+		ChainId string `json:"chain_id"`
+	}
+	if err := json.Unmarshal(data, &genesis); err != nil {
+		return "", fmt.Errorf("failed to parse genesis file %s: %w", genesisPath, err)
+	}
+	if genesis.ChainId == "" {
+		return "", fmt.Errorf("no chain ID found in %s", genesisPath)
+	}
+	return genesis.ChainId, nil
+}
+
+func readSnapshotManifest(snapshotPath string) (*weaveio.SnapshotManifest, error) {
+	file, err := os.Open(snapshotPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open snapshot: %w", err)
+	}
+	defer file.Close()
+
+	return weaveio.ReadSnapshotManifest(file)
+}
+
+// writeSnapshot creates the snapshot at outputPath along with outputPath.sha256, and removes both if it fails
+func writeSnapshot(outputPath, home, format string, paths []string, manifest weaveio.SnapshotManifest) (err error) {
+	file, err := os.OpenFile(outputPath, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0o644)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if closeErr := file.Close(); err == nil && closeErr != nil {
+			err = closeErr
+		}
+		if err != nil {
+			_ = os.Remove(outputPath)
+			_ = os.Remove(outputPath + client.ChecksumFileSuffix)
+		}
+	}()
+
+	hash := sha256.New()
+	if err = weaveio.CreateSnapshot(io.MultiWriter(file, hash), home, format, paths, manifest); err != nil {
+		return err
+	}
+
+	checksum := fmt.Sprintf("%s  %s\n", hex.EncodeToString(hash.Sum(nil)), filepath.Base(outputPath))
+	return os.WriteFile(outputPath+client.ChecksumFileSuffix, []byte(checksum), 0o644)
+}
+
+// stopRunningService stops s if it is running and reports whether it was, so that it is only started again in that case
+func stopRunningService(s service.Service, nodeName string) (bool, error) {
+	running, err := s.IsRunning()
+	if err != nil {
+		return false, fmt.Errorf("failed to check whether the %s service is running: %w", nodeName, err)
+	}
+	if !running {
+		return false, nil
+	}
+	fmt.Printf("Stopping the %s service...\n", nodeName)
+	if err = s.Stop(); err != nil {
+		return false, fmt.Errorf("failed to stop the %s service: %w", nodeName, err)
+	}
+	return true, nil
+}
+
+// snapshotRestoreDirectory is where a snapshot is extracted under the node home until it is verified
+const snapshotRestoreDirectory = "data.restore-tmp"
+
+// replaceDataDirectories extracts a snapshot with extract into a staging directory next to the data directory and, once
+// extract succeeds, swaps the staged directories of snapshotDataPaths with the ones at home. A wasm directory the snapshot
+// does not have is cleared as well, since its contracts belong to the replaced state. The validator signing state is kept,
+// so that a validator restored to a lower height cannot sign the same heights twice.
+func replaceDataDirectories(home string, extract func(stagingDir string) error) error {
+	stagingDir := filepath.Join(home, snapshotRestoreDirectory)
+	if err := os.RemoveAll(stagingDir); err != nil {
+		return fmt.Errorf("failed to clean up %s: %w", stagingDir, err)
+	}
+	if err := os.MkdirAll(stagingDir, 0o700); err != nil {
+		return fmt.Errorf("failed to create %s: %w", stagingDir, err)
+	}
+	if err := extract(stagingDir); err != nil {
+		_ = os.RemoveAll(stagingDir)
+		return err
+	}
+
+	// Only the data paths are restored, whatever else the snapshot holds
+	staged, err := os.ReadDir(stagingDir)
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", stagingDir, err)
+	}
+	for _, entry := range staged {
+		if !slices.Contains(snapshotDataPaths, entry.Name()) {
+			if err := os.RemoveAll(filepath.Join(stagingDir, entry.Name())); err != nil {
+				return fmt.Errorf("failed to remove %s from the snapshot: %w", entry.Name(), err)
+			}
+		}
+	}
+	if !weaveio.FileOrFolderExists(filepath.Join(stagingDir, "data")) {
+		_ = os.RemoveAll(stagingDir)
+		return fmt.Errorf("snapshot has no data directory")
+	}
+	for _, path := range snapshotDataPaths {
+		if weaveio.FileOrFolderExists(filepath.Join(home, path)) && !weaveio.FileOrFolderExists(filepath.Join(stagingDir, path)) {
+			if err := os.MkdirAll(filepath.Join(stagingDir, path), 0o700); err != nil {
+				return fmt.Errorf("failed to create %s: %w", path, err)
+			}
+		}
+	}
+
+	// Snapshots from other sources may carry their own signing state, which must not replace ours
+	statePath := filepath.Join("data", "priv_validator_state.json")
+	state, err := os.ReadFile(filepath.Join(home, statePath))
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to read validator state: %w", err)
+	}
+	if state != nil {
+		if err := os.WriteFile(filepath.Join(stagingDir, statePath), state, 0o600); err != nil {
+			return fmt.Errorf("failed to keep validator state: %w", err)
+		}
+	}
+
+	return weaveio.CommitStagedSnapshot(stagingDir, home, nil)
+}
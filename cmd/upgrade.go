@@ -11,6 +11,7 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/initia-labs/weave/common"
+	"github.com/initia-labs/weave/config"
 	"github.com/initia-labs/weave/cosmosutils"
 	"github.com/initia-labs/weave/io"
 )
@@ -126,7 +127,7 @@ func downloadAndReplaceBinary(downloadURL string) error {
 	tarballPath := filepath.Join(tempDir, "weave-binary.tar.gz")
 	fmt.Printf("⬇️ Downloading from %s...\n", downloadURL)
 
-	if err = io.DownloadAndExtractTarGz(downloadURL, tarballPath, tempDir); err != nil {
+	if err = io.DownloadAndExtractTarGz(downloadURL, tarballPath, tempDir, config.GetReleaseVerifyOptions()); err != nil {
 		return fmt.Errorf("failed to download and extract binary: %v", err)
 	}
 
@@ -6,7 +6,8 @@ const (
 	WeaveDataDirectory = WeaveDirectory + "/data"
 	WeaveLogDirectory  = WeaveDirectory + "/log"
 
-	SnapshotFilename = "snapshot.weave"
+	WeaveProfilesDirectory  = WeaveDirectory + "/profiles"
+	WeaveCurrentProfileFile = WeaveProfilesDirectory + "/current"
 
 	InitiaDirectory       = ".initia"
 	InitiaConfigDirectory = "/config"
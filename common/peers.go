@@ -0,0 +1,60 @@
+package common
+
+import (
+	"strings"
+)
+
+// SplitPeers splits a comma-separated seeds or persistent_peers value into its non-empty entries
+func SplitPeers(peers string) []string {
+	var entries []string
+	for _, peer := range strings.Split(peers, ",") {
+		if peer = strings.TrimSpace(peer); peer != "" {
+			entries = append(entries, peer)
+		}
+	}
+	return entries
+}
+
+// PeerNodeID returns the node ID of a nodeID@host:port peer, or the input itself if it has no address
+func PeerNodeID(peer string) string {
+	nodeID, _, _ := strings.Cut(strings.TrimSpace(peer), "@")
+	return strings.ToLower(nodeID)
+}
+
+// AddPeers appends peers to the comma-separated list existing. A peer whose node ID is already listed replaces the
+// listed address, so that a node that moved is not dialed at both addresses.
+func AddPeers(existing string, peers []string) string {
+	entries := SplitPeers(existing)
+	for _, peer := range peers {
+		peer = strings.TrimSpace(peer)
+		replaced := false
+		for i, entry := range entries {
+			if PeerNodeID(entry) == PeerNodeID(peer) {
+				entries[i], replaced = peer, true
+			}
+		}
+		if !replaced {
+			entries = append(entries, peer)
+		}
+	}
+	return strings.Join(entries, ",")
+}
+
+// RemovePeers drops the entries of the comma-separated list existing whose node ID matches one of peers, which may be
+// given as node IDs or as full peer addresses. It also returns the entries that were removed.
+func RemovePeers(existing string, peers []string) (string, []string) {
+	remove := make(map[string]bool)
+	for _, peer := range peers {
+		remove[PeerNodeID(peer)] = true
+	}
+
+	var kept, removed []string
+	for _, entry := range SplitPeers(existing) {
+		if remove[PeerNodeID(entry)] {
+			removed = append(removed, entry)
+		} else {
+			kept = append(kept, entry)
+		}
+	}
+	return strings.Join(kept, ","), removed
+}
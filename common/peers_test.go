@@ -0,0 +1,35 @@
+package common
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	peerA = "a0b1c2d3e4f5a6b7c8d9e0f1a2b3c4d5e6f7a8b9@1.2.3.4:26656"
+	peerB = "ffffffffffffffffffffffffffffffffffffffff@seed.example.com:26656"
+)
+
+func TestSplitPeers(t *testing.T) {
+	assert.Equal(t, []string{peerA, peerB}, SplitPeers(" "+peerA+", ,"+peerB+","))
+	assert.Empty(t, SplitPeers(""))
+}
+
+func TestAddPeers(t *testing.T) {
+	assert.Equal(t, peerA, AddPeers("", []string{peerA}))
+	assert.Equal(t, peerA+","+peerB, AddPeers(peerA, []string{peerB}))
+
+	moved := "a0b1c2d3e4f5a6b7c8d9e0f1a2b3c4d5e6f7a8b9@5.6.7.8:26656"
+	assert.Equal(t, moved+","+peerB, AddPeers(peerA+","+peerB, []string{moved}))
+}
+
+func TestRemovePeers(t *testing.T) {
+	remaining, removed := RemovePeers(peerA+","+peerB, []string{"A0B1C2D3E4F5A6B7C8D9E0F1A2B3C4D5E6F7A8B9"})
+	assert.Equal(t, peerB, remaining)
+	assert.Equal(t, []string{peerA}, removed)
+
+	remaining, removed = RemovePeers(peerA, []string{peerB})
+	assert.Equal(t, peerA, remaining)
+	assert.Empty(t, removed)
+}
@@ -1,10 +1,12 @@
 package config
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 
 	"github.com/google/uuid"
 	"github.com/spf13/viper"
@@ -22,7 +24,10 @@ func InitializeConfig() error {
 		return fmt.Errorf("failed to get user home directory: %v", err)
 	}
 
-	configPath := filepath.Join(homeDir, common.WeaveConfigFile)
+	configPath, err := ProfileConfigPath(activeProfile)
+	if err != nil {
+		return err
+	}
 	if err := os.MkdirAll(filepath.Dir(configPath), os.ModePerm); err != nil {
 		return fmt.Errorf("failed to create config directory: %v", err)
 	}
@@ -41,6 +46,9 @@ func InitializeConfig() error {
 	viper.SetConfigType("json")
 
 	if _, err := os.Stat(configPath); os.IsNotExist(err) {
+		if activeProfile != DefaultProfile {
+			return fmt.Errorf("profile %s does not exist, create it with `weave profile create %s`", activeProfile, activeProfile)
+		}
 		if err := createDefaultConfigFile(configPath); err != nil {
 			return fmt.Errorf("failed to create default config file: %v", err)
 		}
@@ -64,10 +72,13 @@ func createDefaultConfigFile(filePath string) error {
 	return nil
 }
 
+// LoadConfig reads the config file and migrates it to the current schema version. A migration that fails is
+// retried, and its error reported, when the gas station key is read.
 func LoadConfig() error {
 	if err := viper.ReadInConfig(); err != nil {
 		return fmt.Errorf("failed to read config file: %v", err)
 	}
+	_ = migrateConfig()
 	return nil
 }
 
@@ -75,20 +86,138 @@ func GetConfig(key string) interface{} {
 	return viper.Get(key)
 }
 
+// SetConfig writes value to the config file. Only the key is changed in the file: values given through flags or
+// the environment are never persisted.
 func SetConfig(key string, value interface{}) error {
-	viper.Set(key, value)
-	return WriteConfig()
+	normalized, err := normalizeConfigValue(value)
+	if err != nil {
+		return err
+	}
+	viper.Set(key, normalized)
+
+	settings, err := readConfigFile()
+	if err != nil {
+		return err
+	}
+	setConfigPath(settings, strings.Split(strings.ToLower(key), "."), normalized)
+	return writeConfigFile(settings)
+}
+
+// UnsetConfig removes key from the config file
+func UnsetConfig(key string) error {
+	settings, err := readConfigFile()
+	if err != nil {
+		return err
+	}
+	if !deleteConfigPath(settings, strings.Split(strings.ToLower(key), ".")) {
+		return fmt.Errorf("%s is not set in %s", key, viper.ConfigFileUsed())
+	}
+	// A nil override lets the value of the config file, flags and defaults through again
+	viper.Set(key, nil)
+	return writeConfigFile(settings)
 }
 
-func WriteConfig() error {
-	if err := viper.WriteConfig(); err != nil {
+// readConfigFile returns the settings stored in the config file, as opposed to viper.AllSettings which includes
+// the values of flags and defaults
+func readConfigFile() (map[string]interface{}, error) {
+	if viper.ConfigFileUsed() == "" {
+		return nil, fmt.Errorf("config file is not initialized")
+	}
+	content, err := os.ReadFile(viper.ConfigFileUsed())
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config file: %v", err)
+	}
+
+	settings := make(map[string]interface{})
+	if len(bytes.TrimSpace(content)) == 0 {
+		return settings, nil
+	}
+	if err := json.Unmarshal(content, &settings); err != nil {
+		return nil, fmt.Errorf("failed to parse config file: %v", err)
+	}
+	return settings, nil
+}
+
+func writeConfigFile(settings map[string]interface{}) error {
+	content, err := json.MarshalIndent(settings, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal config: %v", err)
+	}
+	if err := os.WriteFile(viper.ConfigFileUsed(), content, 0o600); err != nil {
 		return fmt.Errorf("failed to write config file: %v", err)
 	}
-	return nil
+	return viper.ReadInConfig()
+}
+
+// normalizeConfigValue converts value to the maps and scalars it is read back as from the config file
+func normalizeConfigValue(value interface{}) (interface{}, error) {
+	content, err := json.Marshal(value)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal json: %v", err)
+	}
+	var normalized interface{}
+	if err := json.Unmarshal(content, &normalized); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal json: %v", err)
+	}
+	return normalized, nil
+}
+
+// configSectionKey returns the key of settings that matches name, which viper compares case-insensitively
+func configSectionKey(settings map[string]interface{}, name string) string {
+	for key := range settings {
+		if strings.EqualFold(key, name) {
+			return key
+		}
+	}
+	return name
+}
+
+func setConfigPath(settings map[string]interface{}, path []string, value interface{}) {
+	key := configSectionKey(settings, path[0])
+	if len(path) == 1 {
+		settings[key] = value
+		return
+	}
+	section, ok := settings[key].(map[string]interface{})
+	if !ok {
+		section = make(map[string]interface{})
+		settings[key] = section
+	}
+	setConfigPath(section, path[1:], value)
+}
+
+func getConfigPath(settings map[string]interface{}, path []string) interface{} {
+	value := settings[configSectionKey(settings, path[0])]
+	if len(path) == 1 {
+		return value
+	}
+	section, ok := value.(map[string]interface{})
+	if !ok {
+		return nil
+	}
+	return getConfigPath(section, path[1:])
+}
+
+// deleteConfigPath removes the value at path, along with the sections it leaves empty
+func deleteConfigPath(settings map[string]interface{}, path []string) bool {
+	key := configSectionKey(settings, path[0])
+	if len(path) == 1 {
+		_, ok := settings[key]
+		delete(settings, key)
+		return ok
+	}
+	section, ok := settings[key].(map[string]interface{})
+	if !ok || !deleteConfigPath(section, path[1:]) {
+		return false
+	}
+	if len(section) == 0 {
+		delete(settings, key)
+	}
+	return true
 }
 
 func IsFirstTimeSetup() bool {
-	return viper.Get("common.gas_station") == nil
+	return viper.Get(GasStationConfigKey) == nil
 }
 
 func GetGasStationKey() (*GasStationKey, error) {
@@ -96,13 +225,18 @@ func GetGasStationKey() (*GasStationKey, error) {
 		return nil, fmt.Errorf("gas station key not exists")
 	}
 
+	// Migrations that need the passphrase of a locked key run once it is available
+	if err := migrateConfig(); err != nil {
+		return nil, err
+	}
+
 	gasKey, err := loadGasStationKeyFromConfig()
 	if err != nil {
 		return nil, err
 	}
 
-	if gasKey.Mnemonic != "" {
-		if err := ensureGasStationKeyIntegrity(gasKey); err != nil {
+	if gasKey.EncryptedMnemonic != nil {
+		if err := decryptGasStationMnemonic(gasKey, GetGasStationPassphrase()); err != nil {
 			return nil, err
 		}
 	}
@@ -115,7 +249,7 @@ func GetGasStationKey() (*GasStationKey, error) {
 }
 
 func loadGasStationKeyFromConfig() (*GasStationKey, error) {
-	data := GetConfig("common.gas_station")
+	data := GetConfig(GasStationConfigKey)
 	jsonData, err := json.Marshal(data)
 	if err != nil {
 		return nil, fmt.Errorf("failed to marshal json: %v", err)
@@ -129,61 +263,31 @@ func loadGasStationKeyFromConfig() (*GasStationKey, error) {
 	return &gasKey, nil
 }
 
-func ensureGasStationKeyIntegrity(gasKey *GasStationKey) error {
-	updated := false
-
-	// Detect and set coin type if missing
-	if gasKey.CoinType == nil {
-		coinTypeSet, err := detectAndSetCoinType(gasKey)
-		if err != nil {
-			return err
-		}
-		updated = coinTypeSet
-	}
-
-	// Only recover addresses if coin type is set
-	if gasKey.CoinType != nil {
-		addressesUpdated, err := recoverAndUpdateAddresses(gasKey)
-		if err != nil {
-			return err
-		}
-		updated = updated || addressesUpdated
-	}
-
-	if updated {
-		if err := SetConfig("common.gas_station", gasKey); err != nil {
-			return fmt.Errorf("failed to persist gas station addresses: %v", err)
-		}
-	}
-
-	return nil
-}
-
-func detectAndSetCoinType(gasKey *GasStationKey) (bool, error) {
+func detectAndSetCoinType(gasKey *GasStationKey) bool {
 	// If we have a stored address, try to match it
 	if gasKey.InitiaAddress != "" {
-		if coinType, ok := matchCoinTypeToAddress(gasKey.Mnemonic, gasKey.InitiaAddress); ok {
+		if coinType, ok := matchCoinTypeToAddress(gasKey.Mnemonic, gasKey.InitiaAddress, gasKey.DerivationOptions()); ok {
 			gasKey.CoinType = &coinType
-			return true, nil
+			return true
 		}
 		// If neither matches, leave CoinType nil to prevent overwriting
-		return false, nil
+		return false
 	}
 
 	// Default to 118 for existing configs without stored address
 	coinType := 118
 	gasKey.CoinType = &coinType
-	return true, nil
+	return true
 }
 
-func matchCoinTypeToAddress(mnemonic, storedAddress string) (int, bool) {
+func matchCoinTypeToAddress(mnemonic, storedAddress string, opts crypto.DerivationOptions) (int, bool) {
 	// Try coin type 60 (EVM)
-	if addr60, err := crypto.MnemonicToBech32AddressWithCoinType("init", mnemonic, 60); err == nil && addr60 == storedAddress {
+	if addr60, err := crypto.MnemonicToBech32AddressWithOptions("init", mnemonic, 60, opts); err == nil && addr60 == storedAddress {
 		return 60, true
 	}
 
 	// Try coin type 118 (Cosmos)
-	if addr118, err := crypto.MnemonicToBech32AddressWithCoinType("init", mnemonic, 118); err == nil && addr118 == storedAddress {
+	if addr118, err := crypto.MnemonicToBech32AddressWithOptions("init", mnemonic, 118, opts); err == nil && addr118 == storedAddress {
 		return 118, true
 	}
 
@@ -194,13 +298,13 @@ func recoverAndUpdateAddresses(gasKey *GasStationKey) (bool, error) {
 	updated := false
 
 	// Recover initia address
-	initiaAddress, err := crypto.MnemonicToBech32AddressWithCoinType("init", gasKey.Mnemonic, *gasKey.CoinType)
+	initiaAddress, err := crypto.MnemonicToBech32AddressWithOptions("init", gasKey.Mnemonic, *gasKey.CoinType, gasKey.DerivationOptions())
 	if err != nil {
 		return false, fmt.Errorf("failed to recover initia gas station key: %v", err)
 	}
 
 	// Recover celestia address
-	celestiaKey, err := io.RecoverKey("celestia", gasKey.Mnemonic, crypto.CosmosAddressType)
+	celestiaKey, err := io.RecoverKeyWithOptions("celestia", gasKey.Mnemonic, crypto.CosmosAddressType, gasKey.CelestiaDerivationOptions())
 	if err != nil {
 		return false, fmt.Errorf("failed to recover celestia gas station key: %v", err)
 	}
@@ -235,26 +339,26 @@ func AnalyticsOptOut() bool {
 		return true
 	}
 
-	if GetConfig("common.analytics_opt_out") == nil {
-		_ = SetConfig("common.analytics_opt_out", false)
+	if GetConfig(AnalyticsOptOutKey) == nil {
+		_ = SetConfig(AnalyticsOptOutKey, false)
 		return false
 	}
 
-	return GetConfig("common.analytics_opt_out").(bool)
+	return GetConfig(AnalyticsOptOutKey).(bool)
 }
 
 func GetAnalyticsDeviceID() string {
-	if GetConfig("common.analytics_device_id") == nil {
+	if GetConfig(AnalyticsDeviceIDKey) == nil {
 		deviceID := uuid.New().String()
-		_ = SetConfig("common.analytics_device_id", deviceID)
+		_ = SetConfig(AnalyticsDeviceIDKey, deviceID)
 		return deviceID
 	}
 
-	return GetConfig("common.analytics_device_id").(string)
+	return GetConfig(AnalyticsDeviceIDKey).(string)
 }
 
 func SetAnalyticsOptOut(optOut bool) error {
-	return SetConfig("common.analytics_opt_out", optOut)
+	return SetConfig(AnalyticsOptOutKey, optOut)
 }
 
 const DefaultConfigTemplate = `{}`
@@ -262,17 +366,69 @@ const DefaultConfigTemplate = `{}`
 type GasStationKey struct {
 	InitiaAddress   string `json:"initia_address"`
 	CelestiaAddress string `json:"celestia_address"`
-	Mnemonic        string `json:"mnemonic"`
+	Mnemonic        string `json:"mnemonic,omitempty"`
 	CoinType        *int   `json:"coin_type,omitempty"`
+
+	// EncryptedMnemonic holds the passphrase-encrypted mnemonic. When set, Mnemonic is never persisted.
+	EncryptedMnemonic *crypto.EncryptedData `json:"encrypted_mnemonic,omitempty"`
+
+	// Derivation holds the advanced derivation settings. Its BIP39 passphrase is encrypted along with the mnemonic.
+	Derivation               *crypto.DerivationOptions `json:"derivation,omitempty"`
+	EncryptedBIP39Passphrase *crypto.EncryptedData     `json:"encrypted_bip39_passphrase,omitempty"`
+
+	// ExternalSigner, when set, signs gas station transactions through an external command. No mnemonic is stored then.
+	ExternalSigner *crypto.ExternalSignerConfig `json:"external_signer,omitempty"`
+}
+
+// DerivationOptions returns the options the gas station key is derived with
+func (g *GasStationKey) DerivationOptions() crypto.DerivationOptions {
+	if g.Derivation == nil {
+		return crypto.DerivationOptions{}
+	}
+	return *g.Derivation
+}
+
+// CelestiaDerivationOptions returns the options the Celestia key is derived with, a custom HD path takes coin type 118
+func (g *GasStationKey) CelestiaDerivationOptions() crypto.DerivationOptions {
+	return g.DerivationOptions().ForCoinType(118)
+}
+
+// InitiaSigner returns the signer of the gas station account on Initia L1 and rollups
+func (g *GasStationKey) InitiaSigner() (crypto.Signer, error) {
+	if g.CoinType == nil || *g.CoinType == 0 {
+		return nil, fmt.Errorf("coin type must be explicitly provided (60 or 118)")
+	}
+	return g.signer(*g.CoinType, g.DerivationOptions())
+}
+
+// CelestiaSigner returns the signer of the gas station account on Celestia, which always uses coin type 118
+func (g *GasStationKey) CelestiaSigner() (crypto.Signer, error) {
+	return g.signer(118, g.CelestiaDerivationOptions())
+}
+
+func (g *GasStationKey) signer(coinType int, opts crypto.DerivationOptions) (crypto.Signer, error) {
+	if g.ExternalSigner != nil {
+		return crypto.NewExternalSigner(*g.ExternalSigner, crypto.KeyTypeForCoinType(coinType))
+	}
+	if g.Mnemonic == "" {
+		return nil, fmt.Errorf("gas station mnemonic is not available")
+	}
+	return crypto.NewMnemonicSigner(g.Mnemonic, coinType, opts)
 }
 
 func RecoverGasStationKey(mnemonic string) (*GasStationKey, error) {
-	initiaKey, err := io.RecoverKey("init", mnemonic, crypto.EVMAddressType)
+	return RecoverGasStationKeyWithOptions(mnemonic, crypto.DerivationOptions{})
+}
+
+// RecoverGasStationKeyWithOptions recovers the gas station key with an optional BIP39 passphrase and HD path.
+// A custom HD path is used for the Initia address, the Celestia address is derived at the same path with coin type 118.
+func RecoverGasStationKeyWithOptions(mnemonic string, opts crypto.DerivationOptions) (*GasStationKey, error) {
+	initiaKey, err := io.RecoverKeyWithOptions("init", mnemonic, crypto.EVMAddressType, opts)
 	if err != nil {
 		return nil, fmt.Errorf("failed to recover initia gas station key: %v", err)
 	}
 
-	celestiaKey, err := io.RecoverKey("celestia", mnemonic, crypto.CosmosAddressType)
+	celestiaKey, err := io.RecoverKeyWithOptions("celestia", mnemonic, crypto.CosmosAddressType, opts.ForCoinType(118))
 	if err != nil {
 		return nil, fmt.Errorf("failed to recover celestia gas station key: %v", err)
 	}
@@ -284,5 +440,34 @@ func RecoverGasStationKey(mnemonic string) (*GasStationKey, error) {
 		CelestiaAddress: celestiaKey.Address,
 		Mnemonic:        mnemonic,
 		CoinType:        &coinType,
+		Derivation:      initiaKey.Derivation,
+	}, nil
+}
+
+// NewExternalSignerGasStationKey creates a gas station key whose private key is held by an external signer.
+// Both addresses are derived from the public key of the signer.
+func NewExternalSignerGasStationKey(signerConfig crypto.ExternalSignerConfig, coinType int) (*GasStationKey, error) {
+	if coinType != 60 && coinType != 118 {
+		return nil, fmt.Errorf("coin type must be 60 or 118")
+	}
+	pubKey, err := signerConfig.PubKeyBytes()
+	if err != nil {
+		return nil, fmt.Errorf("invalid external signer public key: %v", err)
+	}
+
+	initiaAddress, err := crypto.SecpPubKeyToBech32Address("init", pubKey, coinType)
+	if err != nil {
+		return nil, fmt.Errorf("failed to derive initia gas station address: %v", err)
+	}
+	celestiaAddress, err := crypto.SecpPubKeyToBech32Address("celestia", pubKey, 118)
+	if err != nil {
+		return nil, fmt.Errorf("failed to derive celestia gas station address: %v", err)
+	}
+
+	return &GasStationKey{
+		InitiaAddress:   initiaAddress,
+		CelestiaAddress: celestiaAddress,
+		CoinType:        &coinType,
+		ExternalSigner:  &signerConfig,
 	}, nil
 }
@@ -0,0 +1,25 @@
+package config
+
+import (
+	"github.com/spf13/viper"
+
+	"github.com/initia-labs/weave/client"
+)
+
+const (
+	// DownloadConnectionsKey is the number of parallel range requests used for large downloads saved to disk.
+	// Snapshots are extracted while they stream and always use a single connection.
+	DownloadConnectionsKey = "common.download.connections"
+	// DownloadBandwidthLimitKey caps the download speed of large downloads in bytes per second
+	DownloadBandwidthLimitKey = "common.download.bandwidth_limit"
+)
+
+// GetLargeDownloadOptions returns the configured settings for large downloads.
+// Checksums are verified whenever the provider publishes one.
+func GetLargeDownloadOptions() client.DownloadOptions {
+	return client.DownloadOptions{
+		Connections:            viper.GetInt(DownloadConnectionsKey),
+		BandwidthLimit:         viper.GetInt64(DownloadBandwidthLimitKey),
+		FetchPublishedChecksum: true,
+	}
+}
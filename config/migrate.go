@@ -0,0 +1,135 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/spf13/viper"
+)
+
+// ConfigVersionKey is the schema version of the config file, see configMigrations
+const ConfigVersionKey = "config_version"
+
+// errMigrationDeferred is returned by a migration that needs the passphrase of a locked gas station key.
+// The migration stays pending until a command that unlocks the key runs it.
+var errMigrationDeferred = errors.New("migration deferred")
+
+type configMigration struct {
+	version     int
+	description string
+	migrate     func() error
+}
+
+// configMigrations bring the config file up to date, one schema version after another.
+// New migrations are appended with the next version. Released ones must never change.
+var configMigrations = []configMigration{
+	{version: 1, description: "detect the coin type and the addresses of the gas station key", migrate: migrateGasStationCoinType},
+}
+
+// CurrentConfigVersion is the schema version the config file is migrated to
+func CurrentConfigVersion() int {
+	return configMigrations[len(configMigrations)-1].version
+}
+
+// migrateConfig runs the pending migrations in order, recording the version reached after each of them.
+// The config file is backed up once before the first pending migration changes it.
+func migrateConfig() error {
+	if viper.ConfigFileUsed() == "" {
+		return nil
+	}
+
+	version := viper.GetInt(ConfigVersionKey)
+	if version >= CurrentConfigVersion() {
+		return nil
+	}
+	if err := backupConfigFile(version); err != nil {
+		return err
+	}
+
+	for _, migration := range configMigrations {
+		if migration.version <= version {
+			continue
+		}
+		if err := migration.migrate(); err != nil {
+			if errors.Is(err, errMigrationDeferred) {
+				return nil
+			}
+			return fmt.Errorf("failed to migrate config to version %d (%s): %w", migration.version, migration.description, err)
+		}
+		if err := SetConfig(ConfigVersionKey, migration.version); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// backupConfigFile copies the config file next to itself before it is migrated from version. An empty config has
+// nothing worth keeping, and an existing backup of the same version is kept as the original one.
+func backupConfigFile(version int) error {
+	settings, err := readConfigFile()
+	if err != nil {
+		return err
+	}
+	if len(settings) == 0 {
+		return nil
+	}
+
+	backupPath := fmt.Sprintf("%s.v%d.bak", viper.ConfigFileUsed(), version)
+	if _, err := os.Stat(backupPath); err == nil {
+		return nil
+	}
+	content, err := os.ReadFile(viper.ConfigFileUsed())
+	if err != nil {
+		return fmt.Errorf("failed to read config file: %v", err)
+	}
+	if err := os.WriteFile(backupPath, content, 0o600); err != nil {
+		return fmt.Errorf("failed to back up config file: %v", err)
+	}
+	return nil
+}
+
+// migrateGasStationCoinType sets the coin type of gas station keys saved before it was stored, and the addresses
+// derived with it. Keys saved without an address were created with coin type 118.
+func migrateGasStationCoinType() error {
+	if IsFirstTimeSetup() {
+		return nil
+	}
+
+	gasKey, err := loadGasStationKeyFromConfig()
+	if err != nil {
+		return err
+	}
+	if gasKey.EncryptedMnemonic != nil {
+		passphrase := GetGasStationPassphrase()
+		if passphrase == "" {
+			return errMigrationDeferred
+		}
+		if err := decryptGasStationMnemonic(gasKey, passphrase); err != nil {
+			return err
+		}
+	}
+	if gasKey.Mnemonic == "" {
+		return nil
+	}
+
+	updated := false
+	if gasKey.CoinType == nil {
+		updated = detectAndSetCoinType(gasKey)
+	}
+	// Only recover addresses if coin type is set
+	if gasKey.CoinType != nil {
+		addressesUpdated, err := recoverAndUpdateAddresses(gasKey)
+		if err != nil {
+			return err
+		}
+		updated = updated || addressesUpdated
+	}
+
+	if updated {
+		if err := persistGasStationKey(gasKey); err != nil {
+			return fmt.Errorf("failed to persist gas station addresses: %v", err)
+		}
+	}
+	return nil
+}
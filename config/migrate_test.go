@@ -0,0 +1,91 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/initia-labs/weave/crypto"
+)
+
+func TestMigrateConfig_NewConfig(t *testing.T) {
+	configPath := setupConfigFile(t, DefaultConfigTemplate)
+
+	assert.Equal(t, CurrentConfigVersion(), viper.GetInt(ConfigVersionKey))
+	_, err := os.Stat(fmt.Sprintf("%s.v0.bak", configPath))
+	assert.True(t, os.IsNotExist(err), "an empty config is not backed up")
+}
+
+func TestMigrateConfig_GasStationCoinType(t *testing.T) {
+	address118, err := crypto.MnemonicToBech32AddressWithOptions("init", testMnemonic, 118, crypto.DerivationOptions{})
+	assert.NoError(t, err)
+	address60, err := crypto.MnemonicToBech32AddressWithOptions("init", testMnemonic, 60, crypto.DerivationOptions{})
+	assert.NoError(t, err)
+
+	tests := []struct {
+		name             string
+		storedAddress    string
+		expectedCoinType int
+		expectedAddress  string
+	}{
+		{name: "without address", storedAddress: "", expectedCoinType: 118, expectedAddress: address118},
+		{name: "cosmos address", storedAddress: address118, expectedCoinType: 118, expectedAddress: address118},
+		{name: "evm address", storedAddress: address60, expectedCoinType: 60, expectedAddress: address60},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			legacy := fmt.Sprintf(`{"common": {"gas_station": {"initia_address": %q, "celestia_address": "", "mnemonic": %q}}}`, tt.storedAddress, testMnemonic)
+			configPath := setupConfigFile(t, legacy)
+
+			assert.Equal(t, CurrentConfigVersion(), viper.GetInt(ConfigVersionKey))
+			gasKey, err := GetGasStationKey()
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedCoinType, *gasKey.CoinType)
+			assert.Equal(t, tt.expectedAddress, gasKey.InitiaAddress)
+			assert.NotEmpty(t, gasKey.CelestiaAddress)
+
+			backup, err := os.ReadFile(fmt.Sprintf("%s.v0.bak", configPath))
+			assert.NoError(t, err)
+			assert.Equal(t, legacy, string(backup))
+		})
+	}
+}
+
+func TestMigrateConfig_LockedKeyDeferred(t *testing.T) {
+	encrypted, err := crypto.Encrypt([]byte(testMnemonic), "passphrase")
+	assert.NoError(t, err)
+	encryptedJSON, err := json.Marshal(encrypted)
+	assert.NoError(t, err)
+	setupConfigFile(t, fmt.Sprintf(`{"common": {"gas_station": {"initia_address": "", "celestia_address": "", "encrypted_mnemonic": %s}}}`, encryptedJSON))
+
+	assert.Equal(t, 0, viper.GetInt(ConfigVersionKey), "the migration waits for the passphrase")
+	_, err = GetGasStationKey()
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "locked")
+
+	SetGasStationPassphrase("passphrase")
+	defer SetGasStationPassphrase("")
+	gasKey, err := GetGasStationKey()
+	assert.NoError(t, err)
+	assert.Equal(t, 118, *gasKey.CoinType)
+	assert.NotEmpty(t, gasKey.InitiaAddress)
+	assert.Equal(t, CurrentConfigVersion(), viper.GetInt(ConfigVersionKey))
+
+	stored, err := loadGasStationKeyFromConfig()
+	assert.NoError(t, err)
+	assert.Empty(t, stored.Mnemonic, "the migrated key stays locked")
+	assert.NotNil(t, stored.EncryptedMnemonic)
+}
+
+func TestMigrateConfig_AddressMismatch(t *testing.T) {
+	setupConfigFile(t, fmt.Sprintf(`{"common": {"gas_station": {"initia_address": "init1invalid", "coin_type": 60, "mnemonic": %q}}}`, testMnemonic))
+
+	assert.Equal(t, 0, viper.GetInt(ConfigVersionKey), "a failed migration stays pending")
+	_, err := GetGasStationKey()
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "does not match derived address")
+}
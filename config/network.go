@@ -0,0 +1,99 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/viper"
+
+	"github.com/initia-labs/weave/client"
+)
+
+const (
+	// MirrorsKey maps URL prefixes, such as https://api.github.com, to the mirror that serves them
+	MirrorsKey = "common.network.mirrors"
+	// ProxyKey is the proxy all requests go through, in place of HTTP_PROXY, HTTPS_PROXY and NO_PROXY
+	ProxyKey = "common.network.proxy"
+	// CABundleKey is a PEM file of certificates trusted in addition to the system pool
+	CABundleKey = "common.network.ca_bundle"
+	// RetryMaxAttemptsKey is the number of times a request is sent before giving up
+	RetryMaxAttemptsKey = "common.network.retry.max_attempts"
+	// RetryBaseDelayKey is the backoff after the first failed attempt, such as 1s. It doubles with every attempt.
+	RetryBaseDelayKey = "common.network.retry.base_delay"
+	// RetryMaxDelayKey caps the backoff, including the wait a server asks for with Retry-After
+	RetryMaxDelayKey = "common.network.retry.max_delay"
+	// RequestTimeoutKey is the deadline of an API request, and how long a download waits for the response headers
+	RequestTimeoutKey = "common.network.request_timeout"
+
+	// MirrorsEnvVar adds mirrors as comma-separated prefix=mirror pairs, taking precedence over the config file
+	MirrorsEnvVar = "WEAVE_MIRRORS"
+	// ProxyEnvVar overrides ProxyKey
+	ProxyEnvVar = "WEAVE_PROXY"
+	// CABundleEnvVar overrides CABundleKey
+	CABundleEnvVar = "WEAVE_CA_BUNDLE"
+)
+
+// GetNetworkOptions returns the configured mirrors, proxy, CA bundle and retry policy, with the environment variables
+// applied on top. Unset retry settings fall back to client.DefaultRetryPolicy.
+func GetNetworkOptions() (client.NetworkOptions, error) {
+	opts := client.NetworkOptions{
+		Mirrors:      viper.GetStringMapString(MirrorsKey),
+		ProxyURL:     viper.GetString(ProxyKey),
+		CABundlePath: viper.GetString(CABundleKey),
+		Retry: client.RetryPolicy{
+			MaxAttempts:    viper.GetInt(RetryMaxAttemptsKey),
+			BaseDelay:      viper.GetDuration(RetryBaseDelayKey),
+			MaxDelay:       viper.GetDuration(RetryMaxDelayKey),
+			RequestTimeout: viper.GetDuration(RequestTimeoutKey),
+		},
+	}
+
+	if mirrors := os.Getenv(MirrorsEnvVar); mirrors != "" {
+		parsed, err := parseMirrors(mirrors)
+		if err != nil {
+			return client.NetworkOptions{}, fmt.Errorf("invalid %s: %w", MirrorsEnvVar, err)
+		}
+		if opts.Mirrors == nil {
+			opts.Mirrors = make(map[string]string)
+		}
+		for from, to := range parsed {
+			opts.Mirrors[from] = to
+		}
+	}
+	if proxy := os.Getenv(ProxyEnvVar); proxy != "" {
+		opts.ProxyURL = proxy
+	}
+	if caBundle := os.Getenv(CABundleEnvVar); caBundle != "" {
+		opts.CABundlePath = caBundle
+	}
+	return opts, nil
+}
+
+// ConfigureNetwork applies GetNetworkOptions to every HTTP request weave makes
+func ConfigureNetwork() error {
+	opts, err := GetNetworkOptions()
+	if err != nil {
+		return err
+	}
+	if err := client.Configure(opts); err != nil {
+		return fmt.Errorf("invalid network settings: %w", err)
+	}
+	return nil
+}
+
+func parseMirrors(value string) (map[string]string, error) {
+	mirrors := make(map[string]string)
+	for _, pair := range strings.Split(value, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+		from, to, found := strings.Cut(pair, "=")
+		if !found || from == "" || to == "" {
+			return nil, fmt.Errorf("expected prefix=mirror, got %q", pair)
+		}
+		mirrors[from] = to
+	}
+	return mirrors, nil
+}
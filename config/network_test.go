@@ -0,0 +1,51 @@
+package config
+
+import (
+	"testing"
+	"time"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/initia-labs/weave/client"
+)
+
+func TestGetNetworkOptions(t *testing.T) {
+	viper.Set(MirrorsKey, map[string]string{
+		"https://api.github.com":            "https://artifacts.example.com/github-api",
+		"https://raw.githubusercontent.com": "https://artifacts.example.com/github-raw",
+	})
+	viper.Set(ProxyKey, "http://proxy.example.com:3128")
+	viper.Set(CABundleKey, "/etc/ssl/corp.pem")
+	viper.Set(RetryMaxAttemptsKey, 5)
+	viper.Set(RetryBaseDelayKey, "500ms")
+	t.Cleanup(func() {
+		viper.Set(RetryMaxAttemptsKey, nil)
+		viper.Set(RetryBaseDelayKey, nil)
+		viper.Set(MirrorsKey, nil)
+		viper.Set(ProxyKey, nil)
+		viper.Set(CABundleKey, nil)
+	})
+
+	opts, err := GetNetworkOptions()
+	assert.NoError(t, err)
+	assert.Equal(t, "https://artifacts.example.com/github-api", opts.Mirrors["https://api.github.com"])
+	assert.Equal(t, "http://proxy.example.com:3128", opts.ProxyURL)
+	assert.Equal(t, "/etc/ssl/corp.pem", opts.CABundlePath)
+	assert.Equal(t, client.RetryPolicy{MaxAttempts: 5, BaseDelay: 500 * time.Millisecond}, opts.Retry)
+
+	t.Setenv(MirrorsEnvVar, "https://api.github.com=https://mirror.example.com/api, https://registry.initia.xyz=https://mirror.example.com/registry")
+	t.Setenv(ProxyEnvVar, "http://other-proxy.example.com:8080")
+	opts, err = GetNetworkOptions()
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{
+		"https://api.github.com":            "https://mirror.example.com/api",
+		"https://raw.githubusercontent.com": "https://artifacts.example.com/github-raw",
+		"https://registry.initia.xyz":       "https://mirror.example.com/registry",
+	}, opts.Mirrors)
+	assert.Equal(t, "http://other-proxy.example.com:8080", opts.ProxyURL)
+
+	t.Setenv(MirrorsEnvVar, "https://api.github.com")
+	_, err = GetNetworkOptions()
+	assert.Error(t, err)
+}
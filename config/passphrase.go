@@ -0,0 +1,265 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/viper"
+
+	"github.com/initia-labs/weave/crypto"
+)
+
+// PassphraseEnvVar is the environment variable used to unlock encrypted keys non-interactively
+const PassphraseEnvVar = "WEAVE_PASSPHRASE"
+
+// GasStationEncryptionOfferedKey records that the user was offered to encrypt a gas station mnemonic stored in plaintext
+const GasStationEncryptionOfferedKey = "common.gas_station_encryption_offered"
+
+// gasStationSecret identifies the gas station key among the secrets with a cached passphrase
+const gasStationSecret = "gas-station"
+
+// cachedPassphrases holds the passphrases entered during the current command, one per secret,
+// so that the gas station key and every key file can be protected by a passphrase of its own
+var cachedPassphrases = make(map[string]string)
+
+func setPassphrase(secret, passphrase string) {
+	if passphrase == "" {
+		delete(cachedPassphrases, secret)
+		return
+	}
+	cachedPassphrases[secret] = passphrase
+}
+
+// getPassphrase returns the passphrase set for the secret in this command, falling back to WEAVE_PASSPHRASE
+func getPassphrase(secret string) string {
+	if passphrase, ok := cachedPassphrases[secret]; ok {
+		return passphrase
+	}
+	return os.Getenv(PassphraseEnvVar)
+}
+
+// keyFileSecret identifies a key file by its absolute path, so that every spelling of the path shares the passphrase
+func keyFileSecret(keyFilePath string) string {
+	if absPath, err := filepath.Abs(keyFilePath); err == nil {
+		return absPath
+	}
+	return keyFilePath
+}
+
+func SetGasStationPassphrase(passphrase string) {
+	setPassphrase(gasStationSecret, passphrase)
+}
+
+// GetGasStationPassphrase returns the passphrase of the gas station key set for this command, falling back to WEAVE_PASSPHRASE
+func GetGasStationPassphrase() string {
+	return getPassphrase(gasStationSecret)
+}
+
+func SetKeyFilePassphrase(keyFilePath, passphrase string) {
+	setPassphrase(keyFileSecret(keyFilePath), passphrase)
+}
+
+// GetKeyFilePassphrase returns the passphrase of the key file set for this command, falling back to WEAVE_PASSPHRASE
+func GetKeyFilePassphrase(keyFilePath string) string {
+	return getPassphrase(keyFileSecret(keyFilePath))
+}
+
+// IsGasStationKeyLocked reports whether the stored gas station mnemonic is encrypted
+func IsGasStationKeyLocked() bool {
+	if IsFirstTimeSetup() {
+		return false
+	}
+
+	gasKey, err := loadGasStationKeyFromConfig()
+	if err != nil {
+		return false
+	}
+	return gasKey.EncryptedMnemonic != nil
+}
+
+func decryptGasStationMnemonic(gasKey *GasStationKey, passphrase string) error {
+	if passphrase == "" {
+		return fmt.Errorf("gas station key is locked: provide the passphrase via %s or an interactive prompt", PassphraseEnvVar)
+	}
+
+	mnemonic, err := gasKey.EncryptedMnemonic.Decrypt(passphrase)
+	if err != nil {
+		return fmt.Errorf("failed to unlock gas station key: %v", err)
+	}
+	gasKey.Mnemonic = string(mnemonic)
+
+	if gasKey.EncryptedBIP39Passphrase != nil {
+		bip39Passphrase, err := gasKey.EncryptedBIP39Passphrase.Decrypt(passphrase)
+		if err != nil {
+			return fmt.Errorf("failed to unlock gas station BIP39 passphrase: %v", err)
+		}
+		derivation := gasKey.DerivationOptions()
+		derivation.BIP39Passphrase = string(bip39Passphrase)
+		gasKey.Derivation = &derivation
+	}
+	return nil
+}
+
+// encryptGasStationSecrets encrypts the mnemonic and, if any, the BIP39 passphrase of the gas station key
+func encryptGasStationSecrets(gasKey *GasStationKey, passphrase string) error {
+	encrypted, err := crypto.Encrypt([]byte(gasKey.Mnemonic), passphrase)
+	if err != nil {
+		return fmt.Errorf("failed to encrypt gas station mnemonic: %v", err)
+	}
+	gasKey.EncryptedMnemonic = encrypted
+
+	gasKey.EncryptedBIP39Passphrase = nil
+	if derivation := gasKey.DerivationOptions(); derivation.BIP39Passphrase != "" {
+		gasKey.EncryptedBIP39Passphrase, err = crypto.Encrypt([]byte(derivation.BIP39Passphrase), passphrase)
+		if err != nil {
+			return fmt.Errorf("failed to encrypt gas station BIP39 passphrase: %v", err)
+		}
+	}
+	return nil
+}
+
+// SaveGasStationKey persists the gas station key, encrypting the mnemonic when a passphrase is available.
+// Keys without a mnemonic, such as the ones held by an external signer, have no secrets to encrypt.
+// A locked key is never replaced by a plaintext one: without its passphrase, saving fails.
+func SaveGasStationKey(gasKey *GasStationKey) error {
+	if gasKey.EncryptedMnemonic == nil && gasKey.Mnemonic != "" {
+		passphrase := GetGasStationPassphrase()
+		if passphrase == "" && IsGasStationKeyLocked() {
+			return fmt.Errorf("the existing gas station key is encrypted: provide its passphrase via %s or an interactive prompt to replace it, "+
+				"or run `weave gas-station unlock` first", PassphraseEnvVar)
+		}
+		if passphrase != "" {
+			if err := encryptGasStationSecrets(gasKey, passphrase); err != nil {
+				return err
+			}
+		}
+	}
+	return persistGasStationKey(gasKey)
+}
+
+// persistGasStationKey writes the key to the config, never storing the plaintext secrets next to their ciphertext
+func persistGasStationKey(gasKey *GasStationKey) error {
+	stored := *gasKey
+	if stored.EncryptedMnemonic != nil {
+		stored.Mnemonic = ""
+	}
+	if stored.EncryptedBIP39Passphrase != nil {
+		derivation := stored.DerivationOptions()
+		derivation.BIP39Passphrase = ""
+		stored.Derivation = &derivation
+	}
+	return SetConfig(GasStationConfigKey, &stored)
+}
+
+// ShouldOfferGasStationEncryption reports whether the gas station mnemonic is stored in plaintext
+// and the user has not been offered to encrypt it yet
+func ShouldOfferGasStationEncryption() bool {
+	if IsFirstTimeSetup() || IsGasStationKeyLocked() || viper.GetBool(GasStationEncryptionOfferedKey) {
+		return false
+	}
+
+	gasKey, err := loadGasStationKeyFromConfig()
+	if err != nil {
+		return false
+	}
+	return gasKey.ExternalSigner == nil && gasKey.Mnemonic != ""
+}
+
+// MarkGasStationEncryptionOffered records that the user answered the offer to encrypt the gas station mnemonic
+func MarkGasStationEncryptionOffered() error {
+	return SetConfig(GasStationEncryptionOfferedKey, true)
+}
+
+// LockGasStationKey encrypts a plaintext gas station mnemonic with the given passphrase
+func LockGasStationKey(passphrase string) error {
+	if IsFirstTimeSetup() {
+		return fmt.Errorf("gas station key not exists")
+	}
+
+	gasKey, err := loadGasStationKeyFromConfig()
+	if err != nil {
+		return err
+	}
+	if gasKey.EncryptedMnemonic != nil {
+		return fmt.Errorf("gas station key is already locked")
+	}
+	if gasKey.ExternalSigner != nil {
+		return fmt.Errorf("gas station key is held by an external signer, there is no mnemonic to lock")
+	}
+
+	if err := encryptGasStationSecrets(gasKey, passphrase); err != nil {
+		return err
+	}
+
+	return persistGasStationKey(gasKey)
+}
+
+// UnlockGasStationKey decrypts the gas station mnemonic and stores it back in plaintext
+func UnlockGasStationKey(passphrase string) error {
+	if IsFirstTimeSetup() {
+		return fmt.Errorf("gas station key not exists")
+	}
+
+	gasKey, err := loadGasStationKeyFromConfig()
+	if err != nil {
+		return err
+	}
+	if gasKey.EncryptedMnemonic == nil {
+		return fmt.Errorf("gas station key is not locked")
+	}
+
+	if err := decryptGasStationMnemonic(gasKey, passphrase); err != nil {
+		return err
+	}
+	gasKey.EncryptedMnemonic = nil
+	gasKey.EncryptedBIP39Passphrase = nil
+
+	return persistGasStationKey(gasKey)
+}
+
+// ChangeGasStationPassphrase re-encrypts the gas station mnemonic under a new passphrase
+func ChangeGasStationPassphrase(oldPassphrase, newPassphrase string) error {
+	if IsFirstTimeSetup() {
+		return fmt.Errorf("gas station key not exists")
+	}
+
+	gasKey, err := loadGasStationKeyFromConfig()
+	if err != nil {
+		return err
+	}
+	if gasKey.EncryptedMnemonic == nil {
+		return fmt.Errorf("gas station key is not locked, use `weave gas-station lock` instead")
+	}
+
+	if err := decryptGasStationMnemonic(gasKey, oldPassphrase); err != nil {
+		return err
+	}
+
+	if err := encryptGasStationSecrets(gasKey, newPassphrase); err != nil {
+		return err
+	}
+
+	return persistGasStationKey(gasKey)
+}
+
+// GetGasStationPublicKey returns the gas station addresses and coin type without requiring the passphrase of a locked key.
+// The returned key never carries the mnemonic or the derivation settings.
+func GetGasStationPublicKey() (*GasStationKey, error) {
+	var gasKey *GasStationKey
+	var err error
+	if IsGasStationKeyLocked() {
+		gasKey, err = loadGasStationKeyFromConfig()
+	} else {
+		gasKey, err = GetGasStationKey()
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	gasKey.Mnemonic = ""
+	gasKey.EncryptedMnemonic = nil
+	gasKey.Derivation = nil
+	gasKey.EncryptedBIP39Passphrase = nil
+	return gasKey, nil
+}
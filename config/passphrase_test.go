@@ -0,0 +1,229 @@
+package config
+
+import (
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/initia-labs/weave/common"
+	"github.com/initia-labs/weave/crypto"
+)
+
+const testMnemonic = "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about"
+
+func setupGasStationConfig(t *testing.T) string {
+	viper.Reset()
+	t.Cleanup(viper.Reset)
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv(PassphraseEnvVar, "")
+	SetGasStationPassphrase("")
+
+	assert.NoError(t, InitializeConfig())
+	gasKey, err := RecoverGasStationKey(testMnemonic)
+	assert.NoError(t, err)
+	assert.NoError(t, SaveGasStationKey(gasKey))
+
+	return filepath.Join(home, common.WeaveConfigFile)
+}
+
+func TestGasStationLockUnlock(t *testing.T) {
+	configPath := setupGasStationConfig(t)
+	assert.False(t, IsGasStationKeyLocked())
+
+	assert.NoError(t, LockGasStationKey("passphrase"))
+	assert.True(t, IsGasStationKeyLocked())
+
+	content, err := os.ReadFile(configPath)
+	assert.NoError(t, err)
+	assert.NotContains(t, string(content), "abandon")
+
+	_, err = GetGasStationKey()
+	assert.Error(t, err, "locked key must not be readable without a passphrase")
+
+	publicKey, err := GetGasStationPublicKey()
+	assert.NoError(t, err)
+	assert.NotEmpty(t, publicKey.InitiaAddress)
+	assert.Empty(t, publicKey.Mnemonic)
+
+	SetGasStationPassphrase("passphrase")
+	gasKey, err := GetGasStationKey()
+	assert.NoError(t, err)
+	assert.Equal(t, testMnemonic, gasKey.Mnemonic)
+
+	assert.Error(t, ChangeGasStationPassphrase("wrong", "new-passphrase"))
+	assert.NoError(t, ChangeGasStationPassphrase("passphrase", "new-passphrase"))
+	assert.Error(t, UnlockGasStationKey("passphrase"))
+	assert.NoError(t, UnlockGasStationKey("new-passphrase"))
+	assert.False(t, IsGasStationKeyLocked())
+
+	SetGasStationPassphrase("")
+	gasKey, err = GetGasStationKey()
+	assert.NoError(t, err)
+	assert.Equal(t, testMnemonic, gasKey.Mnemonic)
+}
+
+func TestSaveGasStationKeyWithEnvPassphrase(t *testing.T) {
+	configPath := setupGasStationConfig(t)
+	t.Setenv(PassphraseEnvVar, "from-env")
+
+	gasKey, err := RecoverGasStationKey(testMnemonic)
+	assert.NoError(t, err)
+	assert.NoError(t, SaveGasStationKey(gasKey))
+	assert.True(t, IsGasStationKeyLocked())
+
+	content, err := os.ReadFile(configPath)
+	assert.NoError(t, err)
+	assert.NotContains(t, string(content), "abandon")
+
+	gasKey, err = GetGasStationKey()
+	assert.NoError(t, err)
+	assert.Equal(t, testMnemonic, gasKey.Mnemonic)
+}
+
+func TestGasStationKeyWithDerivationOptions(t *testing.T) {
+	configPath := setupGasStationConfig(t)
+	defaultKey, err := GetGasStationKey()
+	assert.NoError(t, err)
+
+	opts := crypto.DerivationOptions{BIP39Passphrase: "bip39-secret", HDPath: "m/44'/60'/0'/0/1"}
+	gasKey, err := RecoverGasStationKeyWithOptions(testMnemonic, opts)
+	assert.NoError(t, err)
+	assert.NotEqual(t, defaultKey.InitiaAddress, gasKey.InitiaAddress)
+	assert.NoError(t, SaveGasStationKey(gasKey))
+
+	assert.NoError(t, LockGasStationKey("passphrase"))
+	content, err := os.ReadFile(configPath)
+	assert.NoError(t, err)
+	assert.NotContains(t, string(content), "bip39-secret")
+	assert.Contains(t, string(content), opts.HDPath)
+
+	SetGasStationPassphrase("passphrase")
+	defer SetGasStationPassphrase("")
+	unlocked, err := GetGasStationKey()
+	assert.NoError(t, err)
+	assert.Equal(t, opts, unlocked.DerivationOptions())
+	assert.Equal(t, gasKey.InitiaAddress, unlocked.InitiaAddress)
+	assert.Equal(t, gasKey.CelestiaAddress, unlocked.CelestiaAddress)
+}
+
+func TestGasStationKeyCelestiaHDPath(t *testing.T) {
+	opts := crypto.DerivationOptions{HDPath: crypto.HDPath(60, 0, 1)}
+	gasKey, err := RecoverGasStationKeyWithOptions(testMnemonic, opts)
+	assert.NoError(t, err)
+
+	celestiaAddress, err := crypto.MnemonicToBech32AddressWithOptions("celestia", testMnemonic, 118, crypto.DerivationOptions{HDPath: crypto.HDPath(118, 0, 1)})
+	assert.NoError(t, err)
+	assert.Equal(t, celestiaAddress, gasKey.CelestiaAddress)
+	assert.Equal(t, opts, gasKey.DerivationOptions())
+
+	signer, err := gasKey.CelestiaSigner()
+	assert.NoError(t, err)
+	pubKey, err := signer.PubKey()
+	assert.NoError(t, err)
+	signerAddress, err := crypto.SecpPubKeyToBech32Address("celestia", pubKey, 118)
+	assert.NoError(t, err)
+	assert.Equal(t, celestiaAddress, signerAddress)
+}
+
+func TestExternalSignerGasStationKey(t *testing.T) {
+	configPath := setupGasStationConfig(t)
+	mnemonicKey, err := GetGasStationKey()
+	assert.NoError(t, err)
+	mnemonicSigner, err := mnemonicKey.InitiaSigner()
+	assert.NoError(t, err)
+	pubKey, err := mnemonicSigner.PubKey()
+	assert.NoError(t, err)
+
+	t.Setenv(PassphraseEnvVar, "from-env")
+	gasKey, err := NewExternalSignerGasStationKey(crypto.ExternalSignerConfig{
+		Command: []string{"kms-bridge", "--key", "gas-station"},
+		PubKey:  hex.EncodeToString(pubKey),
+	}, *mnemonicKey.CoinType)
+	assert.NoError(t, err)
+	assert.Equal(t, mnemonicKey.InitiaAddress, gasKey.InitiaAddress)
+	assert.NoError(t, SaveGasStationKey(gasKey))
+	assert.False(t, IsGasStationKeyLocked())
+	assert.Error(t, LockGasStationKey("passphrase"))
+
+	content, err := os.ReadFile(configPath)
+	assert.NoError(t, err)
+	assert.NotContains(t, string(content), "mnemonic")
+
+	stored, err := GetGasStationKey()
+	assert.NoError(t, err)
+	signer, err := stored.InitiaSigner()
+	assert.NoError(t, err)
+	assert.IsType(t, &crypto.ExternalSigner{}, signer)
+	address, err := crypto.SignerAddress("init", signer)
+	assert.NoError(t, err)
+	assert.Equal(t, stored.InitiaAddress, address)
+
+	celestiaSigner, err := stored.CelestiaSigner()
+	assert.NoError(t, err)
+	celestiaAddress, err := crypto.SignerAddress("celestia", celestiaSigner)
+	assert.NoError(t, err)
+	assert.Equal(t, stored.CelestiaAddress, celestiaAddress)
+}
+
+func TestPassphrasePerSecret(t *testing.T) {
+	setupGasStationConfig(t)
+	dir := t.TempDir()
+	t.Chdir(dir)
+
+	SetGasStationPassphrase("gas-station-passphrase")
+	defer SetGasStationPassphrase("")
+	SetKeyFilePassphrase("weave.keys.json", "key-file-passphrase")
+	defer SetKeyFilePassphrase("weave.keys.json", "")
+
+	assert.Equal(t, "gas-station-passphrase", GetGasStationPassphrase())
+	assert.Equal(t, "key-file-passphrase", GetKeyFilePassphrase(filepath.Join(dir, "weave.keys.json")))
+	assert.Equal(t, "", GetKeyFilePassphrase(filepath.Join(dir, "other.keys.json")))
+
+	// A key file passphrase never locks the gas station key
+	gasKey, err := RecoverGasStationKey(testMnemonic)
+	assert.NoError(t, err)
+	SetGasStationPassphrase("")
+	assert.NoError(t, SaveGasStationKey(gasKey))
+	assert.False(t, IsGasStationKeyLocked())
+
+	t.Setenv(PassphraseEnvVar, "from-env")
+	assert.Equal(t, "from-env", GetGasStationPassphrase())
+	assert.Equal(t, "from-env", GetKeyFilePassphrase(filepath.Join(dir, "other.keys.json")))
+	assert.Equal(t, "key-file-passphrase", GetKeyFilePassphrase("weave.keys.json"))
+}
+
+func TestSaveGasStationKeyKeepsLockedKeyEncrypted(t *testing.T) {
+	configPath := setupGasStationConfig(t)
+	assert.NoError(t, LockGasStationKey("passphrase"))
+
+	gasKey, err := RecoverGasStationKey(testMnemonic)
+	assert.NoError(t, err)
+	assert.Error(t, SaveGasStationKey(gasKey), "a locked key must not be replaced by a plaintext one")
+	assert.True(t, IsGasStationKeyLocked())
+
+	SetGasStationPassphrase("passphrase")
+	defer SetGasStationPassphrase("")
+	assert.NoError(t, SaveGasStationKey(gasKey))
+	assert.True(t, IsGasStationKeyLocked())
+
+	content, err := os.ReadFile(configPath)
+	assert.NoError(t, err)
+	assert.NotContains(t, string(content), "abandon")
+}
+
+func TestShouldOfferGasStationEncryption(t *testing.T) {
+	setupGasStationConfig(t)
+	assert.True(t, ShouldOfferGasStationEncryption())
+
+	assert.NoError(t, MarkGasStationEncryptionOffered())
+	assert.False(t, ShouldOfferGasStationEncryption())
+
+	assert.NoError(t, SetConfig(GasStationEncryptionOfferedKey, false))
+	assert.NoError(t, LockGasStationKey("passphrase"))
+	assert.False(t, ShouldOfferGasStationEncryption(), "a locked key is already encrypted")
+}
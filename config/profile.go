@@ -0,0 +1,351 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/spf13/viper"
+
+	"github.com/initia-labs/weave/common"
+)
+
+const (
+	// DefaultProfile keeps ~/.weave/config.json, the usual home directories and service names without a suffix
+	DefaultProfile = "default"
+	// ProfileEnvVar selects the profile when --profile is not given
+	ProfileEnvVar = "WEAVE_PROFILE"
+
+	// InitiaHomeKey is the default --initia-dir of the commands run with the profile
+	InitiaHomeKey = "common.profile.initia_home"
+	// MinitiaHomeKey is the default --minitia-dir of the commands run with the profile
+	MinitiaHomeKey = "common.profile.minitia_home"
+	// OPinitHomeKey is the default --opinit-dir of the commands run with the profile
+	OPinitHomeKey = "common.profile.opinit_home"
+)
+
+var profileNamePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9-]{0,31}$`)
+
+// activeProfile is the profile of the current command, set by SetProfile before the config is initialized
+var activeProfile = DefaultProfile
+
+// Profile is a separate config file, with its own gas station and network settings, together with the home
+// directories and service names used by the commands run with it
+type Profile struct {
+	Name        string `json:"name"`
+	Active      bool   `json:"active"`
+	ConfigPath  string `json:"config_path"`
+	InitiaHome  string `json:"initia_home"`
+	MinitiaHome string `json:"minitia_home"`
+	OPinitHome  string `json:"opinit_home"`
+}
+
+// ValidateProfileName checks that name can be used in a directory and a service name
+func ValidateProfileName(name string) error {
+	if !profileNamePattern.MatchString(name) {
+		return fmt.Errorf("invalid profile name %q: use up to 32 lowercase letters, digits and dashes", name)
+	}
+	return nil
+}
+
+// SetProfile makes the config, home directories and services of name the ones of the current command
+func SetProfile(name string) error {
+	if err := ValidateProfileName(name); err != nil {
+		return err
+	}
+	activeProfile = name
+	return nil
+}
+
+// GetProfile returns the profile of the current command
+func GetProfile() string {
+	return activeProfile
+}
+
+// ProfileServiceSuffix returns what the service names of profile end with, which is nothing for the default profile
+func ProfileServiceSuffix(profile string) string {
+	if profile == "" || profile == DefaultProfile {
+		return ""
+	}
+	return "-" + profile
+}
+
+// ProfileConfigPath returns the config file of profile
+func ProfileConfigPath(profile string) (string, error) {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to get user home directory: %v", err)
+	}
+	if profile == DefaultProfile {
+		return filepath.Join(homeDir, common.WeaveConfigFile), nil
+	}
+	return filepath.Join(homeDir, common.WeaveProfilesDirectory, profile, "config.json"), nil
+}
+
+// ResolveProfile returns the profile a command runs with: the one given with --profile, else WEAVE_PROFILE, else the
+// one selected with `weave profile use`
+func ResolveProfile(flagValue string) (string, error) {
+	name := flagValue
+	if name == "" {
+		name = os.Getenv(ProfileEnvVar)
+	}
+	if name == "" {
+		current, err := CurrentProfile()
+		if err != nil {
+			return "", err
+		}
+		name = current
+	}
+
+	if err := ValidateProfileName(name); err != nil {
+		return "", err
+	}
+	if exists, err := profileExists(name); err != nil {
+		return "", err
+	} else if !exists {
+		return "", fmt.Errorf("profile %s does not exist, create it with `weave profile create %s` or select another one with `weave profile use`", name, name)
+	}
+	return name, nil
+}
+
+// CurrentProfile returns the profile selected with `weave profile use`
+func CurrentProfile() (string, error) {
+	path, err := currentProfilePath()
+	if err != nil {
+		return "", err
+	}
+	content, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return DefaultProfile, nil
+	} else if err != nil {
+		return "", fmt.Errorf("failed to read the current profile: %v", err)
+	}
+	if name := strings.TrimSpace(string(content)); name != "" {
+		return name, nil
+	}
+	return DefaultProfile, nil
+}
+
+// UseProfile selects the profile of the commands run without --profile or WEAVE_PROFILE
+func UseProfile(name string) error {
+	if err := ValidateProfileName(name); err != nil {
+		return err
+	}
+	if exists, err := profileExists(name); err != nil {
+		return err
+	} else if !exists {
+		return fmt.Errorf("profile %s does not exist, create it with `weave profile create %s`", name, name)
+	}
+
+	path, err := currentProfilePath()
+	if err != nil {
+		return err
+	}
+	if name == DefaultProfile {
+		if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("failed to reset the current profile: %v", err)
+		}
+		return nil
+	}
+	if err := os.MkdirAll(filepath.Dir(path), os.ModePerm); err != nil {
+		return fmt.Errorf("failed to create profiles directory: %v", err)
+	}
+	if err := os.WriteFile(path, []byte(name+"\n"), 0o644); err != nil {
+		return fmt.Errorf("failed to save the current profile: %v", err)
+	}
+	return nil
+}
+
+// CreateProfile creates the config file of a new profile. Home directories left empty default to the usual ones
+// with the profile name appended, such as ~/.initia-testnet.
+func CreateProfile(profile Profile) error {
+	if err := ValidateProfileName(profile.Name); err != nil {
+		return err
+	}
+	if profile.Name == DefaultProfile {
+		return fmt.Errorf("the %s profile always exists", DefaultProfile)
+	}
+	if exists, err := profileExists(profile.Name); err != nil {
+		return err
+	} else if exists {
+		return fmt.Errorf("profile %s already exists", profile.Name)
+	}
+
+	defaults, err := defaultProfileHomes(profile.Name)
+	if err != nil {
+		return err
+	}
+	settings := map[string]interface{}{ConfigVersionKey: CurrentConfigVersion()}
+	for _, home := range []struct {
+		key      string
+		value    string
+		fallback string
+	}{
+		{InitiaHomeKey, profile.InitiaHome, defaults.InitiaHome},
+		{MinitiaHomeKey, profile.MinitiaHome, defaults.MinitiaHome},
+		{OPinitHomeKey, profile.OPinitHome, defaults.OPinitHome},
+	} {
+		value := home.fallback
+		if home.value != "" {
+			if value, err = filepath.Abs(home.value); err != nil {
+				return fmt.Errorf("invalid path %s: %v", home.value, err)
+			}
+		}
+		setConfigPath(settings, strings.Split(home.key, "."), value)
+	}
+
+	content, err := json.MarshalIndent(settings, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal config: %v", err)
+	}
+
+	configPath, err := ProfileConfigPath(profile.Name)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(configPath), os.ModePerm); err != nil {
+		return fmt.Errorf("failed to create profile directory: %v", err)
+	}
+	if err := os.WriteFile(configPath, content, 0o600); err != nil {
+		return fmt.Errorf("failed to write config file: %v", err)
+	}
+	return nil
+}
+
+// ListProfiles returns the default profile followed by the created ones, in alphabetical order
+func ListProfiles() ([]Profile, error) {
+	names := []string{DefaultProfile}
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user home directory: %v", err)
+	}
+	entries, err := os.ReadDir(filepath.Join(homeDir, common.WeaveProfilesDirectory))
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("failed to read profiles directory: %v", err)
+	}
+	var created []string
+	for _, entry := range entries {
+		if !entry.IsDir() || ValidateProfileName(entry.Name()) != nil || entry.Name() == DefaultProfile {
+			continue
+		}
+		if exists, err := profileExists(entry.Name()); err == nil && exists {
+			created = append(created, entry.Name())
+		}
+	}
+	sort.Strings(created)
+	names = append(names, created...)
+
+	profiles := make([]Profile, 0, len(names))
+	for _, name := range names {
+		profile, err := readProfile(name)
+		if err != nil {
+			return nil, err
+		}
+		profile.Active = name == activeProfile
+		profiles = append(profiles, profile)
+	}
+	return profiles, nil
+}
+
+// GetInitiaHome returns the default --initia-dir of the active profile
+func GetInitiaHome() (string, error) {
+	return profileHome(InitiaHomeKey, common.InitiaDirectory)
+}
+
+// GetMinitiaHome returns the default --minitia-dir of the active profile
+func GetMinitiaHome() (string, error) {
+	return profileHome(MinitiaHomeKey, common.MinitiaDirectory)
+}
+
+// GetOPinitHome returns the default --opinit-dir of the active profile
+func GetOPinitHome() (string, error) {
+	return profileHome(OPinitHomeKey, common.OPinitDirectory)
+}
+
+func profileHome(key, directory string) (string, error) {
+	if home := viper.GetString(key); home != "" {
+		return home, nil
+	}
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to get user home directory: %v", err)
+	}
+	return filepath.Join(homeDir, directory+ProfileServiceSuffix(activeProfile)), nil
+}
+
+func defaultProfileHomes(name string) (Profile, error) {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return Profile{}, fmt.Errorf("failed to get user home directory: %v", err)
+	}
+	suffix := ProfileServiceSuffix(name)
+	return Profile{
+		Name:        name,
+		InitiaHome:  filepath.Join(homeDir, common.InitiaDirectory+suffix),
+		MinitiaHome: filepath.Join(homeDir, common.MinitiaDirectory+suffix),
+		OPinitHome:  filepath.Join(homeDir, common.OPinitDirectory+suffix),
+	}, nil
+}
+
+// readProfile reads the home directories of a profile from its config file, which may not be the active one
+func readProfile(name string) (Profile, error) {
+	profile, err := defaultProfileHomes(name)
+	if err != nil {
+		return Profile{}, err
+	}
+	profile.ConfigPath, err = ProfileConfigPath(name)
+	if err != nil {
+		return Profile{}, err
+	}
+
+	content, err := os.ReadFile(profile.ConfigPath)
+	if errors.Is(err, os.ErrNotExist) {
+		return profile, nil
+	} else if err != nil {
+		return Profile{}, fmt.Errorf("failed to read config file of profile %s: %v", name, err)
+	}
+	settings := make(map[string]interface{})
+	if len(strings.TrimSpace(string(content))) > 0 {
+		if err := json.Unmarshal(content, &settings); err != nil {
+			return Profile{}, fmt.Errorf("failed to parse config file of profile %s: %v", name, err)
+		}
+	}
+	for key, target := range map[string]*string{
+		InitiaHomeKey:  &profile.InitiaHome,
+		MinitiaHomeKey: &profile.MinitiaHome,
+		OPinitHomeKey:  &profile.OPinitHome,
+	} {
+		if home, ok := getConfigPath(settings, strings.Split(key, ".")).(string); ok && home != "" {
+			*target = home
+		}
+	}
+	return profile, nil
+}
+
+func profileExists(name string) (bool, error) {
+	if name == DefaultProfile {
+		return true, nil
+	}
+	configPath, err := ProfileConfigPath(name)
+	if err != nil {
+		return false, err
+	}
+	_, err = os.Stat(configPath)
+	if errors.Is(err, os.ErrNotExist) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+func currentProfilePath() (string, error) {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to get user home directory: %v", err)
+	}
+	return filepath.Join(homeDir, common.WeaveCurrentProfileFile), nil
+}
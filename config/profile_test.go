@@ -0,0 +1,117 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/initia-labs/weave/common"
+)
+
+func setupProfiles(t *testing.T) string {
+	viper.Reset()
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv(ProfileEnvVar, "")
+	t.Cleanup(func() {
+		activeProfile = DefaultProfile
+		viper.Reset()
+	})
+	return home
+}
+
+func TestCreateProfile(t *testing.T) {
+	home := setupProfiles(t)
+
+	assert.NoError(t, CreateProfile(Profile{Name: "testnet"}))
+	assert.NoError(t, CreateProfile(Profile{Name: "mainnet", InitiaHome: "/srv/initia"}))
+	assert.Error(t, CreateProfile(Profile{Name: "testnet"}), "profiles are created once")
+	assert.Error(t, CreateProfile(Profile{Name: DefaultProfile}))
+	assert.Error(t, CreateProfile(Profile{Name: "Test Net"}))
+
+	profiles, err := ListProfiles()
+	assert.NoError(t, err)
+	assert.Equal(t, []Profile{
+		{
+			Name:        DefaultProfile,
+			Active:      true,
+			ConfigPath:  filepath.Join(home, common.WeaveConfigFile),
+			InitiaHome:  filepath.Join(home, common.InitiaDirectory),
+			MinitiaHome: filepath.Join(home, common.MinitiaDirectory),
+			OPinitHome:  filepath.Join(home, common.OPinitDirectory),
+		},
+		{
+			Name:        "mainnet",
+			ConfigPath:  filepath.Join(home, common.WeaveProfilesDirectory, "mainnet", "config.json"),
+			InitiaHome:  "/srv/initia",
+			MinitiaHome: filepath.Join(home, ".minitia-mainnet"),
+			OPinitHome:  filepath.Join(home, ".opinit-mainnet"),
+		},
+		{
+			Name:        "testnet",
+			ConfigPath:  filepath.Join(home, common.WeaveProfilesDirectory, "testnet", "config.json"),
+			InitiaHome:  filepath.Join(home, ".initia-testnet"),
+			MinitiaHome: filepath.Join(home, ".minitia-testnet"),
+			OPinitHome:  filepath.Join(home, ".opinit-testnet"),
+		},
+	}, profiles)
+}
+
+func TestResolveProfile(t *testing.T) {
+	setupProfiles(t)
+	assert.NoError(t, CreateProfile(Profile{Name: "testnet"}))
+	assert.NoError(t, CreateProfile(Profile{Name: "mainnet"}))
+
+	profile, err := ResolveProfile("")
+	assert.NoError(t, err)
+	assert.Equal(t, DefaultProfile, profile)
+
+	assert.NoError(t, UseProfile("testnet"))
+	profile, err = ResolveProfile("")
+	assert.NoError(t, err)
+	assert.Equal(t, "testnet", profile)
+
+	t.Setenv(ProfileEnvVar, "mainnet")
+	profile, err = ResolveProfile("")
+	assert.NoError(t, err)
+	assert.Equal(t, "mainnet", profile, "the environment takes precedence over the selected profile")
+
+	profile, err = ResolveProfile("testnet")
+	assert.NoError(t, err)
+	assert.Equal(t, "testnet", profile, "the flag takes precedence over the environment")
+
+	_, err = ResolveProfile("devnet")
+	assert.Error(t, err)
+	assert.Error(t, UseProfile("devnet"))
+
+	assert.NoError(t, UseProfile(DefaultProfile))
+	current, err := CurrentProfile()
+	assert.NoError(t, err)
+	assert.Equal(t, DefaultProfile, current)
+}
+
+func TestProfileConfig(t *testing.T) {
+	home := setupProfiles(t)
+	assert.NoError(t, CreateProfile(Profile{Name: "testnet", OPinitHome: "/srv/opinit"}))
+
+	assert.NoError(t, SetProfile("testnet"))
+	assert.NoError(t, InitializeConfig())
+	assert.Equal(t, filepath.Join(home, common.WeaveProfilesDirectory, "testnet", "config.json"), viper.ConfigFileUsed())
+	assert.NoError(t, SetSetting(ProxyKey, "http://proxy.example.com:3128"))
+
+	initiaHome, err := GetInitiaHome()
+	assert.NoError(t, err)
+	assert.Equal(t, filepath.Join(home, ".initia-testnet"), initiaHome)
+	opinitHome, err := GetOPinitHome()
+	assert.NoError(t, err)
+	assert.Equal(t, "/srv/opinit", opinitHome)
+
+	_, err = os.Stat(filepath.Join(home, common.WeaveConfigFile))
+	assert.True(t, os.IsNotExist(err), "the default config is left alone")
+
+	assert.NoError(t, SetProfile("devnet"))
+	assert.Error(t, InitializeConfig(), "a profile must be created before it is used")
+}
@@ -0,0 +1,270 @@
+package config
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+const (
+	// AnalyticsOptOutKey turns off the collection of analytics data
+	AnalyticsOptOutKey = "common.analytics_opt_out"
+	// AnalyticsDeviceIDKey identifies this machine in analytics events
+	AnalyticsDeviceIDKey = "common.analytics_device_id"
+	// GasStationConfigKey holds the gas station key, see GasStationKey
+	GasStationConfigKey = "common.gas_station"
+	// ValidatorMonitorWebhookKey is the webhook the validator monitor service posts alerts to. It is kept in the config
+	// file rather than in the service definition, since the URL often carries a token.
+	ValidatorMonitorWebhookKey = "common.validator_monitor.webhook"
+
+	// MaskedValue replaces secrets in the output of ListConfig
+	MaskedValue = "********"
+)
+
+// SettingType is the kind of value a setting accepts
+type SettingType string
+
+const (
+	BoolSetting     SettingType = "bool"
+	IntSetting      SettingType = "int"
+	DurationSetting SettingType = "duration"
+	StringSetting   SettingType = "string"
+	URLSetting      SettingType = "url"
+	FileSetting     SettingType = "file"
+	PathSetting     SettingType = "path"
+	EnumSetting     SettingType = "enum"
+	// MirrorsSetting is a map given as comma-separated prefix=mirror pairs, such as WEAVE_MIRRORS
+	MirrorsSetting SettingType = "mirrors"
+	// ObjectSetting is a section of the config that weave manages as a whole
+	ObjectSetting SettingType = "object"
+)
+
+// Setting is a key of the config file that weave knows about
+type Setting struct {
+	Key         string
+	Description string
+	Type        SettingType
+	// Values are the accepted values of an EnumSetting
+	Values []string
+	// Min is the smallest accepted value of an IntSetting
+	Min int
+	// ManagedBy is the command that changes the setting, for the ones that cannot be set through `weave config`
+	ManagedBy string
+}
+
+// Settings are the known keys of the config file
+var Settings = []Setting{
+	{Key: ConfigVersionKey, Description: "Schema version of the config file", Type: IntSetting, ManagedBy: "weave"},
+	{Key: AnalyticsOptOutKey, Description: "Do not allow Weave to collect analytics data", Type: BoolSetting},
+	{Key: AnalyticsDeviceIDKey, Description: "Identifier of this machine in analytics data", Type: StringSetting, ManagedBy: "weave"},
+	{Key: GasStationConfigKey, Description: "Gas station account that funds the keys weave creates", Type: ObjectSetting, ManagedBy: "weave gas-station"},
+	{Key: GasStationEncryptionOfferedKey, Description: "Whether weave offered to encrypt a plaintext gas station mnemonic", Type: BoolSetting, ManagedBy: "weave"},
+	{Key: DownloadConnectionsKey, Description: "Number of parallel range requests used for large downloads", Type: IntSetting, Min: 0},
+	{Key: DownloadBandwidthLimitKey, Description: "Download speed limit of large downloads in bytes per second", Type: IntSetting, Min: 0},
+	{Key: SnapshotProviderTypeKey, Description: "Where snapshots, state sync servers and peers come from", Type: EnumSetting, Values: []string{"polkachu", "manifest", "local"}},
+	{Key: SnapshotProviderLocationKey, Description: "Manifest or directory listing URL, or local snapshot file or directory", Type: StringSetting},
+	{Key: InsecureSkipVerifyKey, Description: "Install downloaded binaries without verifying their checksums and signatures", Type: BoolSetting},
+	{Key: CosignPublicKeyPathKey, Description: "PEM file of the cosign public key release checksum files must be signed with", Type: FileSetting},
+	{Key: MinisignPublicKeyKey, Description: "Minisign public key release checksum files must be signed with", Type: StringSetting},
+	{Key: MirrorsKey, Description: "URL prefixes and the mirrors that serve them, as prefix=mirror pairs", Type: MirrorsSetting},
+	{Key: ProxyKey, Description: "Proxy every request goes through", Type: URLSetting},
+	{Key: CABundleKey, Description: "PEM file of certificates trusted in addition to the system pool", Type: FileSetting},
+	{Key: RetryMaxAttemptsKey, Description: "Number of times a request is sent before giving up", Type: IntSetting, Min: 1},
+	{Key: RetryBaseDelayKey, Description: "Backoff after the first failed attempt, doubling with every attempt", Type: DurationSetting},
+	{Key: RetryMaxDelayKey, Description: "Longest backoff between two attempts", Type: DurationSetting},
+	{Key: RequestTimeoutKey, Description: "Deadline of an API request", Type: DurationSetting},
+	{Key: InitiaHomeKey, Description: "Default --initia-dir of the commands run with this profile", Type: PathSetting},
+	{Key: MinitiaHomeKey, Description: "Default --minitia-dir of the commands run with this profile", Type: PathSetting},
+	{Key: ValidatorMonitorWebhookKey, Description: "Webhook the validator monitor service posts alerts to", Type: URLSetting, ManagedBy: "weave initia validator monitor start"},
+	{Key: OPinitHomeKey, Description: "Default --opinit-dir of the commands run with this profile", Type: PathSetting},
+}
+
+// LookupSetting returns the known setting key belongs to, which is the setting itself or, for the sections weave
+// manages as a whole, the section that contains it
+func LookupSetting(key string) (Setting, bool) {
+	key = strings.ToLower(key)
+	for _, setting := range Settings {
+		if setting.Key == key {
+			return setting, true
+		}
+		if (setting.Type == ObjectSetting || setting.Type == MirrorsSetting) && strings.HasPrefix(key, setting.Key+".") {
+			return setting, true
+		}
+	}
+	return Setting{}, false
+}
+
+// Parse validates a value given on the command line and converts it to the value stored in the config file
+func (s Setting) Parse(value string) (interface{}, error) {
+	switch s.Type {
+	case BoolSetting:
+		parsed, err := strconv.ParseBool(value)
+		if err != nil {
+			return nil, fmt.Errorf("%s must be true or false", s.Key)
+		}
+		return parsed, nil
+	case IntSetting:
+		parsed, err := strconv.Atoi(value)
+		if err != nil || parsed < s.Min {
+			return nil, fmt.Errorf("%s must be an integer of at least %d", s.Key, s.Min)
+		}
+		return parsed, nil
+	case DurationSetting:
+		parsed, err := time.ParseDuration(value)
+		if err != nil || parsed <= 0 {
+			return nil, fmt.Errorf("%s must be a positive duration such as 30s", s.Key)
+		}
+		return parsed.String(), nil
+	case URLSetting:
+		parsed, err := url.Parse(value)
+		if err != nil || parsed.Scheme == "" || parsed.Host == "" {
+			return nil, fmt.Errorf("%s must be a URL such as http://proxy.example.com:3128", s.Key)
+		}
+		return value, nil
+	case FileSetting:
+		path, err := filepath.Abs(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid path %s: %w", value, err)
+		}
+		if info, err := os.Stat(path); err != nil {
+			return nil, fmt.Errorf("%s must be an existing file: %w", s.Key, err)
+		} else if info.IsDir() {
+			return nil, fmt.Errorf("%s must be a file, %s is a directory", s.Key, path)
+		}
+		return path, nil
+	case PathSetting:
+		path, err := filepath.Abs(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid path %s: %w", value, err)
+		}
+		return path, nil
+	case EnumSetting:
+		if !slices.Contains(s.Values, value) {
+			return nil, fmt.Errorf("%s must be one of %s", s.Key, strings.Join(s.Values, ", "))
+		}
+		return value, nil
+	case MirrorsSetting:
+		mirrors, err := parseMirrors(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s: %w", s.Key, err)
+		}
+		if len(mirrors) == 0 {
+			return nil, fmt.Errorf("%s must have at least one prefix=mirror pair", s.Key)
+		}
+		return mirrors, nil
+	case ObjectSetting:
+		return nil, fmt.Errorf("%s cannot be set from the command line", s.Key)
+	default:
+		return value, nil
+	}
+}
+
+// SetSetting validates value against the known setting key and writes it to the config file
+func SetSetting(key, value string) error {
+	key = strings.ToLower(key)
+	setting, ok := LookupSetting(key)
+	if !ok {
+		return fmt.Errorf("unknown setting %s, run `weave config list` to see the known settings", key)
+	}
+	if setting.ManagedBy != "" {
+		return fmt.Errorf("%s is managed by `%s` and cannot be changed with `weave config`", setting.Key, setting.ManagedBy)
+	}
+	if setting.Key != key {
+		return fmt.Errorf("%s is part of %s, set %s as a whole instead", key, setting.Key, setting.Key)
+	}
+
+	parsed, err := setting.Parse(value)
+	if err != nil {
+		return err
+	}
+	return SetConfig(key, parsed)
+}
+
+// UnsetSetting removes key from the config file so that its default applies again. Unknown keys can be removed as well,
+// such as the ones left by an older version of weave.
+func UnsetSetting(key string) error {
+	key = strings.ToLower(key)
+	if setting, ok := LookupSetting(key); ok {
+		if setting.ManagedBy != "" {
+			return fmt.Errorf("%s is managed by `%s` and cannot be changed with `weave config`", setting.Key, setting.ManagedBy)
+		}
+		if setting.Key != key {
+			return fmt.Errorf("%s is part of %s, unset %s as a whole instead", key, setting.Key, setting.Key)
+		}
+	}
+	return UnsetConfig(key)
+}
+
+// ListConfig returns the value of every known setting, nil for the unset ones, along with every other value found in
+// the config. Nested sections are flattened into dotted keys and secrets are replaced with MaskedValue.
+func ListConfig() map[string]interface{} {
+	entries := make(map[string]interface{})
+	for _, setting := range Settings {
+		if setting.Type != ObjectSetting {
+			entries[setting.Key] = nil
+		}
+	}
+	for key, value := range viper.AllSettings() {
+		flattenConfig(key, value, entries)
+	}
+	return entries
+}
+
+func flattenConfig(key string, value interface{}, entries map[string]interface{}) {
+	if setting, known := LookupSetting(key); known && setting.Key == key && setting.Type == MirrorsSetting {
+		// AllSettings splits the prefixes at their dots, unlike Get
+		entries[key] = viper.Get(key)
+		return
+	}
+	section, ok := value.(map[string]interface{})
+	if !ok || IsSecretKey(key) {
+		entries[key] = MaskSecrets(key, value)
+		return
+	}
+	for name, nested := range section {
+		flattenConfig(key+"."+name, nested, entries)
+	}
+}
+
+// IsSecretKey reports whether the value of key holds a mnemonic, a passphrase or any of their encrypted forms
+func IsSecretKey(key string) bool {
+	segments := strings.Split(strings.ToLower(key), ".")
+	for _, segment := range segments {
+		if strings.HasPrefix(segment, "encrypted_") {
+			return true
+		}
+	}
+	last := segments[len(segments)-1]
+	for _, marker := range []string{"mnemonic", "passphrase", "private_key", "secret", "token", "webhook"} {
+		if strings.Contains(last, marker) {
+			return true
+		}
+	}
+	return false
+}
+
+// MaskSecrets returns value with every secret it holds, including the ones in nested sections, replaced with MaskedValue
+func MaskSecrets(key string, value interface{}) interface{} {
+	if value == nil {
+		return nil
+	}
+	if IsSecretKey(key) {
+		return MaskedValue
+	}
+	section, ok := value.(map[string]interface{})
+	if !ok {
+		return value
+	}
+	masked := make(map[string]interface{}, len(section))
+	for name, nested := range section {
+		masked[name] = MaskSecrets(key+"."+name, nested)
+	}
+	return masked
+}
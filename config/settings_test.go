@@ -0,0 +1,123 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/initia-labs/weave/common"
+)
+
+// setupConfigFile starts from a fresh viper and a config file holding content
+func setupConfigFile(t *testing.T, content string) string {
+	viper.Reset()
+	t.Cleanup(viper.Reset)
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv(PassphraseEnvVar, "")
+	SetGasStationPassphrase("")
+
+	configPath := filepath.Join(home, common.WeaveConfigFile)
+	assert.NoError(t, os.MkdirAll(filepath.Dir(configPath), os.ModePerm))
+	assert.NoError(t, os.WriteFile(configPath, []byte(content), 0o600))
+	assert.NoError(t, InitializeConfig())
+	return configPath
+}
+
+func TestSetSetting(t *testing.T) {
+	configPath := setupConfigFile(t, DefaultConfigTemplate)
+	caBundle := filepath.Join(t.TempDir(), "ca.pem")
+	assert.NoError(t, os.WriteFile(caBundle, []byte("pem"), 0o600))
+
+	assert.NoError(t, SetSetting(DownloadConnectionsKey, "4"))
+	assert.NoError(t, SetSetting(RetryBaseDelayKey, "1500ms"))
+	assert.NoError(t, SetSetting(SnapshotProviderTypeKey, "manifest"))
+	assert.NoError(t, SetSetting(CABundleKey, caBundle))
+	assert.NoError(t, SetSetting(MirrorsKey, "https://api.github.com=https://gh.example.com"))
+	assert.Equal(t, 4, viper.GetInt(DownloadConnectionsKey))
+	assert.Equal(t, "1.5s", viper.GetString(RetryBaseDelayKey))
+	assert.Equal(t, map[string]string{"https://api.github.com": "https://gh.example.com"}, viper.GetStringMapString(MirrorsKey))
+
+	assert.Error(t, SetSetting(DownloadConnectionsKey, "-1"))
+	assert.Error(t, SetSetting(RetryMaxAttemptsKey, "0"))
+	assert.Error(t, SetSetting(RetryBaseDelayKey, "soon"))
+	assert.Error(t, SetSetting(SnapshotProviderTypeKey, "ftp"))
+	assert.Error(t, SetSetting(ProxyKey, "proxy.example.com"))
+	assert.Error(t, SetSetting(CABundleKey, filepath.Join(t.TempDir(), "missing.pem")))
+	assert.Error(t, SetSetting("common.unknown", "value"), "unknown keys are rejected")
+	assert.Error(t, SetSetting(GasStationConfigKey+".mnemonic", testMnemonic), "the gas station is managed by its own commands")
+	assert.Error(t, SetSetting(ConfigVersionKey, "7"))
+
+	// Values of flags and the environment stay out of the file
+	viper.Set(InsecureSkipVerifyKey, true)
+	assert.NoError(t, SetSetting(ProxyKey, "http://proxy.example.com:3128"))
+	settings, err := readConfigFile()
+	assert.NoError(t, err)
+	assert.NotContains(t, settings["common"], "verify")
+
+	content, err := os.ReadFile(configPath)
+	assert.NoError(t, err)
+	assert.Contains(t, string(content), `"connections": 4`)
+	assert.Contains(t, string(content), `"proxy": "http://proxy.example.com:3128"`)
+}
+
+func TestUnsetSetting(t *testing.T) {
+	setupConfigFile(t, `{"common": {"network": {"proxy": "http://proxy.example.com:3128"}, "legacy": true}}`)
+
+	assert.NoError(t, UnsetSetting(ProxyKey))
+	assert.Nil(t, viper.Get(ProxyKey))
+	assert.Error(t, UnsetSetting(ProxyKey), "the key is no longer set")
+
+	assert.NoError(t, UnsetSetting("common.legacy"), "unknown keys can be removed")
+	settings, err := readConfigFile()
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"config_version": float64(CurrentConfigVersion())}, settings, "empty sections are removed")
+
+	assert.NoError(t, SetSetting(ProxyKey, "http://other.example.com:3128"))
+	assert.Equal(t, "http://other.example.com:3128", viper.GetString(ProxyKey))
+	assert.Error(t, UnsetSetting(GasStationConfigKey))
+}
+
+func TestListConfig(t *testing.T) {
+	setupConfigFile(t, `{"common": {"download": {"connections": 8}}}`)
+	gasKey, err := RecoverGasStationKey(testMnemonic)
+	assert.NoError(t, err)
+	assert.NoError(t, SaveGasStationKey(gasKey))
+	assert.NoError(t, SetSetting(MirrorsKey, "https://api.github.com=https://gh.example.com"))
+
+	entries := ListConfig()
+	assert.EqualValues(t, 8, entries[DownloadConnectionsKey])
+	assert.Contains(t, entries, ProxyKey)
+	assert.Nil(t, entries[ProxyKey], "known settings are listed when unset")
+	assert.Equal(t, map[string]interface{}{"https://api.github.com": "https://gh.example.com"}, entries[MirrorsKey])
+	assert.Equal(t, gasKey.InitiaAddress, entries["common.gas_station.initia_address"])
+	assert.Equal(t, MaskedValue, entries["common.gas_station.mnemonic"])
+
+	assert.NoError(t, LockGasStationKey("passphrase"))
+	entries = ListConfig()
+	assert.Equal(t, MaskedValue, entries["common.gas_station.encrypted_mnemonic"])
+	assert.NotContains(t, entries, "common.gas_station.encrypted_mnemonic.ciphertext")
+}
+
+func TestMaskSecrets(t *testing.T) {
+	assert.True(t, IsSecretKey("common.gas_station.mnemonic"))
+	assert.True(t, IsSecretKey("common.gas_station.derivation.bip39_passphrase"))
+	assert.True(t, IsSecretKey("common.gas_station.encrypted_mnemonic.salt"))
+	assert.False(t, IsSecretKey("common.gas_station.initia_address"))
+	assert.True(t, IsSecretKey(ValidatorMonitorWebhookKey))
+	assert.False(t, IsSecretKey(ProxyKey))
+
+	masked := MaskSecrets(GasStationConfigKey, map[string]interface{}{
+		"initia_address": "init1",
+		"mnemonic":       testMnemonic,
+		"derivation":     map[string]interface{}{"hd_path": "m/44'/60'/0'/0/0", "bip39_passphrase": "secret"},
+	})
+	assert.Equal(t, map[string]interface{}{
+		"initia_address": "init1",
+		"mnemonic":       MaskedValue,
+		"derivation":     map[string]interface{}{"hd_path": "m/44'/60'/0'/0/0", "bip39_passphrase": MaskedValue},
+	}, masked)
+}
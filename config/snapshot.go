@@ -0,0 +1,18 @@
+package config
+
+import (
+	"github.com/spf13/viper"
+)
+
+const (
+	// SnapshotProviderTypeKey selects where snapshots, state sync servers and peers come from: polkachu, manifest or local
+	SnapshotProviderTypeKey = "common.snapshot_provider.type"
+	// SnapshotProviderLocationKey is the manifest or directory listing URL, or the local snapshot file or directory
+	SnapshotProviderLocationKey = "common.snapshot_provider.location"
+)
+
+// GetSnapshotProvider returns the configured snapshot provider type and location.
+// An empty type means that none is configured.
+func GetSnapshotProvider() (string, string) {
+	return viper.GetString(SnapshotProviderTypeKey), viper.GetString(SnapshotProviderLocationKey)
+}
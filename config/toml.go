@@ -4,6 +4,7 @@ import (
 	"bufio"
 	"fmt"
 	"os"
+	"path/filepath"
 	"strings"
 
 	"github.com/initia-labs/weave/common"
@@ -61,15 +62,72 @@ func UpdateTomlValue(filePath, key, value string) error {
 		return fmt.Errorf("error reading file: %w", err)
 	}
 
-	// Write the modified lines back to the file
-	err = os.WriteFile(filePath, []byte(strings.Join(updatedLines, "\n")), 0644)
+	// Write the modified lines to a temporary file and move it into place, so that the file is never left half written.
+	// A symlinked file is replaced at its target, with the mode it already had.
+	targetPath, err := filepath.EvalSymlinks(filePath)
 	if err != nil {
+		return fmt.Errorf("error resolving file: %w", err)
+	}
+	info, err := os.Stat(targetPath)
+	if err != nil {
+		return fmt.Errorf("error reading file: %w", err)
+	}
+	tmpPath := targetPath + ".tmp"
+	if err = os.WriteFile(tmpPath, []byte(strings.Join(updatedLines, "\n")), info.Mode().Perm()); err != nil {
+		return fmt.Errorf("error writing to file: %w", err)
+	}
+	// The mode given to WriteFile is reduced by the umask
+	if err = os.Chmod(tmpPath, info.Mode().Perm()); err != nil {
+		_ = os.Remove(tmpPath)
+		return fmt.Errorf("error writing to file: %w", err)
+	}
+	if err = os.Rename(tmpPath, targetPath); err != nil {
+		_ = os.Remove(tmpPath)
 		return fmt.Errorf("error writing to file: %w", err)
 	}
 
 	return nil
 }
 
+// GetTomlValue reads the value of key from a TOML file, using the same key format as UpdateTomlValue.
+// Quotes around string values are removed.
+func GetTomlValue(filePath, key string) (string, error) {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return "", fmt.Errorf("error opening file: %w", err)
+	}
+	defer file.Close()
+
+	var section, field string
+	parts := strings.SplitN(key, ".", 2)
+	if len(parts) == 2 {
+		section = parts[0]
+		field = parts[1]
+	} else {
+		field = key
+	}
+
+	var currentSection string
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		trimmedLine := strings.TrimSpace(scanner.Text())
+		if isSectionHeader(trimmedLine) {
+			currentSection = getSectionName(trimmedLine)
+			continue
+		}
+
+		if currentSection == section && shouldModifyField(true, currentSection, field, trimmedLine) {
+			value := strings.TrimSpace(strings.SplitN(trimmedLine, "=", 2)[1])
+			return strings.Trim(value, `"`), nil
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return "", fmt.Errorf("error reading file: %w", err)
+	}
+
+	return "", fmt.Errorf("key %s not found in %s", key, filePath)
+}
+
 // isSectionHeader checks if a line is a section header (e.g., [api]).
 func isSectionHeader(line string) bool {
 	return strings.HasPrefix(line, "[") && strings.HasSuffix(line, "]")
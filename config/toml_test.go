@@ -0,0 +1,78 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetTomlValue(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.toml")
+	content := `moniker = "node"
+
+[rpc]
+laddr = "tcp://127.0.0.1:26657"
+
+[p2p]
+laddr = "tcp://0.0.0.0:26656"
+`
+	assert.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+
+	value, err := GetTomlValue(path, "moniker")
+	assert.NoError(t, err)
+	assert.Equal(t, "node", value)
+
+	value, err = GetTomlValue(path, "rpc.laddr")
+	assert.NoError(t, err)
+	assert.Equal(t, "tcp://127.0.0.1:26657", value)
+
+	value, err = GetTomlValue(path, "p2p.laddr")
+	assert.NoError(t, err)
+	assert.Equal(t, "tcp://0.0.0.0:26656", value)
+
+	_, err = GetTomlValue(path, "api.enable")
+	assert.Error(t, err)
+}
+
+func TestUpdateTomlValue(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.toml")
+	content := `[p2p]
+seeds = ""
+persistent_peers = "a@1.2.3.4:26656"
+`
+	assert.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+
+	assert.NoError(t, UpdateTomlValue(path, "p2p.persistent_peers", "a@1.2.3.4:26656,b@5.6.7.8:26656"))
+	value, err := GetTomlValue(path, "p2p.persistent_peers")
+	assert.NoError(t, err)
+	assert.Equal(t, "a@1.2.3.4:26656,b@5.6.7.8:26656", value)
+
+	value, err = GetTomlValue(path, "p2p.seeds")
+	assert.NoError(t, err)
+	assert.Empty(t, value)
+	assert.NoFileExists(t, path+".tmp")
+}
+
+func TestUpdateTomlValue_KeepsModeAndSymlink(t *testing.T) {
+	dir := t.TempDir()
+	target := filepath.Join(dir, "shared", "app.toml")
+	assert.NoError(t, os.MkdirAll(filepath.Dir(target), 0o755))
+	assert.NoError(t, os.WriteFile(target, []byte("[api]\nenable = \"false\"\n"), 0o600))
+	link := filepath.Join(dir, "app.toml")
+	assert.NoError(t, os.Symlink(target, link))
+
+	assert.NoError(t, UpdateTomlValue(link, "api.enable", "true"))
+
+	linkInfo, err := os.Lstat(link)
+	assert.NoError(t, err)
+	assert.NotZero(t, linkInfo.Mode()&os.ModeSymlink, "the symlink is kept")
+	targetInfo, err := os.Stat(target)
+	assert.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o600), targetInfo.Mode().Perm())
+
+	value, err := GetTomlValue(target, "api.enable")
+	assert.NoError(t, err)
+	assert.Equal(t, "true", value)
+}
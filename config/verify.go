@@ -0,0 +1,25 @@
+package config
+
+import (
+	"github.com/spf13/viper"
+
+	"github.com/initia-labs/weave/client"
+)
+
+const (
+	// InsecureSkipVerifyKey turns off the verification of downloaded binaries. It is also set by --insecure-skip-verify.
+	InsecureSkipVerifyKey = "common.verify.insecure_skip_verify"
+	// CosignPublicKeyPathKey is the PEM file of the cosign public key release checksum files must be signed with
+	CosignPublicKeyPathKey = "common.verify.cosign_public_key"
+	// MinisignPublicKeyKey is the minisign public key release checksum files must be signed with
+	MinisignPublicKeyKey = "common.verify.minisign_public_key"
+)
+
+// GetReleaseVerifyOptions returns the configured checks for downloaded binaries
+func GetReleaseVerifyOptions() client.VerifyOptions {
+	return client.VerifyOptions{
+		InsecureSkipVerify:  viper.GetBool(InsecureSkipVerifyKey),
+		CosignPublicKeyPath: viper.GetString(CosignPublicKeyPathKey),
+		MinisignPublicKey:   viper.GetString(MinisignPublicKeyKey),
+	}
+}
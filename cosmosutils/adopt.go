@@ -0,0 +1,98 @@
+package cosmosutils
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/initia-labs/weave/io"
+)
+
+// FindNodeBinary looks for binaryName among the cosmovisor binaries of home, then in PATH
+func FindNodeBinary(home, binaryName string) (string, error) {
+	for _, candidate := range []string{
+		filepath.Join(home, "cosmovisor", "current", "bin", binaryName),
+		filepath.Join(home, "cosmovisor", "genesis", "bin", binaryName),
+	} {
+		if io.FileOrFolderExists(candidate) {
+			return candidate, nil
+		}
+	}
+	binaryPath, err := exec.LookPath(binaryName)
+	if err != nil {
+		return "", fmt.Errorf("could not find %s in %s/cosmovisor or in PATH", binaryName, home)
+	}
+	return binaryPath, nil
+}
+
+// DetectBinaryVersion returns the release version binaryPath reports, such as v1.0.0
+func DetectBinaryVersion(binaryPath string) (string, error) {
+	output, err := stagedBinaryVersion(binaryPath, filepath.Dir(binaryPath))
+	if err != nil {
+		return "", err
+	}
+	return parseBinaryVersion(output)
+}
+
+func parseBinaryVersion(output string) (string, error) {
+	output = strings.TrimSpace(output)
+	version := normalizeVersion(output)
+	if !semverPattern.MatchString(version) {
+		// Binaries built without a tag report the version without the v prefix
+		version = normalizeVersion("v" + output)
+	}
+	if !semverPattern.MatchString(version) {
+		return "", fmt.Errorf("unexpected version output: %q", output)
+	}
+	return version, nil
+}
+
+// DetectMinitiaVM returns the VM of the rollup at home, from the long version output of binaryPath or else from the modules in its genesis
+func DetectMinitiaVM(binaryPath, home string) (string, error) {
+	cmd := exec.Command(binaryPath, "version", "--long")
+	env, err := io.WithLibraryPathEnv(os.Environ(), filepath.Dir(binaryPath))
+	if err != nil {
+		return "", err
+	}
+	cmd.Env = env
+	if output, err := cmd.CombinedOutput(); err == nil {
+		for _, line := range strings.Split(string(output), "\n") {
+			name, value, found := strings.Cut(line, ":")
+			if found && strings.TrimSpace(name) == "name" {
+				if vm := detectMinitiaVM(value); vm != "" {
+					return vm, nil
+				}
+			}
+		}
+	}
+
+	return DetectMinitiaVMFromGenesisFile(home)
+}
+
+// DetectMinitiaVMFromGenesisFile detects the VM of a rollup from the modules in the genesis file under home
+func DetectMinitiaVMFromGenesisFile(home string) (string, error) {
+	genesisPath := filepath.Join(home, "config", "genesis.json")
+	data, err := os.ReadFile(genesisPath)
+	if err != nil {
+		return "", fmt.Errorf("failed to read genesis file: %w", err)
+	}
+	return detectMinitiaVMFromGenesis(data)
+}
+
+func detectMinitiaVMFromGenesis(data []byte) (string, error) {
+	var genesis struct {
+		AppState map[string]json.RawMessage `json:"app_state"`
+	}
+	if err := json.Unmarshal(data, &genesis); err != nil {
+		return "", fmt.Errorf("failed to parse genesis file: %w", err)
+	}
+	for _, vm := range []string{"evm", "move", "wasm"} {
+		if _, ok := genesis.AppState[vm]; ok {
+			return vm, nil
+		}
+	}
+	return "", fmt.Errorf("could not detect the VM from the genesis modules")
+}
@@ -0,0 +1,76 @@
+package cosmosutils
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFindNodeBinary(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("PATH", t.TempDir())
+
+	_, err := FindNodeBinary(home, "initiad")
+	assert.Error(t, err)
+
+	genesisBinary := filepath.Join(home, "cosmovisor", "genesis", "bin", "initiad")
+	require.NoError(t, os.MkdirAll(filepath.Dir(genesisBinary), 0755))
+	require.NoError(t, os.WriteFile(genesisBinary, []byte("#!/bin/sh\n"), 0755))
+	binaryPath, err := FindNodeBinary(home, "initiad")
+	require.NoError(t, err)
+	assert.Equal(t, genesisBinary, binaryPath)
+
+	currentBinary := filepath.Join(home, "cosmovisor", "current", "bin", "initiad")
+	require.NoError(t, os.MkdirAll(filepath.Dir(currentBinary), 0755))
+	require.NoError(t, os.WriteFile(currentBinary, []byte("#!/bin/sh\n"), 0755))
+	binaryPath, err = FindNodeBinary(home, "initiad")
+	require.NoError(t, err)
+	assert.Equal(t, currentBinary, binaryPath)
+}
+
+func TestParseBinaryVersion(t *testing.T) {
+	tests := []struct {
+		output   string
+		expected string
+	}{
+		{"v1.0.0\n", "v1.0.0"},
+		{"1.1.2", "v1.1.2"},
+		{"v0.7.0-rc.1", "v0.7.0-rc.1"},
+	}
+	for _, tt := range tests {
+		version, err := parseBinaryVersion(tt.output)
+		require.NoError(t, err, tt.output)
+		assert.Equal(t, tt.expected, version)
+	}
+
+	_, err := parseBinaryVersion("dev")
+	assert.Error(t, err)
+}
+
+func TestDetectMinitiaVMFromGenesis(t *testing.T) {
+	vm, err := detectMinitiaVMFromGenesis([]byte(`{"chain_id":"rollup-1","app_state":{"auth":{},"evm":{}}}`))
+	require.NoError(t, err)
+	assert.Equal(t, "evm", vm)
+
+	vm, err = detectMinitiaVMFromGenesis([]byte(`{"app_state":{"move":{}}}`))
+	require.NoError(t, err)
+	assert.Equal(t, "move", vm)
+
+	_, err = detectMinitiaVMFromGenesis([]byte(`{"app_state":{"bank":{}}}`))
+	assert.Error(t, err)
+}
+
+func TestDetectMinitiaVMFromGenesisFile(t *testing.T) {
+	home := t.TempDir()
+	_, err := DetectMinitiaVMFromGenesisFile(home)
+	assert.Error(t, err)
+
+	require.NoError(t, os.MkdirAll(filepath.Join(home, "config"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(home, "config", "genesis.json"), []byte(`{"app_state":{"evm":{}}}`), 0644))
+	vm, err := DetectMinitiaVMFromGenesisFile(home)
+	require.NoError(t, err)
+	assert.Equal(t, "evm", vm)
+}
@@ -1,6 +1,7 @@
 package cosmosutils
 
 import (
+	"encoding/json"
 	"fmt"
 	"math"
 	"os"
@@ -13,6 +14,7 @@ import (
 
 	"github.com/initia-labs/weave/client"
 	"github.com/initia-labs/weave/common"
+	"github.com/initia-labs/weave/config"
 	"github.com/initia-labs/weave/io"
 )
 
@@ -72,12 +74,62 @@ func fetchReleases(url string) ([]BinaryRelease, error) {
 	var releases []BinaryRelease
 	_, err := httpClient.Get(url, "", nil, &releases)
 	if err != nil {
-		return nil, fmt.Errorf("failed to fetch releases: %v", err)
+		// Offline installs read the listing from an installed bundle instead
+		cached, cacheErr := io.ReadBundleCache(url)
+		if cacheErr != nil {
+			return nil, fmt.Errorf("failed to fetch releases: %v", err)
+		}
+		if err := json.Unmarshal(cached, &releases); err != nil {
+			return nil, fmt.Errorf("failed to parse cached releases of %s: %v", url, err)
+		}
 	}
 
 	return filterPreReleases(releases), nil
 }
 
+// MinitiaReleaseAPI returns the GitHub releases endpoint of the minitiad binary for vm
+func MinitiaReleaseAPI(vm string) string {
+	return fmt.Sprintf("https://api.github.com/repos/initia-labs/mini%s/releases", vm)
+}
+
+// GetReleaseDownloadURL returns the version and the download URL for this platform of a release listed at releaseAPI.
+// The latest stable release is used when version is empty.
+func GetReleaseDownloadURL(releaseAPI, version string) (string, string, error) {
+	releases, err := fetchReleases(releaseAPI)
+	if err != nil {
+		return "", "", err
+	}
+	if version == "" {
+		return getLatestVersionFromReleases(releases)
+	}
+	versions, err := mapReleasesToVersions(releases)
+	if err != nil {
+		return "", "", err
+	}
+	url, ok := versions[version]
+	if !ok {
+		return "", "", fmt.Errorf("release %s not found at %s", version, releaseAPI)
+	}
+	return version, url, nil
+}
+
+// ReleaseListing returns the release listing at releaseAPI narrowed down to versions, in the form fetchReleases reads
+func ReleaseListing(releaseAPI string, versions []string) ([]byte, error) {
+	releases, err := fetchReleases(releaseAPI)
+	if err != nil {
+		return nil, err
+	}
+	listing := make([]BinaryRelease, 0, len(versions))
+	for _, release := range releases {
+		for _, version := range versions {
+			if release.TagName == version {
+				listing = append(listing, release)
+			}
+		}
+	}
+	return json.Marshal(listing)
+}
+
 func mapReleasesToVersions(releases []BinaryRelease) (BinaryVersionWithDownloadURL, error) {
 	versions := make(BinaryVersionWithDownloadURL)
 	goos, arch, err := getOSArch()
@@ -197,8 +249,7 @@ func getLatestVersionFromReleases(releases []BinaryRelease) (string, string, err
 }
 
 func GetLatestMinitiaVersion(vm string) (string, string, error) {
-	url := fmt.Sprintf("https://api.github.com/repos/initia-labs/mini%s/releases", vm)
-	releases, err := fetchReleases(url)
+	releases, err := fetchReleases(MinitiaReleaseAPI(vm))
 	if err != nil {
 		return "", "", err
 	}
@@ -225,7 +276,7 @@ func GetMinitiadBinaryUrlFromLcd(httpClient *client.HTTPClient, rest string) (vm
 		return "", "", "", fmt.Errorf("could not detect VM type from version string: %s", rawVersion)
 	}
 
-	url, err = getMinitiadBinaryURL(vm, version)
+	url, err = GetMinitiadBinaryURL(vm, version)
 	if err != nil {
 		return "", "", "", err
 	}
@@ -247,7 +298,8 @@ func detectMinitiaVM(versionString string) string {
 	}
 }
 
-func getMinitiadBinaryURL(vm, version string) (string, error) {
+// GetMinitiadBinaryURL returns the release tarball of minitiad for vm and version on the current platform
+func GetMinitiadBinaryURL(vm, version string) (string, error) {
 	goos, arch, err := getOSArch()
 	if err != nil {
 		return "", err
@@ -304,7 +356,7 @@ func EnsureMinitiadBinary(vm, version, url string) (string, error) {
 			return "", fmt.Errorf("failed to create version directory: %v", err)
 		}
 		tarballPath := filepath.Join(userHome, common.WeaveDataDirectory, "minitia.tar.gz")
-		if err := io.DownloadAndExtractTarGz(url, tarballPath, extractedPath); err != nil {
+		if err := io.DownloadAndExtractTarGz(url, tarballPath, extractedPath, config.GetReleaseVerifyOptions()); err != nil {
 			return "", fmt.Errorf("failed to download and extract minitiad binary: %v", err)
 		}
 		binaryDir, err = FindBinaryDir(extractedPath, "minitiad")
@@ -328,7 +380,7 @@ func EnsureMinitiadBinary(vm, version, url string) (string, error) {
 }
 
 func GetLatestOPInitBotVersion() (string, string, error) {
-	releases, err := fetchReleases("https://api.github.com/repos/initia-labs/opinit-bots/releases")
+	releases, err := fetchReleases(OPInitBotsReleaseAPI)
 	if err != nil {
 		return "", "", err
 	}
@@ -470,7 +522,7 @@ func padVersionParts(version string) []string {
 }
 
 func GetOPInitVersions() (BinaryVersionWithDownloadURL, string, error) {
-	versions, err := ListBinaryReleases("https://api.github.com/repos/initia-labs/opinit-bots/releases")
+	versions, err := ListBinaryReleases(OPInitBotsReleaseAPI)
 	if err != nil {
 		return nil, "", err
 	}
@@ -502,7 +554,7 @@ func GetInitiaBinaryUrlFromLcd(httpClient *client.HTTPClient, rest string) (stri
 	}
 
 	version := normalizeVersion(result.ApplicationVersion.Version)
-	url, err := getBinaryURL(version)
+	url, err := GetInitiaBinaryURL(version)
 	if err != nil {
 		return "", "", err
 	}
@@ -510,7 +562,8 @@ func GetInitiaBinaryUrlFromLcd(httpClient *client.HTTPClient, rest string) (stri
 	return version, url, nil
 }
 
-func getBinaryURL(version string) (string, error) {
+// GetInitiaBinaryURL returns the release tarball of initiad for version on the current platform
+func GetInitiaBinaryURL(version string) (string, error) {
 	goos := runtime.GOOS
 	goarch := runtime.GOARCH
 
@@ -578,7 +631,7 @@ func InstallInitiaBinary(version, url, binaryPath string) error {
 			}
 		}
 
-		if err = io.DownloadAndExtractTarGz(url, tarballPath, extractedPath); err != nil {
+		if err = io.DownloadAndExtractTarGz(url, tarballPath, extractedPath, config.GetReleaseVerifyOptions()); err != nil {
 			return fmt.Errorf("failed to download and extract binary: %v", err)
 		}
 
@@ -633,7 +686,7 @@ func InstallCosmovisor(version string) (string, error) {
 		}
 
 		// Download and extract the tarball
-		if err = io.DownloadAndExtractTarGz(url, tarballPath, extractedPath); err != nil {
+		if err = io.DownloadAndExtractTarGz(url, tarballPath, extractedPath, config.GetReleaseVerifyOptions()); err != nil {
 			return "", fmt.Errorf("failed to download and extract cosmovisor binary: %v", err)
 		}
 
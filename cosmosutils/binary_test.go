@@ -2,11 +2,16 @@ package cosmosutils
 
 import (
 	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"path/filepath"
 	"reflect"
 	"strings"
 	"testing"
+
+	"github.com/initia-labs/weave/common"
+	"github.com/initia-labs/weave/io"
 )
 
 func TestCompareSemVer(t *testing.T) {
@@ -437,3 +442,84 @@ func TestEnsureMinitiadBinary_NestedLayout(t *testing.T) {
 		t.Errorf("got %q, want %q", binaryPath, want)
 	}
 }
+
+func releasesServer(t *testing.T) *httptest.Server {
+	goos, arch, err := getOSArch()
+	if err != nil {
+		t.Skip(err)
+	}
+	asset := func(version string) string {
+		return fmt.Sprintf(`{"browser_download_url":"https://example.com/%s/opinitd_%s_%s_%s.tar.gz"}`, version, version, goos, arch)
+	}
+	body := fmt.Sprintf(`[{"tag_name":"v1.1.0","assets":[%s]},{"tag_name":"v1.2.0-rc.1","prerelease":true,"assets":[%s]},{"tag_name":"v1.0.0","assets":[%s]}]`,
+		asset("v1.1.0"), asset("v1.2.0-rc.1"), asset("v1.0.0"))
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(body))
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestGetReleaseDownloadURL(t *testing.T) {
+	server := releasesServer(t)
+
+	version, url, err := GetReleaseDownloadURL(server.URL, "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if version != "v1.1.0" || !strings.Contains(url, "/v1.1.0/") {
+		t.Errorf("got %q %q, want the latest stable release v1.1.0", version, url)
+	}
+
+	version, url, err = GetReleaseDownloadURL(server.URL, "v1.0.0")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if version != "v1.0.0" || !strings.Contains(url, "/v1.0.0/") {
+		t.Errorf("got %q %q, want v1.0.0", version, url)
+	}
+
+	if _, _, err = GetReleaseDownloadURL(server.URL, "v9.9.9"); err == nil {
+		t.Error("expected an error for a missing release")
+	}
+}
+
+func TestReleaseListing_BundleFallback(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	server := releasesServer(t)
+
+	listing, err := ReleaseListing(server.URL, []string{"v1.0.0"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// The listing stands in for the release API once it cannot be reached
+	offline := httptest.NewServer(http.NotFoundHandler())
+	defer offline.Close()
+	cachePath, err := bundleCachePath(offline.URL)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(cachePath), 0o755); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := os.WriteFile(cachePath, listing, 0o644); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	version, url, err := GetReleaseDownloadURL(offline.URL, "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if version != "v1.0.0" || !strings.Contains(url, "/v1.0.0/") {
+		t.Errorf("got %q %q, want the bundled release v1.0.0", version, url)
+	}
+}
+
+func bundleCachePath(url string) (string, error) {
+	userHome, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(userHome, common.WeaveDataDirectory, io.BundleCacheDirectory, io.BundleCacheFilename(url)), nil
+}
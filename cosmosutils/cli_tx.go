@@ -5,9 +5,11 @@ import (
 	"fmt"
 	"os"
 	"os/exec"
+	"strings"
 	"time"
 
 	"github.com/initia-labs/weave/client"
+	"github.com/initia-labs/weave/crypto"
 )
 
 const (
@@ -120,8 +122,8 @@ func NewInitiadTxExecutor(rest string) (*InitiadTxExecutor, error) {
 	}, nil
 }
 
-func (te *InitiadTxExecutor) BroadcastMsgSend(senderMnemonic, recipientAddress, amount, gasPrices, rpc, chainId string, coinType int) (*InitiadTxResponse, error) {
-	return broadcastMsgSend(te.binaryPath, senderMnemonic, recipientAddress, amount, gasPrices, rpc, chainId, coinType)
+func (te *InitiadTxExecutor) BroadcastMsgSend(signer crypto.Signer, recipientAddress, amount, gasPrices, rpc, chainId string) (*InitiadTxResponse, error) {
+	return broadcastMsgSend(te.binaryPath, signer, recipientAddress, amount, gasPrices, rpc, chainId)
 }
 
 func NewMinitiadTxExecutor(rest string) (*MinitiadTxExecutor, error) {
@@ -138,48 +140,47 @@ func NewMinitiadTxExecutor(rest string) (*MinitiadTxExecutor, error) {
 	return &MinitiadTxExecutor{binaryPath: binaryPath}, nil
 }
 
-func (te *MinitiadTxExecutor) BroadcastMsgSend(senderMnemonic, recipientAddress, amount, gasPrices, rpc, chainId string, coinType int) (*InitiadTxResponse, error) {
-	return broadcastMsgSend(te.binaryPath, senderMnemonic, recipientAddress, amount, gasPrices, rpc, chainId, coinType)
+func (te *MinitiadTxExecutor) BroadcastMsgSend(signer crypto.Signer, recipientAddress, amount, gasPrices, rpc, chainId string) (*InitiadTxResponse, error) {
+	return broadcastMsgSend(te.binaryPath, signer, recipientAddress, amount, gasPrices, rpc, chainId)
 }
 
-func broadcastMsgSend(binaryPath, senderMnemonic, recipientAddress, amount, gasPrices, rpc, chainId string, coinType int) (*InitiadTxResponse, error) {
-	if coinType == 0 {
-		return nil, fmt.Errorf("coin type must be explicitly provided (60 or 118)")
-	}
-	
-	_, err := RecoverKeyFromMnemonicWithCoinType(binaryPath, TmpKeyName, senderMnemonic, coinType)
+func broadcastMsgSend(binaryPath string, signer crypto.Signer, recipientAddress, amount, gasPrices, rpc, chainId string) (*InitiadTxResponse, error) {
+	return broadcastWithSigner(binaryPath, signer, []string{"tx", "bank", "send", TmpKeyName, recipientAddress, amount}, gasPrices, rpc, chainId)
+}
+
+// broadcastWithSigner builds the transaction of the `tx` command args for the signer, signs it in process and broadcasts it
+func broadcastWithSigner(binaryPath string, signer crypto.Signer, args []string, gasPrices, rpc, chainId string) (*InitiadTxResponse, error) {
+	keyInfo, err := AddSignerKey(binaryPath, TmpKeyName, signer)
 	if err != nil {
-		return nil, fmt.Errorf("failed to recover gas station key: %v", err)
+		return nil, fmt.Errorf("failed to add signer key: %v", err)
 	}
 	defer func() {
 		_ = DeleteKey(binaryPath, TmpKeyName)
 	}()
 
-	cmd := exec.Command(binaryPath, "tx", "bank", "send", TmpKeyName, recipientAddress, amount, "--from",
-		TmpKeyName, "--chain-id", chainId, "--gas", "auto", "--gas-adjustment", DefaultGasAdjustment,
-		"--gas-prices", gasPrices, "--node", rpc, "--output", "json", "--keyring-backend", "test", "-y")
+	args = append(args, "--from", TmpKeyName, "--chain-id", chainId, "--gas", "auto", "--gas-adjustment", DefaultGasAdjustment,
+		"--gas-prices", gasPrices, "--node", rpc, "--output", "json", "--keyring-backend", "test", "--generate-only")
+	cmd := exec.Command(binaryPath, args...)
 
 	outputBytes, err := cmd.Output()
 	if err != nil {
-		return nil, fmt.Errorf("failed to send tx MsgSend for %s: %v, output: %s", TmpKeyName, err, string(outputBytes))
+		return nil, fmt.Errorf("failed to generate tx %s for %s: %v, output: %s", strings.Join(args[:3], " "), keyInfo.Address, err, string(outputBytes))
 	}
 
-	var txResponse InitiadTxResponse
-	err = json.Unmarshal(outputBytes, &txResponse)
+	txFile, err := os.CreateTemp("", "weave-tx-*.json")
 	if err != nil {
-		return nil, fmt.Errorf("failed to unmarshal JSON: %v", err)
-	}
-
-	if txResponse.Code != 0 {
-		return nil, fmt.Errorf("tx failed with error: %v", txResponse.RawLog)
+		return nil, fmt.Errorf("failed to create tx file: %v", err)
 	}
-
-	err = waitForTransactionInclusion(binaryPath, rpc, txResponse.TxHash)
-	if err != nil {
-		return nil, err
+	txPath := txFile.Name()
+	_ = txFile.Close()
+	defer func() {
+		_ = os.Remove(txPath)
+	}()
+	if err := os.WriteFile(txPath, outputBytes, 0600); err != nil {
+		return nil, fmt.Errorf("failed to write tx file: %v", err)
 	}
 
-	return &txResponse, nil
+	return SignAndBroadcastTxFile(binaryPath, signer, txPath, keyInfo.Address, rpc, chainId)
 }
 
 func waitForTransactionInclusion(binaryPath, rpcURL, txHash string) error {
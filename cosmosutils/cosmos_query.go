@@ -102,3 +102,21 @@ func QueryNodeInfo(addresses []string) (response NodeInfoResponse, err error) {
 		},
 	)
 }
+
+// QueryABCIInfo queries the application version and height of the node behind a CometBFT RPC endpoint
+func QueryABCIInfo(rpc string) (*ABCIInfoResponse, error) {
+	var response ABCIInfoResponse
+	if _, err := client.NewHTTPClient().Get(rpc, "/abci_info", nil, &response); err != nil {
+		return nil, fmt.Errorf("failed to query abci info: %w", err)
+	}
+	return &response, nil
+}
+
+// QueryNodeStatus queries the status of the node behind a CometBFT RPC endpoint
+func QueryNodeStatus(rpc string) (*NodeStatusResponse, error) {
+	var response NodeStatusResponse
+	if _, err := client.NewHTTPClient().Get(rpc, "/status", nil, &response); err != nil {
+		return nil, fmt.Errorf("failed to query node status: %w", err)
+	}
+	return &response, nil
+}
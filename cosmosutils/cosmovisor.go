@@ -0,0 +1,250 @@
+package cosmosutils
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/initia-labs/weave/client"
+	"github.com/initia-labs/weave/config"
+	"github.com/initia-labs/weave/io"
+)
+
+const (
+	InitiaReleaseAPI     string = "https://api.github.com/repos/initia-labs/initia/releases"
+	OPInitBotsReleaseAPI string = "https://api.github.com/repos/initia-labs/opinit-bots/releases"
+
+	msgSoftwareUpgradeType = "/cosmos.upgrade.v1beta1.MsgSoftwareUpgrade"
+)
+
+// UpgradeProposal is a governance proposal in its voting period that schedules a software upgrade if it passes
+type UpgradeProposal struct {
+	Id           string      `json:"id"`
+	Title        string      `json:"title"`
+	VotingEndsAt string      `json:"voting_end_time"`
+	Plan         UpgradePlan `json:"plan"`
+}
+
+// GetCosmovisorCurrentUpgrade returns the name of the upgrade whose binary cosmovisor currently runs for the node at home,
+// or "genesis" before the first upgrade
+func GetCosmovisorCurrentUpgrade(home string) (string, error) {
+	target, err := os.Readlink(filepath.Join(home, "cosmovisor", "current"))
+	if err != nil {
+		return "", fmt.Errorf("failed to read the current cosmovisor binary: %w", err)
+	}
+	return filepath.Base(target), nil
+}
+
+// CosmovisorUpgradeBinaryPath returns where cosmovisor looks for binaryName when it applies the upgrade called name
+func CosmovisorUpgradeBinaryPath(home, name, binaryName string) string {
+	return filepath.Join(home, "cosmovisor", "upgrades", url.PathEscape(name), "bin", binaryName)
+}
+
+// CosmovisorLibraryPath returns the directory the shared libraries of the genesis initiad are copied to. The node
+// service loads the libraries from the bin directory of the current upgrade first and falls back to this directory,
+// so that the libraries of an upgrade are only picked up once cosmovisor switches to it.
+func CosmovisorLibraryPath(home string) string {
+	return filepath.Join(home, "cosmovisor", "dyld_lib")
+}
+
+// UpgradeRelease is the release an upgrade plan switches to
+type UpgradeRelease struct {
+	// Version is the version the binary reports, empty when it is unknown
+	Version string
+	URL     string
+	// SHA256 is the checksum of the tarball listed in the upgrade plan, empty for a release that publishes its own
+	SHA256 string
+}
+
+// StageCosmovisorUpgrade downloads the release tarball into the directory cosmovisor switches to for the upgrade
+// called name, unless a binary is already staged there. The shared libraries of the release stay next to the binary,
+// where the node service only loads them from once cosmovisor switches to the upgrade. The staged binary must report
+// the version of the release, if one is known. It returns the staged binary path.
+func StageCosmovisorUpgrade(home, name, binaryName string, release UpgradeRelease) (string, error) {
+	binaryPath := CosmovisorUpgradeBinaryPath(home, name, binaryName)
+	binDir := filepath.Dir(binaryPath)
+
+	if !io.FileOrFolderExists(binaryPath) {
+		upgradeDir := filepath.Dir(binDir)
+		downloadDir := filepath.Join(upgradeDir, "download")
+		if err := os.RemoveAll(downloadDir); err != nil {
+			return "", fmt.Errorf("failed to clean up previous download: %v", err)
+		}
+		if err := os.MkdirAll(downloadDir, os.ModePerm); err != nil {
+			return "", fmt.Errorf("failed to create upgrade directory: %v", err)
+		}
+		defer os.RemoveAll(downloadDir)
+
+		verify := config.GetReleaseVerifyOptions()
+		verify.ExpectedSHA256 = release.SHA256
+		if err := io.DownloadAndExtractTarGz(release.URL, filepath.Join(upgradeDir, "release.tar.gz"), downloadDir, verify); err != nil {
+			return "", fmt.Errorf("failed to download and extract %s: %v", release.URL, err)
+		}
+		extractedDir, err := FindBinaryDir(downloadDir, binaryName)
+		if err != nil {
+			return "", err
+		}
+
+		// Move the whole directory into place at once, so that cosmovisor never sees a partially staged upgrade
+		if err := os.RemoveAll(binDir); err != nil {
+			return "", fmt.Errorf("failed to clean up %s: %v", binDir, err)
+		}
+		if err := os.Rename(extractedDir, binDir); err != nil {
+			return "", fmt.Errorf("failed to move %s into place: %v", binaryName, err)
+		}
+		if err := os.Chmod(binaryPath, 0o755); err != nil {
+			return "", fmt.Errorf("failed to set permissions for %s: %v", binaryName, err)
+		}
+	}
+
+	if release.Version != "" {
+		version, err := stagedBinaryVersion(binaryPath, binDir)
+		if err != nil {
+			return "", err
+		}
+		if normalizeVersion(version) != normalizeVersion(release.Version) {
+			return "", fmt.Errorf("staged %s reports version %s instead of %s", binaryPath, version, release.Version)
+		}
+	}
+	return binaryPath, nil
+}
+
+// stagedBinaryVersion runs `<binary> version` with the shared libraries of libraryPath, the bin directory the node
+// service loads them from after the upgrade
+func stagedBinaryVersion(binaryPath, libraryPath string) (string, error) {
+	cmd := exec.Command(binaryPath, "version")
+	env, err := io.WithLibraryPathEnv(os.Environ(), libraryPath)
+	if err != nil {
+		return "", err
+	}
+	cmd.Env = env
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return "", fmt.Errorf("failed to run %s version: %v (output: %s)", binaryPath, err, string(output))
+	}
+	return strings.TrimSpace(string(output)), nil
+}
+
+// UpgradeDownloadURL finds the release for the current platform that plan upgrades to.
+// The release tagged with the plan name is preferred. Otherwise a tarball listed for the platform in the plan info is
+// used, in which case the version is unknown and the tarball must match the checksum the plan lists for it, if any.
+func UpgradeDownloadURL(plan UpgradePlan, releases BinaryVersionWithDownloadURL) (UpgradeRelease, error) {
+	version := plan.Name
+	if !strings.HasPrefix(version, "v") {
+		version = "v" + version
+	}
+	if binaryURL, ok := releases[version]; ok {
+		return UpgradeRelease{Version: version, URL: binaryURL}, nil
+	}
+
+	var info struct {
+		Binaries map[string]string `json:"binaries"`
+	}
+	if err := json.Unmarshal([]byte(plan.Info), &info); err == nil {
+		for _, platform := range []string{runtime.GOOS + "/" + runtime.GOARCH, "any"} {
+			// cosmovisor accepts a ?checksum=<algorithm>:<hex> suffix that is not part of the download URL
+			binaryURL, checksum, _ := strings.Cut(info.Binaries[platform], "?checksum=")
+			if !strings.HasSuffix(binaryURL, ".tar.gz") {
+				continue
+			}
+			if checksum == "" {
+				// Without a checksum in the plan, the tarball is verified like any other release
+				return UpgradeRelease{URL: binaryURL}, nil
+			}
+			algorithm, sum, _ := strings.Cut(checksum, ":")
+			if !strings.EqualFold(algorithm, "sha256") {
+				return UpgradeRelease{}, fmt.Errorf("upgrade %s lists %s with an unsupported %s checksum, only sha256 is supported", plan.Name, binaryURL, algorithm)
+			}
+			return UpgradeRelease{URL: binaryURL, SHA256: sum}, nil
+		}
+	}
+	return UpgradeRelease{}, fmt.Errorf("no release found for upgrade %s", plan.Name)
+}
+
+// QueryUpgradeProposals returns the software upgrade proposals that are in their voting period
+func QueryUpgradeProposals(addresses []string) ([]UpgradeProposal, error) {
+	if len(addresses) == 0 {
+		return nil, fmt.Errorf("no LCD endpoints provided")
+	}
+
+	var response struct {
+		Proposals []struct {
+			Id           string `json:"id"`
+			Title        string `json:"title"`
+			VotingEndsAt string `json:"voting_end_time"`
+			Messages     []struct {
+				Type string      `json:"@type"`
+				Plan UpgradePlan `json:"plan"`
+			} `json:"messages"`
+		} `json:"proposals"`
+	}
+	httpClient := client.NewHTTPClient()
+	var lastErr error
+	for _, address := range addresses {
+		_, lastErr = httpClient.Get(address, "/cosmos/gov/v1/proposals", map[string]string{"proposal_status": "PROPOSAL_STATUS_VOTING_PERIOD"}, &response)
+		if lastErr == nil {
+			break
+		}
+	}
+	if lastErr != nil {
+		return nil, fmt.Errorf("failed to query governance proposals: %w", lastErr)
+	}
+
+	var proposals []UpgradeProposal
+	for _, proposal := range response.Proposals {
+		for _, msg := range proposal.Messages {
+			if msg.Type == msgSoftwareUpgradeType {
+				proposals = append(proposals, UpgradeProposal{Id: proposal.Id, Title: proposal.Title, VotingEndsAt: proposal.VotingEndsAt, Plan: msg.Plan})
+			}
+		}
+	}
+	return proposals, nil
+}
+
+// GetAverageBlockTime measures the average block time over the last window blocks reported by rpc
+func GetAverageBlockTime(rpc string, window int) (time.Duration, int64, error) {
+	latest, err := fetchBlockHeader(rpc, nil)
+	if err != nil {
+		return 0, 0, err
+	}
+	latestHeight, err := strconv.ParseInt(latest.Height, 10, 64)
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to convert block height to integer: %v", err)
+	}
+	window = int(min(int64(window), latestHeight-1))
+	if window <= 0 {
+		return 0, latestHeight, fmt.Errorf("not enough blocks to measure the block time")
+	}
+
+	earlier, err := fetchBlockHeader(rpc, map[string]string{"height": strconv.FormatInt(latestHeight-int64(window), 10)})
+	if err != nil {
+		return 0, latestHeight, err
+	}
+	return latest.Time.Sub(earlier.Time) / time.Duration(window), latestHeight, nil
+}
+
+type blockHeader struct {
+	Height string    `json:"height"`
+	Time   time.Time `json:"time"`
+}
+
+func fetchBlockHeader(rpc string, params map[string]string) (*blockHeader, error) {
+	var response struct {
+		Result struct {
+			Block struct {
+				Header blockHeader `json:"header"`
+			} `json:"block"`
+		} `json:"result"`
+	}
+	if _, err := client.NewHTTPClient().Get(rpc, "/block", params, &response); err != nil {
+		return nil, fmt.Errorf("failed to fetch block: %v", err)
+	}
+	return &response.Result.Block.Header, nil
+}
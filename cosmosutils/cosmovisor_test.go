@@ -0,0 +1,189 @@
+package cosmosutils
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUpgradeDownloadURL(t *testing.T) {
+	releases := BinaryVersionWithDownloadURL{"v1.1.0": "https://github.com/initia-labs/initia/releases/download/v1.1.0/initia_v1.1.0_Linux_x86_64.tar.gz"}
+
+	release, err := UpgradeDownloadURL(UpgradePlan{Name: "v1.1.0"}, releases)
+	assert.NoError(t, err)
+	assert.Equal(t, UpgradeRelease{Version: "v1.1.0", URL: releases["v1.1.0"]}, release)
+
+	// The checksum of the plan is kept to verify the tarball with
+	info := fmt.Sprintf(`{"binaries":{"%s/%s":"https://example.com/initiad.tar.gz?checksum=sha256:abcd"}}`, runtime.GOOS, runtime.GOARCH)
+	release, err = UpgradeDownloadURL(UpgradePlan{Name: "summer", Info: info}, releases)
+	assert.NoError(t, err)
+	assert.Equal(t, UpgradeRelease{URL: "https://example.com/initiad.tar.gz", SHA256: "abcd"}, release)
+
+	info = `{"binaries":{"any":"https://example.com/initiad.tar.gz?checksum=md5:abcd"}}`
+	_, err = UpgradeDownloadURL(UpgradePlan{Name: "summer", Info: info}, releases)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "only sha256 is supported")
+
+	_, err = UpgradeDownloadURL(UpgradePlan{Name: "v2.0.0"}, releases)
+	assert.Error(t, err)
+}
+
+// releaseTarball packs a fake initiad that prints version into a release layout with a nested directory
+func releaseTarball(t *testing.T, version string) []byte {
+	var buffer bytes.Buffer
+	gzw := gzip.NewWriter(&buffer)
+	tw := tar.NewWriter(gzw)
+	script := fmt.Sprintf("#!/bin/sh\necho %s\n", version)
+	assert.NoError(t, tw.WriteHeader(&tar.Header{Name: "initia_" + version + "/", Mode: 0o755, Typeflag: tar.TypeDir}))
+	assert.NoError(t, tw.WriteHeader(&tar.Header{Name: "initia_" + version + "/initiad", Mode: 0o755, Size: int64(len(script)), Typeflag: tar.TypeReg}))
+	_, err := tw.Write([]byte(script))
+	assert.NoError(t, err)
+	assert.NoError(t, tw.WriteHeader(&tar.Header{Name: "initia_" + version + "/libmovevm.so", Mode: 0o644, Size: 3, Typeflag: tar.TypeReg}))
+	_, err = tw.Write([]byte("lib"))
+	assert.NoError(t, err)
+	assert.NoError(t, tw.Close())
+	assert.NoError(t, gzw.Close())
+	return buffer.Bytes()
+}
+
+func TestStageCosmovisorUpgrade(t *testing.T) {
+	tarball := releaseTarball(t, "v1.1.0")
+	checksum := sha256.Sum256(tarball)
+	var requests int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, ".sha256") {
+			_, _ = fmt.Fprintf(w, "%s  initia.tar.gz\n", hex.EncodeToString(checksum[:]))
+			return
+		}
+		if r.URL.Path == "/tampered.tar.gz" {
+			_, _ = w.Write(releaseTarball(t, "v1.2.0"))
+			return
+		}
+		requests++
+		_, _ = w.Write(tarball)
+	}))
+	defer server.Close()
+
+	home := t.TempDir()
+	release := UpgradeRelease{Version: "v1.1.0", URL: server.URL + "/initia.tar.gz"}
+	binaryPath, err := StageCosmovisorUpgrade(home, "v1.1.0", "initiad", release)
+	assert.NoError(t, err)
+	assert.Equal(t, filepath.Join(home, "cosmovisor", "upgrades", "v1.1.0", "bin", "initiad"), binaryPath)
+	assert.FileExists(t, filepath.Join(filepath.Dir(binaryPath), "libmovevm.so"))
+	assert.NoDirExists(t, CosmovisorLibraryPath(home), "the running node keeps its libraries until the upgrade")
+	assert.NoDirExists(t, filepath.Join(home, "cosmovisor", "upgrades", "v1.1.0", "download"))
+
+	// An already staged binary is verified without downloading it again
+	_, err = StageCosmovisorUpgrade(home, "v1.1.0", "initiad", release)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, requests)
+
+	_, err = StageCosmovisorUpgrade(home, "v1.1.0", "initiad", UpgradeRelease{Version: "v1.2.0", URL: release.URL})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "instead of v1.2.0")
+
+	// A release that does not match its published checksum is never staged
+	_, err = StageCosmovisorUpgrade(home, "v1.2.0", "initiad", UpgradeRelease{URL: server.URL + "/tampered.tar.gz"})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "checksum mismatch")
+	assert.NoFileExists(t, CosmovisorUpgradeBinaryPath(home, "v1.2.0", "initiad"))
+
+	// Nor is one that does not match the checksum of its upgrade plan
+	_, err = StageCosmovisorUpgrade(home, "v1.3.0", "initiad", UpgradeRelease{URL: release.URL, SHA256: strings.Repeat("0", 64)})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "checksum mismatch")
+	assert.NoFileExists(t, CosmovisorUpgradeBinaryPath(home, "v1.3.0", "initiad"))
+}
+
+func TestStageCosmovisorUpgrade_VerifiesWithOwnLibraries(t *testing.T) {
+	if runtime.GOOS != "linux" {
+		t.Skip("the fake binary reads LD_LIBRARY_PATH")
+	}
+	var buffer bytes.Buffer
+	gzw := gzip.NewWriter(&buffer)
+	tw := tar.NewWriter(gzw)
+	// The fake binary reports the version of the library it loads, and only from the bin directory of its upgrade
+	script := "#!/bin/sh\ncase \"$LD_LIBRARY_PATH\" in\n*/upgrades/v1.1.0/bin) cat \"$LD_LIBRARY_PATH/libmovevm.so\" ;;\n*) echo unknown ;;\nesac\n"
+	assert.NoError(t, tw.WriteHeader(&tar.Header{Name: "initiad", Mode: 0o755, Size: int64(len(script)), Typeflag: tar.TypeReg}))
+	_, err := tw.Write([]byte(script))
+	assert.NoError(t, err)
+	assert.NoError(t, tw.WriteHeader(&tar.Header{Name: "libmovevm.so", Mode: 0o644, Size: 7, Typeflag: tar.TypeReg}))
+	_, err = tw.Write([]byte("v1.1.0\n"))
+	assert.NoError(t, err)
+	assert.NoError(t, tw.Close())
+	assert.NoError(t, gzw.Close())
+
+	checksum := sha256.Sum256(buffer.Bytes())
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, ".sha256") {
+			_, _ = fmt.Fprintf(w, "%s  initia.tar.gz\n", hex.EncodeToString(checksum[:]))
+			return
+		}
+		_, _ = w.Write(buffer.Bytes())
+	}))
+	defer server.Close()
+
+	// The library the running node loads stays the one of the previous release
+	home := t.TempDir()
+	assert.NoError(t, os.MkdirAll(CosmovisorLibraryPath(home), 0o755))
+	previousLibrary := filepath.Join(CosmovisorLibraryPath(home), "libmovevm.so")
+	assert.NoError(t, os.WriteFile(previousLibrary, []byte("v1.0.0\n"), 0o644))
+	t.Setenv("LD_LIBRARY_PATH", "")
+
+	_, err = StageCosmovisorUpgrade(home, "v1.1.0", "initiad", UpgradeRelease{Version: "v1.1.0", URL: server.URL + "/initia.tar.gz"})
+	assert.NoError(t, err)
+	content, err := os.ReadFile(previousLibrary)
+	assert.NoError(t, err)
+	assert.Equal(t, "v1.0.0\n", string(content))
+}
+
+func TestQueryUpgradeProposals(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "PROPOSAL_STATUS_VOTING_PERIOD", r.URL.Query().Get("proposal_status"))
+		_, _ = w.Write([]byte(`{"proposals":[
+			{"id":"7","title":"Upgrade to v1.1.0","voting_end_time":"2026-10-20T00:00:00Z","messages":[
+				{"@type":"/cosmos.upgrade.v1beta1.MsgSoftwareUpgrade","plan":{"name":"v1.1.0","height":"5000000"}}]},
+			{"id":"8","title":"Spend","messages":[{"@type":"/cosmos.distribution.v1beta1.MsgCommunityPoolSpend"}]}
+		]}`))
+	}))
+	defer server.Close()
+
+	proposals, err := QueryUpgradeProposals([]string{server.URL})
+	assert.NoError(t, err)
+	assert.Equal(t, []UpgradeProposal{{
+		Id:           "7",
+		Title:        "Upgrade to v1.1.0",
+		VotingEndsAt: "2026-10-20T00:00:00Z",
+		Plan:         UpgradePlan{Name: "v1.1.0", Height: "5000000"},
+	}}, proposals)
+}
+
+func TestGetAverageBlockTime(t *testing.T) {
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		height, blockTime := 2_000, start.Add(2_000*500*time.Millisecond)
+		if r.URL.Query().Get("height") == "1000" {
+			height, blockTime = 1_000, start.Add(1_000*500*time.Millisecond)
+		}
+		_, _ = fmt.Fprintf(w, `{"result":{"block":{"header":{"height":"%d","time":%q}}}}`, height, blockTime.Format(time.RFC3339Nano))
+	}))
+	defer server.Close()
+
+	blockTime, latestHeight, err := GetAverageBlockTime(server.URL, 1_000)
+	assert.NoError(t, err)
+	assert.Equal(t, 500*time.Millisecond, blockTime)
+	assert.Equal(t, int64(2_000), latestHeight)
+}
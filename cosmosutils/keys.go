@@ -11,6 +11,7 @@ import (
 	"strings"
 
 	"github.com/initia-labs/weave/common"
+	"github.com/initia-labs/weave/crypto"
 )
 
 type KeyInfo struct {
@@ -78,6 +79,12 @@ func RecoverKeyFromMnemonic(appName, keyname, mnemonic string) (string, error) {
 // If the key already exists, it will replace the key and confirm with 'y' before adding the mnemonic
 // coinType must be explicitly provided (60 or 118), 0 is not allowed
 func RecoverKeyFromMnemonicWithCoinType(appName, keyname, mnemonic string, coinType int) (string, error) {
+	return RecoverKeyFromMnemonicWithOptions(appName, keyname, mnemonic, coinType, crypto.DerivationOptions{})
+}
+
+// RecoverKeyFromMnemonicWithOptions recovers or replaces a key like RecoverKeyFromMnemonicWithCoinType,
+// deriving it with the given BIP39 passphrase and HD path
+func RecoverKeyFromMnemonicWithOptions(appName, keyname, mnemonic string, coinType int, opts crypto.DerivationOptions) (string, error) {
 	// Check if the key already exists
 	exists := KeyExists(appName, keyname)
 
@@ -90,23 +97,29 @@ func RecoverKeyFromMnemonicWithCoinType(appName, keyname, mnemonic string, coinT
 	// Add the mnemonic input after the confirmation (if any)
 	inputBuffer.WriteString(mnemonic + "\n")
 
-	var cmd *exec.Cmd
-	if strings.HasSuffix(appName, "celestia-appd") {
-		cmd = exec.Command(appName, "keys", "add", keyname, "--recover", "--keyring-backend", "test", "--output", "json")
-	} else {
+	args := []string{"keys", "add", keyname, "--recover", "--keyring-backend", "test", "--output", "json"}
+	if !strings.HasSuffix(appName, "celestia-appd") {
 		// Validate coin type
 		if coinType == 0 {
 			return "", fmt.Errorf("coin type must be explicitly provided (60 or 118), got 0")
 		}
 
-		coinTypeStr := fmt.Sprintf("%d", coinType)
 		keyType := "secp256k1"
 		if coinType == 60 {
 			keyType = "eth_secp256k1"
 		}
-
-		cmd = exec.Command(appName, "keys", "add", keyname, "--coin-type", coinTypeStr, "--key-type", keyType, "--recover", "--keyring-backend", "test", "--output", "json")
+		args = append(args, "--coin-type", fmt.Sprintf("%d", coinType), "--key-type", keyType)
 	}
+	if opts.HDPath != "" {
+		args = append(args, "--hd-path", opts.HDPath)
+	}
+	if opts.BIP39Passphrase != "" {
+		// The passphrase is only prompted for, and confirmed, in interactive mode
+		args = append(args, "--interactive")
+		inputBuffer.WriteString(opts.BIP39Passphrase + "\n" + opts.BIP39Passphrase + "\n")
+	}
+
+	cmd := exec.Command(appName, args...)
 
 	// Pass the combined confirmation and mnemonic as input to the command
 	cmd.Stdin = &inputBuffer
@@ -133,8 +146,14 @@ func GenerateNewKeyInfo(appName, keyname string) (KeyInfo, error) {
 }
 
 func GetAddressFromMnemonic(appName, mnemonic string) (string, error) {
+	return GetAddressFromMnemonicWithOptions(appName, mnemonic, crypto.DerivationOptions{})
+}
+
+// GetAddressFromMnemonicWithOptions resolves the address of a mnemonic derived with the given options
+// using the chain binary, so that the address matches the one the binary signs with
+func GetAddressFromMnemonicWithOptions(appName, mnemonic string, opts crypto.DerivationOptions) (string, error) {
 	keyname := "weave.DummyKey"
-	rawKey, err := RecoverKeyFromMnemonic(appName, keyname, mnemonic)
+	rawKey, err := RecoverKeyFromMnemonicWithOptions(appName, keyname, mnemonic, 118, opts)
 	if err != nil {
 		return "", err
 	}
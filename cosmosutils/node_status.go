@@ -0,0 +1,111 @@
+package cosmosutils
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/initia-labs/weave/client"
+)
+
+// NetInfoResponse is the CometBFT RPC /net_info response
+type NetInfoResponse struct {
+	Result struct {
+		NPeers string `json:"n_peers"`
+		Peers  []struct {
+			NodeInfo struct {
+				Id         string `json:"id"`
+				ListenAddr string `json:"listen_addr"`
+				Moniker    string `json:"moniker"`
+			} `json:"node_info"`
+			IsOutbound bool   `json:"is_outbound"`
+			RemoteIP   string `json:"remote_ip"`
+		} `json:"peers"`
+	} `json:"result"`
+}
+
+// UpgradePlan is a software upgrade scheduled through governance
+type UpgradePlan struct {
+	Name   string `json:"name"`
+	Height string `json:"height"`
+	Info   string `json:"info"`
+}
+
+// QueryNetInfo queries the peers of the node behind a CometBFT RPC endpoint
+func QueryNetInfo(rpc string) (*NetInfoResponse, error) {
+	var response NetInfoResponse
+	if _, err := client.NewHTTPClient().Get(rpc, "/net_info", nil, &response); err != nil {
+		return nil, fmt.Errorf("failed to query net info: %w", err)
+	}
+	return &response, nil
+}
+
+// QueryUpgradePlan returns the upgrade that is currently scheduled, or nil if there is none
+func QueryUpgradePlan(addresses []string) (*UpgradePlan, error) {
+	return tryEndpoints(
+		addresses,
+		"/cosmos/upgrade/v1beta1/current_plan",
+		func(data []byte) (*UpgradePlan, error) {
+			var response struct {
+				Plan *UpgradePlan `json:"plan"`
+			}
+			if err := json.Unmarshal(data, &response); err != nil {
+				return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+			}
+			return response.Plan, nil
+		},
+	)
+}
+
+// GetNetworkHeight returns the highest latest block height reported by rpcs
+func GetNetworkHeight(rpcs []string) (int64, error) {
+	heights, errs := queryEndpoints(uniqueRPCEndpoints(rpcs), fetchLatestHeight)
+	var networkHeight int64
+	var lastErr error = fmt.Errorf("no RPC endpoints provided")
+	for i, height := range heights {
+		if errs[i] != nil {
+			lastErr = errs[i]
+			continue
+		}
+		networkHeight = max(networkHeight, int64(height))
+	}
+	if networkHeight == 0 {
+		return 0, fmt.Errorf("failed to query the network height: %w", lastErr)
+	}
+	return networkHeight, nil
+}
+
+// SyncSample is the local and network height observed at a point in time. NetworkHeight is zero when unknown.
+type SyncSample struct {
+	Time          time.Time
+	Height        int64
+	NetworkHeight int64
+}
+
+// EstimateSync returns how many blocks per second the node synced between prev and cur and how long it will take to catch up.
+// The time left is only reported if the node gains on the network; the network keeps producing blocks while the node syncs.
+func EstimateSync(prev, cur SyncSample) (blocksPerSecond float64, eta time.Duration, ok bool) {
+	elapsed := cur.Time.Sub(prev.Time).Seconds()
+	if elapsed <= 0 {
+		return 0, 0, false
+	}
+	blocksPerSecond = float64(cur.Height-prev.Height) / elapsed
+
+	if cur.NetworkHeight == 0 {
+		return blocksPerSecond, 0, false
+	}
+	remaining := cur.NetworkHeight - cur.Height
+	if remaining <= 0 {
+		return blocksPerSecond, 0, true
+	}
+
+	var networkBlocksPerSecond float64
+	if prev.NetworkHeight != 0 {
+		networkBlocksPerSecond = float64(cur.NetworkHeight-prev.NetworkHeight) / elapsed
+	}
+	gain := blocksPerSecond - networkBlocksPerSecond
+	if gain <= 0 {
+		return blocksPerSecond, 0, false
+	}
+	return blocksPerSecond, time.Duration(float64(remaining) / gain * float64(time.Second)), true
+}
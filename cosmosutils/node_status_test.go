@@ -0,0 +1,51 @@
+package cosmosutils
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEstimateSync(t *testing.T) {
+	start := time.Unix(1_700_000_000, 0)
+	prev := SyncSample{Time: start, Height: 1_000, NetworkHeight: 10_000}
+
+	// 100 blocks per second against a network producing 10, so the 9,000 block gap closes at 90 per second
+	blocksPerSecond, eta, ok := EstimateSync(prev, SyncSample{Time: start.Add(10 * time.Second), Height: 2_000, NetworkHeight: 10_100})
+	assert.True(t, ok)
+	assert.Equal(t, 100.0, blocksPerSecond)
+	assert.Equal(t, 90*time.Second, eta)
+
+	_, eta, ok = EstimateSync(prev, SyncSample{Time: start.Add(10 * time.Second), Height: 10_100, NetworkHeight: 10_100})
+	assert.True(t, ok)
+	assert.Zero(t, eta)
+
+	// Falling behind has no ETA
+	_, _, ok = EstimateSync(prev, SyncSample{Time: start.Add(10 * time.Second), Height: 1_050, NetworkHeight: 10_100})
+	assert.False(t, ok)
+
+	_, _, ok = EstimateSync(prev, SyncSample{Time: start, Height: 1_000})
+	assert.False(t, ok)
+}
+
+func TestGetNetworkHeight(t *testing.T) {
+	first := newStateSyncRPC(10_000, "ABCD")
+	defer first.Close()
+	second := newStateSyncRPC(10_005, "ABCD")
+	defer second.Close()
+
+	broken := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("{}"))
+	}))
+	defer broken.Close()
+
+	height, err := GetNetworkHeight([]string{first.URL, second.URL, broken.URL})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(10_005), height)
+
+	_, err = GetNetworkHeight([]string{broken.URL})
+	assert.Error(t, err)
+}
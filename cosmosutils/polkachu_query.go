@@ -7,6 +7,7 @@ import (
 	"github.com/PuerkitoBio/goquery"
 
 	"github.com/initia-labs/weave/client"
+	weaveio "github.com/initia-labs/weave/io"
 	"github.com/initia-labs/weave/registry"
 )
 
@@ -18,8 +19,14 @@ const (
 
 	PolkachuAddrBookAPI = "https://snapshots.polkachu.com/%saddrbook/%s/addrbook.json"
 
-	DefaultInitiaPolkachuName    string = "initia"
-	DefaultSnapshotFileExtension string = ".tar.lz4"
+	DefaultInitiaPolkachuName string = "initia"
+)
+
+var (
+	PolkachuChainIdSlugMap = map[string]string{
+		"interwoven-1": "tendermint_snapshots/initia",
+		"initiation-2": "testnets/initia/snapshots",
+	}
 )
 
 type PolkachuChainAPIResponse struct {
@@ -74,12 +81,12 @@ func FetchPolkachuSnapshotDownloadURL(chainSlug string) (string, error) {
 }
 
 func isSnapshotURL(href string) bool {
-	fileLen := len(href) - len(DefaultSnapshotFileExtension)
-	if fileLen < 0 {
-		return false
+	for _, extension := range weaveio.SnapshotFileExtensions {
+		if strings.HasSuffix(href, extension) {
+			return true
+		}
 	}
-
-	return href != "" && href[fileLen:] == DefaultSnapshotFileExtension
+	return false
 }
 
 func FetchPolkachuStateSyncURL(chainType registry.ChainType) (string, error) {
@@ -0,0 +1,277 @@
+package cosmosutils
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+
+	"google.golang.org/protobuf/encoding/protowire"
+
+	"github.com/initia-labs/weave/crypto"
+)
+
+// AddSignerKey registers the public key of the signer as an offline key in the test keyring.
+// The CLI can then build and simulate transactions for it without ever holding the private key.
+func AddSignerKey(appName, keyname string, signer crypto.Signer) (KeyInfo, error) {
+	pubKey, err := signer.PubKey()
+	if err != nil {
+		return KeyInfo{}, fmt.Errorf("failed to get signer public key: %v", err)
+	}
+	pubKeyJson, err := json.Marshal(map[string]string{
+		"@type": signer.KeyType().PubKeyTypeURL(),
+		"key":   base64.StdEncoding.EncodeToString(pubKey),
+	})
+	if err != nil {
+		return KeyInfo{}, fmt.Errorf("failed to marshal signer public key: %v", err)
+	}
+
+	if KeyExists(appName, keyname) {
+		if err := DeleteKey(appName, keyname); err != nil {
+			return KeyInfo{}, fmt.Errorf("failed to delete existing key %s: %v", keyname, err)
+		}
+	}
+
+	cmd := exec.Command(appName, "keys", "add", keyname, "--pubkey", string(pubKeyJson), "--keyring-backend", "test", "--output", "json")
+	outputBytes, err := cmd.CombinedOutput()
+	if err != nil {
+		return KeyInfo{}, fmt.Errorf("failed to add signer key %s: %v, output: %s", keyname, err, string(outputBytes))
+	}
+
+	return UnmarshalKeyInfo(string(outputBytes))
+}
+
+// SignTxFile signs the unsigned transaction at txPath with the signer and writes the signed transaction back to it.
+// Sign bytes are computed for SIGN_MODE_DIRECT using the on-chain account number and sequence of the address.
+func SignTxFile(appName string, signer crypto.Signer, txPath, address, rpc, chainId string) error {
+	accountNumber, sequence, err := QueryAccountNumberAndSequence(appName, address, rpc)
+	if err != nil {
+		return err
+	}
+	pubKey, err := signer.PubKey()
+	if err != nil {
+		return fmt.Errorf("failed to get signer public key: %v", err)
+	}
+
+	content, err := os.ReadFile(txPath)
+	if err != nil {
+		return fmt.Errorf("failed to read tx file: %v", err)
+	}
+	var tx map[string]interface{}
+	if err := json.Unmarshal(content, &tx); err != nil {
+		return fmt.Errorf("failed to unmarshal tx file: %v", err)
+	}
+	authInfo, ok := tx["auth_info"].(map[string]interface{})
+	if !ok {
+		return fmt.Errorf("tx file is missing auth_info")
+	}
+	authInfo["signer_infos"] = []interface{}{
+		map[string]interface{}{
+			"public_key": map[string]interface{}{
+				"@type": signer.KeyType().PubKeyTypeURL(),
+				"key":   base64.StdEncoding.EncodeToString(pubKey),
+			},
+			"mode_info": map[string]interface{}{
+				"single": map[string]interface{}{"mode": "SIGN_MODE_DIRECT"},
+			},
+			"sequence": strconv.FormatUint(sequence, 10),
+		},
+	}
+	tx["signatures"] = []interface{}{}
+	if err := writeTxFile(txPath, tx); err != nil {
+		return err
+	}
+
+	// Let the CLI produce the exact body and auth info bytes it will broadcast, so the sign doc matches them
+	encodeCmd := exec.Command(appName, "tx", "encode", txPath)
+	encodeRes, err := encodeCmd.Output()
+	if err != nil {
+		return fmt.Errorf("failed to encode tx: %v, output: %s", err, string(encodeRes))
+	}
+	txBytes, err := base64.StdEncoding.DecodeString(strings.TrimSpace(string(encodeRes)))
+	if err != nil {
+		return fmt.Errorf("failed to decode encoded tx: %v", err)
+	}
+	bodyBytes, authInfoBytes, err := parseTxRaw(txBytes)
+	if err != nil {
+		return err
+	}
+
+	signature, err := signer.Sign(crypto.SignRequest{
+		ChainId:       chainId,
+		AccountNumber: accountNumber,
+		Sequence:      sequence,
+		Address:       address,
+		SignBytes:     directSignBytes(bodyBytes, authInfoBytes, chainId, accountNumber),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to sign tx: %v", err)
+	}
+
+	tx["signatures"] = []interface{}{base64.StdEncoding.EncodeToString(signature)}
+	return writeTxFile(txPath, tx)
+}
+
+// BroadcastTxFile broadcasts a signed transaction file and waits until it is included in a block
+func BroadcastTxFile(appName, txPath, rpc string) (*InitiadTxResponse, error) {
+	cmd := exec.Command(appName, "tx", "broadcast", txPath, "--node", rpc, "--output", "json")
+	outputBytes, err := cmd.CombinedOutput()
+	if err != nil {
+		return nil, fmt.Errorf("failed to broadcast transaction: %v, output: %s", err, string(outputBytes))
+	}
+
+	var txResponse InitiadTxResponse
+	if err := json.Unmarshal(outputBytes, &txResponse); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal JSON: %v", err)
+	}
+	if txResponse.Code != 0 {
+		return nil, fmt.Errorf("tx failed with error: %v", txResponse.RawLog)
+	}
+
+	if err := waitForTransactionInclusion(appName, rpc, txResponse.TxHash); err != nil {
+		return nil, err
+	}
+
+	return &txResponse, nil
+}
+
+// SignAndBroadcastTxFile signs the unsigned transaction at txPath with the signer and broadcasts it
+func SignAndBroadcastTxFile(appName string, signer crypto.Signer, txPath, address, rpc, chainId string) (*InitiadTxResponse, error) {
+	if err := SignTxFile(appName, signer, txPath, address, rpc, chainId); err != nil {
+		return nil, err
+	}
+	return BroadcastTxFile(appName, txPath, rpc)
+}
+
+// QueryAccountNumberAndSequence returns the account number and the next sequence of an on-chain account
+func QueryAccountNumberAndSequence(appName, address, rpc string) (uint64, uint64, error) {
+	cmd := exec.Command(appName, "query", "auth", "account", address, "--node", rpc, "--output", "json")
+	outputBytes, err := cmd.CombinedOutput()
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to query account %s: %v, output: %s", address, err, string(outputBytes))
+	}
+
+	return parseAccountNumberAndSequence(outputBytes)
+}
+
+// parseAccountNumberAndSequence extracts the numbers from any account type, since their JSON shape
+// differs between chains and between base, module and vesting accounts
+func parseAccountNumberAndSequence(rawJson []byte) (uint64, uint64, error) {
+	var account interface{}
+	if err := json.Unmarshal(rawJson, &account); err != nil {
+		return 0, 0, fmt.Errorf("failed to unmarshal account: %v", err)
+	}
+
+	accountNumber, ok := findJsonField(account, "account_number")
+	if !ok {
+		return 0, 0, fmt.Errorf("account number not found in account response")
+	}
+	// Accounts that never sent a transaction may omit the zero sequence
+	sequence, _ := findJsonField(account, "sequence")
+
+	number, err := parseJsonUint(accountNumber)
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid account number: %v", err)
+	}
+	seq, err := parseJsonUint(sequence)
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid account sequence: %v", err)
+	}
+
+	return number, seq, nil
+}
+
+func findJsonField(value interface{}, key string) (interface{}, bool) {
+	object, ok := value.(map[string]interface{})
+	if !ok {
+		return nil, false
+	}
+	if field, ok := object[key]; ok {
+		return field, true
+	}
+	for _, nested := range object {
+		if field, ok := findJsonField(nested, key); ok {
+			return field, true
+		}
+	}
+	return nil, false
+}
+
+func parseJsonUint(value interface{}) (uint64, error) {
+	switch v := value.(type) {
+	case nil:
+		return 0, nil
+	case string:
+		return strconv.ParseUint(v, 10, 64)
+	case float64:
+		return uint64(v), nil
+	default:
+		return 0, fmt.Errorf("unexpected value %v", value)
+	}
+}
+
+func writeTxFile(txPath string, tx map[string]interface{}) error {
+	content, err := json.Marshal(tx)
+	if err != nil {
+		return fmt.Errorf("failed to marshal tx: %v", err)
+	}
+	if err := os.WriteFile(txPath, content, 0600); err != nil {
+		return fmt.Errorf("failed to write tx file: %v", err)
+	}
+	return nil
+}
+
+// parseTxRaw returns the body and auth info bytes of a protobuf encoded cosmos.tx.v1beta1.TxRaw
+func parseTxRaw(txBytes []byte) ([]byte, []byte, error) {
+	var bodyBytes, authInfoBytes []byte
+	for len(txBytes) > 0 {
+		num, typ, n := protowire.ConsumeTag(txBytes)
+		if n < 0 {
+			return nil, nil, fmt.Errorf("failed to parse encoded tx: %v", protowire.ParseError(n))
+		}
+		txBytes = txBytes[n:]
+
+		if typ != protowire.BytesType {
+			n = protowire.ConsumeFieldValue(num, typ, txBytes)
+		} else {
+			var field []byte
+			field, n = protowire.ConsumeBytes(txBytes)
+			switch num {
+			case 1:
+				bodyBytes = field
+			case 2:
+				authInfoBytes = field
+			}
+		}
+		if n < 0 {
+			return nil, nil, fmt.Errorf("failed to parse encoded tx: %v", protowire.ParseError(n))
+		}
+		txBytes = txBytes[n:]
+	}
+
+	if bodyBytes == nil || authInfoBytes == nil {
+		return nil, nil, fmt.Errorf("encoded tx is missing its body or auth info")
+	}
+	return bodyBytes, authInfoBytes, nil
+}
+
+// directSignBytes encodes a cosmos.tx.v1beta1.SignDoc, omitting zero values like the SDK does
+func directSignBytes(bodyBytes, authInfoBytes []byte, chainId string, accountNumber uint64) []byte {
+	var signDoc []byte
+	signDoc = protowire.AppendTag(signDoc, 1, protowire.BytesType)
+	signDoc = protowire.AppendBytes(signDoc, bodyBytes)
+	signDoc = protowire.AppendTag(signDoc, 2, protowire.BytesType)
+	signDoc = protowire.AppendBytes(signDoc, authInfoBytes)
+	if chainId != "" {
+		signDoc = protowire.AppendTag(signDoc, 3, protowire.BytesType)
+		signDoc = protowire.AppendString(signDoc, chainId)
+	}
+	if accountNumber != 0 {
+		signDoc = protowire.AppendTag(signDoc, 4, protowire.VarintType)
+		signDoc = protowire.AppendVarint(signDoc, accountNumber)
+	}
+	return signDoc
+}
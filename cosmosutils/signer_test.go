@@ -0,0 +1,144 @@
+package cosmosutils
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/encoding/protowire"
+
+	"github.com/initia-labs/weave/crypto"
+)
+
+const testMnemonic = "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about"
+
+func TestParseAccountNumberAndSequence(t *testing.T) {
+	tests := []struct {
+		name             string
+		response         string
+		expectedNumber   uint64
+		expectedSequence uint64
+		expectErr        bool
+	}{
+		{
+			name:             "Wrapped base account",
+			response:         `{"account":{"type":"/cosmos.auth.v1beta1.BaseAccount","value":{"address":"init1","account_number":"12","sequence":"3"}}}`,
+			expectedNumber:   12,
+			expectedSequence: 3,
+		},
+		{
+			name:             "Vesting account",
+			response:         `{"@type":"/cosmos.vesting.v1beta1.ContinuousVestingAccount","base_vesting_account":{"base_account":{"account_number":"5","sequence":"9"}}}`,
+			expectedNumber:   5,
+			expectedSequence: 9,
+		},
+		{
+			name:           "Fresh account without sequence",
+			response:       `{"account":{"account_number":"40"}}`,
+			expectedNumber: 40,
+		},
+		{
+			name:      "Missing account number",
+			response:  `{"account":{"sequence":"1"}}`,
+			expectErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			number, sequence, err := parseAccountNumberAndSequence([]byte(tt.response))
+			if tt.expectErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedNumber, number)
+			assert.Equal(t, tt.expectedSequence, sequence)
+		})
+	}
+}
+
+func encodeTxRaw(bodyBytes, authInfoBytes []byte) []byte {
+	var txRaw []byte
+	txRaw = protowire.AppendTag(txRaw, 1, protowire.BytesType)
+	txRaw = protowire.AppendBytes(txRaw, bodyBytes)
+	txRaw = protowire.AppendTag(txRaw, 2, protowire.BytesType)
+	txRaw = protowire.AppendBytes(txRaw, authInfoBytes)
+	return txRaw
+}
+
+func TestParseTxRaw(t *testing.T) {
+	bodyBytes, authInfoBytes, err := parseTxRaw(encodeTxRaw([]byte("body"), []byte("auth info")))
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("body"), bodyBytes)
+	assert.Equal(t, []byte("auth info"), authInfoBytes)
+
+	_, _, err = parseTxRaw([]byte{0x0a, 0x05, 0x01})
+	assert.Error(t, err)
+	_, _, err = parseTxRaw(protowire.AppendBytes(protowire.AppendTag(nil, 1, protowire.BytesType), []byte("body")))
+	assert.Error(t, err)
+}
+
+// writeStubBinary writes a script that answers the account query and tx encode calls made while signing
+func writeStubBinary(t *testing.T, dir string, txRaw []byte) string {
+	script := fmt.Sprintf(`#!/bin/sh
+case "$1 $2" in
+  "query auth") echo '{"account":{"value":{"account_number":"7","sequence":"2"}}}' ;;
+  "tx encode") echo %q ;;
+  *) exit 1 ;;
+esac
+`, base64.StdEncoding.EncodeToString(txRaw))
+	binaryPath := filepath.Join(dir, "initiad")
+	assert.NoError(t, os.WriteFile(binaryPath, []byte(script), 0o755))
+	return binaryPath
+}
+
+func TestSignTxFileWithExternalSigner(t *testing.T) {
+	dir := t.TempDir()
+	txRaw := encodeTxRaw([]byte("body"), []byte("auth info"))
+	binaryPath := writeStubBinary(t, dir, txRaw)
+
+	mnemonicSigner, err := crypto.NewMnemonicSigner(testMnemonic, 60, crypto.DerivationOptions{})
+	assert.NoError(t, err)
+	pubKey, err := mnemonicSigner.PubKey()
+	assert.NoError(t, err)
+
+	signBytes := directSignBytes([]byte("body"), []byte("auth info"), "initiation-2", 7)
+	signature, err := mnemonicSigner.Sign(crypto.SignRequest{SignBytes: signBytes})
+	assert.NoError(t, err)
+
+	// The stub signer only knows one signature, so it proves the sign bytes weave computes are the expected ones
+	signerPath := filepath.Join(dir, "signer.sh")
+	signerScript := fmt.Sprintf("#!/bin/sh\ncat > /dev/null\necho %q\n", base64.StdEncoding.EncodeToString(signature))
+	assert.NoError(t, os.WriteFile(signerPath, []byte(signerScript), 0o755))
+	signer, err := crypto.NewExternalSigner(crypto.ExternalSignerConfig{
+		Command: []string{signerPath},
+		PubKey:  base64.StdEncoding.EncodeToString(pubKey),
+	}, crypto.EthSecp256k1KeyType)
+	assert.NoError(t, err)
+
+	txPath := filepath.Join(dir, "tx.json")
+	assert.NoError(t, os.WriteFile(txPath, []byte(`{"body":{"messages":[]},"auth_info":{"signer_infos":[],"fee":{}},"signatures":[]}`), 0o600))
+	assert.NoError(t, SignTxFile(binaryPath, signer, txPath, "init1signer", "http://localhost:26657", "initiation-2"))
+
+	content, err := os.ReadFile(txPath)
+	assert.NoError(t, err)
+	var tx struct {
+		AuthInfo struct {
+			SignerInfos []struct {
+				PublicKey map[string]string `json:"public_key"`
+				Sequence  string            `json:"sequence"`
+			} `json:"signer_infos"`
+		} `json:"auth_info"`
+		Signatures []string `json:"signatures"`
+	}
+	assert.NoError(t, json.Unmarshal(content, &tx))
+	assert.Len(t, tx.AuthInfo.SignerInfos, 1)
+	assert.Equal(t, "2", tx.AuthInfo.SignerInfos[0].Sequence)
+	assert.Equal(t, crypto.EthSecp256k1KeyType.PubKeyTypeURL(), tx.AuthInfo.SignerInfos[0].PublicKey["@type"])
+	assert.Equal(t, []string{base64.StdEncoding.EncodeToString(signature)}, tx.Signatures)
+}
@@ -0,0 +1,241 @@
+package cosmosutils
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/initia-labs/weave/crypto"
+)
+
+const ValconsHRP = "initvalcons"
+
+// SigningInfo is the liveness record the slashing module keeps for a consensus address
+type SigningInfo struct {
+	Address      string
+	StartHeight  int64
+	MissedBlocks int64
+	JailedUntil  time.Time
+	Tombstoned   bool
+}
+
+// SlashingParams holds the liveness parameters of the slashing module
+type SlashingParams struct {
+	SignedBlocksWindow int64
+	MinSignedPerWindow float64
+}
+
+// MaxMissedBlocks is how many blocks of the signing window a validator may miss before it is jailed
+func (p SlashingParams) MaxMissedBlocks() int64 {
+	return p.SignedBlocksWindow - int64(math.Ceil(float64(p.SignedBlocksWindow)*p.MinSignedPerWindow))
+}
+
+// ConsensusAddress returns the bech32 consensus address of priv_validator_key.json in the node home
+func ConsensusAddress(home string) (string, error) {
+	keyPath := filepath.Join(home, "config", "priv_validator_key.json")
+	data, err := os.ReadFile(keyPath)
+	if err != nil {
+		return "", fmt.Errorf("failed to read %s: %w", keyPath, err)
+	}
+	var key struct {
+		Address string `json:"address"`
+	}
+	if err := json.Unmarshal(data, &key); err != nil {
+		return "", fmt.Errorf("failed to parse %s: %w", keyPath, err)
+	}
+	addressBytes, err := hex.DecodeString(key.Address)
+	if err != nil || len(addressBytes) == 0 {
+		return "", fmt.Errorf("invalid address %q in %s", key.Address, keyPath)
+	}
+	return crypto.BytesToBech32(ValconsHRP, addressBytes)
+}
+
+// QuerySigningInfo returns the signing info of consensusAddress
+func QuerySigningInfo(addresses []string, consensusAddress string) (*SigningInfo, error) {
+	return tryEndpoints(
+		addresses,
+		fmt.Sprintf("/cosmos/slashing/v1beta1/signing_infos/%s", consensusAddress),
+		parseSigningInfo,
+	)
+}
+
+func parseSigningInfo(data []byte) (*SigningInfo, error) {
+	var response struct {
+		ValSigningInfo *struct {
+			Address             string    `json:"address"`
+			StartHeight         string    `json:"start_height"`
+			JailedUntil         time.Time `json:"jailed_until"`
+			Tombstoned          bool      `json:"tombstoned"`
+			MissedBlocksCounter string    `json:"missed_blocks_counter"`
+		} `json:"val_signing_info"`
+	}
+	if err := json.Unmarshal(data, &response); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal signing info: %w", err)
+	}
+	if response.ValSigningInfo == nil {
+		return nil, fmt.Errorf("no signing info in response")
+	}
+
+	info := response.ValSigningInfo
+	startHeight, err := parseOptionalInt(info.StartHeight)
+	if err != nil {
+		return nil, fmt.Errorf("invalid start height %q: %w", info.StartHeight, err)
+	}
+	missedBlocks, err := parseOptionalInt(info.MissedBlocksCounter)
+	if err != nil {
+		return nil, fmt.Errorf("invalid missed blocks counter %q: %w", info.MissedBlocksCounter, err)
+	}
+	return &SigningInfo{
+		Address:      info.Address,
+		StartHeight:  startHeight,
+		MissedBlocks: missedBlocks,
+		JailedUntil:  info.JailedUntil,
+		Tombstoned:   info.Tombstoned,
+	}, nil
+}
+
+// QuerySlashingParams returns the liveness parameters of the slashing module
+func QuerySlashingParams(addresses []string) (*SlashingParams, error) {
+	return tryEndpoints(
+		addresses,
+		"/cosmos/slashing/v1beta1/params",
+		func(data []byte) (*SlashingParams, error) {
+			var response struct {
+				Params *struct {
+					SignedBlocksWindow string `json:"signed_blocks_window"`
+					MinSignedPerWindow string `json:"min_signed_per_window"`
+				} `json:"params"`
+			}
+			if err := json.Unmarshal(data, &response); err != nil {
+				return nil, fmt.Errorf("failed to unmarshal slashing params: %w", err)
+			}
+			if response.Params == nil {
+				return nil, fmt.Errorf("no slashing params in response")
+			}
+			window, err := strconv.ParseInt(response.Params.SignedBlocksWindow, 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("invalid signed blocks window %q: %w", response.Params.SignedBlocksWindow, err)
+			}
+			minSigned, err := strconv.ParseFloat(response.Params.MinSignedPerWindow, 64)
+			if err != nil {
+				return nil, fmt.Errorf("invalid min signed per window %q: %w", response.Params.MinSignedPerWindow, err)
+			}
+			return &SlashingParams{SignedBlocksWindow: window, MinSignedPerWindow: minSigned}, nil
+		},
+	)
+}
+
+func parseOptionalInt(s string) (int64, error) {
+	if s == "" {
+		return 0, nil
+	}
+	return strconv.ParseInt(s, 10, 64)
+}
+
+type AlertLevel string
+
+const (
+	AlertInfo     AlertLevel = "info"
+	AlertWarning  AlertLevel = "warning"
+	AlertCritical AlertLevel = "critical"
+)
+
+const (
+	AlertEventMissedBlocks = "missed_blocks"
+	AlertEventRecovered    = "recovered"
+	AlertEventJailed       = "jailed"
+	AlertEventTombstoned   = "tombstoned"
+)
+
+// ValidatorAlert is a change in the liveness of a validator worth telling its operator about
+type ValidatorAlert struct {
+	Level            AlertLevel `json:"level"`
+	Event            string     `json:"event"`
+	Message          string     `json:"message"`
+	ConsensusAddress string     `json:"consensus_address"`
+	MissedBlocks     int64      `json:"missed_blocks"`
+	Time             time.Time  `json:"time"`
+}
+
+// MissedBlockThresholds are the missed block counts over the signing window at which the monitor warns
+type MissedBlockThresholds struct {
+	Warning  int64
+	Critical int64
+}
+
+func (t MissedBlockThresholds) Validate() error {
+	if t.Warning <= 0 || t.Critical <= 0 {
+		return fmt.Errorf("missed block thresholds must be positive")
+	}
+	if t.Warning > t.Critical {
+		return fmt.Errorf("the warning threshold (%d) cannot be above the critical threshold (%d)", t.Warning, t.Critical)
+	}
+	return nil
+}
+
+func (t MissedBlockThresholds) level(missedBlocks int64) AlertLevel {
+	switch {
+	case missedBlocks >= t.Critical:
+		return AlertCritical
+	case missedBlocks >= t.Warning:
+		return AlertWarning
+	default:
+		return AlertInfo
+	}
+}
+
+// EvaluateSigningInfo compares the latest signing info with the previous one and returns the alerts the change raises.
+// previous is nil on the first poll, in which case the current state is reported if it needs attention.
+func EvaluateSigningInfo(previous *SigningInfo, current SigningInfo, params SlashingParams, thresholds MissedBlockThresholds, now time.Time) []ValidatorAlert {
+	var alerts []ValidatorAlert
+	newAlert := func(level AlertLevel, event, message string) {
+		alerts = append(alerts, ValidatorAlert{
+			Level:            level,
+			Event:            event,
+			Message:          message,
+			ConsensusAddress: current.Address,
+			MissedBlocks:     current.MissedBlocks,
+			Time:             now,
+		})
+	}
+
+	if current.Tombstoned {
+		if previous == nil || !previous.Tombstoned {
+			newAlert(AlertCritical, AlertEventTombstoned, "validator is tombstoned for double signing and can never be unjailed")
+		}
+		return alerts
+	}
+
+	jailed := current.JailedUntil.After(now)
+	if jailed && (previous == nil || !current.JailedUntil.Equal(previous.JailedUntil)) {
+		newAlert(AlertCritical, AlertEventJailed, fmt.Sprintf("validator is jailed until %s, run `weave initia validator unjail` once it has passed",
+			current.JailedUntil.UTC().Format(time.RFC3339)))
+	}
+	if jailed {
+		// Jailing resets the missed block counter, so it says nothing until the validator is unjailed
+		return alerts
+	}
+
+	window := ""
+	if maxMissed := params.MaxMissedBlocks(); maxMissed > 0 {
+		window = fmt.Sprintf(" of %d allowed in the last %d blocks", maxMissed, params.SignedBlocksWindow)
+	}
+	level := thresholds.level(current.MissedBlocks)
+	previousLevel := AlertInfo
+	if previous != nil {
+		previousLevel = thresholds.level(previous.MissedBlocks)
+	}
+	switch {
+	case level == previousLevel:
+	case level == AlertInfo:
+		newAlert(AlertInfo, AlertEventRecovered, fmt.Sprintf("missed blocks are back to %d%s", current.MissedBlocks, window))
+	case level == AlertCritical || previousLevel == AlertInfo:
+		newAlert(level, AlertEventMissedBlocks, fmt.Sprintf("validator missed %d blocks%s", current.MissedBlocks, window))
+	}
+	return alerts
+}
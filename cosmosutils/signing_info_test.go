@@ -0,0 +1,116 @@
+package cosmosutils
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConsensusAddress(t *testing.T) {
+	home := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(home, "config"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(home, "config", "priv_validator_key.json"),
+		[]byte(`{"address":"0A1B2C3D4E5F60718293A4B5C6D7E8F901234567","pub_key":{},"priv_key":{}}`), 0600))
+
+	address, err := ConsensusAddress(home)
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(address, "initvalcons1"))
+
+	_, err = ConsensusAddress(t.TempDir())
+	assert.Error(t, err)
+}
+
+func TestParseSigningInfo(t *testing.T) {
+	info, err := parseSigningInfo([]byte(`{"val_signing_info":{"address":"initvalcons1abc","start_height":"120",
+		"index_offset":"5000","jailed_until":"2025-01-02T03:04:05Z","tombstoned":false,"missed_blocks_counter":"42"}}`))
+	require.NoError(t, err)
+	assert.Equal(t, "initvalcons1abc", info.Address)
+	assert.Equal(t, int64(120), info.StartHeight)
+	assert.Equal(t, int64(42), info.MissedBlocks)
+	assert.Equal(t, time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC), info.JailedUntil)
+
+	_, err = parseSigningInfo([]byte(`{}`))
+	assert.Error(t, err)
+}
+
+func TestSlashingParamsMaxMissedBlocks(t *testing.T) {
+	params := SlashingParams{SignedBlocksWindow: 10000, MinSignedPerWindow: 0.05}
+	assert.Equal(t, int64(9500), params.MaxMissedBlocks())
+}
+
+func TestEvaluateSigningInfo(t *testing.T) {
+	now := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	params := SlashingParams{SignedBlocksWindow: 1000, MinSignedPerWindow: 0.5}
+	thresholds := MissedBlockThresholds{Warning: 50, Critical: 200}
+	signing := func(missed int64) SigningInfo {
+		return SigningInfo{Address: "initvalcons1abc", MissedBlocks: missed}
+	}
+	events := func(alerts []ValidatorAlert) []string {
+		var result []string
+		for _, alert := range alerts {
+			result = append(result, string(alert.Level)+":"+alert.Event)
+		}
+		return result
+	}
+
+	tests := []struct {
+		name     string
+		previous *SigningInfo
+		current  SigningInfo
+		expected []string
+	}{
+		{"healthy first poll", nil, signing(3), nil},
+		{"warning on first poll", nil, signing(60), []string{"warning:missed_blocks"}},
+		{"crossing warning", &SigningInfo{MissedBlocks: 49}, signing(50), []string{"warning:missed_blocks"}},
+		{"staying above warning", &SigningInfo{MissedBlocks: 60}, signing(70), nil},
+		{"crossing critical", &SigningInfo{MissedBlocks: 60}, signing(200), []string{"critical:missed_blocks"}},
+		{"easing from critical", &SigningInfo{MissedBlocks: 250}, signing(100), nil},
+		{"recovered", &SigningInfo{MissedBlocks: 60}, signing(10), []string{"info:recovered"}},
+		{
+			"jailed",
+			&SigningInfo{MissedBlocks: 500},
+			SigningInfo{JailedUntil: now.Add(10 * time.Minute)},
+			[]string{"critical:jailed"},
+		},
+		{
+			"still jailed",
+			&SigningInfo{JailedUntil: now.Add(10 * time.Minute)},
+			SigningInfo{JailedUntil: now.Add(10 * time.Minute)},
+			nil,
+		},
+		{
+			"tombstoned",
+			&SigningInfo{},
+			SigningInfo{Tombstoned: true, JailedUntil: now.Add(time.Hour)},
+			[]string{"critical:tombstoned"},
+		},
+		{
+			"still tombstoned",
+			&SigningInfo{Tombstoned: true},
+			SigningInfo{Tombstoned: true},
+			nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, events(EvaluateSigningInfo(tt.previous, tt.current, params, thresholds, now)))
+		})
+	}
+
+	alerts := EvaluateSigningInfo(nil, signing(60), params, thresholds, now)
+	require.Len(t, alerts, 1)
+	assert.Equal(t, "validator missed 60 blocks of 500 allowed in the last 1000 blocks", alerts[0].Message)
+	assert.Equal(t, "initvalcons1abc", alerts[0].ConsensusAddress)
+}
+
+func TestMissedBlockThresholdsValidate(t *testing.T) {
+	assert.NoError(t, MissedBlockThresholds{Warning: 50, Critical: 200}.Validate())
+	assert.Error(t, MissedBlockThresholds{Warning: 0, Critical: 200}.Validate())
+	assert.Error(t, MissedBlockThresholds{Warning: 300, Critical: 200}.Validate())
+}
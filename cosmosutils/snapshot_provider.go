@@ -0,0 +1,359 @@
+package cosmosutils
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+
+	"github.com/initia-labs/weave/client"
+	weaveio "github.com/initia-labs/weave/io"
+	"github.com/initia-labs/weave/registry"
+)
+
+const (
+	PolkachuSnapshotProvider  = "polkachu"
+	ManifestSnapshotProvider  = "manifest"
+	LocalFileSnapshotProvider = "local"
+)
+
+// SnapshotProvider is a source of snapshots, state sync servers and peers for a single network
+type SnapshotProvider interface {
+	// Name describes the provider in prompts
+	Name() string
+	// FetchSnapshotURL returns the URL of the latest snapshot
+	FetchSnapshotURL() (string, error)
+	FetchStateSyncURL() (string, error)
+	FetchStateSyncPeers() (string, error)
+	FetchPersistentPeers() (string, error)
+	DownloadAddrBook(dest string) error
+}
+
+// NewSnapshotProvider creates the provider of the given type for the network identified by chainId and chainType.
+// The location is the manifest or directory listing URL for a manifest provider and a file or directory for a local one.
+func NewSnapshotProvider(providerType, location, chainId string, chainType registry.ChainType) (SnapshotProvider, error) {
+	switch providerType {
+	case "", PolkachuSnapshotProvider:
+		return &PolkachuProvider{ChainId: chainId, ChainType: chainType}, nil
+	case ManifestSnapshotProvider:
+		if location == "" {
+			return nil, fmt.Errorf("the %s snapshot provider requires a manifest or directory listing URL", providerType)
+		}
+		return &ManifestProvider{URL: location, ChainId: chainId}, nil
+	case LocalFileSnapshotProvider:
+		if location == "" {
+			return nil, fmt.Errorf("the %s snapshot provider requires a snapshot file or directory", providerType)
+		}
+		return &LocalFileProvider{Path: location}, nil
+	default:
+		return nil, fmt.Errorf("unknown snapshot provider: %s", providerType)
+	}
+}
+
+// PolkachuProvider serves the snapshots, state sync servers, peers and addrbooks published by Polkachu
+type PolkachuProvider struct {
+	ChainId   string
+	ChainType registry.ChainType
+}
+
+func (p *PolkachuProvider) Name() string {
+	return "Polkachu"
+}
+
+func (p *PolkachuProvider) FetchSnapshotURL() (string, error) {
+	slug, ok := PolkachuChainIdSlugMap[p.ChainId]
+	if !ok {
+		return "", fmt.Errorf("polkachu does not provide snapshots for %s", p.ChainId)
+	}
+	return FetchPolkachuSnapshotDownloadURL(slug)
+}
+
+func (p *PolkachuProvider) FetchStateSyncURL() (string, error) {
+	return FetchPolkachuStateSyncURL(p.ChainType)
+}
+
+func (p *PolkachuProvider) FetchStateSyncPeers() (string, error) {
+	return FetchPolkachuStateSyncPeers(p.ChainType)
+}
+
+func (p *PolkachuProvider) FetchPersistentPeers() (string, error) {
+	return FetchPolkachuPersistentPeers(p.ChainType)
+}
+
+func (p *PolkachuProvider) DownloadAddrBook(dest string) error {
+	return DownloadPolkachuAddrBook(p.ChainType, dest)
+}
+
+// SnapshotManifest is the JSON document served by a manifest provider, keyed by chain ID
+type SnapshotManifest struct {
+	Chains map[string]SnapshotManifestChain `json:"chains"`
+}
+
+// SnapshotManifestChain lists what a manifest provides for one chain. Relative URLs are resolved against the manifest URL.
+type SnapshotManifestChain struct {
+	Snapshots       []SnapshotManifestEntry `json:"snapshots"`
+	StateSyncRPC    string                  `json:"state_sync_rpc,omitempty"`
+	StateSyncPeers  string                  `json:"state_sync_peers,omitempty"`
+	PersistentPeers string                  `json:"persistent_peers,omitempty"`
+	AddrBook        string                  `json:"addrbook,omitempty"`
+}
+
+type SnapshotManifestEntry struct {
+	URL    string `json:"url"`
+	Height int64  `json:"height,omitempty"`
+}
+
+// ManifestProvider reads a JSON manifest or, when the URL serves anything else, a directory listing such as an
+// nginx autoindex page or an S3 bucket listing. A directory listing only provides snapshots.
+type ManifestProvider struct {
+	URL     string
+	ChainId string
+}
+
+func (p *ManifestProvider) Name() string {
+	return p.URL
+}
+
+func (p *ManifestProvider) FetchSnapshotURL() (string, error) {
+	chain, base, err := p.fetchChain()
+	if err != nil {
+		return "", err
+	}
+	latest, err := latestSnapshot(chain.Snapshots)
+	if err != nil {
+		return "", fmt.Errorf("%w at %s", err, p.URL)
+	}
+	return resolveURL(base, latest)
+}
+
+func (p *ManifestProvider) FetchStateSyncURL() (string, error) {
+	chain, _, err := p.fetchChain()
+	if err != nil {
+		return "", err
+	}
+	if chain.StateSyncRPC == "" {
+		return "", fmt.Errorf("%s does not provide a state sync RPC server for %s", p.URL, p.ChainId)
+	}
+	return chain.StateSyncRPC, nil
+}
+
+func (p *ManifestProvider) FetchStateSyncPeers() (string, error) {
+	chain, _, err := p.fetchChain()
+	if err != nil {
+		return "", err
+	}
+	if chain.StateSyncPeers == "" {
+		return "", fmt.Errorf("%s does not provide state sync peers for %s", p.URL, p.ChainId)
+	}
+	return chain.StateSyncPeers, nil
+}
+
+func (p *ManifestProvider) FetchPersistentPeers() (string, error) {
+	chain, _, err := p.fetchChain()
+	if err != nil {
+		return "", err
+	}
+	if chain.PersistentPeers == "" {
+		return "", fmt.Errorf("%s does not provide persistent peers for %s", p.URL, p.ChainId)
+	}
+	return chain.PersistentPeers, nil
+}
+
+func (p *ManifestProvider) DownloadAddrBook(dest string) error {
+	chain, base, err := p.fetchChain()
+	if err != nil {
+		return err
+	}
+	if chain.AddrBook == "" {
+		return fmt.Errorf("%s does not provide an addrbook for %s", p.URL, p.ChainId)
+	}
+	addrBookURL, err := resolveURL(base, chain.AddrBook)
+	if err != nil {
+		return err
+	}
+
+	httpClient := client.NewHTTPClient()
+	if err = httpClient.DownloadFile(addrBookURL, dest, nil, nil); err != nil {
+		return fmt.Errorf("failed to download addrbook: %w", err)
+	}
+	return nil
+}
+
+// fetchChain returns the entry for the chain along with the URL its relative links are resolved against
+func (p *ManifestProvider) fetchChain() (*SnapshotManifestChain, string, error) {
+	httpClient := client.NewHTTPClient()
+	body, err := httpClient.Get(p.URL, "", nil, nil)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to fetch %s: %w", p.URL, err)
+	}
+
+	return parseSnapshotIndex(p.URL, p.ChainId, body)
+}
+
+func parseSnapshotIndex(indexURL, chainId string, body []byte) (*SnapshotManifestChain, string, error) {
+	if trimmed := bytes.TrimSpace(body); len(trimmed) > 0 && trimmed[0] == '{' {
+		var manifest SnapshotManifest
+		if err := json.Unmarshal(trimmed, &manifest); err != nil {
+			return nil, "", fmt.Errorf("failed to parse snapshot manifest %s: %w", indexURL, err)
+		}
+		chain, ok := manifest.Chains[chainId]
+		if !ok {
+			return nil, "", fmt.Errorf("snapshot manifest %s has no entry for %s", indexURL, chainId)
+		}
+		return &chain, indexURL, nil
+	}
+
+	doc, err := goquery.NewDocumentFromReader(bytes.NewReader(body))
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to parse directory listing %s: %w", indexURL, err)
+	}
+
+	// Links in a listing are relative to the directory, so make sure the base URL is one
+	base, err := url.Parse(indexURL)
+	if err != nil {
+		return nil, "", fmt.Errorf("invalid snapshot provider URL %s: %w", indexURL, err)
+	}
+	if !strings.HasSuffix(base.Path, "/") {
+		base.Path += "/"
+	}
+
+	var links []string
+	// Anchors cover HTML index pages and Key elements cover S3 bucket listings
+	doc.Find("a[href], key").Each(func(i int, s *goquery.Selection) {
+		link, exists := s.Attr("href")
+		if !exists {
+			link = strings.TrimSpace(s.Text())
+		}
+		if isSnapshotURL(link) {
+			links = append(links, link)
+		}
+	})
+
+	// A bucket shared by several chains is narrowed down to the snapshots named after this one
+	var chainLinks []string
+	for _, link := range links {
+		if strings.Contains(link, chainId) {
+			chainLinks = append(chainLinks, link)
+		}
+	}
+	if len(chainLinks) > 0 {
+		links = chainLinks
+	}
+
+	chain := &SnapshotManifestChain{}
+	for _, link := range links {
+		chain.Snapshots = append(chain.Snapshots, SnapshotManifestEntry{URL: link})
+	}
+	return chain, base.String(), nil
+}
+
+// LocalFileProvider restores from a snapshot file, or the latest snapshot in a directory, on this machine
+type LocalFileProvider struct {
+	Path string
+}
+
+func (p *LocalFileProvider) Name() string {
+	return p.Path
+}
+
+func (p *LocalFileProvider) FetchSnapshotURL() (string, error) {
+	snapshotPath, err := filepath.Abs(p.Path)
+	if err != nil {
+		return "", err
+	}
+	info, err := os.Stat(snapshotPath)
+	if err != nil {
+		return "", fmt.Errorf("failed to read snapshot path: %w", err)
+	}
+
+	if info.IsDir() {
+		entries, err := os.ReadDir(snapshotPath)
+		if err != nil {
+			return "", fmt.Errorf("failed to read snapshot directory: %w", err)
+		}
+		var snapshots []SnapshotManifestEntry
+		for _, entry := range entries {
+			if !entry.IsDir() && isSnapshotURL(entry.Name()) {
+				snapshots = append(snapshots, SnapshotManifestEntry{URL: entry.Name()})
+			}
+		}
+		latest, err := latestSnapshot(snapshots)
+		if err != nil {
+			return "", fmt.Errorf("%w in %s", err, snapshotPath)
+		}
+		snapshotPath = filepath.Join(snapshotPath, latest)
+	}
+
+	return client.LocalFileURLPrefix + snapshotPath, nil
+}
+
+func (p *LocalFileProvider) FetchStateSyncURL() (string, error) {
+	return "", fmt.Errorf("a local snapshot does not provide a state sync RPC server")
+}
+
+func (p *LocalFileProvider) FetchStateSyncPeers() (string, error) {
+	return "", fmt.Errorf("a local snapshot does not provide state sync peers")
+}
+
+func (p *LocalFileProvider) FetchPersistentPeers() (string, error) {
+	return "", fmt.Errorf("a local snapshot does not provide persistent peers")
+}
+
+func (p *LocalFileProvider) DownloadAddrBook(dest string) error {
+	return fmt.Errorf("a local snapshot does not provide an addrbook")
+}
+
+var digitsRegex = regexp.MustCompile(`\d+`)
+
+// snapshotHeight guesses the height of a snapshot from the last number in its file name, ignoring the extension
+func snapshotHeight(name string) int64 {
+	base := path.Base(name)
+	for _, extension := range weaveio.SnapshotFileExtensions {
+		base = strings.TrimSuffix(base, extension)
+	}
+	numbers := digitsRegex.FindAllString(base, -1)
+	if len(numbers) == 0 {
+		return 0
+	}
+	height, _ := strconv.ParseInt(numbers[len(numbers)-1], 10, 64)
+	return height
+}
+
+// latestSnapshot picks the highest snapshot, preferring the last one listed among equals
+func latestSnapshot(snapshots []SnapshotManifestEntry) (string, error) {
+	var latest string
+	latestHeight := int64(-1)
+	for _, snapshot := range snapshots {
+		height := snapshot.Height
+		if height == 0 {
+			height = snapshotHeight(snapshot.URL)
+		}
+		if height >= latestHeight {
+			latest, latestHeight = snapshot.URL, height
+		}
+	}
+	if latest == "" {
+		return "", fmt.Errorf("no snapshot found")
+	}
+	return latest, nil
+}
+
+func resolveURL(base, ref string) (string, error) {
+	baseURL, err := url.Parse(base)
+	if err != nil {
+		return "", fmt.Errorf("invalid snapshot provider URL %s: %w", base, err)
+	}
+	refURL, err := url.Parse(ref)
+	if err != nil {
+		return "", fmt.Errorf("invalid snapshot URL %s: %w", ref, err)
+	}
+	return baseURL.ResolveReference(refURL).String(), nil
+}
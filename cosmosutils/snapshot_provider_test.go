@@ -0,0 +1,116 @@
+package cosmosutils
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/initia-labs/weave/client"
+	"github.com/initia-labs/weave/registry"
+)
+
+func TestNewSnapshotProvider(t *testing.T) {
+	provider, err := NewSnapshotProvider("", "", "interwoven-1", registry.InitiaL1Mainnet)
+	assert.NoError(t, err)
+	assert.IsType(t, &PolkachuProvider{}, provider)
+
+	_, err = NewSnapshotProvider(ManifestSnapshotProvider, "", "interwoven-1", registry.InitiaL1Mainnet)
+	assert.Error(t, err)
+
+	_, err = NewSnapshotProvider("bucket", "https://example.com", "interwoven-1", registry.InitiaL1Mainnet)
+	assert.Error(t, err)
+}
+
+func TestManifestProvider(t *testing.T) {
+	manifest := `{
+		"chains": {
+			"interwoven-1": {
+				"snapshots": [
+					{"url": "interwoven-1/snapshot-200.tar.zst", "height": 200},
+					{"url": "https://mirror.example.com/snapshot-100.tar.lz4", "height": 100}
+				],
+				"state_sync_rpc": "https://rpc.example.com",
+				"persistent_peers": "id@1.2.3.4:26656"
+			}
+		}
+	}`
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(manifest))
+	}))
+	defer server.Close()
+
+	provider := &ManifestProvider{URL: server.URL + "/snapshots/manifest.json", ChainId: "interwoven-1"}
+	snapshotURL, err := provider.FetchSnapshotURL()
+	assert.NoError(t, err)
+	assert.Equal(t, server.URL+"/snapshots/interwoven-1/snapshot-200.tar.zst", snapshotURL)
+
+	stateSyncURL, err := provider.FetchStateSyncURL()
+	assert.NoError(t, err)
+	assert.Equal(t, "https://rpc.example.com", stateSyncURL)
+
+	_, err = provider.FetchStateSyncPeers()
+	assert.Error(t, err)
+
+	_, err = (&ManifestProvider{URL: server.URL, ChainId: "initiation-2"}).FetchSnapshotURL()
+	assert.Error(t, err)
+}
+
+func TestParseSnapshotIndex_DirectoryListing(t *testing.T) {
+	listing := `<html><body>
+		<a href="../">../</a>
+		<a href="initiation-2_900.tar.lz4">initiation-2_900.tar.lz4</a>
+		<a href="interwoven-1_1200.tar.lz4">interwoven-1_1200.tar.lz4</a>
+		<a href="interwoven-1_980.tar.lz4">interwoven-1_980.tar.lz4</a>
+		<a href="interwoven-1_1200.tar.lz4.sha256">interwoven-1_1200.tar.lz4.sha256</a>
+	</body></html>`
+
+	chain, base, err := parseSnapshotIndex("https://snapshots.example.com/initia", "interwoven-1", []byte(listing))
+	assert.NoError(t, err)
+	assert.Equal(t, "https://snapshots.example.com/initia/", base)
+	assert.Len(t, chain.Snapshots, 2)
+
+	latest, err := latestSnapshot(chain.Snapshots)
+	assert.NoError(t, err)
+	assert.Equal(t, "interwoven-1_1200.tar.lz4", latest)
+}
+
+func TestParseSnapshotIndex_S3Listing(t *testing.T) {
+	listing := `<?xml version="1.0" encoding="UTF-8"?>
+<ListBucketResult xmlns="http://s3.amazonaws.com/doc/2006-03-01/">
+	<Name>snapshots</Name>
+	<Contents><Key>initia/snapshot_300.tar.zst</Key></Contents>
+	<Contents><Key>initia/snapshot_400.tar.zst</Key></Contents>
+	<Contents><Key>initia/addrbook.json</Key></Contents>
+</ListBucketResult>`
+
+	chain, base, err := parseSnapshotIndex("https://snapshots.s3.amazonaws.com/", "interwoven-1", []byte(listing))
+	assert.NoError(t, err)
+	latest, err := latestSnapshot(chain.Snapshots)
+	assert.NoError(t, err)
+	snapshotURL, err := resolveURL(base, latest)
+	assert.NoError(t, err)
+	assert.Equal(t, "https://snapshots.s3.amazonaws.com/initia/snapshot_400.tar.zst", snapshotURL)
+}
+
+func TestLocalFileProvider(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"initia_100.tar.lz4", "initia_250.tar.zst", "notes.txt"} {
+		assert.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte("snapshot"), 0o644))
+	}
+
+	snapshotURL, err := (&LocalFileProvider{Path: dir}).FetchSnapshotURL()
+	assert.NoError(t, err)
+	assert.Equal(t, client.LocalFileURLPrefix+filepath.Join(dir, "initia_250.tar.zst"), snapshotURL)
+
+	file := filepath.Join(dir, "initia_100.tar.lz4")
+	snapshotURL, err = (&LocalFileProvider{Path: file}).FetchSnapshotURL()
+	assert.NoError(t, err)
+	assert.Equal(t, client.LocalFileURLPrefix+file, snapshotURL)
+
+	_, err = (&LocalFileProvider{Path: t.TempDir()}).FetchSnapshotURL()
+	assert.Error(t, err)
+}
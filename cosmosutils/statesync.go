@@ -2,11 +2,23 @@ package cosmosutils
 
 import (
 	"fmt"
+	"net/url"
+	"sort"
 	"strconv"
+	"strings"
+	"sync"
 
 	"github.com/initia-labs/weave/client"
 )
 
+const (
+	// stateSyncTrustOffset is how many blocks below the agreed latest height the trust height is taken
+	stateSyncTrustOffset = 2000
+	// MinStateSyncQuorum is the least number of RPC servers that must agree on the trust hash.
+	// It is also the number of rpc_servers CometBFT requires for state sync.
+	MinStateSyncQuorum = 2
+)
+
 type BlockResponse struct {
 	Result struct {
 		Block struct {
@@ -28,30 +40,188 @@ type HashResponse struct {
 type StateSyncInfo struct {
 	TrustHeight int
 	TrustHash   string
+	// RpcServers are the endpoints that agreed on the trust hash
+	RpcServers []string
+	// Disagreeing maps the endpoints that returned another hash at the trust height to that hash
+	Disagreeing map[string]string
+	// Unreachable maps the endpoints that could not be queried to the reason
+	Unreachable map[string]error
+}
+
+// Warning describes the endpoints that were left out of the quorum, or returns an empty string if every endpoint agreed
+func (i *StateSyncInfo) Warning() string {
+	excluded := i.excluded()
+	if len(excluded) == 0 {
+		return ""
+	}
+	return fmt.Sprintf("%d of %d state sync RPC servers were left out:\n%s",
+		len(excluded), len(excluded)+len(i.RpcServers), strings.Join(excluded, "\n"))
+}
+
+func (i *StateSyncInfo) excluded() []string {
+	var lines []string
+	for _, endpoint := range sortedKeys(i.Disagreeing) {
+		lines = append(lines, fmt.Sprintf("- %s returned block hash %s at height %d", endpoint, i.Disagreeing[endpoint], i.TrustHeight))
+	}
+	for _, endpoint := range sortedKeys(i.Unreachable) {
+		lines = append(lines, fmt.Sprintf("- %s could not be queried: %v", endpoint, i.Unreachable[endpoint]))
+	}
+	return lines
+}
+
+// GetStateSyncInfo cross-verifies the trust height and hash for state sync across rpcs.
+// The trust height is taken below the median latest height of the responding servers, and the block hash at that
+// height must be returned by a majority of them, and by at least MinStateSyncQuorum, for it to be trusted.
+func GetStateSyncInfo(rpcs []string) (*StateSyncInfo, error) {
+	endpoints := uniqueRPCEndpoints(rpcs)
+	if len(endpoints) < MinStateSyncQuorum {
+		return nil, fmt.Errorf("state sync needs at least %d distinct RPC servers to cross-verify the trust hash, got %d", MinStateSyncQuorum, len(endpoints))
+	}
+
+	info := &StateSyncInfo{
+		Disagreeing: make(map[string]string),
+		Unreachable: make(map[string]error),
+	}
+
+	latestHeights, errs := queryEndpoints(endpoints, fetchLatestHeight)
+	var responders []string
+	var heights []int
+	for i, endpoint := range endpoints {
+		if errs[i] != nil {
+			info.Unreachable[endpoint] = errs[i]
+			continue
+		}
+		responders = append(responders, endpoint)
+		heights = append(heights, latestHeights[i])
+	}
+	if len(responders) < MinStateSyncQuorum {
+		return nil, fmt.Errorf("only %d of %d state sync RPC servers responded:\n%s", len(responders), len(endpoints), strings.Join(info.excluded(), "\n"))
+	}
+
+	// The median keeps a single lagging or lying server from moving the trust height
+	sort.Ints(heights)
+	info.TrustHeight = heights[(len(heights)-1)/2] - stateSyncTrustOffset
+	if info.TrustHeight <= 0 {
+		return nil, fmt.Errorf("the chain is not tall enough for state sync: latest height is %d", heights[(len(heights)-1)/2])
+	}
+
+	hashes, errs := queryEndpoints(responders, func(endpoint string) (string, error) {
+		return fetchBlockHash(endpoint, info.TrustHeight)
+	})
+	agreeing := make(map[string][]string)
+	var answered int
+	for i, endpoint := range responders {
+		if errs[i] != nil {
+			info.Unreachable[endpoint] = errs[i]
+			continue
+		}
+		answered++
+		// Endpoints are grouped in the order they were given, so user supplied servers come first
+		agreeing[hashes[i]] = append(agreeing[hashes[i]], endpoint)
+	}
+
+	for hash, endpoints := range agreeing {
+		if len(endpoints) > len(info.RpcServers) {
+			info.TrustHash, info.RpcServers = hash, endpoints
+		}
+	}
+	for i, endpoint := range responders {
+		if errs[i] == nil && hashes[i] != info.TrustHash {
+			info.Disagreeing[endpoint] = hashes[i]
+		}
+	}
+
+	if len(info.RpcServers) < MinStateSyncQuorum || len(info.RpcServers)*2 <= answered {
+		var details []string
+		for i, endpoint := range responders {
+			if errs[i] == nil {
+				details = append(details, fmt.Sprintf("- %s: %s", endpoint, hashes[i]))
+			}
+		}
+		return nil, fmt.Errorf("state sync RPC servers do not agree on the block hash at height %d:\n%s", info.TrustHeight, strings.Join(details, "\n"))
+	}
+	return info, nil
 }
 
-func GetStateSyncInfo(url string) (*StateSyncInfo, error) {
+func fetchLatestHeight(endpoint string) (int, error) {
 	httpClient := client.NewHTTPClient()
 	var latestBlock BlockResponse
-	_, err := httpClient.Get(url, "/block", nil, &latestBlock)
-	if err != nil {
-		return nil, fmt.Errorf("Error fetching latest block height: %v\n", err)
+	if _, err := httpClient.Get(endpoint, "/block", nil, &latestBlock); err != nil {
+		return 0, fmt.Errorf("failed to fetch latest block height: %v", err)
 	}
 
 	latestHeight, err := strconv.Atoi(latestBlock.Result.Block.Header.Height)
 	if err != nil {
-		return nil, fmt.Errorf("Error converting block height to integer: %v\n", err)
+		return 0, fmt.Errorf("failed to convert block height to integer: %v", err)
 	}
-	blockHeight := latestHeight - 2000
+	return latestHeight, nil
+}
 
+func fetchBlockHash(endpoint string, height int) (string, error) {
+	httpClient := client.NewHTTPClient()
 	var trustHashResp HashResponse
-	_, err = httpClient.Get(url, "/block", map[string]string{"height": strconv.Itoa(blockHeight)}, &trustHashResp)
-	if err != nil {
-		return nil, fmt.Errorf("Error fetching trust hash: %v\n", err)
+	if _, err := httpClient.Get(endpoint, "/block", map[string]string{"height": strconv.Itoa(height)}, &trustHashResp); err != nil {
+		return "", fmt.Errorf("failed to fetch trust hash: %v", err)
+	}
+	if trustHashResp.Result.BlockID.Hash == "" {
+		return "", fmt.Errorf("no block hash returned for height %d", height)
+	}
+	return strings.ToUpper(trustHashResp.Result.BlockID.Hash), nil
+}
+
+// queryEndpoints runs query against every endpoint concurrently and returns the results in the same order
+func queryEndpoints[T any](endpoints []string, query func(string) (T, error)) ([]T, []error) {
+	results := make([]T, len(endpoints))
+	errs := make([]error, len(endpoints))
+
+	var wg sync.WaitGroup
+	for i, endpoint := range endpoints {
+		wg.Add(1)
+		go func(i int, endpoint string) {
+			defer wg.Done()
+			results[i], errs[i] = query(endpoint)
+		}(i, endpoint)
 	}
+	wg.Wait()
 
-	return &StateSyncInfo{
-		TrustHeight: blockHeight,
-		TrustHash:   trustHashResp.Result.BlockID.Hash,
-	}, nil
+	return results, errs
+}
+
+// uniqueRPCEndpoints normalizes the endpoints and drops duplicates such as the same server with and without its default port
+func uniqueRPCEndpoints(rpcs []string) []string {
+	seen := make(map[string]bool)
+	var endpoints []string
+	for _, rpc := range rpcs {
+		endpoint := normalizeRPCEndpoint(rpc)
+		if endpoint == "" || seen[endpoint] {
+			continue
+		}
+		seen[endpoint] = true
+		endpoints = append(endpoints, endpoint)
+	}
+	return endpoints
+}
+
+func normalizeRPCEndpoint(rpc string) string {
+	rpc = strings.TrimSpace(rpc)
+	u, err := url.Parse(rpc)
+	if err != nil || u.Host == "" {
+		return strings.TrimRight(rpc, "/")
+	}
+
+	u.Host = strings.ToLower(u.Host)
+	if (u.Scheme == "https" && u.Port() == "443") || (u.Scheme == "http" && u.Port() == "80") {
+		u.Host = u.Hostname()
+	}
+	u.Path = strings.TrimRight(u.Path, "/")
+	return u.String()
+}
+
+func sortedKeys[T any](m map[string]T) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
 }
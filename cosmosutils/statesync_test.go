@@ -0,0 +1,74 @@
+package cosmosutils
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// newStateSyncRPC serves the latest block at latestHeight and answers every height query with hash
+func newStateSyncRPC(latestHeight int, hash string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if height := r.URL.Query().Get("height"); height != "" {
+			_, _ = fmt.Fprintf(w, `{"result":{"block_id":{"hash":%q}}}`, hash)
+			return
+		}
+		_, _ = fmt.Fprintf(w, `{"result":{"block":{"header":{"height":"%d"}}}}`, latestHeight)
+	}))
+}
+
+func TestGetStateSyncInfo(t *testing.T) {
+	first := newStateSyncRPC(10_000, "abcd")
+	defer first.Close()
+	second := newStateSyncRPC(10_010, "ABCD")
+	defer second.Close()
+
+	info, err := GetStateSyncInfo([]string{first.URL, second.URL + "/", first.URL})
+	assert.NoError(t, err)
+	assert.Equal(t, 8_000, info.TrustHeight)
+	assert.Equal(t, "ABCD", info.TrustHash)
+	assert.Equal(t, []string{first.URL, second.URL}, info.RpcServers)
+	assert.Empty(t, info.Warning())
+}
+
+func TestGetStateSyncInfo_OutvotesDisagreeingServer(t *testing.T) {
+	first := newStateSyncRPC(10_000, "ABCD")
+	defer first.Close()
+	second := newStateSyncRPC(10_000, "ABCD")
+	defer second.Close()
+	// A server far ahead with another hash moves neither the trust height nor the trust hash
+	liar := newStateSyncRPC(90_000, "FFFF")
+	defer liar.Close()
+
+	info, err := GetStateSyncInfo([]string{liar.URL, first.URL, second.URL})
+	assert.NoError(t, err)
+	assert.Equal(t, 8_000, info.TrustHeight)
+	assert.Equal(t, "ABCD", info.TrustHash)
+	assert.Equal(t, []string{first.URL, second.URL}, info.RpcServers)
+	assert.Equal(t, map[string]string{liar.URL: "FFFF"}, info.Disagreeing)
+	assert.Contains(t, info.Warning(), liar.URL)
+}
+
+func TestGetStateSyncInfo_NoQuorum(t *testing.T) {
+	first := newStateSyncRPC(10_000, "ABCD")
+	defer first.Close()
+	second := newStateSyncRPC(10_000, "FFFF")
+	defer second.Close()
+
+	_, err := GetStateSyncInfo([]string{first.URL, second.URL})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "do not agree")
+
+	_, err = GetStateSyncInfo([]string{first.URL, first.URL + "/"})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "at least 2 distinct RPC servers")
+}
+
+func TestNormalizeRPCEndpoint(t *testing.T) {
+	assert.Equal(t, "https://rpc.example.com", normalizeRPCEndpoint(" https://RPC.example.com:443/ "))
+	assert.Equal(t, "http://rpc.example.com", normalizeRPCEndpoint("http://rpc.example.com:80"))
+	assert.Equal(t, "https://rpc.example.com:26657", normalizeRPCEndpoint("https://rpc.example.com:26657"))
+}
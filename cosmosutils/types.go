@@ -5,6 +5,7 @@ import (
 	"math/big"
 	"strings"
 
+	"github.com/initia-labs/weave/common"
 	"github.com/initia-labs/weave/styles"
 )
 
@@ -28,8 +29,44 @@ func (coin Coin) IsZero() bool {
 	return amountBigInt.Cmp(big.NewInt(0)) == 0
 }
 
+// ParseCoin parses an integer coin expression such as 1000000uinit
+func ParseCoin(coinStr string) (Coin, error) {
+	coinStr = strings.TrimSpace(coinStr)
+	split := strings.IndexFunc(coinStr, func(r rune) bool { return r < '0' || r > '9' })
+	if split <= 0 {
+		return Coin{}, fmt.Errorf("invalid coin expression %q: expected an amount followed by a denom, ex. 1000000uinit", coinStr)
+	}
+	amount, denom := coinStr[:split], coinStr[split:]
+	if _, ok := new(big.Int).SetString(amount, 10); !ok {
+		return Coin{}, fmt.Errorf("invalid coin amount %q", amount)
+	}
+	if err := common.ValidateDenom(denom); err != nil {
+		return Coin{}, fmt.Errorf("invalid coin denom %q: %w", denom, err)
+	}
+	return Coin{Denom: denom, Amount: amount}, nil
+}
+
+// BigAmount returns the amount as a big.Int, or zero if it cannot be parsed
+func (coin Coin) BigAmount() *big.Int {
+	amount, ok := new(big.Int).SetString(strings.TrimSpace(coin.Amount), 10)
+	if !ok {
+		return big.NewInt(0)
+	}
+	return amount
+}
+
 type Coins []Coin
 
+// AmountOf returns the amount of denom, or zero if there is none
+func (cs *Coins) AmountOf(denom string) *big.Int {
+	for _, coin := range *cs {
+		if coin.Denom == denom {
+			return coin.BigAmount()
+		}
+	}
+	return big.NewInt(0)
+}
+
 func (cs *Coins) Render(maxWidth int) string {
 	if len(*cs) == 0 {
 		return styles.CreateFrame(NoBalancesText, maxWidth)
@@ -72,6 +109,32 @@ type NodeInfoResponse struct {
 	} `json:"application_version"`
 }
 
+// ABCIInfoResponse is the CometBFT RPC /abci_info response
+type ABCIInfoResponse struct {
+	Result struct {
+		Response struct {
+			Version         string `json:"version"`
+			LastBlockHeight string `json:"last_block_height"`
+		} `json:"response"`
+	} `json:"result"`
+}
+
+// NodeStatusResponse is the CometBFT RPC /status response
+type NodeStatusResponse struct {
+	Result struct {
+		NodeInfo struct {
+			Id      string `json:"id"`
+			Network string `json:"network"`
+			Moniker string `json:"moniker"`
+		} `json:"node_info"`
+		SyncInfo struct {
+			LatestBlockHeight string `json:"latest_block_height"`
+			LatestBlockTime   string `json:"latest_block_time"`
+			CatchingUp        bool   `json:"catching_up"`
+		} `json:"sync_info"`
+	} `json:"result"`
+}
+
 type DecCoin struct {
 	Denom  string `protobuf:"bytes,1,opt,name=denom,proto3" json:"denom,omitempty"`
 	Amount string `protobuf:"bytes,2,opt,name=amount,proto3,customtype=cosmossdk.io/math.LegacyDec" json:"amount"`
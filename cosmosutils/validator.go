@@ -0,0 +1,252 @@
+package cosmosutils
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/initia-labs/weave/crypto"
+)
+
+// OperatorKeyFileName is the encrypted key file in the config directory of the node home that holds the operator keys
+const OperatorKeyFileName = "operator_keys.json"
+
+// ValidatorDescription holds the public profile of a validator. Empty fields are left out of the transaction.
+type ValidatorDescription struct {
+	Moniker         string
+	Identity        string
+	Website         string
+	SecurityContact string
+	Details         string
+}
+
+type ValidatorCommission struct {
+	Rate          string
+	MaxRate       string
+	MaxChangeRate string
+}
+
+// ValidatorInfo is the part of an on-chain validator weave reports on
+type ValidatorInfo struct {
+	OperatorAddress string `json:"operator_address"`
+	Jailed          bool   `json:"jailed"`
+	Status          string `json:"status"`
+	Description     struct {
+		Moniker string `json:"moniker"`
+	} `json:"description"`
+}
+
+// CreateValidatorJSON builds the file `initiad tx mstaking create-validator` reads its parameters from
+func CreateValidatorJSON(consensusPubKey json.RawMessage, amount string, description ValidatorDescription, commission ValidatorCommission) ([]byte, error) {
+	if description.Moniker == "" {
+		return nil, fmt.Errorf("validator moniker cannot be empty")
+	}
+	validator := map[string]interface{}{
+		"pubkey":                     consensusPubKey,
+		"amount":                     amount,
+		"moniker":                    description.Moniker,
+		"identity":                   description.Identity,
+		"website":                    description.Website,
+		"security":                   description.SecurityContact,
+		"details":                    description.Details,
+		"commission-rate":            commission.Rate,
+		"commission-max-rate":        commission.MaxRate,
+		"commission-max-change-rate": commission.MaxChangeRate,
+	}
+	return json.MarshalIndent(validator, "", "  ")
+}
+
+// EditValidatorArgs returns the `initiad tx mstaking edit-validator` arguments for the fields that are set
+func EditValidatorArgs(description ValidatorDescription, commissionRate string) []string {
+	args := []string{"tx", "mstaking", "edit-validator"}
+	for _, field := range []struct{ flag, value string }{
+		{"--new-moniker", description.Moniker},
+		{"--identity", description.Identity},
+		{"--website", description.Website},
+		{"--security-contact", description.SecurityContact},
+		{"--details", description.Details},
+		{"--commission-rate", commissionRate},
+	} {
+		if field.value != "" {
+			args = append(args, field.flag, field.value)
+		}
+	}
+	return args
+}
+
+// ValidatorFunding returns how much of the self-delegation denom the operator still needs,
+// so that the balance covers the self-delegation and reserve is left for fees
+func ValidatorFunding(balances Coins, selfDelegation Coin, reserve *big.Int) *big.Int {
+	required := new(big.Int).Add(selfDelegation.BigAmount(), reserve)
+	shortfall := required.Sub(required, balances.AmountOf(selfDelegation.Denom))
+	if shortfall.Sign() < 0 {
+		return big.NewInt(0)
+	}
+	return shortfall
+}
+
+// OperatorKeyFilePath returns the path of the operator key file of the node home
+func OperatorKeyFilePath(home string) string {
+	return filepath.Join(home, "config", OperatorKeyFileName)
+}
+
+// OperatorKey is the key validator transactions are signed with
+type OperatorKey struct {
+	Name    string
+	Address string
+	// Signer signs with a mnemonic of the operator key file. It is nil for a key of the test keyring of the node home,
+	// where older versions of weave kept the operator key.
+	Signer crypto.Signer
+}
+
+// NewOperatorKey returns the operator key derived from mnemonic
+func NewOperatorKey(name, mnemonic string) (OperatorKey, error) {
+	signer, err := crypto.NewMnemonicSigner(mnemonic, 118, crypto.DerivationOptions{})
+	if err != nil {
+		return OperatorKey{}, fmt.Errorf("failed to derive operator key %s: %v", name, err)
+	}
+	address, err := crypto.SignerAddress("init", signer)
+	if err != nil {
+		return OperatorKey{}, fmt.Errorf("failed to derive operator key %s: %v", name, err)
+	}
+	return OperatorKey{Name: name, Address: address, Signer: signer}, nil
+}
+
+// ValoperAddress returns the validator operator address of the key
+func (k OperatorKey) ValoperAddress() (string, error) {
+	valoper, err := crypto.ConvertAddress(k.Address, "initvaloper")
+	if err != nil {
+		return "", fmt.Errorf("failed to get the operator address of %s: %v", k.Name, err)
+	}
+	return valoper, nil
+}
+
+// OperatorKeyExists checks if keyName is in the test keyring of the node home
+func (te *InitiadTxExecutor) OperatorKeyExists(home, keyName string) bool {
+	cmd := exec.Command(te.binaryPath, "keys", "show", keyName, "--keyring-backend", "test", "--home", home)
+	return cmd.Run() == nil
+}
+
+// ShowOperatorKey returns the account of keyName in the test keyring of the node home
+func (te *InitiadTxExecutor) ShowOperatorKey(home, keyName string) (KeyInfo, error) {
+	cmd := exec.Command(te.binaryPath, "keys", "show", keyName, "--keyring-backend", "test", "--home", home, "--output", "json")
+	outputBytes, err := cmd.CombinedOutput()
+	if err != nil {
+		return KeyInfo{}, fmt.Errorf("failed to show key %s: %v, output: %s", keyName, err, string(outputBytes))
+	}
+	return UnmarshalKeyInfo(string(outputBytes))
+}
+
+// ConsensusPubKey returns the public key of priv_validator_key.json in the node home
+func (te *InitiadTxExecutor) ConsensusPubKey(home string) (json.RawMessage, error) {
+	cmd := exec.Command(te.binaryPath, "comet", "show-validator", "--home", home)
+	outputBytes, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to show the consensus public key: %v", err)
+	}
+	pubKey := json.RawMessage(bytes.TrimSpace(outputBytes))
+	if !json.Valid(pubKey) {
+		return nil, fmt.Errorf("unexpected consensus public key output: %s", string(outputBytes))
+	}
+	return pubKey, nil
+}
+
+// BroadcastCreateValidator signs MsgCreateValidator with key and waits until it is included in a block
+func (te *InitiadTxExecutor) BroadcastCreateValidator(home string, key OperatorKey, validatorJSON []byte, gasPrices, rpc, chainId string) (*InitiadTxResponse, error) {
+	validatorFile, err := os.CreateTemp("", "weave-validator-*.json")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create validator file: %v", err)
+	}
+	validatorPath := validatorFile.Name()
+	_ = validatorFile.Close()
+	defer func() {
+		_ = os.Remove(validatorPath)
+	}()
+	if err := os.WriteFile(validatorPath, validatorJSON, 0600); err != nil {
+		return nil, fmt.Errorf("failed to write validator file: %v", err)
+	}
+
+	return te.broadcastWithOperatorKey([]string{"tx", "mstaking", "create-validator", validatorPath}, home, key, gasPrices, rpc, chainId)
+}
+
+// BroadcastEditValidator updates the fields of the validator of key that are set
+func (te *InitiadTxExecutor) BroadcastEditValidator(home string, key OperatorKey, description ValidatorDescription, commissionRate, gasPrices, rpc, chainId string) (*InitiadTxResponse, error) {
+	return te.broadcastWithOperatorKey(EditValidatorArgs(description, commissionRate), home, key, gasPrices, rpc, chainId)
+}
+
+// BroadcastUnjail unjails the validator of key
+func (te *InitiadTxExecutor) BroadcastUnjail(home string, key OperatorKey, gasPrices, rpc, chainId string) (*InitiadTxResponse, error) {
+	return te.broadcastWithOperatorKey([]string{"tx", "slashing", "unjail"}, home, key, gasPrices, rpc, chainId)
+}
+
+func (te *InitiadTxExecutor) broadcastWithOperatorKey(args []string, home string, key OperatorKey, gasPrices, rpc, chainId string) (*InitiadTxResponse, error) {
+	if key.Signer != nil {
+		return broadcastWithSigner(te.binaryPath, key.Signer, args, gasPrices, rpc, chainId)
+	}
+
+	args = append(args, "--from", key.Name, "--keyring-backend", "test", "--home", home,
+		"--chain-id", chainId, "--gas", "auto", "--gas-adjustment", DefaultGasAdjustment,
+		"--gas-prices", gasPrices, "--node", rpc, "--output", "json", "--yes")
+	cmd := exec.Command(te.binaryPath, args...)
+	outputBytes, err := cmd.Output()
+	if err != nil {
+		var stderr []byte
+		if exitErr, ok := err.(*exec.ExitError); ok {
+			stderr = exitErr.Stderr
+		}
+		return nil, fmt.Errorf("failed to broadcast %s: %v, output: %s", strings.Join(args[:3], " "), err, string(stderr))
+	}
+
+	var txResponse InitiadTxResponse
+	if err := json.Unmarshal(outputBytes, &txResponse); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal JSON: %v", err)
+	}
+	if txResponse.Code != 0 {
+		return nil, fmt.Errorf("tx failed with error: %v", txResponse.RawLog)
+	}
+	if err := waitForTransactionInclusion(te.binaryPath, rpc, txResponse.TxHash); err != nil {
+		return nil, err
+	}
+
+	return &txResponse, nil
+}
+
+// QueryValidator returns the validator with the given operator address, or nil if there is none
+func (te *InitiadTxExecutor) QueryValidator(valoperAddress, rpc string) (*ValidatorInfo, error) {
+	cmd := exec.Command(te.binaryPath, "query", "mstaking", "validator", valoperAddress, "--node", rpc, "--output", "json")
+	outputBytes, err := cmd.CombinedOutput()
+	if err != nil {
+		if strings.Contains(strings.ToLower(string(outputBytes)), "not found") {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to query validator %s: %v, output: %s", valoperAddress, err, string(outputBytes))
+	}
+	return parseValidatorInfo(outputBytes)
+}
+
+// parseValidatorInfo accepts the validator both on its own and wrapped in a query response
+func parseValidatorInfo(data []byte) (*ValidatorInfo, error) {
+	var response struct {
+		Validator *ValidatorInfo `json:"validator"`
+	}
+	if err := json.Unmarshal(data, &response); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal validator: %v", err)
+	}
+	if response.Validator != nil {
+		return response.Validator, nil
+	}
+
+	var validator ValidatorInfo
+	if err := json.Unmarshal(data, &validator); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal validator: %v", err)
+	}
+	if validator.OperatorAddress == "" {
+		return nil, fmt.Errorf("unexpected validator output: %s", string(data))
+	}
+	return &validator, nil
+}
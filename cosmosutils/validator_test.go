@@ -0,0 +1,95 @@
+package cosmosutils
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/initia-labs/weave/crypto"
+)
+
+func TestCreateValidatorJSON(t *testing.T) {
+	pubKey := json.RawMessage(`{"@type":"/cosmos.crypto.ed25519.PubKey","key":"abc="}`)
+	data, err := CreateValidatorJSON(pubKey, "1000000uinit",
+		ValidatorDescription{Moniker: "my-node", Website: "https://example.com"},
+		ValidatorCommission{Rate: "0.10", MaxRate: "0.20", MaxChangeRate: "0.01"})
+	require.NoError(t, err)
+
+	var validator map[string]interface{}
+	require.NoError(t, json.Unmarshal(data, &validator))
+	assert.Equal(t, "1000000uinit", validator["amount"])
+	assert.Equal(t, "my-node", validator["moniker"])
+	assert.Equal(t, "https://example.com", validator["website"])
+	assert.Equal(t, "0.20", validator["commission-max-rate"])
+	assert.Equal(t, "/cosmos.crypto.ed25519.PubKey", validator["pubkey"].(map[string]interface{})["@type"])
+
+	_, err = CreateValidatorJSON(pubKey, "1000000uinit", ValidatorDescription{}, ValidatorCommission{})
+	assert.Error(t, err)
+}
+
+func TestEditValidatorArgs(t *testing.T) {
+	args := EditValidatorArgs(ValidatorDescription{Moniker: "renamed", Details: "hello world"}, "0.05")
+	assert.Equal(t, []string{"tx", "mstaking", "edit-validator", "--new-moniker", "renamed", "--details", "hello world", "--commission-rate", "0.05"}, args)
+
+	assert.Equal(t, []string{"tx", "mstaking", "edit-validator"}, EditValidatorArgs(ValidatorDescription{}, ""))
+}
+
+func TestParseCoin(t *testing.T) {
+	coin, err := ParseCoin(" 1000000uinit ")
+	require.NoError(t, err)
+	assert.Equal(t, Coin{Denom: "uinit", Amount: "1000000"}, coin)
+
+	coin, err = ParseCoin("5move/abc")
+	require.NoError(t, err)
+	assert.Equal(t, "move/abc", coin.Denom)
+
+	for _, invalid := range []string{"", "uinit", "1000000", "1.5uinit", "-1uinit"} {
+		_, err := ParseCoin(invalid)
+		assert.Error(t, err, invalid)
+	}
+}
+
+func TestValidatorFunding(t *testing.T) {
+	selfDelegation := Coin{Denom: "uinit", Amount: "1000000"}
+	reserve := big.NewInt(500000)
+
+	assert.Equal(t, "1500000", ValidatorFunding(Coins{}, selfDelegation, reserve).String())
+	assert.Equal(t, "1000000", ValidatorFunding(Coins{{Denom: "uinit", Amount: "500000"}, {Denom: "uusdc", Amount: "9999999"}}, selfDelegation, reserve).String())
+	assert.Equal(t, "0", ValidatorFunding(Coins{{Denom: "uinit", Amount: "2000000"}}, selfDelegation, reserve).String())
+}
+
+func TestParseValidatorInfo(t *testing.T) {
+	wrapped, err := parseValidatorInfo([]byte(`{"validator":{"operator_address":"initvaloper1abc","jailed":true,"description":{"moniker":"node"}}}`))
+	require.NoError(t, err)
+	assert.Equal(t, "initvaloper1abc", wrapped.OperatorAddress)
+	assert.True(t, wrapped.Jailed)
+	assert.Equal(t, "node", wrapped.Description.Moniker)
+
+	bare, err := parseValidatorInfo([]byte(`{"operator_address":"initvaloper1abc","status":"BOND_STATUS_BONDED"}`))
+	require.NoError(t, err)
+	assert.False(t, bare.Jailed)
+	assert.Equal(t, "BOND_STATUS_BONDED", bare.Status)
+
+	_, err = parseValidatorInfo([]byte(`{}`))
+	assert.Error(t, err)
+}
+
+func TestNewOperatorKey(t *testing.T) {
+	key, err := NewOperatorKey("validator", testMnemonic)
+	require.NoError(t, err)
+
+	address, err := crypto.MnemonicToBech32AddressWithCoinType("init", testMnemonic, 118)
+	require.NoError(t, err)
+	assert.Equal(t, address, key.Address)
+	assert.NotNil(t, key.Signer)
+
+	valoper, err := key.ValoperAddress()
+	require.NoError(t, err)
+	expected, err := crypto.ConvertAddress(address, "initvaloper")
+	require.NoError(t, err)
+	assert.Equal(t, expected, valoper)
+	assert.Contains(t, valoper, "initvaloper1")
+}
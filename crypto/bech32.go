@@ -43,22 +43,26 @@ func MnemonicToBech32Address(hrp, mnemonic string, addressType AddressType) (str
 
 // MnemonicToBech32AddressWithCoinType converts a mnemonic to a Cosmos SDK Bech32 address using a custom coin type.
 func MnemonicToBech32AddressWithCoinType(hrp, mnemonic string, coinType int) (string, error) {
-	seed, err := bip39.NewSeedWithErrorChecking(mnemonic, "")
+	addressBytes, err := MnemonicToAddressBytes(mnemonic, coinType, 0, 0)
 	if err != nil {
-		return "", fmt.Errorf("failed to generate seed: %w", err)
+		return "", err
 	}
 
-	masterKey, err := bip32.NewMasterKey(seed)
-	if err != nil {
-		return "", fmt.Errorf("failed to derive master key: %w", err)
-	}
+	return BytesToBech32(hrp, addressBytes)
+}
 
-	hdPath := fmt.Sprintf("m/44'/%d'/0'/0/0", coinType)
-	addressBytes, err := deriveAddressBytes(masterKey, hdPath, coinType)
-	if err != nil {
-		return "", fmt.Errorf("failed to derive address: %w", err)
-	}
+// HDPath returns the BIP44 derivation path for the given coin type, account and address index.
+func HDPath(coinType, account, index int) string {
+	return fmt.Sprintf("m/44'/%d'/%d'/0/%d", coinType, account, index)
+}
 
+// MnemonicToAddressBytes derives the raw address bytes of a mnemonic at the given account and address index.
+func MnemonicToAddressBytes(mnemonic string, coinType, account, index int) ([]byte, error) {
+	return MnemonicToAddressBytesWithOptions(mnemonic, coinType, DerivationOptions{HDPath: HDPath(coinType, account, index)})
+}
+
+// BytesToBech32 encodes raw address bytes as a Bech32 address with the given prefix.
+func BytesToBech32(hrp string, addressBytes []byte) (string, error) {
 	converted, err := bech32.ConvertBits(addressBytes, 8, 5, true)
 	if err != nil {
 		return "", fmt.Errorf("failed to convert to Bech32: %w", err)
@@ -71,13 +75,12 @@ func MnemonicToBech32AddressWithCoinType(hrp, mnemonic string, coinType int) (st
 	return bech32Addr, nil
 }
 
-func deriveAddressBytes(masterKey *bip32.Key, hdPath string, coinType int) ([]byte, error) {
-	derivedKey, err := deriveKey(masterKey, hdPath)
-	if err != nil {
-		return nil, fmt.Errorf("failed to derive child key: %w", err)
-	}
+func deriveAddressBytes(privateKey []byte, coinType int) []byte {
+	_, pubKey := btcec.PrivKeyFromBytes(privateKey)
+	return pubKeyToAddressBytes(pubKey, coinType)
+}
 
-	_, pubKey := btcec.PrivKeyFromBytes(derivedKey.Key)
+func pubKeyToAddressBytes(pubKey *btcec.PublicKey, coinType int) []byte {
 
 	// For EVM (coin type 60), use uncompressed public key and Keccak256
 	if coinType == 60 {
@@ -88,7 +91,7 @@ func deriveAddressBytes(masterKey *bip32.Key, hdPath string, coinType int) ([]by
 		hash.Write(pubKeyBytes)
 		hashBytes := hash.Sum(nil)
 
-		return hashBytes[len(hashBytes)-20:], nil
+		return hashBytes[len(hashBytes)-20:]
 	}
 
 	// For Cosmos (coin type 118 and others), use compressed public key with SHA256 + RIPEMD160
@@ -97,7 +100,7 @@ func deriveAddressBytes(masterKey *bip32.Key, hdPath string, coinType int) ([]by
 	ripemd := ripemd160.New()
 	ripemd.Write(shaHash[:])
 
-	return ripemd.Sum(nil), nil
+	return ripemd.Sum(nil)
 }
 
 // deriveKey derives the private key along the given HD path.
@@ -151,38 +154,40 @@ func GenerateMnemonic() (string, error) {
 
 // PubKeyToBech32Address converts a hex string public key to a Cosmos SDK Bech32 address.
 func PubKeyToBech32Address(pubKeyHex string) (string, error) {
-	// Remove "0x" prefix if present
-	pubKeyHex = strings.TrimPrefix(pubKeyHex, "0x")
-
-	// Pad odd-length hex strings with leading zero
-	if len(pubKeyHex)%2 != 0 {
-		pubKeyHex = "0" + pubKeyHex
-	}
+	return HexToBech32(InitHRP, pubKeyHex)
+}
 
-	// Decode the hex string to bytes
-	pubKeyBytes, err := hex.DecodeString(pubKeyHex)
+// HexToBech32 converts a hex address to a Bech32 address with the given prefix, padding it to 20 or 32 bytes.
+func HexToBech32(hrp, hexAddress string) (string, error) {
+	addressBytes, err := hexToAddressBytes(hexAddress)
 	if err != nil {
-		return "", fmt.Errorf("failed to decode hex string: %w", err)
+		return "", err
 	}
 
-	addressHash, err := getPaddedBytes(pubKeyBytes)
-	if err != nil {
-		return "", fmt.Errorf("failed to get padded bytes: %w", err)
+	return BytesToBech32(hrp, addressBytes)
+}
+
+func hexToAddressBytes(hexAddress string) ([]byte, error) {
+	// Remove "0x" prefix if present
+	hexAddress = strings.TrimPrefix(strings.TrimPrefix(hexAddress, "0x"), "0X")
+
+	// Pad odd-length hex strings with leading zero
+	if len(hexAddress)%2 != 0 {
+		hexAddress = "0" + hexAddress
 	}
 
-	// Convert to Bech32 format
-	converted, err := bech32.ConvertBits(addressHash, 8, 5, true)
+	// Decode the hex string to bytes
+	addressBytes, err := hex.DecodeString(hexAddress)
 	if err != nil {
-		return "", fmt.Errorf("failed to convert to Bech32: %w", err)
+		return nil, fmt.Errorf("failed to decode hex string: %w", err)
 	}
 
-	// Encode as Bech32 address
-	bech32Addr, err := bech32.Encode(InitHRP, converted)
+	paddedBytes, err := getPaddedBytes(addressBytes)
 	if err != nil {
-		return "", fmt.Errorf("failed to encode to Bech32: %w", err)
+		return nil, fmt.Errorf("failed to get padded bytes: %w", err)
 	}
 
-	return bech32Addr, nil
+	return paddedBytes, nil
 }
 
 // getPaddedBytes applies padding based on the length of pubKeyBytes
@@ -204,3 +209,47 @@ func getPaddedBytes(pubKeyBytes []byte) ([]byte, error) {
 
 	return paddedBytes, nil
 }
+
+// Bech32ToHex converts a Bech32 address of any prefix into its 0x-prefixed hex representation.
+func Bech32ToHex(address string) (string, error) {
+	addressBytes, err := bech32ToAddressBytes(address)
+	if err != nil {
+		return "", err
+	}
+
+	return "0x" + hex.EncodeToString(addressBytes), nil
+}
+
+func bech32ToAddressBytes(address string) ([]byte, error) {
+	_, data, err := bech32.Decode(address)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode Bech32 address: %w", err)
+	}
+
+	addressBytes, err := bech32.ConvertBits(data, 5, 8, false)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert from Bech32: %w", err)
+	}
+
+	return addressBytes, nil
+}
+
+// ConvertAddress converts a Bech32 or 0x hex address into another form.
+// The target is either "hex" or the Bech32 prefix to encode the address with.
+func ConvertAddress(address, to string) (string, error) {
+	var addressBytes []byte
+	var err error
+	if strings.HasPrefix(address, "0x") || strings.HasPrefix(address, "0X") {
+		addressBytes, err = hexToAddressBytes(address)
+	} else {
+		addressBytes, err = bech32ToAddressBytes(address)
+	}
+	if err != nil {
+		return "", err
+	}
+
+	if to == "hex" {
+		return "0x" + hex.EncodeToString(addressBytes), nil
+	}
+	return BytesToBech32(to, addressBytes)
+}
@@ -145,3 +145,109 @@ func TestMnemonicToBech32Address(t *testing.T) {
 		})
 	}
 }
+
+func TestBech32ToHex(t *testing.T) {
+	tests := []struct {
+		name      string
+		address   string
+		expected  string
+		expectErr bool
+	}{
+		{
+			name:     "Valid init address",
+			address:  "init1jvk3gadm45cxxg4g8y3c64h7thy3s36yat0ezy",
+			expected: "0x932d1475bbad306322a839238d56fe5dc9184744",
+		},
+		{
+			name:     "Valid 32 bytes init address",
+			address:  "init1v2xnnl08y508ec6q4q4hxqr2avdeyu3cew5rxghwnn5t3y4jhd2smmah7n",
+			expected: "0x628d39fde7251e7ce340a82b73006aeb1b927238cba83322ee9ce8b892b2bb55",
+		},
+		{
+			name:      "Invalid checksum",
+			address:   "init1jvk3gadm45cxxg4g8y3c64h7thy3s36yat0ezz",
+			expectErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hexAddress, err := Bech32ToHex(tt.address)
+			if tt.expectErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, hexAddress)
+		})
+	}
+}
+
+func TestConvertAddress(t *testing.T) {
+	tests := []struct {
+		name      string
+		address   string
+		to        string
+		expected  string
+		expectErr bool
+	}{
+		{
+			name:     "Bech32 to hex",
+			address:  "init1jvk3gadm45cxxg4g8y3c64h7thy3s36yat0ezy",
+			to:       "hex",
+			expected: "0x932d1475bbad306322a839238d56fe5dc9184744",
+		},
+		{
+			name:     "Hex to init",
+			address:  "0x932d1475bbad306322a839238d56fe5dc9184744",
+			to:       "init",
+			expected: "init1jvk3gadm45cxxg4g8y3c64h7thy3s36yat0ezy",
+		},
+		{
+			name:     "Init to celestia",
+			address:  "init19rl4cm2hmr8afy4kldpxz3fka4jguq0ajkdw5h",
+			to:       "celestia",
+			expected: "celestia19rl4cm2hmr8afy4kldpxz3fka4jguq0ad2ud9c",
+		},
+		{
+			name:     "Celestia to init",
+			address:  "celestia19rl4cm2hmr8afy4kldpxz3fka4jguq0ad2ud9c",
+			to:       "init",
+			expected: "init19rl4cm2hmr8afy4kldpxz3fka4jguq0ajkdw5h",
+		},
+		{
+			name:      "Invalid address",
+			address:   "not-an-address",
+			to:        "hex",
+			expectErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			converted, err := ConvertAddress(tt.address, tt.to)
+			if tt.expectErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, converted)
+		})
+	}
+}
+
+func TestMnemonicToAddressBytes(t *testing.T) {
+	mnemonic := "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about"
+
+	first, err := MnemonicToAddressBytes(mnemonic, 118, 0, 0)
+	assert.NoError(t, err)
+	address, err := BytesToBech32("init", first)
+	assert.NoError(t, err)
+	assert.Equal(t, "init19rl4cm2hmr8afy4kldpxz3fka4jguq0ajkdw5h", address)
+
+	second, err := MnemonicToAddressBytes(mnemonic, 118, 0, 1)
+	assert.NoError(t, err)
+	assert.NotEqual(t, first, second)
+
+	assert.Equal(t, "m/44'/60'/1'/0/2", HDPath(60, 1, 2))
+}
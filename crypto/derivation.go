@@ -0,0 +1,127 @@
+package crypto
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/tyler-smith/go-bip32"
+	"github.com/tyler-smith/go-bip39"
+)
+
+// DerivationOptions customizes how a key is derived from its mnemonic.
+// The zero value derives at the standard BIP44 path of the coin type without a BIP39 passphrase.
+type DerivationOptions struct {
+	BIP39Passphrase string `json:"bip39_passphrase,omitempty"`
+	HDPath          string `json:"hd_path,omitempty"`
+}
+
+// IsDefault reports whether the options derive keys the same way as the zero value
+func (o DerivationOptions) IsDefault() bool {
+	return o.BIP39Passphrase == "" && o.HDPath == ""
+}
+
+// PathForCoinType returns the custom HD path if set, otherwise the default path of the coin type
+func (o DerivationOptions) PathForCoinType(coinType int) string {
+	if o.HDPath != "" {
+		return o.HDPath
+	}
+	return HDPath(coinType, 0, 0)
+}
+
+// ForCoinType returns the options to derive the key of another coin type from the same mnemonic.
+// A custom BIP44 path keeps its account and index but takes the coin type, any other path is kept as is.
+func (o DerivationOptions) ForCoinType(coinType int) DerivationOptions {
+	parts := strings.Split(o.HDPath, "/")
+	if len(parts) > 2 && parts[0] == "m" && parts[1] == "44'" {
+		parts[2] = fmt.Sprintf("%d'", coinType)
+		o.HDPath = strings.Join(parts, "/")
+	}
+	return o
+}
+
+// ValidateHDPath checks that the path has the form m/44'/118'/0'/0/0 with indices below the hardened offset
+func ValidateHDPath(path string) error {
+	parts := strings.Split(path, "/")
+	if len(parts) < 2 || parts[0] != "m" {
+		return fmt.Errorf("invalid HD path %q: must start with m/", path)
+	}
+
+	for _, part := range parts[1:] {
+		n, err := strconv.ParseUint(strings.TrimSuffix(part, "'"), 10, 32)
+		if err != nil || n >= uint64(HardenedOffset) {
+			return fmt.Errorf("invalid HD path %q: bad index %q", path, part)
+		}
+	}
+
+	return nil
+}
+
+// MnemonicToAddressBytesWithOptions derives the raw address bytes of a mnemonic.
+// The coin type selects the address hashing scheme and the default path when no HD path is given.
+func MnemonicToAddressBytesWithOptions(mnemonic string, coinType int, opts DerivationOptions) ([]byte, error) {
+	derivedKey, err := mnemonicToDerivedKey(mnemonic, coinType, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return deriveAddressBytes(derivedKey.Key, coinType), nil
+}
+
+// MnemonicToBech32AddressWithOptions converts a mnemonic to a Bech32 address using custom derivation options.
+func MnemonicToBech32AddressWithOptions(hrp, mnemonic string, coinType int, opts DerivationOptions) (string, error) {
+	addressBytes, err := MnemonicToAddressBytesWithOptions(mnemonic, coinType, opts)
+	if err != nil {
+		return "", err
+	}
+
+	return BytesToBech32(hrp, addressBytes)
+}
+
+// MnemonicToPrivateKeyHex derives the hex-encoded secp256k1 private key of a mnemonic.
+func MnemonicToPrivateKeyHex(mnemonic string, coinType int, opts DerivationOptions) (string, error) {
+	derivedKey, err := mnemonicToDerivedKey(mnemonic, coinType, opts)
+	if err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(derivedKey.Key), nil
+}
+
+// PrivateKeyHexToBech32Address converts a hex-encoded secp256k1 private key to a Bech32 address.
+func PrivateKeyHexToBech32Address(hrp, privateKeyHex string, coinType int) (string, error) {
+	privateKey, err := hex.DecodeString(strings.TrimPrefix(privateKeyHex, "0x"))
+	if err != nil {
+		return "", fmt.Errorf("failed to decode private key: %w", err)
+	}
+	if len(privateKey) != 32 {
+		return "", fmt.Errorf("invalid private key length: %d bytes", len(privateKey))
+	}
+
+	return BytesToBech32(hrp, deriveAddressBytes(privateKey, coinType))
+}
+
+func mnemonicToDerivedKey(mnemonic string, coinType int, opts DerivationOptions) (*bip32.Key, error) {
+	hdPath := opts.PathForCoinType(coinType)
+	if err := ValidateHDPath(hdPath); err != nil {
+		return nil, err
+	}
+
+	seed, err := bip39.NewSeedWithErrorChecking(mnemonic, opts.BIP39Passphrase)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate seed: %w", err)
+	}
+
+	masterKey, err := bip32.NewMasterKey(seed)
+	if err != nil {
+		return nil, fmt.Errorf("failed to derive master key: %w", err)
+	}
+
+	derivedKey, err := deriveKey(masterKey, hdPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to derive address: %w", err)
+	}
+
+	return derivedKey, nil
+}
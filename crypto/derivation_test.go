@@ -0,0 +1,104 @@
+package crypto
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const testMnemonic = "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about"
+
+func TestMnemonicToBech32AddressWithOptions(t *testing.T) {
+	defaultAddress, err := MnemonicToBech32AddressWithCoinType(InitHRP, testMnemonic, 118)
+	assert.NoError(t, err)
+
+	address, err := MnemonicToBech32AddressWithOptions(InitHRP, testMnemonic, 118, DerivationOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, defaultAddress, address)
+
+	address, err = MnemonicToBech32AddressWithOptions(InitHRP, testMnemonic, 118, DerivationOptions{HDPath: CosmosHDPath})
+	assert.NoError(t, err)
+	assert.Equal(t, defaultAddress, address)
+
+	withPassphrase, err := MnemonicToBech32AddressWithOptions(InitHRP, testMnemonic, 118, DerivationOptions{BIP39Passphrase: "TREZOR"})
+	assert.NoError(t, err)
+	assert.NotEqual(t, defaultAddress, withPassphrase)
+
+	indexBytes, err := MnemonicToAddressBytes(testMnemonic, 118, 0, 1)
+	assert.NoError(t, err)
+	indexAddress, err := BytesToBech32(InitHRP, indexBytes)
+	assert.NoError(t, err)
+	customPath, err := MnemonicToBech32AddressWithOptions(InitHRP, testMnemonic, 118, DerivationOptions{HDPath: "m/44'/118'/0'/0/1"})
+	assert.NoError(t, err)
+	assert.Equal(t, indexAddress, customPath)
+
+	_, err = MnemonicToBech32AddressWithOptions(InitHRP, testMnemonic, 118, DerivationOptions{HDPath: "44'/118'/0'/0/0"})
+	assert.Error(t, err)
+}
+
+func TestMnemonicToPrivateKeyHex(t *testing.T) {
+	privKey, err := MnemonicToPrivateKeyHex(testMnemonic, 118, DerivationOptions{})
+	assert.NoError(t, err)
+	assert.Len(t, privKey, 64)
+
+	withPassphrase, err := MnemonicToPrivateKeyHex(testMnemonic, 118, DerivationOptions{BIP39Passphrase: "TREZOR"})
+	assert.NoError(t, err)
+	assert.NotEqual(t, privKey, withPassphrase)
+
+	expected, err := MnemonicToBech32AddressWithOptions(InitHRP, testMnemonic, 118, DerivationOptions{BIP39Passphrase: "TREZOR"})
+	assert.NoError(t, err)
+	address, err := PrivateKeyHexToBech32Address(InitHRP, withPassphrase, 118)
+	assert.NoError(t, err)
+	assert.Equal(t, expected, address)
+
+	_, err = PrivateKeyHexToBech32Address(InitHRP, "abcd", 118)
+	assert.Error(t, err)
+}
+
+func TestValidateHDPath(t *testing.T) {
+	tests := []struct {
+		name      string
+		path      string
+		expectErr bool
+	}{
+		{name: "Cosmos path", path: CosmosHDPath},
+		{name: "EVM path", path: EVMHDPath},
+		{name: "Short path", path: "m/0'"},
+		{name: "Missing root", path: "44'/118'/0'/0/0", expectErr: true},
+		{name: "Root only", path: "m", expectErr: true},
+		{name: "Empty index", path: "m/44'//0", expectErr: true},
+		{name: "Non-numeric index", path: "m/44'/abc'/0'/0/0", expectErr: true},
+		{name: "Index out of range", path: "m/44'/2147483648/0", expectErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateHDPath(tt.path)
+			if tt.expectErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestDerivationOptionsForCoinType(t *testing.T) {
+	tests := []struct {
+		name     string
+		opts     DerivationOptions
+		expected DerivationOptions
+	}{
+		{name: "Default", opts: DerivationOptions{}, expected: DerivationOptions{}},
+		{name: "EVM path", opts: DerivationOptions{HDPath: EVMHDPath}, expected: DerivationOptions{HDPath: CosmosHDPath}},
+		{name: "Custom account and index", opts: DerivationOptions{HDPath: HDPath(60, 2, 5)}, expected: DerivationOptions{HDPath: HDPath(118, 2, 5)}},
+		{name: "Passphrase is kept", opts: DerivationOptions{BIP39Passphrase: "secret"}, expected: DerivationOptions{BIP39Passphrase: "secret"}},
+		{name: "Non-BIP44 path", opts: DerivationOptions{HDPath: "m/0'/1"}, expected: DerivationOptions{HDPath: "m/0'/1"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.opts.ForCoinType(118))
+		})
+	}
+}
@@ -0,0 +1,126 @@
+package crypto
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+
+	"golang.org/x/crypto/scrypt"
+)
+
+const (
+	ScryptKDF     = "scrypt"
+	AES256GCM     = "aes-256-gcm"
+	saltLength    = 16
+	derivedKeyLen = 32
+)
+
+// DefaultScryptParams are the scrypt cost parameters used for new ciphertexts
+var DefaultScryptParams = ScryptParams{N: 1 << 15, R: 8, P: 1}
+
+type ScryptParams struct {
+	N int `json:"n"`
+	R int `json:"r"`
+	P int `json:"p"`
+}
+
+// EncryptedData is a self-describing, JSON-serializable passphrase-encrypted payload
+type EncryptedData struct {
+	KDF        string       `json:"kdf"`
+	KDFParams  ScryptParams `json:"kdf_params"`
+	Cipher     string       `json:"cipher"`
+	Salt       string       `json:"salt"`
+	Nonce      string       `json:"nonce"`
+	Ciphertext string       `json:"ciphertext"`
+}
+
+// Encrypt seals the plaintext with AES-256-GCM using a key derived from the passphrase with scrypt
+func Encrypt(plaintext []byte, passphrase string) (*EncryptedData, error) {
+	if passphrase == "" {
+		return nil, fmt.Errorf("passphrase cannot be empty")
+	}
+
+	salt := make([]byte, saltLength)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, fmt.Errorf("failed to generate salt: %v", err)
+	}
+
+	params := DefaultScryptParams
+	gcm, err := newGCM(passphrase, salt, params)
+	if err != nil {
+		return nil, err
+	}
+
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, fmt.Errorf("failed to generate nonce: %v", err)
+	}
+
+	return &EncryptedData{
+		KDF:        ScryptKDF,
+		KDFParams:  params,
+		Cipher:     AES256GCM,
+		Salt:       base64.StdEncoding.EncodeToString(salt),
+		Nonce:      base64.StdEncoding.EncodeToString(nonce),
+		Ciphertext: base64.StdEncoding.EncodeToString(gcm.Seal(nil, nonce, plaintext, nil)),
+	}, nil
+}
+
+// Decrypt opens the payload with the given passphrase
+func (e *EncryptedData) Decrypt(passphrase string) ([]byte, error) {
+	if e.KDF != ScryptKDF {
+		return nil, fmt.Errorf("unsupported key derivation function: %s", e.KDF)
+	}
+	if e.Cipher != AES256GCM {
+		return nil, fmt.Errorf("unsupported cipher: %s", e.Cipher)
+	}
+
+	salt, err := base64.StdEncoding.DecodeString(e.Salt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode salt: %v", err)
+	}
+	nonce, err := base64.StdEncoding.DecodeString(e.Nonce)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode nonce: %v", err)
+	}
+	ciphertext, err := base64.StdEncoding.DecodeString(e.Ciphertext)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode ciphertext: %v", err)
+	}
+
+	gcm, err := newGCM(passphrase, salt, e.KDFParams)
+	if err != nil {
+		return nil, err
+	}
+	if len(nonce) != gcm.NonceSize() {
+		return nil, fmt.Errorf("invalid nonce length: %d", len(nonce))
+	}
+
+	plaintext, err := gcm.Open(nil, nonce, ciphertext, nil)
+	if err != nil {
+		return nil, fmt.Errorf("incorrect passphrase or corrupted data")
+	}
+
+	return plaintext, nil
+}
+
+func newGCM(passphrase string, salt []byte, params ScryptParams) (cipher.AEAD, error) {
+	key, err := scrypt.Key([]byte(passphrase), salt, params.N, params.R, params.P, derivedKeyLen)
+	if err != nil {
+		return nil, fmt.Errorf("failed to derive key: %v", err)
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cipher: %v", err)
+	}
+
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create gcm: %v", err)
+	}
+
+	return gcm, nil
+}
@@ -0,0 +1,50 @@
+package crypto
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEncryptDecrypt(t *testing.T) {
+	plaintext := []byte("abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about")
+
+	encrypted, err := Encrypt(plaintext, "correct horse")
+	assert.NoError(t, err)
+	assert.Equal(t, ScryptKDF, encrypted.KDF)
+	assert.Equal(t, AES256GCM, encrypted.Cipher)
+	assert.NotContains(t, encrypted.Ciphertext, "abandon")
+
+	tests := []struct {
+		name       string
+		passphrase string
+		expectErr  bool
+	}{
+		{
+			name:       "Correct passphrase",
+			passphrase: "correct horse",
+		},
+		{
+			name:       "Wrong passphrase",
+			passphrase: "battery staple",
+			expectErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			decrypted, err := encrypted.Decrypt(tt.passphrase)
+			if tt.expectErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, plaintext, decrypted)
+		})
+	}
+}
+
+func TestEncryptEmptyPassphrase(t *testing.T) {
+	_, err := Encrypt([]byte("secret"), "")
+	assert.Error(t, err)
+}
@@ -0,0 +1,245 @@
+package crypto
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os/exec"
+	"strings"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/btcec/v2/ecdsa"
+	"golang.org/x/crypto/sha3"
+)
+
+type KeyType string
+
+const (
+	Secp256k1KeyType    KeyType = "secp256k1"
+	EthSecp256k1KeyType KeyType = "eth_secp256k1"
+)
+
+// KeyTypeForCoinType returns the key type the chain expects for keys of the given coin type
+func KeyTypeForCoinType(coinType int) KeyType {
+	if coinType == 60 {
+		return EthSecp256k1KeyType
+	}
+	return Secp256k1KeyType
+}
+
+// PubKeyTypeURL returns the protobuf type URL of public keys of this type
+func (k KeyType) PubKeyTypeURL() string {
+	if k == EthSecp256k1KeyType {
+		return "/initia.crypto.v1beta1.ethsecp256k1.PubKey"
+	}
+	return "/cosmos.crypto.secp256k1.PubKey"
+}
+
+// CoinType returns the coin type whose address scheme matches this key type
+func (k KeyType) CoinType() int {
+	if k == EthSecp256k1KeyType {
+		return 60
+	}
+	return 118
+}
+
+// digest hashes sign bytes the way the chain does before verifying a signature of this key type
+func (k KeyType) digest(signBytes []byte) []byte {
+	if k == EthSecp256k1KeyType {
+		hash := sha3.NewLegacyKeccak256()
+		hash.Write(signBytes)
+		return hash.Sum(nil)
+	}
+	digest := sha256.Sum256(signBytes)
+	return digest[:]
+}
+
+// SignRequest describes the transaction being signed. SignBytes are the SIGN_MODE_DIRECT sign doc bytes.
+type SignRequest struct {
+	ChainId       string  `json:"chain_id"`
+	AccountNumber uint64  `json:"account_number,string"`
+	Sequence      uint64  `json:"sequence,string"`
+	Address       string  `json:"address"`
+	KeyType       KeyType `json:"key_type"`
+	PubKey        []byte  `json:"pub_key"`
+	SignBytes     []byte  `json:"sign_bytes"`
+}
+
+// Signer signs transactions on behalf of a single secp256k1 key
+type Signer interface {
+	KeyType() KeyType
+	// PubKey returns the compressed public key of the signing key
+	PubKey() ([]byte, error)
+	// Sign returns the 64-byte R || S signature over the request sign bytes
+	Sign(req SignRequest) ([]byte, error)
+}
+
+// SignerAddress returns the Bech32 address of the signer with the given prefix
+func SignerAddress(hrp string, signer Signer) (string, error) {
+	pubKeyBytes, err := signer.PubKey()
+	if err != nil {
+		return "", err
+	}
+	return SecpPubKeyToBech32Address(hrp, pubKeyBytes, signer.KeyType().CoinType())
+}
+
+// SecpPubKeyToBech32Address converts a compressed secp256k1 public key to a Bech32 address.
+func SecpPubKeyToBech32Address(hrp string, pubKeyBytes []byte, coinType int) (string, error) {
+	pubKey, err := btcec.ParsePubKey(pubKeyBytes)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse public key: %w", err)
+	}
+	return BytesToBech32(hrp, pubKeyToAddressBytes(pubKey, coinType))
+}
+
+// VerifySignature checks a 64-byte R || S signature over sign bytes the same way the chain does
+func VerifySignature(keyType KeyType, pubKeyBytes, signBytes, signature []byte) error {
+	pubKey, err := btcec.ParsePubKey(pubKeyBytes)
+	if err != nil {
+		return fmt.Errorf("failed to parse public key: %w", err)
+	}
+	if len(signature) != 64 {
+		return fmt.Errorf("invalid signature length: %d bytes", len(signature))
+	}
+
+	var r, s btcec.ModNScalar
+	if r.SetByteSlice(signature[:32]) || s.SetByteSlice(signature[32:]) {
+		return fmt.Errorf("invalid signature: value out of range")
+	}
+	if s.IsOverHalfOrder() {
+		return fmt.Errorf("invalid signature: s is not in the lower half order")
+	}
+	if !ecdsa.NewSignature(&r, &s).Verify(keyType.digest(signBytes), pubKey) {
+		return fmt.Errorf("signature does not match the public key")
+	}
+	return nil
+}
+
+// MnemonicSigner signs with a key derived from a mnemonic held in memory
+type MnemonicSigner struct {
+	privateKey *btcec.PrivateKey
+	keyType    KeyType
+}
+
+func NewMnemonicSigner(mnemonic string, coinType int, opts DerivationOptions) (*MnemonicSigner, error) {
+	derivedKey, err := mnemonicToDerivedKey(mnemonic, coinType, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	privateKey, _ := btcec.PrivKeyFromBytes(derivedKey.Key)
+	return &MnemonicSigner{
+		privateKey: privateKey,
+		keyType:    KeyTypeForCoinType(coinType),
+	}, nil
+}
+
+func (s *MnemonicSigner) KeyType() KeyType {
+	return s.keyType
+}
+
+func (s *MnemonicSigner) PubKey() ([]byte, error) {
+	return s.privateKey.PubKey().SerializeCompressed(), nil
+}
+
+func (s *MnemonicSigner) Sign(req SignRequest) ([]byte, error) {
+	// SignCompact prefixes the canonical low-S signature with a recovery byte, which the chain does not use
+	signature := ecdsa.SignCompact(s.privateKey, s.keyType.digest(req.SignBytes), true)
+	return signature[1:], nil
+}
+
+// ExternalSignerConfig configures a signer that delegates signing to another process, such as a KMS bridge
+type ExternalSignerConfig struct {
+	// Command is the executable followed by its arguments
+	Command []string `json:"command"`
+	// PubKey is the hex-encoded compressed public key of the key held by the command
+	PubKey string `json:"pub_key"`
+}
+
+// PubKeyBytes decodes the configured public key and checks that it is a valid secp256k1 key
+func (c ExternalSignerConfig) PubKeyBytes() ([]byte, error) {
+	pubKeyBytes, err := DecodePubKey(c.PubKey)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := btcec.ParsePubKey(pubKeyBytes); err != nil {
+		return nil, fmt.Errorf("failed to parse public key: %w", err)
+	}
+	return pubKeyBytes, nil
+}
+
+// DecodePubKey accepts a compressed public key encoded as hex or base64
+func DecodePubKey(encoded string) ([]byte, error) {
+	encoded = strings.TrimSpace(encoded)
+	if pubKeyBytes, err := hex.DecodeString(strings.TrimPrefix(encoded, "0x")); err == nil && len(pubKeyBytes) == btcec.PubKeyBytesLenCompressed {
+		return pubKeyBytes, nil
+	}
+	if pubKeyBytes, err := base64.StdEncoding.DecodeString(encoded); err == nil {
+		return pubKeyBytes, nil
+	}
+	return nil, fmt.Errorf("public key must be hex or base64 encoded")
+}
+
+// ExternalSigner runs the configured command for every signature.
+// The command receives the SignRequest as JSON on stdin and must print the base64-encoded signature on stdout.
+type ExternalSigner struct {
+	config  ExternalSignerConfig
+	keyType KeyType
+}
+
+func NewExternalSigner(config ExternalSignerConfig, keyType KeyType) (*ExternalSigner, error) {
+	if len(config.Command) == 0 || config.Command[0] == "" {
+		return nil, fmt.Errorf("external signer command is empty")
+	}
+	if _, err := config.PubKeyBytes(); err != nil {
+		return nil, fmt.Errorf("invalid external signer public key: %w", err)
+	}
+
+	return &ExternalSigner{config: config, keyType: keyType}, nil
+}
+
+func (s *ExternalSigner) KeyType() KeyType {
+	return s.keyType
+}
+
+func (s *ExternalSigner) PubKey() ([]byte, error) {
+	return s.config.PubKeyBytes()
+}
+
+func (s *ExternalSigner) Sign(req SignRequest) ([]byte, error) {
+	pubKey, err := s.PubKey()
+	if err != nil {
+		return nil, err
+	}
+	req.KeyType = s.keyType
+	req.PubKey = pubKey
+
+	input, err := json.Marshal(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal sign request: %w", err)
+	}
+
+	var stdout, stderr bytes.Buffer
+	cmd := exec.Command(s.config.Command[0], s.config.Command[1:]...)
+	cmd.Stdin = bytes.NewReader(input)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("external signer failed: %w, stderr: %s", err, strings.TrimSpace(stderr.String()))
+	}
+
+	signature, err := base64.StdEncoding.DecodeString(strings.TrimSpace(stdout.String()))
+	if err != nil {
+		return nil, fmt.Errorf("external signer returned a malformed signature: %w", err)
+	}
+
+	// Catch a misconfigured bridge here rather than as an opaque signature verification failure on chain
+	if err := VerifySignature(s.keyType, pubKey, req.SignBytes, signature); err != nil {
+		return nil, fmt.Errorf("external signer returned an invalid signature: %w", err)
+	}
+
+	return signature, nil
+}
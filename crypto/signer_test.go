@@ -0,0 +1,96 @@
+package crypto
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMnemonicSigner(t *testing.T) {
+	signBytes := []byte("sign doc bytes")
+
+	for _, coinType := range []int{118, 60} {
+		t.Run(fmt.Sprintf("coin type %d", coinType), func(t *testing.T) {
+			signer, err := NewMnemonicSigner(testMnemonic, coinType, DerivationOptions{})
+			assert.NoError(t, err)
+
+			expected, err := MnemonicToBech32AddressWithCoinType(InitHRP, testMnemonic, coinType)
+			assert.NoError(t, err)
+			address, err := SignerAddress(InitHRP, signer)
+			assert.NoError(t, err)
+			assert.Equal(t, expected, address)
+
+			signature, err := signer.Sign(SignRequest{SignBytes: signBytes})
+			assert.NoError(t, err)
+			assert.Len(t, signature, 64)
+
+			pubKey, err := signer.PubKey()
+			assert.NoError(t, err)
+			assert.NoError(t, VerifySignature(signer.KeyType(), pubKey, signBytes, signature))
+			assert.Error(t, VerifySignature(signer.KeyType(), pubKey, []byte("other bytes"), signature))
+		})
+	}
+}
+
+// writeStubSigner writes a script that records its stdin and prints the given output
+func writeStubSigner(t *testing.T, output string) (string, string) {
+	dir := t.TempDir()
+	requestPath := filepath.Join(dir, "request.json")
+	scriptPath := filepath.Join(dir, "signer.sh")
+	script := fmt.Sprintf("#!/bin/sh\ncat > %q\necho %q\n", requestPath, output)
+	assert.NoError(t, os.WriteFile(scriptPath, []byte(script), 0o755))
+	return scriptPath, requestPath
+}
+
+func TestExternalSigner(t *testing.T) {
+	mnemonicSigner, err := NewMnemonicSigner(testMnemonic, 118, DerivationOptions{})
+	assert.NoError(t, err)
+	pubKey, err := mnemonicSigner.PubKey()
+	assert.NoError(t, err)
+
+	req := SignRequest{ChainId: "initiation-2", AccountNumber: 7, Sequence: 3, SignBytes: []byte("sign doc bytes")}
+	signature, err := mnemonicSigner.Sign(req)
+	assert.NoError(t, err)
+
+	scriptPath, requestPath := writeStubSigner(t, base64.StdEncoding.EncodeToString(signature))
+	signer, err := NewExternalSigner(ExternalSignerConfig{
+		Command: []string{scriptPath},
+		PubKey:  hex.EncodeToString(pubKey),
+	}, Secp256k1KeyType)
+	assert.NoError(t, err)
+
+	externalSignature, err := signer.Sign(req)
+	assert.NoError(t, err)
+	assert.Equal(t, signature, externalSignature)
+
+	content, err := os.ReadFile(requestPath)
+	assert.NoError(t, err)
+	var received SignRequest
+	assert.NoError(t, json.Unmarshal(content, &received))
+	assert.Equal(t, req.ChainId, received.ChainId)
+	assert.Equal(t, req.AccountNumber, received.AccountNumber)
+	assert.Equal(t, req.Sequence, received.Sequence)
+	assert.Equal(t, req.SignBytes, received.SignBytes)
+	assert.Equal(t, Secp256k1KeyType, received.KeyType)
+	assert.Equal(t, pubKey, received.PubKey)
+
+	_, err = signer.Sign(SignRequest{SignBytes: []byte("different bytes")})
+	assert.Error(t, err, "a signature over other bytes must be rejected")
+
+	scriptPath, _ = writeStubSigner(t, "not-base64!")
+	signer, err = NewExternalSigner(ExternalSignerConfig{Command: []string{scriptPath}, PubKey: base64.StdEncoding.EncodeToString(pubKey)}, Secp256k1KeyType)
+	assert.NoError(t, err)
+	_, err = signer.Sign(req)
+	assert.Error(t, err)
+
+	_, err = NewExternalSigner(ExternalSignerConfig{PubKey: hex.EncodeToString(pubKey)}, Secp256k1KeyType)
+	assert.Error(t, err)
+	_, err = NewExternalSigner(ExternalSignerConfig{Command: []string{scriptPath}, PubKey: "abcd"}, Secp256k1KeyType)
+	assert.Error(t, err)
+}
@@ -0,0 +1,206 @@
+package io
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/initia-labs/weave/common"
+)
+
+const (
+	// BundleManifestFilename is the archive entry CreateBundle writes the BundleManifest to
+	BundleManifestFilename = "bundle_manifest.json"
+	// BundleCacheDirectory is where InstallBundle keeps the cached responses, relative to the weave data directory
+	BundleCacheDirectory = "bundle_cache"
+
+	bundleDataPrefix  = "data/"
+	bundleCachePrefix = "cache/"
+)
+
+// BundleComponent is a release directory of the weave data directory carried by a bundle
+type BundleComponent struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+	Path    string `json:"path"`
+}
+
+// BundleManifest describes what a bundle carries. The binaries only run on Platform.
+type BundleManifest struct {
+	Platform   string            `json:"platform"`
+	CreatedAt  time.Time         `json:"created_at"`
+	Components []BundleComponent `json:"components"`
+	CachedURLs []string          `json:"cached_urls"`
+}
+
+// BundlePlatform returns the platform of the running binary in the form BundleManifest uses
+func BundlePlatform() string {
+	return runtime.GOOS + "/" + runtime.GOARCH
+}
+
+// BundleCacheFilename returns the file the cached response of url is kept in
+func BundleCacheFilename(url string) string {
+	sum := sha256.Sum256([]byte(url))
+	return hex.EncodeToString(sum[:]) + ".json"
+}
+
+// CreateBundle writes a gzipped tar archive of the component directories under dataDir and the cached responses keyed by URL to w.
+// The manifest is written as the first entry so that ReadBundleManifest can find it without decompressing everything.
+func CreateBundle(w io.Writer, dataDir string, manifest BundleManifest, cache map[string][]byte) error {
+	manifest.CachedURLs = make([]string, 0, len(cache))
+	for url := range cache {
+		manifest.CachedURLs = append(manifest.CachedURLs, url)
+	}
+	sort.Strings(manifest.CachedURLs)
+	for _, component := range manifest.Components {
+		if err := validateBundlePath(component.Path); err != nil {
+			return err
+		}
+	}
+
+	encoder := gzip.NewWriter(w)
+	tarWriter := tar.NewWriter(encoder)
+	manifestBytes, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal bundle manifest: %w", err)
+	}
+	if err := writeTarEntry(tarWriter, BundleManifestFilename, manifestBytes, manifest.CreatedAt); err != nil {
+		return fmt.Errorf("failed to write bundle manifest: %w", err)
+	}
+
+	for _, url := range manifest.CachedURLs {
+		if err := writeTarEntry(tarWriter, bundleCachePrefix+BundleCacheFilename(url), cache[url], manifest.CreatedAt); err != nil {
+			return fmt.Errorf("failed to write the cached response of %s: %w", url, err)
+		}
+	}
+	for _, component := range manifest.Components {
+		if err := addToTar(tarWriter, dataDir, component.Path, bundleDataPrefix); err != nil {
+			return fmt.Errorf("failed to add %s to the bundle: %w", component.Path, err)
+		}
+	}
+
+	if err := tarWriter.Close(); err != nil {
+		return fmt.Errorf("failed to finish bundle archive: %w", err)
+	}
+	if err := encoder.Close(); err != nil {
+		return fmt.Errorf("failed to finish bundle compression: %w", err)
+	}
+	return nil
+}
+
+// ReadBundleManifest returns the manifest of a bundle made by CreateBundle
+func ReadBundleManifest(r io.Reader) (*BundleManifest, error) {
+	decoder, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, fmt.Errorf("invalid bundle: %w", err)
+	}
+	defer decoder.Close()
+
+	tarReader := tar.NewReader(decoder)
+	header, err := tarReader.Next()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read bundle: %w", err)
+	}
+	if path.Clean(header.Name) != BundleManifestFilename {
+		return nil, fmt.Errorf("invalid bundle: %s is missing", BundleManifestFilename)
+	}
+
+	var manifest BundleManifest
+	if err := json.NewDecoder(tarReader).Decode(&manifest); err != nil {
+		return nil, fmt.Errorf("failed to parse bundle manifest: %w", err)
+	}
+	return &manifest, nil
+}
+
+// InstallBundle unpacks a bundle made by CreateBundle into dataDir. Component directories that already exist are replaced.
+// The archive is extracted next to dataDir first, so that a broken bundle leaves dataDir untouched.
+func InstallBundle(r io.Reader, dataDir string) (*BundleManifest, error) {
+	if err := os.MkdirAll(dataDir, os.ModePerm); err != nil {
+		return nil, fmt.Errorf("failed to create %s: %w", dataDir, err)
+	}
+	staging, err := os.MkdirTemp(dataDir, ".bundle-")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create staging directory: %w", err)
+	}
+	defer os.RemoveAll(staging)
+
+	decoder, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, fmt.Errorf("invalid bundle: %w", err)
+	}
+	defer decoder.Close()
+	if err := ExtractTar(decoder, staging); err != nil {
+		return nil, fmt.Errorf("failed to extract bundle: %w", err)
+	}
+
+	manifestBytes, err := os.ReadFile(filepath.Join(staging, BundleManifestFilename))
+	if err != nil {
+		return nil, fmt.Errorf("invalid bundle: %s is missing", BundleManifestFilename)
+	}
+	var manifest BundleManifest
+	if err := json.Unmarshal(manifestBytes, &manifest); err != nil {
+		return nil, fmt.Errorf("failed to parse bundle manifest: %w", err)
+	}
+	if manifest.Platform != BundlePlatform() {
+		return nil, fmt.Errorf("the bundle is for %s, but this machine is %s", manifest.Platform, BundlePlatform())
+	}
+
+	for _, component := range manifest.Components {
+		if err := validateBundlePath(component.Path); err != nil {
+			return nil, err
+		}
+		src := filepath.Join(staging, bundleDataPrefix, component.Path)
+		if !FileOrFolderExists(src) {
+			return nil, fmt.Errorf("invalid bundle: %s is missing", component.Path)
+		}
+	}
+
+	cacheDir := filepath.Join(dataDir, BundleCacheDirectory)
+	if err := os.MkdirAll(cacheDir, os.ModePerm); err != nil {
+		return nil, fmt.Errorf("failed to create %s: %w", cacheDir, err)
+	}
+	for _, url := range manifest.CachedURLs {
+		filename := BundleCacheFilename(url)
+		if err := os.Rename(filepath.Join(staging, bundleCachePrefix, filename), filepath.Join(cacheDir, filename)); err != nil {
+			return nil, fmt.Errorf("failed to install the cached response of %s: %w", url, err)
+		}
+	}
+	for _, component := range manifest.Components {
+		dest := filepath.Join(dataDir, component.Path)
+		if err := os.RemoveAll(dest); err != nil {
+			return nil, fmt.Errorf("failed to replace %s: %w", dest, err)
+		}
+		if err := os.Rename(filepath.Join(staging, bundleDataPrefix, component.Path), dest); err != nil {
+			return nil, fmt.Errorf("failed to install %s: %w", component.Path, err)
+		}
+	}
+	return &manifest, nil
+}
+
+// ReadBundleCache returns the response to url an installed bundle carries
+func ReadBundleCache(url string) ([]byte, error) {
+	userHome, err := os.UserHomeDir()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user home directory: %v", err)
+	}
+	return os.ReadFile(filepath.Join(userHome, common.WeaveDataDirectory, BundleCacheDirectory, BundleCacheFilename(url)))
+}
+
+// validateBundlePath only lets components name a directory right under the weave data directory
+func validateBundlePath(name string) error {
+	if name == "" || name == "." || name == ".." || strings.ContainsAny(name, `/\`) {
+		return fmt.Errorf("invalid bundle component path %q", name)
+	}
+	return nil
+}
@@ -0,0 +1,90 @@
+package io
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateAndInstallBundle(t *testing.T) {
+	dataDir := t.TempDir()
+	files := map[string]string{
+		"initia@v1.0.0/initiad":           "initiad",
+		"initia@v1.0.0/libmovevm.so":      "libmovevm",
+		"cosmovisor@v1.7.0/cosmovisor":    "cosmovisor",
+		"opinitd@v1.0.0/opinitd":          "opinitd",
+		"minievm@v1.0.0/minitiad":         "not bundled",
+		"initia@v1.0.0/lib/libcompiler.a": "libcompiler",
+	}
+	for name, content := range files {
+		assert.NoError(t, os.MkdirAll(filepath.Dir(filepath.Join(dataDir, name)), 0o755))
+		assert.NoError(t, os.WriteFile(filepath.Join(dataDir, name), []byte(content), 0o755))
+	}
+	manifest := BundleManifest{
+		Platform:  BundlePlatform(),
+		CreatedAt: time.Unix(0, 0).UTC(),
+		Components: []BundleComponent{
+			{Name: "initia", Version: "v1.0.0", Path: "initia@v1.0.0"},
+			{Name: "cosmovisor", Version: "v1.7.0", Path: "cosmovisor@v1.7.0"},
+			{Name: "opinit", Version: "v1.0.0", Path: "opinitd@v1.0.0"},
+		},
+	}
+	cache := map[string][]byte{
+		"https://api.github.com/repos/initia-labs/initia/releases": []byte(`[{"tag_name":"v1.0.0"}]`),
+	}
+
+	var buffer bytes.Buffer
+	assert.NoError(t, CreateBundle(&buffer, dataDir, manifest, cache))
+
+	read, err := ReadBundleManifest(bytes.NewReader(buffer.Bytes()))
+	assert.NoError(t, err)
+	assert.Equal(t, manifest.Components, read.Components)
+	assert.Equal(t, []string{"https://api.github.com/repos/initia-labs/initia/releases"}, read.CachedURLs)
+
+	dest := t.TempDir()
+	// An older copy of a component is replaced as a whole
+	assert.NoError(t, os.MkdirAll(filepath.Join(dest, "initia@v1.0.0"), 0o755))
+	assert.NoError(t, os.WriteFile(filepath.Join(dest, "initia@v1.0.0", "stale"), []byte("stale"), 0o644))
+
+	installed, err := InstallBundle(bytes.NewReader(buffer.Bytes()), dest)
+	assert.NoError(t, err)
+	assert.Equal(t, read, installed)
+	for _, name := range []string{"initia@v1.0.0/initiad", "initia@v1.0.0/lib/libcompiler.a", "cosmovisor@v1.7.0/cosmovisor", "opinitd@v1.0.0/opinitd"} {
+		content, err := os.ReadFile(filepath.Join(dest, name))
+		assert.NoError(t, err)
+		assert.Equal(t, files[name], string(content))
+	}
+	assert.NoFileExists(t, filepath.Join(dest, "initia@v1.0.0", "stale"))
+	assert.NoDirExists(t, filepath.Join(dest, "minievm@v1.0.0"))
+
+	cached, err := os.ReadFile(filepath.Join(dest, BundleCacheDirectory, BundleCacheFilename("https://api.github.com/repos/initia-labs/initia/releases")))
+	assert.NoError(t, err)
+	assert.Equal(t, `[{"tag_name":"v1.0.0"}]`, string(cached))
+
+	entries, err := os.ReadDir(dest)
+	assert.NoError(t, err)
+	for _, entry := range entries {
+		assert.NotContains(t, entry.Name(), ".bundle-")
+	}
+}
+
+func TestInstallBundle_OtherPlatform(t *testing.T) {
+	var buffer bytes.Buffer
+	manifest := BundleManifest{Platform: "plan9/386", CreatedAt: time.Unix(0, 0).UTC()}
+	assert.NoError(t, CreateBundle(&buffer, t.TempDir(), manifest, nil))
+
+	_, err := InstallBundle(bytes.NewReader(buffer.Bytes()), t.TempDir())
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "plan9/386")
+}
+
+func TestCreateBundle_InvalidComponentPath(t *testing.T) {
+	for _, path := range []string{"", "..", "initia@v1.0.0/initiad", `..\initia`} {
+		manifest := BundleManifest{Platform: BundlePlatform(), Components: []BundleComponent{{Name: "initia", Path: path}}}
+		assert.Error(t, CreateBundle(&bytes.Buffer{}, t.TempDir(), manifest, nil), path)
+	}
+}
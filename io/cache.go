@@ -0,0 +1,193 @@
+package io
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/initia-labs/weave/client"
+)
+
+type DataArtifactKind string
+
+const (
+	DataArtifactBinary          DataArtifactKind = "binary"
+	DataArtifactTarball         DataArtifactKind = "tarball"
+	DataArtifactPartialDownload DataArtifactKind = "partial download"
+	DataArtifactTemp            DataArtifactKind = "temp"
+	DataArtifactLaunchConfig    DataArtifactKind = "launch config"
+	DataArtifactBundleCache     DataArtifactKind = "bundle cache"
+)
+
+const launchConfigFilename = "minitia.config.json"
+
+// dataTempFiles are left in the weave data directory by rollup launches and older snapshot downloads
+var dataTempFiles = []string{"messages.json", "weave.minitia.tx.json", "weave.minitia.celestia.tx.json", "snapshot.weave"}
+
+// dataTempDirPrefixes are the staging directories of bundle installs and weave upgrades
+var dataTempDirPrefixes = []string{".bundle-", "weave-upgrade-"}
+
+// DataArtifact is an entry right under the weave data directory that weave downloaded or left behind
+type DataArtifact struct {
+	Name      string           `json:"name"`
+	Path      string           `json:"path"`
+	Kind      DataArtifactKind `json:"kind"`
+	Component string           `json:"component,omitempty"`
+	Version   string           `json:"version,omitempty"`
+	Size      int64            `json:"size_bytes"`
+	ModTime   time.Time        `json:"modified_at"`
+	UsedBy    []string         `json:"used_by,omitempty"`
+}
+
+// DataPruneOptions selects the artifacts PruneCandidates returns
+type DataPruneOptions struct {
+	// StaleAfter is how long unused binaries, tarballs, temp files and partial downloads are kept, so that a running install
+	// is not disturbed
+	StaleAfter time.Duration
+	// IncludeLaunchConfig also prunes the rollup launch config, which holds the mnemonics of the system keys
+	IncludeLaunchConfig bool
+}
+
+// ListDataArtifacts returns the artifacts under dataDir sorted by name. Entries weave does not know about,
+// such as key files and validator backups, are left out.
+func ListDataArtifacts(dataDir string) ([]DataArtifact, error) {
+	entries, err := os.ReadDir(dataDir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read %s: %w", dataDir, err)
+	}
+
+	var artifacts []DataArtifact
+	for _, entry := range entries {
+		artifact, ok := classifyDataEntry(entry)
+		if !ok {
+			continue
+		}
+		artifact.Path = filepath.Join(dataDir, entry.Name())
+		info, err := entry.Info()
+		if err != nil {
+			return nil, fmt.Errorf("failed to stat %s: %w", artifact.Path, err)
+		}
+		artifact.ModTime = info.ModTime()
+		if artifact.Size, err = diskUsage(artifact.Path); err != nil {
+			return nil, fmt.Errorf("failed to measure %s: %w", artifact.Path, err)
+		}
+		artifacts = append(artifacts, artifact)
+	}
+	sort.Slice(artifacts, func(i, j int) bool { return artifacts[i].Name < artifacts[j].Name })
+	return artifacts, nil
+}
+
+func classifyDataEntry(entry fs.DirEntry) (DataArtifact, bool) {
+	name := entry.Name()
+	artifact := DataArtifact{Name: name}
+	if entry.Type()&fs.ModeSymlink != 0 {
+		// Symlinks such as the opinitd one point into a release directory and take no space of their own
+		return artifact, false
+	}
+
+	if entry.IsDir() {
+		switch {
+		case name == BundleCacheDirectory:
+			artifact.Kind = DataArtifactBundleCache
+		case hasAnyPrefix(name, dataTempDirPrefixes):
+			artifact.Kind = DataArtifactTemp
+		default:
+			component, version, found := strings.Cut(name, "@")
+			if !found || component == "" || version == "" {
+				return artifact, false
+			}
+			artifact.Kind, artifact.Component, artifact.Version = DataArtifactBinary, component, version
+		}
+		return artifact, true
+	}
+
+	switch {
+	case strings.HasSuffix(name, client.PartialFileSuffix), strings.HasSuffix(name, client.PartialStateSuffix):
+		artifact.Kind = DataArtifactPartialDownload
+	case strings.HasSuffix(name, ".tar.gz"):
+		artifact.Kind = DataArtifactTarball
+		artifact.Component = strings.TrimSuffix(name, ".tar.gz")
+	case name == launchConfigFilename:
+		artifact.Kind = DataArtifactLaunchConfig
+	default:
+		for _, tempFile := range dataTempFiles {
+			if name == tempFile {
+				artifact.Kind = DataArtifactTemp
+				return artifact, true
+			}
+		}
+		return artifact, false
+	}
+	return artifact, true
+}
+
+// DataArtifactOf returns the name of the entry right under dataDir that contains path
+func DataArtifactOf(dataDir, path string) (string, bool) {
+	rel, err := filepath.Rel(dataDir, path)
+	if err != nil || rel == "." || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) || filepath.IsAbs(rel) {
+		return "", false
+	}
+	return strings.Split(rel, string(filepath.Separator))[0], true
+}
+
+// PruneCandidates returns the artifacts that can be removed: binaries no service uses, tarballs, temp files and partial
+// downloads, once they are older than opts.StaleAfter. A binary an install in progress just unpacked has no service
+// yet, so it is kept until it is stale as well. The bundle cache is kept for offline installs.
+func PruneCandidates(artifacts []DataArtifact, opts DataPruneOptions, now time.Time) []DataArtifact {
+	var candidates []DataArtifact
+	for _, artifact := range artifacts {
+		if len(artifact.UsedBy) > 0 {
+			continue
+		}
+		stale := now.Sub(artifact.ModTime) >= opts.StaleAfter
+		switch artifact.Kind {
+		case DataArtifactBinary, DataArtifactTarball, DataArtifactTemp, DataArtifactPartialDownload:
+			if !stale {
+				continue
+			}
+		case DataArtifactLaunchConfig:
+			if !opts.IncludeLaunchConfig || !stale {
+				continue
+			}
+		default:
+			continue
+		}
+		candidates = append(candidates, artifact)
+	}
+	return candidates
+}
+
+// diskUsage returns the total size of the regular files at path, without following symlinks
+func diskUsage(path string) (int64, error) {
+	var size int64
+	err := filepath.WalkDir(path, func(_ string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if entry.Type().IsRegular() {
+			info, err := entry.Info()
+			if err != nil {
+				return err
+			}
+			size += info.Size()
+		}
+		return nil
+	})
+	return size, err
+}
+
+func hasAnyPrefix(s string, prefixes []string) bool {
+	for _, prefix := range prefixes {
+		if strings.HasPrefix(s, prefix) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,107 @@
+package io
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestListDataArtifacts(t *testing.T) {
+	dataDir := t.TempDir()
+	files := map[string]string{
+		"initia@v1.0.0/initiad":                  "initiad",
+		"initia@v1.0.0/libmovevm.so":             "lib",
+		"minievm@v1.1.0/minievm_v1.1.0/minitiad": "minitiad",
+		"opinitd@v1.0.0/opinitd":                 "opinitd",
+		"initia.tar.gz":                          "tarball",
+		"minitia.tar.gz.partial":                 "part",
+		"minitia.tar.gz.partial.json":            "{}",
+		"messages.json":                          "[]",
+		"minitia.config.json":                    "{}",
+		"weave-upgrade-123/weave":                "weave",
+		BundleCacheDirectory + "/abc.json":       "[]",
+		"weave-dummy.executor.keyfile":           "key",
+		"validator_backups/priv_validator.json":  "{}",
+	}
+	for name, content := range files {
+		assert.NoError(t, os.MkdirAll(filepath.Dir(filepath.Join(dataDir, name)), 0o755))
+		assert.NoError(t, os.WriteFile(filepath.Join(dataDir, name), []byte(content), 0o644))
+	}
+	assert.NoError(t, os.Symlink(filepath.Join(dataDir, "opinitd@v1.0.0", "opinitd"), filepath.Join(dataDir, "opinitd")))
+
+	artifacts, err := ListDataArtifacts(dataDir)
+	assert.NoError(t, err)
+
+	kinds := make(map[string]DataArtifactKind)
+	byName := make(map[string]DataArtifact)
+	for _, artifact := range artifacts {
+		kinds[artifact.Name] = artifact.Kind
+		byName[artifact.Name] = artifact
+	}
+	assert.Equal(t, map[string]DataArtifactKind{
+		"initia@v1.0.0":               DataArtifactBinary,
+		"minievm@v1.1.0":              DataArtifactBinary,
+		"opinitd@v1.0.0":              DataArtifactBinary,
+		"initia.tar.gz":               DataArtifactTarball,
+		"minitia.tar.gz.partial":      DataArtifactPartialDownload,
+		"minitia.tar.gz.partial.json": DataArtifactPartialDownload,
+		"messages.json":               DataArtifactTemp,
+		"minitia.config.json":         DataArtifactLaunchConfig,
+		"weave-upgrade-123":           DataArtifactTemp,
+		BundleCacheDirectory:          DataArtifactBundleCache,
+	}, kinds)
+
+	assert.Equal(t, BundleCacheDirectory, artifacts[0].Name)
+	assert.Equal(t, "initia", byName["initia@v1.0.0"].Component)
+	assert.Equal(t, "v1.0.0", byName["initia@v1.0.0"].Version)
+	assert.Equal(t, int64(len("initiad")+len("lib")), byName["initia@v1.0.0"].Size)
+}
+
+func TestListDataArtifacts_MissingDir(t *testing.T) {
+	artifacts, err := ListDataArtifacts(filepath.Join(t.TempDir(), "missing"))
+	assert.NoError(t, err)
+	assert.Empty(t, artifacts)
+}
+
+func TestDataArtifactOf(t *testing.T) {
+	dataDir := filepath.Join("/home", "user", ".weave", "data")
+	name, ok := DataArtifactOf(dataDir, filepath.Join(dataDir, "minievm@v1.1.0", "minievm_v1.1.0", "minitiad"))
+	assert.True(t, ok)
+	assert.Equal(t, "minievm@v1.1.0", name)
+
+	for _, path := range []string{dataDir, filepath.Join("/home", "user", "initia", "cosmovisor"), filepath.Join(dataDir, "..", "data2", "initiad")} {
+		_, ok := DataArtifactOf(dataDir, path)
+		assert.False(t, ok, path)
+	}
+}
+
+func TestPruneCandidates(t *testing.T) {
+	now := time.Now()
+	old, recent := now.Add(-48*time.Hour), now.Add(-time.Minute)
+	artifacts := []DataArtifact{
+		{Name: "initia@v1.0.0", Kind: DataArtifactBinary, ModTime: old},
+		{Name: "initia@v1.1.0", Kind: DataArtifactBinary, ModTime: recent},
+		{Name: "cosmovisor@v1.7.0", Kind: DataArtifactBinary, ModTime: old, UsedBy: []string{"upgradable_initia"}},
+		{Name: "initia.tar.gz", Kind: DataArtifactTarball, ModTime: old},
+		{Name: "minitia.tar.gz.partial", Kind: DataArtifactPartialDownload, ModTime: recent},
+		{Name: "messages.json", Kind: DataArtifactTemp, ModTime: old},
+		{Name: "minitia.config.json", Kind: DataArtifactLaunchConfig, ModTime: old},
+		{Name: BundleCacheDirectory, Kind: DataArtifactBundleCache, ModTime: old},
+	}
+
+	names := func(artifacts []DataArtifact) []string {
+		var names []string
+		for _, artifact := range artifacts {
+			names = append(names, artifact.Name)
+		}
+		return names
+	}
+	opts := DataPruneOptions{StaleAfter: 24 * time.Hour}
+	assert.Equal(t, []string{"initia@v1.0.0", "initia.tar.gz", "messages.json"}, names(PruneCandidates(artifacts, opts, now)))
+
+	opts.IncludeLaunchConfig = true
+	assert.Equal(t, []string{"initia@v1.0.0", "initia.tar.gz", "messages.json", "minitia.config.json"}, names(PruneCandidates(artifacts, opts, now)))
+}
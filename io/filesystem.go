@@ -20,9 +20,17 @@ func FileOrFolderExists(path string) bool {
 	return !os.IsNotExist(err)
 }
 
-func DownloadAndExtractTarGz(url, tarballPath, extractedPath string) error {
+// DownloadAndExtractTarGz downloads the release tarball at url, verifies it as set by verify and extracts it into extractedPath
+func DownloadAndExtractTarGz(url, tarballPath, extractedPath string, verify client.VerifyOptions) error {
 	httpClient := client.NewHTTPClient()
-	if err := httpClient.DownloadFile(url, tarballPath, nil, nil); err != nil {
+	validateFn := func(path string) error {
+		if err := httpClient.VerifyRelease(url, path, verify); err != nil {
+			return fmt.Errorf("%w. Use --insecure-skip-verify to install it without verification", err)
+		}
+		return nil
+	}
+	if err := httpClient.DownloadAndValidateFile(url, tarballPath, nil, nil, validateFn); err != nil {
+		_ = os.Remove(tarballPath)
 		return err
 	}
 
@@ -38,11 +46,6 @@ func DownloadAndExtractTarGz(url, tarballPath, extractedPath string) error {
 }
 
 func ExtractTarGz(src string, dest string) error {
-	destRoot, err := filepath.Abs(dest)
-	if err != nil {
-		return err
-	}
-
 	file, err := os.Open(src)
 	if err != nil {
 		return err
@@ -55,7 +58,22 @@ func ExtractTarGz(src string, dest string) error {
 	}
 	defer gzr.Close()
 
-	tarReader := tar.NewReader(gzr)
+	return ExtractTar(gzr, dest)
+}
+
+// ExtractTar extracts an uncompressed tar stream into dest, rejecting entries that would escape it
+func ExtractTar(r io.Reader, dest string) error {
+	return extractTar(r, dest, nil)
+}
+
+// extractTar is ExtractTar that leaves out the entries skip returns true for
+func extractTar(r io.Reader, dest string, skip func(name string) bool) error {
+	destRoot, err := filepath.Abs(dest)
+	if err != nil {
+		return err
+	}
+
+	tarReader := tar.NewReader(r)
 	for {
 		header, err := tarReader.Next()
 		if err == io.EOF {
@@ -64,6 +82,9 @@ func ExtractTarGz(src string, dest string) error {
 		if err != nil {
 			return err
 		}
+		if header.Typeflag == tar.TypeXGlobalHeader || (skip != nil && skip(header.Name)) {
+			continue
+		}
 
 		target, err := safeArchivePath(destRoot, header.Name)
 		if err != nil {
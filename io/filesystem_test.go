@@ -9,6 +9,8 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+
+	weaveclient "github.com/initia-labs/weave/client"
 )
 
 // Mock HTTP client for testing
@@ -51,7 +53,7 @@ func TestDownloadAndExtractTarGz(t *testing.T) {
 
 	t.Run("TestDownloadAndExtractTarGzFailure", func(t *testing.T) {
 		client.On("DownloadFile", "http://example.com/tarball.tar.gz", "./test.tar.gz", nil, nil).Return(assert.AnError)
-		err := DownloadAndExtractTarGz("http://example.com/tarball.tar.gz", "./test.tar.gz", "./testdir")
+		err := DownloadAndExtractTarGz("http://example.com/tarball.tar.gz", "./test.tar.gz", "./testdir", weaveclient.VerifyOptions{})
 		assert.Error(t, err)
 	})
 }
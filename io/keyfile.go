@@ -2,6 +2,7 @@ package io
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
 
@@ -12,6 +13,9 @@ type Key struct {
 	Address     string             `json:"address"`
 	Mnemonic    string             `json:"mnemonic"`
 	AddressType crypto.AddressType `json:"address_type"`
+
+	// Derivation is set when the key was recovered with a BIP39 passphrase or a custom HD path
+	Derivation *crypto.DerivationOptions `json:"derivation,omitempty"`
 }
 
 func NewKey(address, mnemonic string, addressType crypto.AddressType) *Key {
@@ -37,15 +41,29 @@ func GenerateKey(hrp string, addressType crypto.AddressType) (*Key, error) {
 }
 
 func RecoverKey(hrp, mnemonic string, addressType crypto.AddressType) (*Key, error) {
-	address, err := crypto.MnemonicToBech32Address(hrp, mnemonic, addressType)
+	return RecoverKeyWithOptions(hrp, mnemonic, addressType, crypto.DerivationOptions{})
+}
+
+// RecoverKeyWithOptions recovers a key from its mnemonic with an optional BIP39 passphrase and HD path
+func RecoverKeyWithOptions(hrp, mnemonic string, addressType crypto.AddressType, opts crypto.DerivationOptions) (*Key, error) {
+	coinType := 118
+	if addressType == crypto.EVMAddressType {
+		coinType = 60
+	}
+
+	address, err := crypto.MnemonicToBech32AddressWithOptions(hrp, mnemonic, coinType, opts)
 	if err != nil {
 		return nil, fmt.Errorf("failed to derive address: %w", err)
 	}
 
-	return &Key{
+	key := &Key{
 		Mnemonic: mnemonic,
 		Address:  address,
-	}, nil
+	}
+	if !opts.IsDefault() {
+		key.Derivation = &opts
+	}
+	return key, nil
 }
 
 type KeyFile map[string]*Key
@@ -63,17 +81,73 @@ func (k KeyFile) GetMnemonic(name string) string {
 	return k[name].Mnemonic
 }
 
-func (k KeyFile) Write(filePath string) error {
+const (
+	KeyFileFormat  = "weave-keyfile"
+	KeyFileVersion = 1
+)
+
+var ErrKeyFilePassphraseRequired = errors.New("key file is encrypted, a passphrase is required")
+
+// KeyFileHeader identifies an encrypted key file and the version of its layout
+type KeyFileHeader struct {
+	Format  string `json:"format"`
+	Version int    `json:"version"`
+}
+
+// EncryptedKeyFile is the on-disk representation of an encrypted KeyFile
+type EncryptedKeyFile struct {
+	Header KeyFileHeader `json:"header"`
+	// Addresses holds the address of every key in plaintext, so that the keys can be listed without the passphrase
+	Addresses map[string]string     `json:"addresses,omitempty"`
+	Crypto    *crypto.EncryptedData `json:"crypto"`
+}
+
+// Addresses returns the address of every key by name
+func (k KeyFile) Addresses() map[string]string {
+	addresses := make(map[string]string, len(k))
+	for name, key := range k {
+		addresses[name] = key.Address
+	}
+	return addresses
+}
+
+// Write encrypts the key file with the passphrase and writes it to filePath
+func (k KeyFile) Write(filePath, passphrase string) error {
+	plaintext, err := json.Marshal(k)
+	if err != nil {
+		return fmt.Errorf("error marshaling KeyFile to JSON: %w", err)
+	}
+
+	encrypted, err := crypto.Encrypt(plaintext, passphrase)
+	if err != nil {
+		return fmt.Errorf("error encrypting KeyFile: %w", err)
+	}
+
+	data, err := json.MarshalIndent(EncryptedKeyFile{
+		Header:    KeyFileHeader{Format: KeyFileFormat, Version: KeyFileVersion},
+		Addresses: k.Addresses(),
+		Crypto:    encrypted,
+	}, "", "  ")
+	if err != nil {
+		return fmt.Errorf("error marshaling encrypted KeyFile to JSON: %w", err)
+	}
+
+	return os.WriteFile(filePath, data, 0600)
+}
+
+// Export writes the key file with its mnemonics in plaintext. Only use this when the user explicitly asks for it.
+func (k KeyFile) Export(filePath string) error {
 	data, err := json.MarshalIndent(k, "", "  ")
 	if err != nil {
 		return fmt.Errorf("error marshaling KeyFile to JSON: %w", err)
 	}
 
-	return os.WriteFile(filePath, data, 0644)
+	return os.WriteFile(filePath, data, 0600)
 }
 
-// Load tries to load an existing key file into the struct if the file exists
-func (k KeyFile) Load(filePath string) error {
+// Load tries to load an existing key file into the struct if the file exists.
+// Both the encrypted and the legacy plaintext formats are accepted; the passphrase is only used for the former.
+func (k KeyFile) Load(filePath, passphrase string) error {
 	if _, err := os.Stat(filePath); os.IsNotExist(err) {
 		return nil
 	}
@@ -83,10 +157,74 @@ func (k KeyFile) Load(filePath string) error {
 		return fmt.Errorf("error reading file: %w", err)
 	}
 
-	err = json.Unmarshal(data, &k)
+	return k.Unmarshal(data, passphrase)
+}
+
+// Unmarshal decodes key file content in either the encrypted or the legacy plaintext format
+func (k KeyFile) Unmarshal(data []byte, passphrase string) error {
+	if encrypted, ok := parseEncryptedKeyFile(data); ok {
+		if encrypted.Header.Version != KeyFileVersion {
+			return fmt.Errorf("unsupported key file version: %d", encrypted.Header.Version)
+		}
+		if passphrase == "" {
+			return ErrKeyFilePassphraseRequired
+		}
+
+		plaintext, err := encrypted.Crypto.Decrypt(passphrase)
+		if err != nil {
+			return fmt.Errorf("error decrypting key file: %w", err)
+		}
+		data = plaintext
+	}
+
+	err := json.Unmarshal(data, &k)
 	if err != nil {
 		return fmt.Errorf("error unmarshaling JSON: %w", err)
 	}
 
 	return nil
 }
+
+// LoadKeyFileAddresses returns the address of every key in the key file at filePath without decrypting it.
+// It reports false for an encrypted key file that was written without its addresses.
+func LoadKeyFileAddresses(filePath string) (map[string]string, bool, error) {
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, false, fmt.Errorf("error reading file: %w", err)
+	}
+
+	if encrypted, ok := parseEncryptedKeyFile(data); ok {
+		if encrypted.Addresses == nil {
+			return nil, false, nil
+		}
+		return encrypted.Addresses, true, nil
+	}
+
+	keyFile := NewKeyFile()
+	if err := keyFile.Unmarshal(data, ""); err != nil {
+		return nil, false, err
+	}
+	return keyFile.Addresses(), true, nil
+}
+
+// IsEncryptedKeyFile reports whether the file at filePath uses the encrypted key file format
+func IsEncryptedKeyFile(filePath string) (bool, error) {
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		return false, fmt.Errorf("error reading file: %w", err)
+	}
+
+	_, ok := parseEncryptedKeyFile(data)
+	return ok, nil
+}
+
+func parseEncryptedKeyFile(data []byte) (*EncryptedKeyFile, bool) {
+	var encrypted EncryptedKeyFile
+	if err := json.Unmarshal(data, &encrypted); err != nil {
+		return nil, false
+	}
+	if encrypted.Header.Format != KeyFileFormat || encrypted.Crypto == nil {
+		return nil, false
+	}
+	return &encrypted, true
+}
@@ -0,0 +1,109 @@
+package io
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/initia-labs/weave/crypto"
+)
+
+func newTestKeyFile() KeyFile {
+	keyFile := NewKeyFile()
+	keyFile.AddKey("weave_executor", NewKey("init1executor", "executor mnemonic", crypto.CosmosAddressType))
+	keyFile.AddKey("weave_challenger", NewKey("init1challenger", "challenger mnemonic", crypto.CosmosAddressType))
+	return keyFile
+}
+
+func TestKeyFileWriteLoad(t *testing.T) {
+	keyFilePath := filepath.Join(t.TempDir(), "weave.keyfile.json")
+	assert.NoError(t, newTestKeyFile().Write(keyFilePath, "passphrase"))
+
+	content, err := os.ReadFile(keyFilePath)
+	assert.NoError(t, err)
+	assert.NotContains(t, string(content), "mnemonic\"")
+	assert.Contains(t, string(content), KeyFileFormat)
+
+	encrypted, err := IsEncryptedKeyFile(keyFilePath)
+	assert.NoError(t, err)
+	assert.True(t, encrypted)
+
+	tests := []struct {
+		name       string
+		passphrase string
+		err        error
+		expectErr  bool
+	}{
+		{name: "Correct passphrase", passphrase: "passphrase"},
+		{name: "Missing passphrase", passphrase: "", err: ErrKeyFilePassphraseRequired, expectErr: true},
+		{name: "Wrong passphrase", passphrase: "wrong", expectErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			keyFile := NewKeyFile()
+			err := keyFile.Load(keyFilePath, tt.passphrase)
+			if tt.expectErr {
+				assert.Error(t, err)
+				if tt.err != nil {
+					assert.ErrorIs(t, err, tt.err)
+				}
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, "executor mnemonic", keyFile.GetMnemonic("weave_executor"))
+			assert.Equal(t, "challenger mnemonic", keyFile.GetMnemonic("weave_challenger"))
+		})
+	}
+}
+
+func TestKeyFileLoadPlaintext(t *testing.T) {
+	keyFilePath := filepath.Join(t.TempDir(), "weave.keyfile.json")
+	assert.NoError(t, newTestKeyFile().Export(keyFilePath))
+
+	encrypted, err := IsEncryptedKeyFile(keyFilePath)
+	assert.NoError(t, err)
+	assert.False(t, encrypted)
+
+	keyFile := NewKeyFile()
+	assert.NoError(t, keyFile.Load(keyFilePath, ""))
+	assert.Equal(t, "executor mnemonic", keyFile.GetMnemonic("weave_executor"))
+}
+
+func TestLoadKeyFileAddresses(t *testing.T) {
+	dir := t.TempDir()
+
+	encryptedPath := filepath.Join(dir, "encrypted.json")
+	assert.NoError(t, newTestKeyFile().Write(encryptedPath, "passphrase"))
+	plaintextPath := filepath.Join(dir, "plaintext.json")
+	assert.NoError(t, newTestKeyFile().Export(plaintextPath))
+
+	expected := map[string]string{
+		"weave_executor":   "init1executor",
+		"weave_challenger": "init1challenger",
+	}
+	for _, path := range []string{encryptedPath, plaintextPath} {
+		addresses, ok, err := LoadKeyFileAddresses(path)
+		assert.NoError(t, err)
+		assert.True(t, ok)
+		assert.Equal(t, expected, addresses)
+	}
+
+	// Encrypted key files written without their addresses cannot be listed without the passphrase
+	legacyPath := filepath.Join(dir, "legacy.json")
+	encrypted, err := crypto.Encrypt([]byte("{}"), "passphrase")
+	assert.NoError(t, err)
+	data, err := json.Marshal(EncryptedKeyFile{
+		Header: KeyFileHeader{Format: KeyFileFormat, Version: KeyFileVersion},
+		Crypto: encrypted,
+	})
+	assert.NoError(t, err)
+	assert.NoError(t, os.WriteFile(legacyPath, data, 0600))
+
+	_, ok, err := LoadKeyFileAddresses(legacyPath)
+	assert.NoError(t, err)
+	assert.False(t, ok)
+}
@@ -0,0 +1,282 @@
+package io
+
+import (
+	"archive/tar"
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/klauspost/compress/zstd"
+	"github.com/pierrec/lz4/v4"
+)
+
+const (
+	// SnapshotManifestFilename is the archive entry CreateSnapshot writes the SnapshotManifest to
+	SnapshotManifestFilename = "snapshot_manifest.json"
+
+	SnapshotFormatZstd = "zst"
+	SnapshotFormatLz4  = "lz4"
+)
+
+var (
+	lz4MagicNumber  = []byte{0x04, 0x22, 0x4D, 0x18}
+	zstdMagicNumber = []byte{0x28, 0xB5, 0x2F, 0xFD}
+	gzipMagicNumber = []byte{0x1F, 0x8B}
+)
+
+// SnapshotFileExtensions lists the snapshot archive formats ExtractSnapshot can read
+var SnapshotFileExtensions = []string{".tar.lz4", ".tar.zst", ".tar.gz"}
+
+// snapshotExcludedPaths are never archived. Restoring another node's signing state could make a validator double sign.
+var snapshotExcludedPaths = map[string]bool{
+	"data/priv_validator_state.json": true,
+}
+
+// SnapshotManifest describes the node state a snapshot was taken from
+type SnapshotManifest struct {
+	ChainId    string    `json:"chain_id"`
+	Height     int64     `json:"height,omitempty"`
+	AppVersion string    `json:"app_version,omitempty"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// ExtractSnapshot extracts a compressed tar stream into dest while it is being read.
+// The compression is detected from the magic number of the stream, so r can come straight from a download.
+func ExtractSnapshot(r io.Reader, dest string) error {
+	decoder, closeDecoder, err := newSnapshotDecoder(r)
+	if err != nil {
+		return err
+	}
+	defer closeDecoder()
+
+	return extractTar(decoder, dest, func(name string) bool {
+		return path.Clean(name) == SnapshotManifestFilename
+	})
+}
+
+// CommitStagedSnapshot moves every top-level entry of stagingDir, where a snapshot was extracted and verified, into dest.
+// An entry of dest with the same name is only removed once the staged one is in place, and is restored if that fails.
+// The files of keep, given relative to dest, are carried over from the replaced entries when the snapshot has none.
+func CommitStagedSnapshot(stagingDir, dest string, keep []string) error {
+	entries, err := os.ReadDir(stagingDir)
+	if err != nil {
+		return fmt.Errorf("failed to read staged snapshot: %w", err)
+	}
+
+	var replaced []string
+	for _, entry := range entries {
+		target := filepath.Join(dest, entry.Name())
+		previous := target + ".previous"
+		if err := os.RemoveAll(previous); err != nil {
+			return fmt.Errorf("failed to clean up %s: %w", previous, err)
+		}
+		if _, err := os.Lstat(target); err == nil {
+			if err := os.Rename(target, previous); err != nil {
+				return fmt.Errorf("failed to move %s aside: %w", target, err)
+			}
+			replaced = append(replaced, previous)
+		}
+		if err := os.Rename(filepath.Join(stagingDir, entry.Name()), target); err != nil {
+			if _, statErr := os.Lstat(previous); statErr == nil {
+				_ = os.Rename(previous, target)
+			}
+			return fmt.Errorf("failed to move %s into place: %w", target, err)
+		}
+	}
+
+	for _, relPath := range keep {
+		target := filepath.Join(dest, relPath)
+		parts := strings.SplitN(filepath.ToSlash(relPath), "/", 2)
+		previous := filepath.Join(dest, parts[0]+".previous", filepath.Join(parts[1:]...))
+		if FileOrFolderExists(target) || !FileOrFolderExists(previous) {
+			continue
+		}
+		if err := os.MkdirAll(filepath.Dir(target), 0o755); err != nil {
+			return fmt.Errorf("failed to create %s: %w", filepath.Dir(target), err)
+		}
+		if err := os.Rename(previous, target); err != nil {
+			return fmt.Errorf("failed to keep %s: %w", target, err)
+		}
+	}
+
+	for _, previous := range replaced {
+		if err := os.RemoveAll(previous); err != nil {
+			return fmt.Errorf("failed to remove %s: %w", previous, err)
+		}
+	}
+	return os.RemoveAll(stagingDir)
+}
+
+// ReadSnapshotManifest returns the manifest of a snapshot made by CreateSnapshot, or nil if the snapshot has none
+func ReadSnapshotManifest(r io.Reader) (*SnapshotManifest, error) {
+	decoder, closeDecoder, err := newSnapshotDecoder(r)
+	if err != nil {
+		return nil, err
+	}
+	defer closeDecoder()
+
+	tarReader := tar.NewReader(decoder)
+	for {
+		header, err := tarReader.Next()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read snapshot: %w", err)
+		}
+		if header.Typeflag == tar.TypeXGlobalHeader {
+			continue
+		}
+		// CreateSnapshot writes the manifest first, so there is no need to read through the whole archive
+		if path.Clean(header.Name) != SnapshotManifestFilename {
+			return nil, nil
+		}
+
+		var manifest SnapshotManifest
+		if err := json.NewDecoder(tarReader).Decode(&manifest); err != nil {
+			return nil, fmt.Errorf("failed to parse snapshot manifest: %w", err)
+		}
+		return &manifest, nil
+	}
+}
+
+func newSnapshotDecoder(r io.Reader) (io.Reader, func(), error) {
+	reader := bufio.NewReader(r)
+	header, err := reader.Peek(len(lz4MagicNumber))
+	if err != nil && !(err == io.EOF && len(header) >= len(gzipMagicNumber)) {
+		return nil, nil, fmt.Errorf("failed to read snapshot header: %w", err)
+	}
+
+	switch {
+	case bytes.HasPrefix(header, lz4MagicNumber):
+		return lz4.NewReader(reader), func() {}, nil
+	case bytes.HasPrefix(header, zstdMagicNumber):
+		decoder, err := zstd.NewReader(reader)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to create zstd decoder: %w", err)
+		}
+		return decoder, decoder.Close, nil
+	case bytes.HasPrefix(header, gzipMagicNumber):
+		gzr, err := gzip.NewReader(reader)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to create gzip decoder: %w", err)
+		}
+		return gzr, func() { _ = gzr.Close() }, nil
+	default:
+		return nil, nil, fmt.Errorf("invalid snapshot format: expected a .tar.lz4, .tar.zst or .tar.gz archive")
+	}
+}
+
+// CreateSnapshot writes a tar archive of paths, given relative to home, compressed with format to w.
+// The manifest is written as the first entry so that ReadSnapshotManifest can find it without decompressing everything.
+func CreateSnapshot(w io.Writer, home, format string, paths []string, manifest SnapshotManifest) error {
+	var encoder io.WriteCloser
+	switch format {
+	case SnapshotFormatZstd:
+		zstdEncoder, err := zstd.NewWriter(w)
+		if err != nil {
+			return fmt.Errorf("failed to create zstd encoder: %w", err)
+		}
+		encoder = zstdEncoder
+	case SnapshotFormatLz4:
+		encoder = lz4.NewWriter(w)
+	default:
+		return fmt.Errorf("unsupported snapshot format %q: expected %s or %s", format, SnapshotFormatZstd, SnapshotFormatLz4)
+	}
+
+	tarWriter := tar.NewWriter(encoder)
+	manifestBytes, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal snapshot manifest: %w", err)
+	}
+	if err := writeTarEntry(tarWriter, SnapshotManifestFilename, manifestBytes, manifest.CreatedAt); err != nil {
+		return fmt.Errorf("failed to write snapshot manifest: %w", err)
+	}
+
+	for _, relPath := range paths {
+		if err := addToTar(tarWriter, home, relPath, ""); err != nil {
+			return err
+		}
+	}
+
+	if err := tarWriter.Close(); err != nil {
+		return fmt.Errorf("failed to finish snapshot archive: %w", err)
+	}
+	if err := encoder.Close(); err != nil {
+		return fmt.Errorf("failed to finish snapshot compression: %w", err)
+	}
+	return nil
+}
+
+func writeTarEntry(tarWriter *tar.Writer, name string, content []byte, modTime time.Time) error {
+	if err := tarWriter.WriteHeader(&tar.Header{
+		Name:     name,
+		Mode:     0o644,
+		Size:     int64(len(content)),
+		ModTime:  modTime,
+		Typeflag: tar.TypeReg,
+	}); err != nil {
+		return err
+	}
+	_, err := tarWriter.Write(content)
+	return err
+}
+
+// addToTar archives relPath under home, naming the entries by their path relative to home with prefix in front
+func addToTar(tarWriter *tar.Writer, home, relPath, prefix string) error {
+	return filepath.WalkDir(filepath.Join(home, relPath), func(fullPath string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		rel, err := filepath.Rel(home, fullPath)
+		if err != nil {
+			return err
+		}
+		name := filepath.ToSlash(rel)
+		if snapshotExcludedPaths[name] {
+			return nil
+		}
+
+		info, err := entry.Info()
+		if err != nil {
+			return err
+		}
+		// ExtractTar only restores directories and regular files
+		if !info.IsDir() && !info.Mode().IsRegular() {
+			return fmt.Errorf("unsupported file type in archive: %s", fullPath)
+		}
+
+		header, err := tar.FileInfoHeader(info, "")
+		if err != nil {
+			return err
+		}
+		name = prefix + name
+		header.Name = name
+		if info.IsDir() {
+			header.Name += "/"
+		}
+		if err := tarWriter.WriteHeader(header); err != nil {
+			return fmt.Errorf("failed to write %s to archive: %w", name, err)
+		}
+		if info.IsDir() {
+			return nil
+		}
+
+		file, err := os.Open(fullPath)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		if _, err := io.Copy(tarWriter, file); err != nil {
+			return fmt.Errorf("failed to write %s to archive: %w", name, err)
+		}
+		return nil
+	})
+}
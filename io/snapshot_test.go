@@ -0,0 +1,165 @@
+package io
+
+import (
+	"archive/tar"
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/klauspost/compress/zstd"
+	"github.com/pierrec/lz4/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func buildTar(t *testing.T, files map[string]string) []byte {
+	var buffer bytes.Buffer
+	tw := tar.NewWriter(&buffer)
+	assert.NoError(t, tw.WriteHeader(&tar.Header{Name: "data/", Mode: 0o755, Typeflag: tar.TypeDir}))
+	for name, content := range files {
+		assert.NoError(t, tw.WriteHeader(&tar.Header{Name: name, Mode: 0o600, Size: int64(len(content)), Typeflag: tar.TypeReg}))
+		_, err := tw.Write([]byte(content))
+		assert.NoError(t, err)
+	}
+	assert.NoError(t, tw.Close())
+	return buffer.Bytes()
+}
+
+func compress(t *testing.T, newWriter func(io.Writer) io.WriteCloser, content []byte) []byte {
+	var buffer bytes.Buffer
+	w := newWriter(&buffer)
+	_, err := w.Write(content)
+	assert.NoError(t, err)
+	assert.NoError(t, w.Close())
+	return buffer.Bytes()
+}
+
+func TestExtractSnapshot(t *testing.T) {
+	files := map[string]string{
+		"data/priv_validator_state.json": `{"height":"0"}`,
+		"data/application.db/000001.log": "application data",
+	}
+	archive := buildTar(t, files)
+
+	tests := []struct {
+		name      string
+		newWriter func(io.Writer) io.WriteCloser
+	}{
+		{
+			name:      "LZ4",
+			newWriter: func(w io.Writer) io.WriteCloser { return lz4.NewWriter(w) },
+		},
+		{
+			name: "Zstandard",
+			newWriter: func(w io.Writer) io.WriteCloser {
+				encoder, err := zstd.NewWriter(w)
+				assert.NoError(t, err)
+				return encoder
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dest := t.TempDir()
+			err := ExtractSnapshot(bytes.NewReader(compress(t, tt.newWriter, archive)), dest)
+			assert.NoError(t, err)
+
+			for name, content := range files {
+				extracted, err := os.ReadFile(filepath.Join(dest, name))
+				assert.NoError(t, err)
+				assert.Equal(t, content, string(extracted))
+			}
+		})
+	}
+
+	t.Run("RejectsPathTraversalEntries", func(t *testing.T) {
+		dest := filepath.Join(t.TempDir(), "home")
+		snapshot := compress(t, func(w io.Writer) io.WriteCloser { return lz4.NewWriter(w) }, buildTar(t, map[string]string{"../escape": "bad"}))
+		err := ExtractSnapshot(bytes.NewReader(snapshot), dest)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "unsafe archive entry path")
+		assert.NoFileExists(t, filepath.Join(filepath.Dir(dest), "escape"))
+	})
+
+	t.Run("RejectsUnknownFormat", func(t *testing.T) {
+		err := ExtractSnapshot(bytes.NewReader(archive), t.TempDir())
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid snapshot format")
+	})
+}
+
+func TestCreateSnapshot(t *testing.T) {
+	home := t.TempDir()
+	files := map[string]string{
+		"data/application.db/000001.log": "application data",
+		"data/priv_validator_state.json": `{"height":"1200"}`,
+		"config/addrbook.json":           `{"addrs":[]}`,
+		"config/node_key.json":           "secret",
+	}
+	for name, content := range files {
+		assert.NoError(t, os.MkdirAll(filepath.Dir(filepath.Join(home, name)), 0o755))
+		assert.NoError(t, os.WriteFile(filepath.Join(home, name), []byte(content), 0o600))
+	}
+	manifest := SnapshotManifest{ChainId: "interwoven-1", Height: 1200, AppVersion: "v1.0.0", CreatedAt: time.Unix(0, 0).UTC()}
+
+	for _, format := range []string{SnapshotFormatZstd, SnapshotFormatLz4} {
+		t.Run(format, func(t *testing.T) {
+			var buffer bytes.Buffer
+			assert.NoError(t, CreateSnapshot(&buffer, home, format, []string{"data", "config/addrbook.json"}, manifest))
+
+			read, err := ReadSnapshotManifest(bytes.NewReader(buffer.Bytes()))
+			assert.NoError(t, err)
+			assert.Equal(t, &manifest, read)
+
+			dest := t.TempDir()
+			assert.NoError(t, ExtractSnapshot(bytes.NewReader(buffer.Bytes()), dest))
+			extracted, err := os.ReadFile(filepath.Join(dest, "data/application.db/000001.log"))
+			assert.NoError(t, err)
+			assert.Equal(t, "application data", string(extracted))
+			assert.FileExists(t, filepath.Join(dest, "config/addrbook.json"))
+			assert.NoFileExists(t, filepath.Join(dest, "config/node_key.json"))
+			assert.NoFileExists(t, filepath.Join(dest, "data/priv_validator_state.json"))
+			assert.NoFileExists(t, filepath.Join(dest, SnapshotManifestFilename))
+		})
+	}
+
+	t.Run("UnsupportedFormat", func(t *testing.T) {
+		assert.Error(t, CreateSnapshot(io.Discard, home, "rar", []string{"data"}, manifest))
+	})
+}
+
+func TestReadSnapshotManifest_NoManifest(t *testing.T) {
+	snapshot := compress(t, func(w io.Writer) io.WriteCloser { return lz4.NewWriter(w) }, buildTar(t, map[string]string{"data/state.db": "state"}))
+	manifest, err := ReadSnapshotManifest(bytes.NewReader(snapshot))
+	assert.NoError(t, err)
+	assert.Nil(t, manifest)
+}
+
+func TestCommitStagedSnapshot(t *testing.T) {
+	home := t.TempDir()
+	writeFile := func(relPath, content string) {
+		path := filepath.Join(home, relPath)
+		assert.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+		assert.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	}
+	writeFile("config/config.toml", "config")
+	writeFile("data/application.db/old", "old")
+	writeFile("data/priv_validator_state.json", "state")
+	writeFile("staging/data/application.db/new", "new")
+	writeFile("staging/wasm/code", "code")
+
+	assert.NoError(t, CommitStagedSnapshot(filepath.Join(home, "staging"), home, []string{"data/priv_validator_state.json"}))
+
+	assert.FileExists(t, filepath.Join(home, "data", "application.db", "new"))
+	assert.NoFileExists(t, filepath.Join(home, "data", "application.db", "old"), "the staged directory replaces the previous one")
+	assert.FileExists(t, filepath.Join(home, "wasm", "code"))
+	assert.FileExists(t, filepath.Join(home, "config", "config.toml"), "entries the snapshot does not carry are kept")
+	content, err := os.ReadFile(filepath.Join(home, "data", "priv_validator_state.json"))
+	assert.NoError(t, err)
+	assert.Equal(t, "state", string(content))
+	assert.NoDirExists(t, filepath.Join(home, "data.previous"))
+	assert.NoDirExists(t, filepath.Join(home, "staging"))
+}
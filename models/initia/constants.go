@@ -4,10 +4,3 @@ const (
 	DefaultGasPriceDenom string = "uinit"
 	CosmovisorVersion    string = "v1.7.0"
 )
-
-var (
-	PolkachuChainIdSlugMap = map[string]string{
-		"interwoven-1": "tendermint_snapshots/initia",
-		"initiation-2": "testnets/initia/snapshots",
-	}
-)
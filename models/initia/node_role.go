@@ -0,0 +1,282 @@
+package initia
+
+import (
+	"context"
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/initia-labs/weave/analytics"
+	weavecontext "github.com/initia-labs/weave/context"
+	"github.com/initia-labs/weave/styles"
+	"github.com/initia-labs/weave/tooltip"
+	"github.com/initia-labs/weave/types"
+	"github.com/initia-labs/weave/ui"
+)
+
+// NewNodeRoleModel asks for the node role, or goes straight to reviewing the preset when one was given with --with-config
+func NewNodeRoleModel(ctx context.Context) tea.Model {
+	state := weavecontext.GetCurrentState[RunL1NodeState](ctx)
+	if state.nodePreset != nil {
+		return NewNodePresetReview(ctx)
+	}
+	return NewNodeRoleSelect(ctx)
+}
+
+type NodeRoleOption string
+
+const CustomNodeRoleOption NodeRoleOption = "Custom"
+
+type NodeRoleSelect struct {
+	ui.Selector[NodeRoleOption]
+	weavecontext.BaseModel
+	question   string
+	highlights []string
+}
+
+func NewNodeRoleSelect(ctx context.Context) *NodeRoleSelect {
+	options := make([]NodeRoleOption, 0, len(types.NodeRoles)+1)
+	for _, role := range types.NodeRoles {
+		options = append(options, NodeRoleOption(role.DisplayName()))
+	}
+	options = append(options, CustomNodeRoleOption)
+
+	tooltips := []ui.Tooltip{
+		tooltip.L1PublicRPCRoleTooltip,
+		tooltip.L1ArchiveRoleTooltip,
+		tooltip.L1SentryRoleTooltip,
+		tooltip.L1ValidatorRoleTooltip,
+		tooltip.L1CustomRoleTooltip,
+	}
+	return &NodeRoleSelect{
+		Selector: ui.Selector[NodeRoleOption]{
+			Options:  options,
+			Tooltips: &tooltips,
+		},
+		BaseModel:  weavecontext.BaseModel{Ctx: ctx},
+		question:   "Select the role of this node",
+		highlights: []string{"role"},
+	}
+}
+
+func (m *NodeRoleSelect) GetQuestion() string {
+	return m.question
+}
+
+func (m *NodeRoleSelect) Init() tea.Cmd {
+	return nil
+}
+
+func (m *NodeRoleSelect) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if model, cmd, handled := weavecontext.HandleCommonCommands[RunL1NodeState](m, msg); handled {
+		return model, cmd
+	}
+	selected, cmd := m.Select(msg)
+	if selected != nil {
+		state := weavecontext.PushPageAndGetState[RunL1NodeState](m)
+		state.weave.PushPreviousResponse(styles.RenderPreviousResponse(styles.ArrowSeparator, m.GetQuestion(), m.highlights, string(*selected)))
+
+		if *selected == CustomNodeRoleOption {
+			analytics.TrackEvent(analytics.NodeRoleSelected, analytics.NewEmptyEvent().Add(analytics.OptionEventKey, "custom"))
+			state.nodePreset = nil
+			return NewEnableFeaturesCheckbox(weavecontext.SetCurrentState(m.Ctx, state)), nil
+		}
+
+		role := types.NodeRoles[m.Cursor]
+		analytics.TrackEvent(analytics.NodeRoleSelected, analytics.NewEmptyEvent().Add(analytics.OptionEventKey, string(role)))
+		preset, err := types.NewNodePreset(role)
+		if err != nil {
+			return m, m.HandlePanic(err)
+		}
+		state.nodePreset = preset
+		return NewNodePresetReview(weavecontext.SetCurrentState(m.Ctx, state)), nil
+	}
+
+	return m, cmd
+}
+
+func (m *NodeRoleSelect) View() string {
+	state := weavecontext.GetCurrentState[RunL1NodeState](m.Ctx)
+	m.Selector.ViewTooltip(m.Ctx)
+	return m.WrapView(state.weave.Render() + styles.RenderPrompt(m.GetQuestion(), m.highlights, styles.Question) + m.Selector.View())
+}
+
+type NodePresetReviewOption string
+
+const (
+	ApplyNodePresetOption NodePresetReviewOption = "Apply these settings"
+	EditNodePresetOption  NodePresetReviewOption = "Edit a setting"
+)
+
+// NodePresetReview shows the settings of the selected node role before they are applied
+type NodePresetReview struct {
+	ui.Selector[NodePresetReviewOption]
+	weavecontext.BaseModel
+	question string
+}
+
+func NewNodePresetReview(ctx context.Context) *NodePresetReview {
+	state := weavecontext.GetCurrentState[RunL1NodeState](ctx)
+	return &NodePresetReview{
+		Selector: ui.Selector[NodePresetReviewOption]{
+			Options: []NodePresetReviewOption{
+				ApplyNodePresetOption,
+				EditNodePresetOption,
+			},
+		},
+		BaseModel: weavecontext.BaseModel{Ctx: ctx},
+		question:  fmt.Sprintf("Apply the %s settings?", state.nodePreset.Role.DisplayName()),
+	}
+}
+
+func (m *NodePresetReview) GetQuestion() string {
+	return m.question
+}
+
+func (m *NodePresetReview) Init() tea.Cmd {
+	return nil
+}
+
+func (m *NodePresetReview) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if model, cmd, handled := weavecontext.HandleCommonCommands[RunL1NodeState](m, msg); handled {
+		return model, cmd
+	}
+	selected, cmd := m.Select(msg)
+	if selected != nil {
+		state := weavecontext.PushPageAndGetState[RunL1NodeState](m)
+		switch *selected {
+		case ApplyNodePresetOption:
+			state.weave.PushPreviousResponse(styles.RenderPreviousResponse(styles.ArrowSeparator, m.GetQuestion(), []string{}, string(*selected)))
+			state.pruning = state.nodePreset.Pruning
+			state.enableLCD = state.nodePreset.EnableAPI
+			state.enableGRPC = state.nodePreset.EnableGRPC
+			return NewSeedsInput(weavecontext.SetCurrentState(m.Ctx, state)), nil
+		case EditNodePresetOption:
+			return NewNodePresetSettingSelect(weavecontext.SetCurrentState(m.Ctx, state)), nil
+		}
+	}
+
+	return m, cmd
+}
+
+func (m *NodePresetReview) View() string {
+	state := weavecontext.GetCurrentState[RunL1NodeState](m.Ctx)
+	return m.WrapView(state.weave.Render() + renderNodePreset(state.nodePreset) + styles.RenderPrompt(m.GetQuestion(), []string{}, styles.Question) + m.Selector.View())
+}
+
+func renderNodePreset(preset *types.NodePreset) string {
+	view := styles.RenderPrompt(fmt.Sprintf("%s settings:", preset.Role.DisplayName()), []string{}, styles.Information) + "\n"
+	for _, setting := range preset.Settings() {
+		view += fmt.Sprintf("  %s %s = %s\n", styles.Text(setting.File, styles.Gray), styles.BoldText(setting.Key, styles.White), setting.Value)
+	}
+	return view + "\n"
+}
+
+// NodePresetSettingSelect picks the preset setting to edit
+type NodePresetSettingSelect struct {
+	ui.Selector[string]
+	weavecontext.BaseModel
+	question string
+}
+
+func NewNodePresetSettingSelect(ctx context.Context) *NodePresetSettingSelect {
+	state := weavecontext.GetCurrentState[RunL1NodeState](ctx)
+	var options []string
+	for _, setting := range state.nodePreset.Settings() {
+		options = append(options, setting.Key)
+	}
+	return &NodePresetSettingSelect{
+		Selector: ui.Selector[string]{
+			Options: options,
+		},
+		BaseModel: weavecontext.BaseModel{Ctx: ctx},
+		question:  "Which setting would you like to edit?",
+	}
+}
+
+func (m *NodePresetSettingSelect) GetQuestion() string {
+	return m.question
+}
+
+func (m *NodePresetSettingSelect) Init() tea.Cmd {
+	return nil
+}
+
+func (m *NodePresetSettingSelect) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if model, cmd, handled := weavecontext.HandleCommonCommands[RunL1NodeState](m, msg); handled {
+		return model, cmd
+	}
+	selected, cmd := m.Select(msg)
+	if selected != nil {
+		state := weavecontext.PushPageAndGetState[RunL1NodeState](m)
+		return NewNodePresetSettingInput(weavecontext.SetCurrentState(m.Ctx, state), *selected), nil
+	}
+
+	return m, cmd
+}
+
+func (m *NodePresetSettingSelect) View() string {
+	state := weavecontext.GetCurrentState[RunL1NodeState](m.Ctx)
+	return m.WrapView(state.weave.Render() + renderNodePreset(state.nodePreset) + styles.RenderPrompt(m.GetQuestion(), []string{}, styles.Question) + m.Selector.View())
+}
+
+type NodePresetSettingInput struct {
+	ui.TextInput
+	weavecontext.BaseModel
+	key        string
+	question   string
+	highlights []string
+}
+
+func NewNodePresetSettingInput(ctx context.Context, key string) *NodePresetSettingInput {
+	state := weavecontext.GetCurrentState[RunL1NodeState](ctx)
+	model := &NodePresetSettingInput{
+		TextInput:  ui.NewTextInput(false),
+		BaseModel:  weavecontext.BaseModel{Ctx: ctx},
+		key:        key,
+		question:   fmt.Sprintf("Specify %s", key),
+		highlights: []string{key},
+	}
+	for _, setting := range state.nodePreset.Settings() {
+		if setting.Key == key {
+			model.WithDefaultValue(setting.Value)
+			model.WithPlaceholder(fmt.Sprintf("Press tab to keep %s", setting.Value))
+		}
+	}
+	// Validate against a copy so that a rejected value never reaches the state
+	model.WithValidatorFn(func(value string) error {
+		return state.nodePreset.Clone().Set(key, value)
+	})
+	return model
+}
+
+func (m *NodePresetSettingInput) GetQuestion() string {
+	return m.question
+}
+
+func (m *NodePresetSettingInput) Init() tea.Cmd {
+	return nil
+}
+
+func (m *NodePresetSettingInput) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if model, cmd, handled := weavecontext.HandleCommonCommands[RunL1NodeState](m, msg); handled {
+		return model, cmd
+	}
+	input, cmd, done := m.TextInput.Update(msg)
+	if done {
+		state := weavecontext.PushPageAndGetState[RunL1NodeState](m)
+		preset := state.nodePreset.Clone()
+		if err := preset.Set(m.key, input.Text); err != nil {
+			return m, m.HandlePanic(err)
+		}
+		state.nodePreset = preset
+		return NewNodePresetReview(weavecontext.SetCurrentState(m.Ctx, state)), cmd
+	}
+	m.TextInput = input
+	return m, cmd
+}
+
+func (m *NodePresetSettingInput) View() string {
+	state := weavecontext.GetCurrentState[RunL1NodeState](m.Ctx)
+	return m.WrapView(state.weave.Render() + styles.RenderPrompt(m.GetQuestion(), m.highlights, styles.Question) + m.TextInput.View())
+}
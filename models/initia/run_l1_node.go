@@ -3,10 +3,12 @@ package initia
 import (
 	"context"
 	"fmt"
+	"io"
 	"os"
 	"os/exec"
 	"path/filepath"
 	"strconv"
+	"strings"
 	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
@@ -17,7 +19,7 @@ import (
 	"github.com/initia-labs/weave/config"
 	weavecontext "github.com/initia-labs/weave/context"
 	"github.com/initia-labs/weave/cosmosutils"
-	"github.com/initia-labs/weave/io"
+	weaveio "github.com/initia-labs/weave/io"
 	"github.com/initia-labs/weave/registry"
 	"github.com/initia-labs/weave/service"
 	"github.com/initia-labs/weave/styles"
@@ -177,7 +179,7 @@ type RunL1NodeVersionSelect struct {
 }
 
 func NewRunL1NodeVersionSelect(ctx context.Context) (*RunL1NodeVersionSelect, error) {
-	versions, err := cosmosutils.ListBinaryReleases("https://api.github.com/repos/initia-labs/initia/releases")
+	versions, err := cosmosutils.ListBinaryReleases(cosmosutils.InitiaReleaseAPI)
 	if err != nil {
 		return nil, err
 	}
@@ -289,7 +291,7 @@ func (m *RunL1NodeChainIdInput) View() string {
 func IsExistApp(initiaConfigPath string) bool {
 	appTomlPath := filepath.Join(initiaConfigPath, "app.toml")
 	configTomlPath := filepath.Join(initiaConfigPath, "config.toml")
-	if !io.FileOrFolderExists(configTomlPath) || !io.FileOrFolderExists(appTomlPath) {
+	if !weaveio.FileOrFolderExists(configTomlPath) || !weaveio.FileOrFolderExists(appTomlPath) {
 		return false
 	}
 
@@ -421,7 +423,7 @@ func (m *RunL1NodeMonikerInput) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				return m, m.HandlePanic(err)
 			}
 			state.minGasPrice = minGasPrice
-			return NewEnableFeaturesCheckbox(weavecontext.SetCurrentState(m.Ctx, state)), cmd
+			return NewNodeRoleModel(weavecontext.SetCurrentState(m.Ctx, state)), cmd
 		}
 	}
 	m.TextInput = input
@@ -473,7 +475,7 @@ func (m *MinGasPriceInput) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		state.weave.PushPreviousResponse(styles.RenderPreviousResponse(styles.DotsSeparator, m.GetQuestion(), m.highlights, input.Text))
 		state.minGasPrice = input.Text
 		m.Ctx = weavecontext.SetCurrentState(m.Ctx, state)
-		return NewEnableFeaturesCheckbox(weavecontext.SetCurrentState(m.Ctx, state)), cmd
+		return NewNodeRoleModel(weavecontext.SetCurrentState(m.Ctx, state)), cmd
 	}
 	m.TextInput = input
 	return m, cmd
@@ -636,8 +638,9 @@ func (m *SeedsInput) View() string {
 type PersistentPeersInput struct {
 	ui.TextInput
 	weavecontext.BaseModel
-	question   string
-	highlights []string
+	question     string
+	highlights   []string
+	defaultPeers string
 }
 
 func NewPersistentPeersInput(ctx context.Context) (*PersistentPeersInput, error) {
@@ -653,10 +656,15 @@ func NewPersistentPeersInput(ctx context.Context) (*PersistentPeersInput, error)
 
 	state := weavecontext.GetCurrentState[RunL1NodeState](ctx)
 	if state.network != string(Local) {
-		persistentPeers, err := cosmosutils.FetchPolkachuPersistentPeers(state.chainType)
+		provider, err := getSnapshotProvider(state)
+		if err != nil {
+			return nil, err
+		}
+		persistentPeers, err := provider.FetchPersistentPeers()
 		if err == nil {
+			model.defaultPeers = persistentPeers
 			model.WithDefaultValue(persistentPeers)
-			model.WithPlaceholder("Press tab to use persistent peers from Polkachu")
+			model.WithPlaceholder(fmt.Sprintf("Press tab to use persistent peers from %s", provider.Name()))
 			return model, nil
 		}
 	}
@@ -682,6 +690,7 @@ func (m *PersistentPeersInput) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	if done {
 		state := weavecontext.PushPageAndGetState[RunL1NodeState](m)
 		state.persistentPeers = input.Text
+		state.persistentPeersFromProvider = input.Text != "" && input.Text == m.defaultPeers
 		var prevAnswer string
 		if input.Text == "" {
 			prevAnswer = "None"
@@ -689,6 +698,10 @@ func (m *PersistentPeersInput) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			prevAnswer = input.Text
 		}
 		state.weave.PushPreviousResponse(styles.RenderPreviousResponse(styles.DotsSeparator, m.GetQuestion(), m.highlights, prevAnswer))
+		// The pruning strategy of a node role preset has already been reviewed
+		if state.nodePreset != nil {
+			return newPostPruningModel(weavecontext.SetCurrentState(m.Ctx, state)), nil
+		}
 		return NewSelectingPruningStrategy(weavecontext.SetCurrentState(m.Ctx, state)), nil
 	}
 	m.TextInput = input
@@ -767,17 +780,20 @@ func (m *SelectingPruningStrategy) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		state := weavecontext.PushPageAndGetState[RunL1NodeState](m)
 		state.weave.PushPreviousResponse(styles.RenderPreviousResponse(styles.ArrowSeparator, m.GetQuestion(), m.highlights, string(*selected)))
 		state.pruning = selected.toString()
-
-		if state.network == string(Local) {
-			return NewGenesisEndpointInput(weavecontext.SetCurrentState(m.Ctx, state)), nil
-		} else {
-			return NewCosmovisorAutoUpgradeSelector(weavecontext.SetCurrentState(m.Ctx, state)), nil
-		}
+		return newPostPruningModel(weavecontext.SetCurrentState(m.Ctx, state)), nil
 	}
 
 	return m, cmd
 }
 
+func newPostPruningModel(ctx context.Context) tea.Model {
+	state := weavecontext.GetCurrentState[RunL1NodeState](ctx)
+	if state.network == string(Local) {
+		return NewGenesisEndpointInput(ctx)
+	}
+	return NewCosmovisorAutoUpgradeSelector(ctx)
+}
+
 func (m *SelectingPruningStrategy) View() string {
 	state := weavecontext.GetCurrentState[RunL1NodeState](m.Ctx)
 	m.Selector.ViewTooltip(m.Ctx)
@@ -815,7 +831,7 @@ func WaitExistingGenesisChecker(ctx context.Context) tea.Cmd {
 
 		time.Sleep(1500 * time.Millisecond)
 
-		if !io.FileOrFolderExists(genesisFilePath) {
+		if !weaveio.FileOrFolderExists(genesisFilePath) {
 			state.existingGenesis = false
 		} else {
 			state.existingGenesis = true
@@ -1117,7 +1133,7 @@ func initializeApp(ctx context.Context) tea.Cmd {
 			}
 		}
 
-		err = io.CopyDirectory(filepath.Dir(binaryPath), filepath.Join(initiaHome, "cosmovisor", "dyld_lib"))
+		err = weaveio.CopyDirectory(filepath.Dir(binaryPath), cosmosutils.CosmovisorLibraryPath(initiaHome))
 		if err != nil {
 			return ui.NonRetryableErrorLoading{Err: fmt.Errorf("failed to copy initia binary: %v", err)}
 		}
@@ -1155,6 +1171,14 @@ func initializeApp(ctx context.Context) tea.Cmd {
 			if err = config.UpdateTomlValue(filepath.Join(initiaConfigPath, "app.toml"), "pruning", state.pruning); err != nil {
 				return ui.NonRetryableErrorLoading{Err: fmt.Errorf("failed to update pruning strategy: %v", err)}
 			}
+
+			if state.nodePreset != nil {
+				for _, setting := range state.nodePreset.Settings() {
+					if err = config.UpdateTomlValue(filepath.Join(initiaConfigPath, setting.File), setting.Key, setting.Value); err != nil {
+						return ui.NonRetryableErrorLoading{Err: fmt.Errorf("failed to update %s: %v", setting.Key, err)}
+					}
+				}
+			}
 		}
 
 		if state.genesisEndpoint != "" {
@@ -1211,7 +1235,9 @@ func initializeApp(ctx context.Context) tea.Cmd {
 		}
 
 		if state.network != string(Local) {
-			_ = cosmosutils.DownloadPolkachuAddrBook(state.chainType, filepath.Join(initiaConfigPath, "addrbook.json"))
+			if provider, err := getSnapshotProvider(state); err == nil {
+				_ = provider.DownloadAddrBook(filepath.Join(initiaConfigPath, "addrbook.json"))
+			}
 		}
 
 		// prune existing logs, ignore error
@@ -1279,7 +1305,14 @@ func (m *SyncMethodSelect) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			// TODO: What if there's existing /data. Should we also prune it here?
 			return NewTerminalState(weavecontext.SetCurrentState(m.Ctx, state)), tea.Quit
 		case Snapshot, StateSync:
-			model := NewExistingDataChecker(weavecontext.SetCurrentState(m.Ctx, state))
+			m.Ctx = weavecontext.SetCurrentState(m.Ctx, state)
+			if providerType, _ := config.GetSnapshotProvider(); providerType == "" {
+				return NewSnapshotProviderSelect(m.Ctx), nil
+			}
+			if _, err := getSnapshotProvider(state); err != nil {
+				return m, m.HandlePanic(err)
+			}
+			model := NewExistingDataChecker(m.Ctx)
 			return model, model.Init()
 		}
 	}
@@ -1369,6 +1402,185 @@ func (m *CosmovisorAutoUpgradeSelector) View() string {
 	) + m.Selector.View())
 }
 
+// getSnapshotProvider returns the provider chosen during init, falling back to the configured one and then to Polkachu
+func getSnapshotProvider(state RunL1NodeState) (cosmosutils.SnapshotProvider, error) {
+	providerType, location := state.snapshotProviderType, state.snapshotProviderLocation
+	if providerType == "" {
+		providerType, location = config.GetSnapshotProvider()
+	}
+	return cosmosutils.NewSnapshotProvider(providerType, location, state.chainId, state.chainType)
+}
+
+type SnapshotProviderSelect struct {
+	ui.Selector[SnapshotProviderOption]
+	weavecontext.BaseModel
+	question string
+}
+
+type SnapshotProviderOption string
+
+const (
+	PolkachuProviderOption  SnapshotProviderOption = "Polkachu"
+	ManifestProviderOption  SnapshotProviderOption = "Snapshot manifest or directory listing URL"
+	LocalFileProviderOption SnapshotProviderOption = "Local snapshot file or directory"
+)
+
+func NewSnapshotProviderSelect(ctx context.Context) *SnapshotProviderSelect {
+	state := weavecontext.GetCurrentState[RunL1NodeState](ctx)
+	options := []SnapshotProviderOption{
+		PolkachuProviderOption,
+		ManifestProviderOption,
+	}
+	// A local snapshot has nothing to offer to state sync
+	if state.syncMethod == string(Snapshot) {
+		options = append(options, LocalFileProviderOption)
+	}
+
+	return &SnapshotProviderSelect{
+		Selector: ui.Selector[SnapshotProviderOption]{
+			Options:    options,
+			CannotBack: true,
+		},
+		BaseModel: weavecontext.BaseModel{Ctx: ctx, CannotBack: true},
+		question:  "Select where to get the snapshot and peers from",
+	}
+}
+
+func (m *SnapshotProviderSelect) GetQuestion() string {
+	return m.question
+}
+
+func (m *SnapshotProviderSelect) Init() tea.Cmd {
+	return nil
+}
+
+func (m *SnapshotProviderSelect) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if model, cmd, handled := weavecontext.HandleCommonCommands[RunL1NodeState](m, msg); handled {
+		return model, cmd
+	}
+	selected, cmd := m.Select(msg)
+	if selected != nil {
+		state := weavecontext.PushPageAndGetState[RunL1NodeState](m)
+		state.weave.PushPreviousResponse(styles.RenderPreviousResponse(styles.ArrowSeparator, m.GetQuestion(), []string{}, string(*selected)))
+		analytics.TrackEvent(analytics.SnapshotProviderSelected, analytics.NewEmptyEvent().Add(analytics.OptionEventKey, string(*selected)))
+
+		switch *selected {
+		case PolkachuProviderOption:
+			state.snapshotProviderType = cosmosutils.PolkachuSnapshotProvider
+			model := NewExistingDataChecker(weavecontext.SetCurrentState(m.Ctx, state))
+			return model, model.Init()
+		case ManifestProviderOption:
+			state.snapshotProviderType = cosmosutils.ManifestSnapshotProvider
+		case LocalFileProviderOption:
+			state.snapshotProviderType = cosmosutils.LocalFileSnapshotProvider
+		}
+		return NewSnapshotProviderLocationInput(weavecontext.SetCurrentState(m.Ctx, state)), nil
+	}
+
+	return m, cmd
+}
+
+func (m *SnapshotProviderSelect) View() string {
+	state := weavecontext.GetCurrentState[RunL1NodeState](m.Ctx)
+	return m.WrapView(state.weave.Render() + styles.RenderPrompt(
+		m.GetQuestion(),
+		[]string{},
+		styles.Question,
+	) + m.Selector.View())
+}
+
+type SnapshotProviderLocationInput struct {
+	ui.TextInput
+	weavecontext.BaseModel
+	question   string
+	highlights []string
+}
+
+func NewSnapshotProviderLocationInput(ctx context.Context) *SnapshotProviderLocationInput {
+	state := weavecontext.GetCurrentState[RunL1NodeState](ctx)
+	model := &SnapshotProviderLocationInput{
+		TextInput: ui.NewTextInput(false),
+		BaseModel: weavecontext.BaseModel{Ctx: ctx},
+	}
+
+	if state.snapshotProviderType == cosmosutils.LocalFileSnapshotProvider {
+		model.question = "Specify the snapshot file or the directory containing snapshots"
+		model.highlights = []string{"snapshot file", "directory"}
+		model.WithPlaceholder("Enter a path to a .tar.lz4, .tar.zst or .tar.gz snapshot, or to a directory of them")
+		model.WithValidatorFn(func(s string) error {
+			if !weaveio.FileOrFolderExists(s) {
+				return fmt.Errorf("%s does not exist", s)
+			}
+			return nil
+		})
+	} else {
+		model.question = "Specify the URL of the snapshot manifest or directory listing"
+		model.highlights = []string{"snapshot manifest", "directory listing"}
+		model.WithPlaceholder("Enter a URL serving a JSON manifest or a listing of snapshots")
+		model.WithValidatorFn(common.ValidateURL)
+	}
+
+	return model
+}
+
+func (m *SnapshotProviderLocationInput) GetQuestion() string {
+	return m.question
+}
+
+func (m *SnapshotProviderLocationInput) Init() tea.Cmd {
+	return nil
+}
+
+func (m *SnapshotProviderLocationInput) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if model, cmd, handled := weavecontext.HandleCommonCommands[RunL1NodeState](m, msg); handled {
+		return model, cmd
+	}
+	input, cmd, done := m.TextInput.Update(msg)
+	if done {
+		state := weavecontext.PushPageAndGetState[RunL1NodeState](m)
+		state.snapshotProviderLocation = input.Text
+		state.weave.PushPreviousResponse(styles.RenderPreviousResponse(styles.DotsSeparator, m.GetQuestion(), m.highlights, input.Text))
+		model := NewExistingDataChecker(weavecontext.SetCurrentState(m.Ctx, state))
+		return model, model.Init()
+	}
+	m.TextInput = input
+	return m, cmd
+}
+
+func (m *SnapshotProviderLocationInput) View() string {
+	state := weavecontext.GetCurrentState[RunL1NodeState](m.Ctx)
+	return m.WrapView(state.weave.Render() + styles.RenderPrompt(m.GetQuestion(), m.highlights, styles.Question) + m.TextInput.View())
+}
+
+// applySnapshotProviderPeers refreshes the peers and the addrbook with the provider selected after the app was initialized.
+// Peers typed in by the user are kept, only the ones taken from the fallback provider are replaced.
+func applySnapshotProviderPeers(ctx context.Context, state *RunL1NodeState) error {
+	if state.network == string(Local) {
+		return nil
+	}
+	provider, err := getSnapshotProvider(*state)
+	if err != nil {
+		return err
+	}
+	initiaConfigPath, err := weavecontext.GetInitiaConfigDirectory(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get initia config dir: %v", err)
+	}
+
+	if state.persistentPeersFromProvider {
+		if persistentPeers, err := provider.FetchPersistentPeers(); err == nil {
+			if err := config.UpdateTomlValue(filepath.Join(initiaConfigPath, "config.toml"), "p2p.persistent_peers", persistentPeers); err != nil {
+				return fmt.Errorf("failed to update p2p peers: %v", err)
+			}
+			state.persistentPeers = persistentPeers
+		}
+	}
+
+	// A provider without an addrbook leaves the one downloaded during initialization in place
+	_ = provider.DownloadAddrBook(filepath.Join(initiaConfigPath, "addrbook.json"))
+	return nil
+}
+
 type ExistingDataChecker struct {
 	weavecontext.BaseModel
 	ui.Loading
@@ -1388,6 +1600,12 @@ func (m *ExistingDataChecker) Init() tea.Cmd {
 func WaitExistingDataChecker(ctx context.Context) tea.Cmd {
 	return func() tea.Msg {
 		state := weavecontext.GetCurrentState[RunL1NodeState](ctx)
+		if state.snapshotProviderType != "" {
+			if err := applySnapshotProviderPeers(ctx, &state); err != nil {
+				return ui.NonRetryableErrorLoading{Err: err}
+			}
+		}
+
 		initiaDataPath, err := weavecontext.GetInitiaDataDirectory(ctx)
 		if err != nil {
 			return ui.NonRetryableErrorLoading{Err: err}
@@ -1431,7 +1649,11 @@ func (m *ExistingDataChecker) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				return model, nil
 			case string(StateSync):
 				m.Ctx = weavecontext.SetCurrentState(m.Ctx, state)
-				return NewStateSyncEndpointInput(m.Ctx), nil
+				model, err := NewStateSyncEndpointInput(m.Ctx)
+				if err != nil {
+					return m, m.HandlePanic(err)
+				}
+				return model, nil
 			}
 			return NewTerminalState(weavecontext.SetCurrentState(m.Ctx, state)), tea.Quit
 		} else {
@@ -1522,7 +1744,11 @@ func (m *ExistingDataReplaceSelect) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				}
 				return model, nil
 			case string(StateSync):
-				return NewStateSyncEndpointInput(m.Ctx), nil
+				model, err := NewStateSyncEndpointInput(m.Ctx)
+				if err != nil {
+					return m, m.HandlePanic(err)
+				}
+				return model, nil
 			}
 		}
 		return NewTerminalState(weavecontext.SetCurrentState(m.Ctx, state)), tea.Quit
@@ -1555,9 +1781,13 @@ func NewSnapshotEndpointInput(ctx context.Context) (*SnapshotEndpointInput, erro
 	}
 
 	state := weavecontext.GetCurrentState[RunL1NodeState](ctx)
-	defaultSnapshot, err := cosmosutils.FetchPolkachuSnapshotDownloadURL(PolkachuChainIdSlugMap[state.chainId])
+	provider, err := getSnapshotProvider(state)
+	if err != nil {
+		return nil, err
+	}
+	defaultSnapshot, err := provider.FetchSnapshotURL()
 	if err == nil {
-		model.WithPlaceholder(fmt.Sprintf("Press tab to use the latest snapshot provided by Polkachu (%s)", defaultSnapshot))
+		model.WithPlaceholder(fmt.Sprintf("Press tab to use the latest snapshot provided by %s (%s)", provider.Name(), defaultSnapshot))
 		model.WithDefaultValue(defaultSnapshot)
 	} else {
 		model.WithPlaceholder("Enter the snapshot endpoint")
@@ -1611,7 +1841,7 @@ type StateSyncEndpointInput struct {
 	highlights []string
 }
 
-func NewStateSyncEndpointInput(ctx context.Context) *StateSyncEndpointInput {
+func NewStateSyncEndpointInput(ctx context.Context) (*StateSyncEndpointInput, error) {
 	model := &StateSyncEndpointInput{
 		TextInput: ui.NewTextInput(false),
 		BaseModel: weavecontext.BaseModel{Ctx: ctx},
@@ -1623,15 +1853,19 @@ func NewStateSyncEndpointInput(ctx context.Context) *StateSyncEndpointInput {
 	model.WithValidatorFn(common.ValidateEmptyString)
 
 	state := weavecontext.GetCurrentState[RunL1NodeState](ctx)
-	defaultStateSync, err := cosmosutils.FetchPolkachuStateSyncURL(state.chainType)
+	provider, err := getSnapshotProvider(state)
+	if err != nil {
+		return nil, err
+	}
+	defaultStateSync, err := provider.FetchStateSyncURL()
 	if err == nil {
-		model.WithPlaceholder(fmt.Sprintf("Press tab to use the latest state sync RPC server provided by Polkachu (%s)", defaultStateSync))
+		model.WithPlaceholder(fmt.Sprintf("Press tab to use the latest state sync RPC server provided by %s (%s)", provider.Name(), defaultStateSync))
 		model.WithDefaultValue(defaultStateSync)
 	} else {
-		model.WithPlaceholder("Enter an RPC endpoint to be used for state sync")
+		model.WithPlaceholder("Enter an RPC endpoint to be used for state sync. You can add more by separating them with a comma (,)")
 	}
 
-	return model
+	return model, nil
 }
 
 func (m *StateSyncEndpointInput) GetQuestion() string {
@@ -1689,9 +1923,13 @@ func NewAdditionalStateSyncPeersInput(ctx context.Context) (*AdditionalStateSync
 	model.WithValidatorFn(common.ValidatePeerOrSeed)
 
 	state := weavecontext.GetCurrentState[RunL1NodeState](ctx)
-	defaultStateSyncPeers, err := cosmosutils.FetchPolkachuStateSyncPeers(state.chainType)
+	provider, err := getSnapshotProvider(state)
+	if err != nil {
+		return nil, err
+	}
+	defaultStateSyncPeers, err := provider.FetchStateSyncPeers()
 	if err == nil {
-		model.WithPlaceholder(fmt.Sprintf("Press tab to use the latest state sync peers provided by Polkachu (%s)", defaultStateSyncPeers))
+		model.WithPlaceholder(fmt.Sprintf("Press tab to use the latest state sync peers provided by %s (%s)", provider.Name(), defaultStateSyncPeers))
 		model.WithDefaultValue(defaultStateSyncPeers)
 	} else {
 		model.WithPlaceholder("Enter in the format `id@ip:port`. You can add multiple peers by separating them with a comma (,)")
@@ -1743,18 +1981,19 @@ type SnapshotDownloadLoading struct {
 
 func NewSnapshotDownloadLoading(ctx context.Context) (*SnapshotDownloadLoading, error) {
 	state := weavecontext.GetCurrentState[RunL1NodeState](ctx)
-	userHome, err := os.UserHomeDir()
+	initiaHome, err := weavecontext.GetInitiaHome(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("[error] Failed to get user home: %v", err)
+		return nil, fmt.Errorf("[error] Failed to get initia home: %v", err)
 	}
 
+	stagingDir := filepath.Join(initiaHome, snapshotStagingDirectory)
 	return &SnapshotDownloadLoading{
-		Downloader: *ui.NewDownloader(
-			"Downloading snapshot from the provided URL",
+		Downloader: *ui.NewStreamingDownloader(
+			"Downloading and extracting snapshot from the provided URL",
 			state.snapshotEndpoint,
-			fmt.Sprintf("%s/%s/%s", userHome, common.WeaveDataDirectory, common.SnapshotFilename),
-			common.ValidateTarLz4Header,
-		),
+			snapshotExtractor(stagingDir),
+			config.GetLargeDownloadOptions(),
+		).WithFinalizer(snapshotFinalizer(state.initiadVersion, initiaHome, stagingDir)),
 		BaseModel: weavecontext.BaseModel{Ctx: ctx, CannotBack: true},
 	}, nil
 }
@@ -1778,11 +2017,13 @@ func (m *SnapshotDownloadLoading) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 	if m.GetCompletion() {
 		state := weavecontext.PushPageAndGetState[RunL1NodeState](m)
-		state.weave.PushPreviousResponse(styles.RenderPreviousResponse(styles.NoSeparator, "Snapshot download completed.", []string{}, ""))
+		initiaDataDir, err := weavecontext.GetInitiaDataDirectory(m.Ctx)
+		if err != nil {
+			return m, m.HandlePanic(err)
+		}
+		state.weave.PushPreviousResponse(styles.RenderPreviousResponse(styles.NoSeparator, fmt.Sprintf("Snapshot extracted to %s successfully.", initiaDataDir), []string{}, ""))
 		m.Ctx = weavecontext.SetCurrentState(m.Ctx, state)
-		newLoader := NewSnapshotExtractLoading(m.Ctx)
-
-		return newLoader, newLoader.Init()
+		return NewTerminalState(m.Ctx), tea.Quit
 	}
 
 	downloader, cmd := m.Downloader.Update(msg)
@@ -1796,90 +2037,45 @@ func (m *SnapshotDownloadLoading) View() string {
 	return m.WrapView(state.weave.Render() + m.Downloader.View())
 }
 
-type SnapshotExtractLoading struct {
-	ui.Loading
-	weavecontext.BaseModel
-}
-
-func NewSnapshotExtractLoading(ctx context.Context) *SnapshotExtractLoading {
-	return &SnapshotExtractLoading{
-		Loading:   ui.NewLoading("Extracting downloaded snapshot...", snapshotExtractor(ctx)),
-		BaseModel: weavecontext.BaseModel{Ctx: ctx, CannotBack: true},
-	}
-}
-
-func (m *SnapshotExtractLoading) Init() tea.Cmd {
-	return m.Loading.Init()
-}
+// snapshotStagingDirectory is where a snapshot is extracted under the Initia home until its checksum is verified
+const snapshotStagingDirectory = "snapshot.staging"
 
-func (m *SnapshotExtractLoading) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
-	if model, cmd, handled := weavecontext.HandleCommonCommands[RunL1NodeState](m, msg); handled {
-		return model, cmd
-	}
-	loader, cmd := m.Loading.Update(msg)
-	m.Loading = loader
-	switch msg := msg.(type) {
-	case ui.ErrorLoading:
-		state := weavecontext.PushPageAndGetState[RunL1NodeState](m)
-		state.weave.PopPreviousResponse()
-		state.weave.PopPreviousResponse()
-		m.Ctx = weavecontext.SetCurrentState(m.Ctx, state)
-		model, err := NewSnapshotEndpointInput(m.Ctx)
-		if err != nil {
-			return m, m.HandlePanic(err)
+// snapshotExtractor extracts the snapshot into stagingDir as it streams, leaving the node data untouched
+func snapshotExtractor(stagingDir string) func(io.Reader) error {
+	return func(snapshot io.Reader) error {
+		if err := os.RemoveAll(stagingDir); err != nil {
+			return fmt.Errorf("[error] Failed to clean up %s: %v", stagingDir, err)
 		}
-		model.err = msg.Err
-		return model, cmd
-	}
-
-	if m.Loading.NonRetryableErr != nil {
-		return m, m.HandlePanic(m.Loading.NonRetryableErr)
-	}
-	if m.Loading.Completing {
-		state := weavecontext.PushPageAndGetState[RunL1NodeState](m)
-		initiaDataDir, err := weavecontext.GetInitiaDataDirectory(m.Ctx)
-		if err != nil {
-			return m, m.HandlePanic(err)
+		if err := weaveio.ExtractSnapshot(snapshot, stagingDir); err != nil {
+			return fmt.Errorf("[error] Failed to extract snapshot: %v", err)
 		}
-		state.weave.PushPreviousResponse(styles.RenderPreviousResponse(styles.NoSeparator, fmt.Sprintf("Snapshot extracted to %s successfully.", initiaDataDir), []string{}, ""))
-		m.Ctx = weavecontext.SetCurrentState(m.Ctx, state)
-		return NewTerminalState(m.Ctx), tea.Quit
-	}
-	return m, cmd
-}
-
-func (m *SnapshotExtractLoading) View() string {
-	state := weavecontext.GetCurrentState[RunL1NodeState](m.Ctx)
-	if m.Completing {
-		return m.WrapView(state.weave.Render())
+		return nil
 	}
-	return m.WrapView(state.weave.Render() + m.Loading.View())
 }
 
-func snapshotExtractor(ctx context.Context) tea.Cmd {
-	return func() tea.Msg {
-		state := weavecontext.GetCurrentState[RunL1NodeState](ctx)
-		userHome, err := os.UserHomeDir()
-		if err != nil {
-			return ui.ErrorLoading{Err: fmt.Errorf("[error] Failed to get user home: %v", err)}
+// snapshotFinalizer resets the node data and moves the staged snapshot into initiaHome once the download is verified,
+// keeping the validator state the reset leaves behind. A failed download is discarded.
+func snapshotFinalizer(initiadVersion, initiaHome, stagingDir string) func(error) error {
+	return func(downloadErr error) error {
+		if downloadErr != nil {
+			_ = os.RemoveAll(stagingDir)
+			return nil
 		}
 
-		initiaHome, err := weavecontext.GetInitiaHome(ctx)
+		userHome, err := os.UserHomeDir()
 		if err != nil {
-			return ui.NonRetryableErrorLoading{Err: fmt.Errorf("[error] Failed to get initia home: %v", err)}
+			return fmt.Errorf("[error] Failed to get user home: %v", err)
 		}
-		binaryPath := filepath.Join(userHome, common.WeaveDataDirectory, fmt.Sprintf("initia@%s", state.initiadVersion), "initiad")
+		binaryPath := filepath.Join(userHome, common.WeaveDataDirectory, fmt.Sprintf("initia@%s", initiadVersion), "initiad")
 		runCmd := exec.Command(binaryPath, "comet", "unsafe-reset-all", "--keep-addr-book", "--home", initiaHome)
 		if output, err := runCmd.CombinedOutput(); err != nil {
-			return ui.NonRetryableErrorLoading{Err: fmt.Errorf("failed to run initiad comet unsafe-reset-all: %v (output: %s)", err, string(output))}
+			return fmt.Errorf("failed to run initiad comet unsafe-reset-all: %v (output: %s)", err, string(output))
 		}
 
-		cmd := exec.Command("bash", "-c", fmt.Sprintf("lz4 -c -d %s | tar -x -C %s", filepath.Join(userHome, common.WeaveDataDirectory, common.SnapshotFilename), initiaHome))
-		output, err := cmd.CombinedOutput()
-		if err != nil {
-			return ui.ErrorLoading{Err: fmt.Errorf("[error] Failed to extract snapshot: %v (output: %s)", err, string(output))}
+		if err := weaveio.CommitStagedSnapshot(stagingDir, initiaHome, []string{"data/priv_validator_state.json"}); err != nil {
+			return fmt.Errorf("[error] Failed to move the snapshot into place: %v", err)
 		}
-		return ui.EndLoading{}
+		return nil
 	}
 }
 
@@ -1905,13 +2101,18 @@ func (m *StateSyncSetupLoading) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	}
 	loader, cmd := m.Loading.Update(msg)
 	m.Loading = loader
-	switch msg.(type) {
+	switch msg := msg.(type) {
 	case ui.ErrorLoading:
 		state := weavecontext.PushPageAndGetState[RunL1NodeState](m)
 		state.weave.PopPreviousResponse()
 		state.weave.PopPreviousResponse()
 		m.Ctx = weavecontext.SetCurrentState(m.Ctx, state)
-		return NewStateSyncEndpointInput(m.Ctx), cmd
+		model, err := NewStateSyncEndpointInput(m.Ctx)
+		if err != nil {
+			return m, m.HandlePanic(err)
+		}
+		model.err = msg.Err
+		return model, cmd
 	}
 
 	if m.Loading.NonRetryableErr != nil {
@@ -1919,7 +2120,11 @@ func (m *StateSyncSetupLoading) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	}
 
 	if m.Loading.Completing {
+		m.Ctx = m.Loading.EndContext
 		state := weavecontext.PushPageAndGetState[RunL1NodeState](m)
+		if state.stateSyncWarning != "" {
+			state.weave.PushPreviousResponse(styles.Text(state.stateSyncWarning, styles.Yellow) + "\n")
+		}
 		state.weave.PushPreviousResponse(styles.RenderPreviousResponse(styles.NoSeparator, "State sync setup successfully.", []string{}, ""))
 		m.Ctx = weavecontext.SetCurrentState(m.Ctx, state)
 		return NewTerminalState(m.Ctx), tea.Quit
@@ -1939,10 +2144,18 @@ func setupStateSync(ctx context.Context) tea.Cmd {
 	return func() tea.Msg {
 		state := weavecontext.GetCurrentState[RunL1NodeState](ctx)
 
-		stateSyncInfo, err := cosmosutils.GetStateSyncInfo(state.stateSyncEndpoint)
+		// The trust root is cross-checked against the registry RPCs as well as the ones given by the user
+		rpcs := strings.Split(state.stateSyncEndpoint, ",")
+		if state.chainRegistry != nil {
+			if registryRpcs, err := state.chainRegistry.GetActiveRpcs(); err == nil {
+				rpcs = append(rpcs, registryRpcs...)
+			}
+		}
+		stateSyncInfo, err := cosmosutils.GetStateSyncInfo(rpcs)
 		if err != nil {
 			return ui.ErrorLoading{Err: fmt.Errorf("[error] Failed to get state sync info: %v", err)}
 		}
+		state.stateSyncWarning = stateSyncInfo.Warning()
 
 		initiaConfigPath, err := weavecontext.GetInitiaConfigDirectory(ctx)
 		if err != nil {
@@ -1962,7 +2175,7 @@ func setupStateSync(ctx context.Context) tea.Cmd {
 		if err = config.UpdateTomlValue(filepath.Join(initiaConfigPath, "config.toml"), "statesync.enable", "true"); err != nil {
 			return ui.ErrorLoading{Err: fmt.Errorf("[error] Failed to setup state sync enable: %v", err)}
 		}
-		if err = config.UpdateTomlValue(filepath.Join(initiaConfigPath, "config.toml"), "statesync.rpc_servers", fmt.Sprintf("%[1]s,%[1]s", state.stateSyncEndpoint)); err != nil {
+		if err = config.UpdateTomlValue(filepath.Join(initiaConfigPath, "config.toml"), "statesync.rpc_servers", strings.Join(stateSyncInfo.RpcServers, ",")); err != nil {
 			return ui.ErrorLoading{Err: fmt.Errorf("[error] Failed to setup state sync rpc_servers: %v", err)}
 		}
 		if err = config.UpdateTomlValue(filepath.Join(initiaConfigPath, "config.toml"), "statesync.trust_height", fmt.Sprintf("%d", stateSyncInfo.TrustHeight)); err != nil {
@@ -1985,7 +2198,7 @@ func setupStateSync(ctx context.Context) tea.Cmd {
 			return ui.ErrorLoading{Err: fmt.Errorf("failed to run initiad comet unsafe-reset-all: %v (output: %s)", err, string(output))}
 		}
 
-		return ui.EndLoading{}
+		return ui.EndLoading{Ctx: weavecontext.SetCurrentState(ctx, state)}
 	}
 }
 
@@ -1,17 +1,23 @@
 package initia
 
 import (
+	"net/http"
+	"net/http/httptest"
 	"os"
+	"path/filepath"
 	"testing"
 
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/spf13/viper"
 	"github.com/stretchr/testify/assert"
 
 	"github.com/initia-labs/weave/analytics"
 	"github.com/initia-labs/weave/common"
+	"github.com/initia-labs/weave/config"
 	weavecontext "github.com/initia-labs/weave/context"
 	"github.com/initia-labs/weave/cosmosutils"
 	"github.com/initia-labs/weave/registry"
+	"github.com/initia-labs/weave/types"
 	"github.com/initia-labs/weave/ui"
 )
 
@@ -216,9 +222,9 @@ func TestRunL1NodeMonikerInputUpdateTestnetNetwork(t *testing.T) {
 	// Simulate pressing Enter to submit the moniker
 	nextModel, _ := model.Update(tea.KeyMsg{Type: tea.KeyEnter})
 
-	// Verify the next model type is EnableFeaturesCheckbox and retrieve updated state
-	if m, ok := nextModel.(*EnableFeaturesCheckbox); !ok {
-		t.Errorf("Expected model to be of type *EnableFeaturesCheckbox, but got %T", nextModel)
+	// Verify the next model type is NodeRoleSelect and retrieve updated state
+	if m, ok := nextModel.(*NodeRoleSelect); !ok {
+		t.Errorf("Expected model to be of type *NodeRoleSelect, but got %T", nextModel)
 	} else {
 		state = weavecontext.GetCurrentState[RunL1NodeState](m.Ctx)
 		assert.Equal(t, "NodeTest", state.moniker)
@@ -243,9 +249,9 @@ func TestRunL1NodeMonikerInputUpdateMainnetNetwork(t *testing.T) {
 	// Simulate pressing Enter to submit the moniker
 	nextModel, _ := model.Update(tea.KeyMsg{Type: tea.KeyEnter})
 
-	// Verify the next model type is EnableFeaturesCheckbox and retrieve updated state
-	if m, ok := nextModel.(*EnableFeaturesCheckbox); !ok {
-		t.Errorf("Expected model to be of type *EnableFeaturesCheckbox, but got %T", nextModel)
+	// Verify the next model type is NodeRoleSelect and retrieve updated state
+	if m, ok := nextModel.(*NodeRoleSelect); !ok {
+		t.Errorf("Expected model to be of type *NodeRoleSelect, but got %T", nextModel)
 	} else {
 		state = weavecontext.GetCurrentState[RunL1NodeState](m.Ctx)
 		assert.Equal(t, "NodeMain", state.moniker)
@@ -290,9 +296,9 @@ func TestMinGasPriceInputUpdate(t *testing.T) {
 
 			// Check if transition should occur
 			if tc.expectTransition {
-				// Expect transition to EnableFeaturesCheckbox
-				if m, ok := nextModel.(*EnableFeaturesCheckbox); !ok {
-					t.Errorf("Expected model to be of type *EnableFeaturesCheckbox, but got %T", nextModel)
+				// Expect transition to NodeRoleSelect
+				if m, ok := nextModel.(*NodeRoleSelect); !ok {
+					t.Errorf("Expected model to be of type *NodeRoleSelect, but got %T", nextModel)
 				} else {
 					state := weavecontext.GetCurrentState[RunL1NodeState](m.Ctx)
 					assert.Equal(t, tc.expectedMinGasPrice, state.minGasPrice) // Verify min-gas-price is saved in the state
@@ -821,12 +827,28 @@ func TestSyncMethodSelect_Update_Snapshot(t *testing.T) {
 	// Simulate selecting "Snapshot" option
 	nextModel, _ := model.Update(tea.KeyMsg{Type: tea.KeyEnter}) // Confirm selection with Enter
 
-	// Expect transition to ExistingDataChecker
-	if m, ok := nextModel.(*ExistingDataChecker); !ok {
-		t.Errorf("Expected model to be of type *ExistingDataChecker, but got %T", nextModel)
+	// Expect transition to SnapshotProviderSelect
+	if m, ok := nextModel.(*SnapshotProviderSelect); !ok {
+		t.Errorf("Expected model to be of type *SnapshotProviderSelect, but got %T", nextModel)
 	} else {
 		state := weavecontext.GetCurrentState[RunL1NodeState](m.Ctx)
 		assert.Equal(t, string(Snapshot), state.syncMethod) // Verify sync method in state
+		assert.Contains(t, m.Options, LocalFileProviderOption)
+	}
+}
+
+func TestSyncMethodSelect_Update_ConfiguredSnapshotProvider(t *testing.T) {
+	viper.Set(config.SnapshotProviderTypeKey, cosmosutils.ManifestSnapshotProvider)
+	viper.Set(config.SnapshotProviderLocationKey, "https://snapshots.example.com/manifest.json")
+	defer viper.Set(config.SnapshotProviderTypeKey, "")
+
+	ctx := weavecontext.NewAppContext(NewRunL1NodeState())
+	model := NewSyncMethodSelect(ctx)
+	nextModel, _ := model.Update(tea.KeyMsg{Type: tea.KeyEnter})
+
+	// The configured provider is used without asking
+	if _, ok := nextModel.(*ExistingDataChecker); !ok {
+		t.Errorf("Expected model to be of type *ExistingDataChecker, but got %T", nextModel)
 	}
 }
 
@@ -838,11 +860,208 @@ func TestSyncMethodSelect_Update_StateSync(t *testing.T) {
 	model.Update(tea.KeyMsg{Type: tea.KeyDown})                  // Move down to StateSync
 	nextModel, _ := model.Update(tea.KeyMsg{Type: tea.KeyEnter}) // Confirm selection with Enter
 
-	// Expect transition to ExistingDataChecker
+	// Expect transition to SnapshotProviderSelect
+	if m, ok := nextModel.(*SnapshotProviderSelect); !ok {
+		t.Errorf("Expected model to be of type *SnapshotProviderSelect, but got %T", nextModel)
+	} else {
+		state := weavecontext.GetCurrentState[RunL1NodeState](m.Ctx)
+		assert.Equal(t, string(StateSync), state.syncMethod) // Verify sync method in state
+		assert.NotContains(t, m.Options, LocalFileProviderOption)
+	}
+}
+
+func TestSnapshotProviderSelect_Update_Polkachu(t *testing.T) {
+	ctx := weavecontext.NewAppContext(NewRunL1NodeState())
+	model := NewSnapshotProviderSelect(ctx)
+	nextModel, _ := model.Update(tea.KeyMsg{Type: tea.KeyEnter})
+
 	if m, ok := nextModel.(*ExistingDataChecker); !ok {
 		t.Errorf("Expected model to be of type *ExistingDataChecker, but got %T", nextModel)
 	} else {
 		state := weavecontext.GetCurrentState[RunL1NodeState](m.Ctx)
-		assert.Equal(t, string(StateSync), state.syncMethod) // Verify sync method in state
+		assert.Equal(t, cosmosutils.PolkachuSnapshotProvider, state.snapshotProviderType)
+	}
+}
+
+func TestSnapshotProviderSelect_Update_LocalFile(t *testing.T) {
+	state := NewRunL1NodeState()
+	state.syncMethod = string(Snapshot)
+	ctx := weavecontext.NewAppContext(state)
+	model := NewSnapshotProviderSelect(ctx)
+	model.Update(tea.KeyMsg{Type: tea.KeyDown})
+	model.Update(tea.KeyMsg{Type: tea.KeyDown})
+	nextModel, _ := model.Update(tea.KeyMsg{Type: tea.KeyEnter})
+
+	input, ok := nextModel.(*SnapshotProviderLocationInput)
+	if !ok {
+		t.Fatalf("Expected model to be of type *SnapshotProviderLocationInput, but got %T", nextModel)
+	}
+
+	// A path that does not exist is rejected
+	input.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("/nonexistent/snapshot.tar.lz4")})
+	nextModel, _ = input.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	assert.IsType(t, &SnapshotProviderLocationInput{}, nextModel)
+
+	dir := t.TempDir()
+	input = NewSnapshotProviderLocationInput(input.Ctx)
+	input.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(dir)})
+	nextModel, _ = input.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if m, ok := nextModel.(*ExistingDataChecker); !ok {
+		t.Errorf("Expected model to be of type *ExistingDataChecker, but got %T", nextModel)
+	} else {
+		state := weavecontext.GetCurrentState[RunL1NodeState](m.Ctx)
+		assert.Equal(t, cosmosutils.LocalFileSnapshotProvider, state.snapshotProviderType)
+		assert.Equal(t, dir, state.snapshotProviderLocation)
+	}
+}
+
+func TestNodeRoleSelectUpdate(t *testing.T) {
+	tests := []struct {
+		name        string
+		downPresses int
+		expectRole  types.NodeRole
+	}{
+		{name: "PublicRPC", downPresses: 0, expectRole: types.NodeRolePublicRPC},
+		{name: "Validator", downPresses: 3, expectRole: types.NodeRoleValidator},
+		{name: "Custom", downPresses: 4},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := weavecontext.NewAppContext(NewRunL1NodeState())
+			model := NewNodeRoleSelect(ctx)
+			for i := 0; i < tc.downPresses; i++ {
+				model.Update(tea.KeyMsg{Type: tea.KeyDown})
+			}
+			nextModel, _ := model.Update(tea.KeyMsg{Type: tea.KeyEnter})
+
+			if tc.expectRole == "" {
+				m, ok := nextModel.(*EnableFeaturesCheckbox)
+				if assert.True(t, ok, "Expected model to be of type *EnableFeaturesCheckbox, but got %T", nextModel) {
+					assert.Nil(t, weavecontext.GetCurrentState[RunL1NodeState](m.Ctx).nodePreset)
+				}
+				return
+			}
+			m, ok := nextModel.(*NodePresetReview)
+			if assert.True(t, ok, "Expected model to be of type *NodePresetReview, but got %T", nextModel) {
+				state := weavecontext.GetCurrentState[RunL1NodeState](m.Ctx)
+				assert.Equal(t, tc.expectRole, state.nodePreset.Role)
+			}
+		})
+	}
+}
+
+func TestNodePresetEditAndApply(t *testing.T) {
+	preset, err := types.NewNodePreset(types.NodeRoleSentry)
+	assert.Nil(t, err)
+	state := NewRunL1NodeState()
+	state.WithNodePreset(preset)
+	state.network = string(Local)
+	ctx := weavecontext.NewAppContext(state)
+
+	// A preset given with --with-config skips the role selection
+	review, ok := NewNodeRoleModel(ctx).(*NodePresetReview)
+	if !assert.True(t, ok) {
+		return
+	}
+
+	// Edit mempool.size, the eighth setting
+	review.Update(tea.KeyMsg{Type: tea.KeyDown})
+	nextModel, _ := review.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	settingSelect, ok := nextModel.(*NodePresetSettingSelect)
+	if !assert.True(t, ok, "Expected model to be of type *NodePresetSettingSelect, but got %T", nextModel) {
+		return
+	}
+	for i := 0; i < 7; i++ {
+		settingSelect.Update(tea.KeyMsg{Type: tea.KeyDown})
+	}
+	nextModel, _ = settingSelect.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	input, ok := nextModel.(*NodePresetSettingInput)
+	if !assert.True(t, ok, "Expected model to be of type *NodePresetSettingInput, but got %T", nextModel) {
+		return
+	}
+
+	// An invalid value keeps the input open
+	input.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("lots")})
+	nextModel, _ = input.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	assert.Equal(t, input, nextModel)
+
+	input.TextInput.Text, input.TextInput.Cursor = "8000", 4
+	nextModel, _ = input.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	review, ok = nextModel.(*NodePresetReview)
+	if !assert.True(t, ok, "Expected model to be of type *NodePresetReview, but got %T", nextModel) {
+		return
+	}
+	assert.Equal(t, 8000, weavecontext.GetCurrentState[RunL1NodeState](review.Ctx).nodePreset.MempoolSize)
+	assert.Equal(t, 5000, preset.MempoolSize)
+
+	nextModel, _ = review.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if m, ok := nextModel.(*SeedsInput); assert.True(t, ok, "Expected model to be of type *SeedsInput, but got %T", nextModel) {
+		state := weavecontext.GetCurrentState[RunL1NodeState](m.Ctx)
+		assert.Equal(t, "everything", state.pruning)
+		assert.False(t, state.enableLCD)
+	}
+}
+
+func TestPersistentPeersInputUpdate_NodePresetSkipsPruning(t *testing.T) {
+	preset, _ := types.NewNodePreset(types.NodeRoleArchive)
+	state := NewRunL1NodeState()
+	state.network = string(Local)
+	state.WithNodePreset(preset)
+	ctx := weavecontext.NewAppContext(state)
+
+	model, err := NewPersistentPeersInput(ctx)
+	assert.Nil(t, err)
+	nextModel, _ := model.Update(tea.KeyMsg{Type: tea.KeyEnter})
+
+	_, ok := nextModel.(*GenesisEndpointInput)
+	assert.True(t, ok, "Expected model to be of type *GenesisEndpointInput, but got %T", nextModel)
+}
+
+func TestApplySnapshotProviderPeers(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/manifest.json":
+			_, _ = w.Write([]byte(`{"chains":{"initiation-2":{"persistent_peers":"manifest@1.2.3.4:26656","addrbook":"addrbook.json"}}}`))
+		case "/addrbook.json":
+			_, _ = w.Write([]byte(`{"addrs":[]}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	for _, tc := range []struct {
+		name         string
+		fromProvider bool
+		wantPeers    string
+	}{
+		{name: "default peers are replaced", fromProvider: true, wantPeers: "manifest@1.2.3.4:26656"},
+		{name: "user peers are kept", fromProvider: false, wantPeers: "user@5.6.7.8:26656"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			initiaHome := t.TempDir()
+			configDir := filepath.Join(initiaHome, common.InitiaConfigDirectory)
+			assert.NoError(t, os.MkdirAll(configDir, 0755))
+			configPath := filepath.Join(configDir, "config.toml")
+			assert.NoError(t, os.WriteFile(configPath, []byte("[p2p]\npersistent_peers = \"user@5.6.7.8:26656\"\n"), 0644))
+
+			state := NewRunL1NodeState()
+			state.network = string(Testnet)
+			state.chainId = "initiation-2"
+			state.persistentPeers = "user@5.6.7.8:26656"
+			state.persistentPeersFromProvider = tc.fromProvider
+			state.snapshotProviderType = cosmosutils.ManifestSnapshotProvider
+			state.snapshotProviderLocation = server.URL + "/manifest.json"
+			ctx := weavecontext.SetInitiaHome(weavecontext.NewAppContext(state), initiaHome)
+
+			assert.NoError(t, applySnapshotProviderPeers(ctx, &state))
+			assert.Equal(t, tc.wantPeers, state.persistentPeers)
+
+			content, err := os.ReadFile(configPath)
+			assert.NoError(t, err)
+			assert.Contains(t, string(content), tc.wantPeers)
+			assert.FileExists(t, filepath.Join(configDir, "addrbook.json"))
+		})
 	}
 }
@@ -22,17 +22,22 @@ type RunL1NodeState struct {
 	enableGRPC                        bool
 	seeds                             string
 	persistentPeers                   string
+	persistentPeersFromProvider       bool
 	existingGenesis                   bool
 	genesisEndpoint                   string
 	existingData                      bool
 	syncMethod                        string
+	snapshotProviderType              string
+	snapshotProviderLocation          string
 	replaceExistingData               bool
 	replaceExistingGenesisWithDefault bool
 	snapshotEndpoint                  string
 	stateSyncEndpoint                 string
+	stateSyncWarning                  string
 	additionalStateSyncPeers          string
 	allowAutoUpgrade                  bool
 	pruning                           string
+	nodePreset                        *types.NodePreset
 }
 
 // NewRunL1NodeState initializes a new RunL1NodeState with default values.
@@ -42,6 +47,11 @@ func NewRunL1NodeState() RunL1NodeState {
 	}
 }
 
+// WithNodePreset makes the init flow review and apply preset instead of asking for the node role
+func (s *RunL1NodeState) WithNodePreset(preset *types.NodePreset) {
+	s.nodePreset = preset
+}
+
 // Clone creates a deep copy of RunL1NodeState without pointers.
 func (s RunL1NodeState) Clone() RunL1NodeState {
 	return RunL1NodeState{
@@ -60,16 +70,21 @@ func (s RunL1NodeState) Clone() RunL1NodeState {
 		enableGRPC:                        s.enableGRPC,
 		seeds:                             s.seeds,
 		persistentPeers:                   s.persistentPeers,
+		persistentPeersFromProvider:       s.persistentPeersFromProvider,
 		existingGenesis:                   s.existingGenesis,
 		genesisEndpoint:                   s.genesisEndpoint,
 		existingData:                      s.existingData,
 		syncMethod:                        s.syncMethod,
+		snapshotProviderType:              s.snapshotProviderType,
+		snapshotProviderLocation:          s.snapshotProviderLocation,
 		replaceExistingData:               s.replaceExistingData,
 		replaceExistingGenesisWithDefault: s.replaceExistingGenesisWithDefault,
 		snapshotEndpoint:                  s.snapshotEndpoint,
 		stateSyncEndpoint:                 s.stateSyncEndpoint,
+		stateSyncWarning:                  s.stateSyncWarning,
 		additionalStateSyncPeers:          s.additionalStateSyncPeers,
 		allowAutoUpgrade:                  s.allowAutoUpgrade,
 		pruning:                           s.pruning,
+		nodePreset:                        s.nodePreset.Clone(),
 	}
 }
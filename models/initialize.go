@@ -15,6 +15,7 @@ import (
 	"github.com/initia-labs/weave/config"
 	weavecontext "github.com/initia-labs/weave/context"
 	"github.com/initia-labs/weave/crypto"
+	"github.com/initia-labs/weave/models/keyderivation"
 	"github.com/initia-labs/weave/styles"
 	"github.com/initia-labs/weave/types"
 	"github.com/initia-labs/weave/ui"
@@ -24,6 +25,7 @@ type ExistingCheckerState struct {
 	weave             types.WeaveState
 	isFirstTime       bool
 	generatedMnemonic string
+	importedMnemonic  string
 }
 
 func NewExistingCheckerState() ExistingCheckerState {
@@ -38,6 +40,7 @@ func (e ExistingCheckerState) Clone() ExistingCheckerState {
 		weave:             e.weave.Clone(),
 		isFirstTime:       e.isFirstTime,
 		generatedMnemonic: e.generatedMnemonic,
+		importedMnemonic:  e.importedMnemonic,
 	}
 
 }
@@ -217,7 +220,7 @@ func (m *GasStationMnemonicDisplayInput) Update(msg tea.Msg) (tea.Model, tea.Cmd
 	input, cmd, done := m.TextInput.Update(msg)
 	if done {
 		state := weavecontext.PushPageAndGetState[ExistingCheckerState](m)
-		model := NewWeaveAppInitialization(m.Ctx, state.generatedMnemonic)
+		model := NewWeaveAppInitialization(m.Ctx, state.generatedMnemonic, crypto.DerivationOptions{})
 		return model, model.Init()
 	}
 	m.TextInput = input
@@ -281,8 +284,8 @@ func (m *GasStationMnemonicInput) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		state.weave.PushPreviousResponse(
 			styles.RenderPreviousResponse(styles.DotsSeparator, "Please set up a Gas Station account", []string{"Gas Station account"}, styles.HiddenMnemonicText),
 		)
-		model := NewWeaveAppInitialization(weavecontext.SetCurrentState(m.Ctx, state), strings.Trim(input.Text, "\n"))
-		return model, model.Init()
+		state.importedMnemonic = strings.Trim(input.Text, "\n")
+		return NewGasStationDerivationSelect(weavecontext.SetCurrentState(m.Ctx, state)), nil
 	}
 	m.TextInput = input
 	return m, cmd
@@ -294,15 +297,35 @@ func (m *GasStationMnemonicInput) View() string {
 	return m.WrapView(InitHeader(state.isFirstTime) + "\n" + state.weave.Render() + styles.RenderPrompt("Please set up a Gas Station account", []string{"Gas Station account"}, styles.Question) + m.TextInput.View())
 }
 
+// NewGasStationDerivationSelect asks how the imported Gas Station account is derived before initializing weave with it
+func NewGasStationDerivationSelect(ctx context.Context) tea.Model {
+	return keyderivation.NewSelect(ctx, keyderivation.Flow[ExistingCheckerState]{
+		Subject:       "Gas Station account",
+		DefaultHDPath: crypto.EVMHDPath,
+		Render: func(state ExistingCheckerState) string {
+			return InitHeader(state.isFirstTime) + "\n" + state.weave.Render()
+		},
+		PushPreviousResponse: func(state ExistingCheckerState, response string) ExistingCheckerState {
+			state.weave.PushPreviousResponse(response)
+			return state
+		},
+		Next: func(ctx context.Context, opts crypto.DerivationOptions) (tea.Model, tea.Cmd, error) {
+			state := weavecontext.GetCurrentState[ExistingCheckerState](ctx)
+			model := NewWeaveAppInitialization(ctx, state.importedMnemonic, opts)
+			return model, model.Init(), nil
+		},
+	})
+}
+
 type WeaveAppInitialization struct {
 	weavecontext.BaseModel
 	ui.Loading
 	mnemonic string
 }
 
-func NewWeaveAppInitialization(ctx context.Context, mnemonic string) tea.Model {
+func NewWeaveAppInitialization(ctx context.Context, mnemonic string, opts crypto.DerivationOptions) tea.Model {
 	return &WeaveAppInitialization{
-		Loading:  ui.NewLoading("Initializing Weave...", WaitSetGasStation(mnemonic)),
+		Loading:  ui.NewLoading("Initializing Weave...", WaitSetGasStation(mnemonic, opts)),
 		mnemonic: mnemonic,
 		BaseModel: weavecontext.BaseModel{
 			Ctx:        ctx,
@@ -315,14 +338,14 @@ func (hi *WeaveAppInitialization) Init() tea.Cmd {
 	return hi.Loading.Init()
 }
 
-func WaitSetGasStation(mnemonic string) tea.Cmd {
+func WaitSetGasStation(mnemonic string, opts crypto.DerivationOptions) tea.Cmd {
 	return func() tea.Msg {
-		gasStationKey, err := config.RecoverGasStationKey(mnemonic)
+		gasStationKey, err := config.RecoverGasStationKeyWithOptions(mnemonic, opts)
 		if err != nil {
 			return ui.ErrorLoading{Err: fmt.Errorf("failed to recover gas station key: %w", err)}
 		}
 
-		err = config.SetConfig("common.gas_station", gasStationKey)
+		err = config.SaveGasStationKey(gasStationKey)
 		if err != nil {
 			return ui.ErrorLoading{Err: fmt.Errorf("failed to set gas station in config: %w", err)}
 		}
@@ -0,0 +1,210 @@
+package keyderivation
+
+import (
+	"context"
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	weavecontext "github.com/initia-labs/weave/context"
+	"github.com/initia-labs/weave/crypto"
+	"github.com/initia-labs/weave/styles"
+	"github.com/initia-labs/weave/tooltip"
+	"github.com/initia-labs/weave/ui"
+)
+
+// Flow describes the derivation questions of an imported key and where the flow goes after them
+type Flow[S weavecontext.CloneableState[S]] struct {
+	// Subject names the key in the questions, e.g. "Gas Station account"
+	Subject string
+	// DefaultHDPath is offered when the derivation is customized
+	DefaultHDPath string
+	// Render renders the page above the question, including the previous responses kept in the state
+	Render func(state S) string
+	// PushPreviousResponse records an answered question in the state
+	PushPreviousResponse func(state S, response string) S
+	// Next builds the model that continues the flow with the chosen derivation options
+	Next func(ctx context.Context, opts crypto.DerivationOptions) (tea.Model, tea.Cmd, error)
+}
+
+// next records the response and continues the flow
+func (f Flow[S]) next(m weavecontext.BaseModelInterface, response string, opts crypto.DerivationOptions) (tea.Model, tea.Cmd) {
+	state := weavecontext.PushPageAndGetState[S](m)
+	state = f.PushPreviousResponse(state, response)
+	model, cmd, err := f.Next(weavecontext.SetCurrentState(m.GetContext(), state), opts)
+	if err != nil {
+		return m, m.HandlePanic(err)
+	}
+	return model, cmd
+}
+
+type Select[S weavecontext.CloneableState[S]] struct {
+	ui.Selector[Option]
+	weavecontext.BaseModel
+	flow     Flow[S]
+	question string
+}
+
+type Option string
+
+const (
+	DefaultOption Option = "Use the default derivation"
+	CustomOption  Option = "Customize the BIP39 passphrase and HD path (advanced)"
+)
+
+// NewSelect asks whether the key is derived the default way, which continues the flow right away
+func NewSelect[S weavecontext.CloneableState[S]](ctx context.Context, flow Flow[S]) *Select[S] {
+	tooltips := ui.NewTooltipSlice(tooltip.KeyDerivationTooltip, 2)
+	return &Select[S]{
+		Selector: ui.Selector[Option]{
+			Options: []Option{
+				DefaultOption,
+				CustomOption,
+			},
+			Tooltips: &tooltips,
+		},
+		BaseModel: weavecontext.BaseModel{Ctx: ctx},
+		flow:      flow,
+		question:  fmt.Sprintf("How should the %s be derived from the mnemonic?", flow.Subject),
+	}
+}
+
+func (m *Select[S]) GetQuestion() string {
+	return m.question
+}
+
+func (m *Select[S]) Init() tea.Cmd {
+	return nil
+}
+
+func (m *Select[S]) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if model, cmd, handled := weavecontext.HandleCommonCommands[S](m, msg); handled {
+		return model, cmd
+	}
+
+	selected, cmd := m.Select(msg)
+	if selected != nil {
+		response := styles.RenderPreviousResponse(styles.ArrowSeparator, m.GetQuestion(), []string{m.flow.Subject}, string(*selected))
+		switch *selected {
+		case DefaultOption:
+			return m.flow.next(m, response, crypto.DerivationOptions{})
+		case CustomOption:
+			state := weavecontext.PushPageAndGetState[S](m)
+			state = m.flow.PushPreviousResponse(state, response)
+			return NewBIP39PassphraseInput(weavecontext.SetCurrentState(m.Ctx, state), m.flow), nil
+		}
+	}
+
+	return m, cmd
+}
+
+func (m *Select[S]) View() string {
+	state := weavecontext.GetCurrentState[S](m.Ctx)
+	m.Selector.ViewTooltip(m.Ctx)
+	return m.WrapView(m.flow.Render(state) + styles.RenderPrompt(m.GetQuestion(), []string{m.flow.Subject}, styles.Question) + m.Selector.View())
+}
+
+type BIP39PassphraseInput[S weavecontext.CloneableState[S]] struct {
+	ui.TextInput
+	weavecontext.BaseModel
+	flow       Flow[S]
+	question   string
+	highlights []string
+}
+
+func NewBIP39PassphraseInput[S weavecontext.CloneableState[S]](ctx context.Context, flow Flow[S]) *BIP39PassphraseInput[S] {
+	toolTip := tooltip.BIP39PassphraseTooltip
+	model := &BIP39PassphraseInput[S]{
+		TextInput:  ui.NewTextInput(false),
+		BaseModel:  weavecontext.BaseModel{Ctx: ctx},
+		flow:       flow,
+		question:   fmt.Sprintf("Specify the BIP39 passphrase for the %s", flow.Subject),
+		highlights: []string{"BIP39 passphrase"},
+	}
+	model.WithPlaceholder("Press enter to use no passphrase")
+	model.WithTooltip(&toolTip)
+	return model
+}
+
+func (m *BIP39PassphraseInput[S]) GetQuestion() string {
+	return m.question
+}
+
+func (m *BIP39PassphraseInput[S]) Init() tea.Cmd {
+	return nil
+}
+
+func (m *BIP39PassphraseInput[S]) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if model, cmd, handled := weavecontext.HandleCommonCommands[S](m, msg); handled {
+		return model, cmd
+	}
+
+	input, cmd, done := m.TextInput.Update(msg)
+	if done {
+		state := weavecontext.PushPageAndGetState[S](m)
+		state = m.flow.PushPreviousResponse(state, styles.RenderPreviousResponse(styles.DotsSeparator, m.GetQuestion(), m.highlights, styles.HiddenPassphraseText))
+		return NewHDPathInput(weavecontext.SetCurrentState(m.Ctx, state), m.flow, input.Text), nil
+	}
+	m.TextInput = input
+	return m, cmd
+}
+
+func (m *BIP39PassphraseInput[S]) View() string {
+	state := weavecontext.GetCurrentState[S](m.Ctx)
+	m.TextInput.ViewTooltip(m.Ctx)
+	return m.WrapView(m.flow.Render(state) + styles.RenderPrompt(m.GetQuestion(), m.highlights, styles.Question) + m.TextInput.View())
+}
+
+type HDPathInput[S weavecontext.CloneableState[S]] struct {
+	ui.TextInput
+	weavecontext.BaseModel
+	flow            Flow[S]
+	bip39Passphrase string
+	question        string
+	highlights      []string
+}
+
+func NewHDPathInput[S weavecontext.CloneableState[S]](ctx context.Context, flow Flow[S], bip39Passphrase string) *HDPathInput[S] {
+	toolTip := tooltip.HDPathTooltip
+	model := &HDPathInput[S]{
+		TextInput:       ui.NewTextInput(false),
+		BaseModel:       weavecontext.BaseModel{Ctx: ctx},
+		flow:            flow,
+		bip39Passphrase: bip39Passphrase,
+		question:        fmt.Sprintf("Specify the HD path for the %s", flow.Subject),
+		highlights:      []string{"HD path"},
+	}
+	model.WithPlaceholder(fmt.Sprintf(`Press tab to use "%s"`, flow.DefaultHDPath))
+	model.WithDefaultValue(flow.DefaultHDPath)
+	model.WithValidatorFn(crypto.ValidateHDPath)
+	model.WithTooltip(&toolTip)
+	return model
+}
+
+func (m *HDPathInput[S]) GetQuestion() string {
+	return m.question
+}
+
+func (m *HDPathInput[S]) Init() tea.Cmd {
+	return nil
+}
+
+func (m *HDPathInput[S]) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if model, cmd, handled := weavecontext.HandleCommonCommands[S](m, msg); handled {
+		return model, cmd
+	}
+
+	input, cmd, done := m.TextInput.Update(msg)
+	if done {
+		response := styles.RenderPreviousResponse(styles.DotsSeparator, m.GetQuestion(), m.highlights, input.Text)
+		return m.flow.next(m, response, crypto.DerivationOptions{BIP39Passphrase: m.bip39Passphrase, HDPath: input.Text})
+	}
+	m.TextInput = input
+	return m, cmd
+}
+
+func (m *HDPathInput[S]) View() string {
+	state := weavecontext.GetCurrentState[S](m.Ctx)
+	m.TextInput.ViewTooltip(m.Ctx)
+	return m.WrapView(m.flow.Render(state) + styles.RenderPrompt(m.GetQuestion(), m.highlights, styles.Question) + m.TextInput.View())
+}
@@ -0,0 +1,99 @@
+package keyderivation
+
+import (
+	"context"
+	"os"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/initia-labs/weave/analytics"
+	weavecontext "github.com/initia-labs/weave/context"
+	"github.com/initia-labs/weave/crypto"
+	"github.com/initia-labs/weave/styles"
+)
+
+func TestMain(m *testing.M) {
+	analytics.Client = &analytics.NoOpClient{}
+	exitCode := m.Run()
+	os.Exit(exitCode)
+}
+
+type testState struct {
+	responses []string
+}
+
+func (s testState) Clone() testState {
+	return testState{responses: append([]string{}, s.responses...)}
+}
+
+type doneModel struct {
+	ctx  context.Context
+	opts crypto.DerivationOptions
+}
+
+func (m doneModel) Init() tea.Cmd                       { return nil }
+func (m doneModel) Update(tea.Msg) (tea.Model, tea.Cmd) { return m, nil }
+func (m doneModel) View() string                        { return "" }
+
+func newTestFlow() Flow[testState] {
+	return Flow[testState]{
+		Subject:       "test account",
+		DefaultHDPath: crypto.EVMHDPath,
+		Render: func(state testState) string {
+			return strings.Join(state.responses, "")
+		},
+		PushPreviousResponse: func(state testState, response string) testState {
+			state.responses = append(state.responses, response)
+			return state
+		},
+		Next: func(ctx context.Context, opts crypto.DerivationOptions) (tea.Model, tea.Cmd, error) {
+			return doneModel{ctx: ctx, opts: opts}, nil, nil
+		},
+	}
+}
+
+func TestSelect_Default(t *testing.T) {
+	ctx := weavecontext.NewAppContext(testState{})
+
+	input := NewSelect(ctx, newTestFlow())
+	assert.Contains(t, input.View(), "How should the test account be derived from the mnemonic?")
+
+	finalModel, _ := input.Update(tea.KeyMsg{Type: tea.KeyEnter})
+
+	model := finalModel.(doneModel)
+	assert.True(t, model.opts.IsDefault())
+	state := weavecontext.GetCurrentState[testState](model.ctx)
+	assert.Contains(t, state.responses, styles.RenderPreviousResponse(
+		styles.ArrowSeparator, input.GetQuestion(), []string{"test account"}, string(DefaultOption)))
+}
+
+func TestSelect_Custom(t *testing.T) {
+	ctx := weavecontext.NewAppContext(testState{})
+
+	input := NewSelect(ctx, newTestFlow())
+	nextModel, _ := input.Update(tea.KeyMsg{Type: tea.KeyDown})
+	passphraseModel, _ := nextModel.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	assert.IsType(t, &BIP39PassphraseInput[testState]{}, passphraseModel)
+
+	nextModel, _ = passphraseModel.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("secret")})
+	hdPathModel, _ := nextModel.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	assert.IsType(t, &HDPathInput[testState]{}, hdPathModel)
+	assert.Contains(t, hdPathModel.View(), crypto.EVMHDPath)
+
+	// An invalid HD path keeps the input open
+	nextModel, _ = hdPathModel.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("44'/60'")})
+	invalidModel, _ := nextModel.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	assert.IsType(t, &HDPathInput[testState]{}, invalidModel)
+
+	hdPathModel = NewHDPathInput(hdPathModel.(*HDPathInput[testState]).Ctx, newTestFlow(), "secret")
+	nextModel, _ = hdPathModel.Update(tea.KeyMsg{Type: tea.KeyTab})
+	finalModel, _ := nextModel.Update(tea.KeyMsg{Type: tea.KeyEnter})
+
+	model := finalModel.(doneModel)
+	assert.Equal(t, crypto.DerivationOptions{BIP39Passphrase: "secret", HDPath: crypto.EVMHDPath}, model.opts)
+	state := weavecontext.GetCurrentState[testState](model.ctx)
+	assert.NotContains(t, strings.Join(state.responses, ""), "secret")
+}
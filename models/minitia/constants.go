@@ -17,7 +17,8 @@ const (
 	DefaultL1ChallengerBalance      string = "2000000"
 	DefaultL2BridgeExecutorBalance  string = "100000000"
 
-	TmpTxFilename string = "weave.minitia.tx.json"
+	TmpTxFilename         string = "weave.minitia.tx.json"
+	TmpCelestiaTxFilename string = "weave.minitia.celestia.tx.json"
 
 	DefaultL1GasDenom       string = "uinit"
 	DefaultL1GasPrices             = "0.015" + DefaultL1GasDenom
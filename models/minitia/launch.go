@@ -25,6 +25,7 @@ import (
 	"github.com/initia-labs/weave/cosmosutils"
 	"github.com/initia-labs/weave/crypto"
 	"github.com/initia-labs/weave/io"
+	"github.com/initia-labs/weave/models/keyderivation"
 	"github.com/initia-labs/weave/registry"
 	"github.com/initia-labs/weave/service"
 	"github.com/initia-labs/weave/styles"
@@ -1303,6 +1304,37 @@ func (m *ExistingGasStationChecker) View() string {
 	return m.WrapView(state.weave.Render() + "\n" + m.Loading.View())
 }
 
+// NewGasStationDerivationSelect asks how the imported gas station account is derived and saves it
+func NewGasStationDerivationSelect(ctx context.Context) tea.Model {
+	return keyderivation.NewSelect(ctx, keyderivation.Flow[LaunchState]{
+		Subject:       "gas station account",
+		DefaultHDPath: crypto.EVMHDPath,
+		Render: func(state LaunchState) string {
+			return state.weave.Render()
+		},
+		PushPreviousResponse: func(state LaunchState, response string) LaunchState {
+			state.weave.PushPreviousResponse(response)
+			return state
+		},
+		Next: func(ctx context.Context, opts crypto.DerivationOptions) (tea.Model, tea.Cmd, error) {
+			state := weavecontext.GetCurrentState[LaunchState](ctx)
+			gasStationKey, err := config.RecoverGasStationKeyWithOptions(state.gasStationMnemonic, opts)
+			if err != nil {
+				return nil, nil, err
+			}
+			if err = config.SaveGasStationKey(gasStationKey); err != nil {
+				return nil, nil, err
+			}
+
+			model, err := NewAccountsFundingPresetSelect(ctx)
+			if err != nil {
+				return nil, nil, err
+			}
+			return model, nil, nil
+		},
+	})
+}
+
 type GasStationMnemonicInput struct {
 	ui.TextInput
 	weavecontext.BaseModel
@@ -1341,21 +1373,9 @@ func (m *GasStationMnemonicInput) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	if done {
 		state := weavecontext.PushPageAndGetState[LaunchState](m)
 
-		gasStationKey, err := config.RecoverGasStationKey(input.Text)
-		if err != nil {
-			return m, m.HandlePanic(err)
-		}
-		err = config.SetConfig("common.gas_station", gasStationKey)
-		if err != nil {
-			return m, m.HandlePanic(err)
-		}
-
+		state.gasStationMnemonic = input.Text
 		state.weave.PushPreviousResponse(styles.RenderPreviousResponse(styles.DotsSeparator, m.GetQuestion(), m.highlights, styles.HiddenMnemonicText))
-		model, err := NewAccountsFundingPresetSelect(weavecontext.SetCurrentState(m.Ctx, state))
-		if err != nil {
-			return m, m.HandlePanic(err)
-		}
-		return model, nil
+		return NewGasStationDerivationSelect(weavecontext.SetCurrentState(m.Ctx, state)), nil
 	}
 	m.TextInput = input
 	return m, cmd
@@ -2235,32 +2255,73 @@ type DownloadCelestiaBinaryLoading struct {
 }
 
 func NewDownloadCelestiaBinaryLoading(ctx context.Context) (*DownloadCelestiaBinaryLoading, error) {
+	version, err := queryCelestiaAppVersion()
+	if err != nil {
+		// Offline launches use the celestia-appd installed from a bundle instead
+		installed, found := installedCelestiaVersion()
+		if !found {
+			return nil, err
+		}
+		version = installed
+	}
+
+	binaryUrl, err := GetCelestiaBinaryURL(version)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get celestia binary url: %v", err)
+	}
+	return &DownloadCelestiaBinaryLoading{
+		Loading:   ui.NewLoading(fmt.Sprintf("Downloading Celestia binary <%s>", version), downloadCelestiaApp(ctx, version, binaryUrl)),
+		BaseModel: weavecontext.BaseModel{Ctx: ctx, CannotBack: true},
+	}, nil
+}
+
+// queryCelestiaAppVersion returns the celestia-appd version Celestia mainnet runs, without the v prefix
+func queryCelestiaAppVersion() (string, error) {
 	celestiaMainnetRegistry, err := registry.GetChainRegistry(registry.CelestiaMainnet)
 	if err != nil {
-		return nil, err
+		return "", err
 	}
 
 	activeLcds, err := celestiaMainnetRegistry.GetActiveLcds()
 	if err != nil {
-		return nil, err
+		return "", err
 	}
 
 	response, err := cosmosutils.QueryNodeInfo(activeLcds)
 	if err != nil {
-		return nil, err
+		return "", err
 	}
-	version := response.ApplicationVersion.Version
+	return response.ApplicationVersion.Version, nil
+}
 
-	goos := runtime.GOOS
-	goarch := runtime.GOARCH
-	binaryUrl, err := getCelestiaBinaryURL(version, goos, goarch)
+// installedCelestiaVersion returns the latest celestia-appd version in the weave data directory, such as the one of a bundle
+func installedCelestiaVersion() (string, bool) {
+	userHome, err := os.UserHomeDir()
 	if err != nil {
-		return nil, fmt.Errorf("failed to get celestia binary url: %v", err)
+		return "", false
 	}
-	return &DownloadCelestiaBinaryLoading{
-		Loading:   ui.NewLoading(fmt.Sprintf("Downloading Celestia binary <%s>", version), downloadCelestiaApp(ctx, version, binaryUrl)),
-		BaseModel: weavecontext.BaseModel{Ctx: ctx, CannotBack: true},
-	}, nil
+	artifacts, err := io.ListDataArtifacts(filepath.Join(userHome, common.WeaveDataDirectory))
+	if err != nil {
+		return "", false
+	}
+	var latest string
+	for _, artifact := range artifacts {
+		if artifact.Kind != io.DataArtifactBinary || artifact.Component != "celestia" {
+			continue
+		}
+		if !io.FileOrFolderExists(filepath.Join(artifact.Path, CelestiaAppName)) {
+			continue
+		}
+		if latest == "" || cosmosutils.CompareSemVer(artifact.Version, latest) {
+			latest = artifact.Version
+		}
+	}
+	return latest, latest != ""
+}
+
+// GetCelestiaBinaryURL returns the download URL of celestia-appd version for this platform. version has no v prefix.
+func GetCelestiaBinaryURL(version string) (string, error) {
+	return getCelestiaBinaryURL(version, runtime.GOOS, runtime.GOARCH)
 }
 
 func getCelestiaBinaryURL(version, os, arch string) (string, error) {
@@ -2308,7 +2369,7 @@ func downloadCelestiaApp(ctx context.Context, version, binaryUrl string) tea.Cmd
 				}
 			}
 
-			if err = io.DownloadAndExtractTarGz(binaryUrl, tarballPath, extractedPath); err != nil {
+			if err = io.DownloadAndExtractTarGz(binaryUrl, tarballPath, extractedPath, config.GetReleaseVerifyOptions()); err != nil {
 				return ui.NonRetryableErrorLoading{Err: fmt.Errorf("failed to download and extract binary: %v", err)}
 			}
 
@@ -3,6 +3,7 @@ package minitia
 import (
 	"fmt"
 	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 
@@ -11,6 +12,7 @@ import (
 	"github.com/test-go/testify/assert"
 
 	"github.com/initia-labs/weave/analytics"
+	"github.com/initia-labs/weave/common"
 	"github.com/initia-labs/weave/config"
 	weavecontext "github.com/initia-labs/weave/context"
 	"github.com/initia-labs/weave/styles"
@@ -1676,6 +1678,27 @@ func TestGetCelestiaBinaryURL(t *testing.T) {
 	}
 }
 
+func TestInstalledCelestiaVersion(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+
+	_, found := installedCelestiaVersion()
+	assert.False(t, found)
+
+	dataDir := filepath.Join(home, common.WeaveDataDirectory)
+	for _, version := range []string{"3.3.1", "3.10.0"} {
+		dir := filepath.Join(dataDir, "celestia@"+version)
+		assert.NoError(t, os.MkdirAll(dir, 0o755))
+		assert.NoError(t, os.WriteFile(filepath.Join(dir, CelestiaAppName), []byte{}, 0o755))
+	}
+	// A directory without the binary, such as an interrupted download, is not used
+	assert.NoError(t, os.MkdirAll(filepath.Join(dataDir, "celestia@4.0.0"), 0o755))
+
+	version, found := installedCelestiaVersion()
+	assert.True(t, found)
+	assert.Equal(t, "3.10.0", version)
+}
+
 func TestNewGenerateOrRecoverSystemKeysLoading_Generate(t *testing.T) {
 	state := LaunchState{
 		generateKeys: true,
@@ -52,6 +52,7 @@ type LaunchState struct {
 	systemKeyL2BridgeExecutorBalance string
 
 	gasStationExist             bool
+	gasStationMnemonic          string
 	downloadedNewBinary         bool
 	downloadedNewCelestiaBinary bool
 
@@ -109,6 +110,7 @@ func (ls LaunchState) Clone() LaunchState {
 		systemKeyL2OperatorBalance:        ls.systemKeyL2OperatorBalance,
 		systemKeyL2BridgeExecutorBalance:  ls.systemKeyL2BridgeExecutorBalance,
 		gasStationExist:                   ls.gasStationExist,
+		gasStationMnemonic:                ls.gasStationMnemonic,
 		downloadedNewBinary:               ls.downloadedNewBinary,
 		downloadedNewCelestiaBinary:       ls.downloadedNewCelestiaBinary,
 		preGenesisAccountsResponsesCount:  ls.preGenesisAccountsResponsesCount,
@@ -14,6 +14,7 @@ import (
 	"github.com/initia-labs/weave/common"
 	"github.com/initia-labs/weave/config"
 	"github.com/initia-labs/weave/cosmosutils"
+	"github.com/initia-labs/weave/crypto"
 	"github.com/initia-labs/weave/io"
 	"github.com/initia-labs/weave/registry"
 	"github.com/initia-labs/weave/types"
@@ -55,23 +56,20 @@ func (lsk *L1SystemKeys) FundAccountsWithGasStation(state *LaunchState) (*FundAc
 		return nil, err
 	}
 
-	recoveredKey, err := cosmosutils.RecoverKeyFromMnemonicWithCoinType(l1BinaryPath, common.WeaveGasStationKeyName, gasStationKey.Mnemonic, *gasStationKey.CoinType)
+	gasStationSigner, err := gasStationKey.InitiaSigner()
 	if err != nil {
-		return nil, fmt.Errorf("failed to recover gas station key: %v", err)
+		return nil, fmt.Errorf("failed to get gas station signer: %v", err)
 	}
-	defer func() {
-		_ = cosmosutils.DeleteKey(l1BinaryPath, common.WeaveGasStationKeyName)
-	}()
-	keyInfo, err := cosmosutils.UnmarshalKeyInfo(recoveredKey)
+	signerAddress, err := crypto.SignerAddress("init", gasStationSigner)
 	if err != nil {
-		return nil, fmt.Errorf("failed to parse gas station key info: %v", err)
+		return nil, fmt.Errorf("failed to get gas station signer address: %v", err)
 	}
 	gasStationAddress := gasStationKey.InitiaAddress
 	if gasStationAddress == "" {
 		return nil, fmt.Errorf("initia gas station address is empty")
 	}
-	if keyInfo.Address != gasStationAddress {
-		return nil, fmt.Errorf("gas station address mismatch: config=%s keyring=%s", gasStationAddress, keyInfo.Address)
+	if signerAddress != gasStationAddress {
+		return nil, fmt.Errorf("gas station address mismatch: config=%s signer=%s", gasStationAddress, signerAddress)
 	}
 
 	var rawTxContent string
@@ -86,18 +84,46 @@ func (lsk *L1SystemKeys) FundAccountsWithGasStation(state *LaunchState) (*FundAc
 			lsk.Challenger.Address,
 			lsk.Challenger.Coins,
 		)
-		_, err = cosmosutils.RecoverKeyFromMnemonic(state.celestiaBinaryPath, common.WeaveGasStationKeyName, gasStationKey.Mnemonic)
+		celestiaSigner, err := gasStationKey.CelestiaSigner()
 		if err != nil {
-			return nil, fmt.Errorf("failed to recover celestia gas station key: %v", err)
+			return nil, fmt.Errorf("failed to get celestia gas station signer: %v", err)
+		}
+		_, err = cosmosutils.AddSignerKey(state.celestiaBinaryPath, common.WeaveGasStationKeyName, celestiaSigner)
+		if err != nil {
+			return nil, fmt.Errorf("failed to add celestia gas station key: %v", err)
 		}
 		defer func() {
 			_ = cosmosutils.DeleteKey(state.celestiaBinaryPath, common.WeaveGasStationKeyName)
 		}()
 
-		sendCmd := exec.Command(state.celestiaBinaryPath, "tx", "bank", "send", common.WeaveGasStationKeyName,
+		generateCmd := exec.Command(state.celestiaBinaryPath, "tx", "bank", "send", common.WeaveGasStationKeyName,
 			lsk.BatchSubmitter.Address, fmt.Sprintf("%sutia", lsk.BatchSubmitter.Coins), "--node", state.daRPC,
-			"--chain-id", state.daChainId, "--gas", "400000", "--gas-prices", "0.004utia", "--output", "json", "-y",
+			"--chain-id", state.daChainId, "--gas", "400000", "--gas-prices", "0.004utia", "--output", "json",
+			"--keyring-backend", "test", "--generate-only",
 		)
+		unsignedTx, err := generateCmd.Output()
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate celestia transaction: %v", err)
+		}
+
+		userHome, err := os.UserHomeDir()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get user home: %v", err)
+		}
+		celestiaTxPath := filepath.Join(userHome, common.WeaveDataDirectory, TmpCelestiaTxFilename)
+		if err = io.WriteFile(celestiaTxPath, string(unsignedTx)); err != nil {
+			return nil, fmt.Errorf("failed to write celestia tx file: %v", err)
+		}
+		// A file that cannot be deleted is cleaned up by `weave cache prune`
+		defer func() {
+			_ = io.DeleteFile(celestiaTxPath)
+		}()
+
+		if err = cosmosutils.SignTxFile(state.celestiaBinaryPath, celestiaSigner, celestiaTxPath, gasStationKey.CelestiaAddress, state.daRPC, state.daChainId); err != nil {
+			return nil, fmt.Errorf("failed to sign celestia transaction: %v", err)
+		}
+
+		sendCmd := exec.Command(state.celestiaBinaryPath, "tx", "broadcast", celestiaTxPath, "--node", state.daRPC, "--output", "json")
 		broadcastRes, err := sendCmd.CombinedOutput()
 		if err != nil {
 			return nil, fmt.Errorf("failed to broadcast transaction: %v", err)
@@ -139,17 +165,13 @@ func (lsk *L1SystemKeys) FundAccountsWithGasStation(state *LaunchState) (*FundAc
 	if err = io.WriteFile(rawTxPath, rawTxContent); err != nil {
 		return nil, fmt.Errorf("failed to write raw tx file: %v", err)
 	}
+	// A file that cannot be deleted is cleaned up by `weave cache prune`
 	defer func() {
-		if err := io.DeleteFile(rawTxPath); err != nil {
-			fmt.Printf("failed to delete raw tx file: %v", err)
-		}
+		_ = io.DeleteFile(rawTxPath)
 	}()
 
-	signCmd := exec.Command(l1BinaryPath, "tx", "sign", rawTxPath, "--from", common.WeaveGasStationKeyName, "--node", state.l1RPC,
-		"--chain-id", state.l1ChainId, "--keyring-backend", "test", "--output-document", rawTxPath)
-	signRes, err := signCmd.CombinedOutput()
-	if err != nil {
-		return nil, fmt.Errorf("failed to sign transaction: %v, output: %s", err, string(signRes))
+	if err = cosmosutils.SignTxFile(l1BinaryPath, gasStationSigner, rawTxPath, gasStationAddress, state.l1RPC, state.l1ChainId); err != nil {
+		return nil, fmt.Errorf("failed to sign transaction: %v", err)
 	}
 
 	broadcastCmd := exec.Command(l1BinaryPath, "tx", "broadcast", rawTxPath, "--node", state.l1RPC, "--output", "json")
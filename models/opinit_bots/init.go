@@ -15,6 +15,7 @@ import (
 	"github.com/initia-labs/weave/analytics"
 	"github.com/initia-labs/weave/client"
 	"github.com/initia-labs/weave/common"
+	weaveconfig "github.com/initia-labs/weave/config"
 	weavecontext "github.com/initia-labs/weave/context"
 	"github.com/initia-labs/weave/cosmosutils"
 	"github.com/initia-labs/weave/crypto"
@@ -147,6 +148,9 @@ func OPInitBotInitSelectExecutor(ctx context.Context) (tea.Model, error) {
 		if err != nil {
 			return nil, fmt.Errorf("failed to parse minitia config file: %v", err)
 		}
+		if err = minitiaConfig.SystemKeys.ValidateDerivation(); err != nil {
+			return nil, err
+		}
 
 		state.MinitiaConfig = &minitiaConfig
 	}
@@ -217,6 +221,9 @@ func OPInitBotInitSelectChallenger(ctx context.Context) (tea.Model, error) {
 		if err != nil {
 			return nil, fmt.Errorf("failed to parse minitia config file: %v", err)
 		}
+		if err = minitiaConfig.SystemKeys.ValidateDerivation(); err != nil {
+			return nil, err
+		}
 
 		state.MinitiaConfig = &minitiaConfig
 	}
@@ -1134,7 +1141,7 @@ func WaitStartingInitBot(ctx context.Context) tea.Cmd {
 		}
 
 		keyFile := io.NewKeyFile()
-		err = keyFile.Load(keyFilePath)
+		err = keyFile.Load(keyFilePath, weaveconfig.GetKeyFilePassphrase(keyFilePath))
 		if err != nil {
 			return ui.NonRetryableErrorLoading{Err: fmt.Errorf("failed to load key file for OPinit: %w", err)}
 		}
@@ -1148,7 +1155,7 @@ func WaitStartingInitBot(ctx context.Context) tea.Cmd {
 			}
 		}
 
-		err = keyFile.Write(keyFilePath)
+		err = keyFile.Write(keyFilePath, weaveconfig.GetKeyFilePassphrase(keyFilePath))
 		if err != nil {
 			return ui.NonRetryableErrorLoading{Err: fmt.Errorf("failed to write key file: %w", err)}
 		}
@@ -1413,7 +1420,7 @@ func (m *SetupOPInitBotsMissingKey) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				}
 
 				keyFile := io.NewKeyFile()
-				err = keyFile.Load(keyFilePath)
+				err = keyFile.Load(keyFilePath, weaveconfig.GetKeyFilePassphrase(keyFilePath))
 				if err != nil {
 					return m, m.HandlePanic(fmt.Errorf("failed to load key file for OPinit: %w", err))
 				}
@@ -1427,7 +1434,7 @@ func (m *SetupOPInitBotsMissingKey) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					}
 				}
 
-				err = keyFile.Write(keyFilePath)
+				err = keyFile.Write(keyFilePath, weaveconfig.GetKeyFilePassphrase(keyFilePath))
 				if err != nil {
 					return m, m.HandlePanic(fmt.Errorf("failed to write key file: %w", err))
 				}
@@ -1465,7 +1472,7 @@ func (m *SetupOPInitBotsMissingKey) View() string {
 		}
 
 		return m.WrapView(state.weave.Render() + "\n" + styles.BoldUnderlineText("Important", styles.Yellow) + "\n" +
-			styles.Text(fmt.Sprintf("Note that the mnemonic phrases will be stored in %s. You can revisit them anytime.", keyFilePath), styles.Yellow) + "\n\n" +
+			styles.Text(fmt.Sprintf("Note that the mnemonic phrases will be stored encrypted in %s. You can export them anytime with `weave keys decrypt`.", keyFilePath), styles.Yellow) + "\n\n" +
 			addressesText + "\nPress enter to go next step\n")
 	}
 	return m.WrapView(state.weave.Render() + "\n")
@@ -1669,7 +1676,7 @@ func ensureOPInitBotsBinary(userHome string) (string, error) {
 	}
 
 	// Download and extract the binary
-	if err := io.DownloadAndExtractTarGz(url, tarballPath, extractedPath); err != nil {
+	if err := io.DownloadAndExtractTarGz(url, tarballPath, extractedPath, weaveconfig.GetReleaseVerifyOptions()); err != nil {
 		return binaryPath, fmt.Errorf("failed to download and extract binary: %v", err)
 	}
 
@@ -12,6 +12,7 @@ import (
 
 	"github.com/initia-labs/weave/analytics"
 	"github.com/initia-labs/weave/common"
+	weaveconfig "github.com/initia-labs/weave/config"
 	weavecontext "github.com/initia-labs/weave/context"
 	"github.com/initia-labs/weave/cosmosutils"
 	"github.com/initia-labs/weave/crypto"
@@ -48,6 +49,9 @@ func ProcessMinitiaConfig(ctx context.Context, nextModelFunc func(ctx context.Co
 	if err != nil {
 		return nil, fmt.Errorf("failed to unmarshal minitia config: %w", err)
 	}
+	if err = minitiaConfig.SystemKeys.ValidateDerivation(); err != nil {
+		return nil, err
+	}
 
 	// Set the loaded config to the state variable
 	state.MinitiaConfig = &minitiaConfig
@@ -461,7 +465,7 @@ func (m *SetupOPInitBots) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 
 		keyFile := io.NewKeyFile()
-		err = keyFile.Load(keyFilePath)
+		err = keyFile.Load(keyFilePath, weaveconfig.GetKeyFilePassphrase(keyFilePath))
 		if err != nil {
 			return m, m.HandlePanic(fmt.Errorf("failed to load key file for OPinit: %w", err))
 		}
@@ -473,7 +477,7 @@ func (m *SetupOPInitBots) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			keyFile.AddKey(string(BotNameToKeyName[botName]), io.NewKey(address[1], mnemonic, crypto.CosmosAddressType))
 		}
 
-		err = keyFile.Write(keyFilePath)
+		err = keyFile.Write(keyFilePath, weaveconfig.GetKeyFilePassphrase(keyFilePath))
 		if err != nil {
 			return m, m.HandlePanic(fmt.Errorf("failed to write key file: %w", err))
 		}
@@ -503,7 +507,7 @@ func (m *SetupOPInitBots) View() string {
 
 			return m.WrapView(state.weave.Render() + "\n" + styles.RenderPrompt("Download binary and add keys successfully.", []string{}, styles.Completed) + "\n\n" +
 				styles.BoldUnderlineText("Important", styles.Yellow) + "\n" +
-				styles.Text(fmt.Sprintf("Note that the mnemonic phrases will be stored in %s. You can revisit them anytime.", keyFilePath), styles.Yellow) + "\n\n" +
+				styles.Text(fmt.Sprintf("Note that the mnemonic phrases will be stored encrypted in %s. You can export them anytime with `weave keys decrypt`.", keyFilePath), styles.Yellow) + "\n\n" +
 				addressesText)
 		} else {
 			return m.WrapView(state.weave.Render() + "\n" + styles.RenderPrompt("Download binary and add keys successfully.", []string{}, styles.Completed))
@@ -625,7 +629,7 @@ func EnsureOPInitBotsBinary(ctx context.Context) tea.Cmd {
 				}
 			}
 
-			if err = io.DownloadAndExtractTarGz(url, tarballPath, extractedPath); err != nil {
+			if err = io.DownloadAndExtractTarGz(url, tarballPath, extractedPath, weaveconfig.GetReleaseVerifyOptions()); err != nil {
 				return ui.NonRetryableErrorLoading{Err: fmt.Errorf("failed to download and extract binary: %v", err)}
 			}
 			err = os.Chmod(binaryPath, 0755) // 0755 ensuring read, write, execute permissions for the owner, and read-execute for group/others
@@ -726,7 +730,7 @@ func (m *TerminalState) View() string {
 
 		return m.WrapView(state.weave.Render() + "\n" + styles.RenderPrompt("Setup keys successfully.", []string{}, styles.Completed) + "\n\n" +
 			styles.BoldUnderlineText("Important", styles.Yellow) + "\n" +
-			styles.Text(fmt.Sprintf("Note that the mnemonic phrases will be stored in %s. You can revisit them anytime.", keyFile), styles.Yellow) + "\n\n" +
+			styles.Text(fmt.Sprintf("Note that the mnemonic phrases will be stored encrypted in %s. You can export them anytime with `weave keys decrypt`.", keyFile), styles.Yellow) + "\n\n" +
 			addressesText)
 	}
 	return m.WrapView(state.weave.Render() + "\n")
@@ -1,12 +1,14 @@
 package relayer
 
 import (
+	"fmt"
 	"os"
 	"path/filepath"
 	"slices"
 	"text/template"
 
 	"github.com/initia-labs/weave/common"
+	"github.com/initia-labs/weave/crypto"
 	"github.com/initia-labs/weave/types"
 )
 
@@ -31,12 +33,14 @@ type Data struct {
 	RPCAddr       string
 	RESTAddr      string
 	GasPrice      GasPrice
+	KeyType       string
 	Mnemonic      string
 	PacketFilter  PacketFilter `toml:"packet_filter"`
 	ID2           string
 	RPCAddr2      string
 	RESTAddr2     string
 	GasPrice2     GasPrice
+	KeyType2      string
 	Mnemonic2     string
 	PacketFilter2 PacketFilter
 }
@@ -80,6 +84,23 @@ func transformToPacketFilter(pairs []types.IBCChannelPair, isL1 bool) PacketFilt
 	return packetFilter
 }
 
+// relayerWalletKey returns the rapid relayer key type and secret for a relayer mnemonic.
+// The rapid relayer only derives mnemonics at the default path, so custom derivations are written as raw private keys.
+func relayerWalletKey(mnemonic string, opts crypto.DerivationOptions) (string, string, error) {
+	if opts.IsDefault() {
+		return "mnemonic", mnemonic, nil
+	}
+
+	privateKey, err := crypto.MnemonicToPrivateKeyHex(mnemonic, 118, opts)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to derive relayer private key: %v", err)
+	}
+	return "raw", privateKey, nil
+}
+
+// createRapidRelayerConfig writes the rapid relayer config, including the relayer keys.
+// Unlike the gas station and the key files, the keys are not encrypted: the rapid relayer reads them from this
+// file when it starts and cannot decrypt weave's format, so the file is only protected by being readable by the owner.
 func createRapidRelayerConfig(state State) error {
 	// Define the template directly in a variable
 	const configTemplate = `
@@ -100,7 +121,7 @@ func createRapidRelayerConfig(state State) error {
       "wallets": [
         {
           "key": {
-            "type": "mnemonic",
+            "type": "{{.KeyType}}",
             "privateKey": "{{.Mnemonic}}"
           },
           "maxHandlePacket": 10,
@@ -122,7 +143,7 @@ func createRapidRelayerConfig(state State) error {
       "wallets": [
         {
           "key": {
-            "type": "mnemonic",
+            "type": "{{.KeyType2}}",
             "privateKey": "{{.Mnemonic2}}"
           },
           "maxHandlePacket": 10,
@@ -139,6 +160,15 @@ func createRapidRelayerConfig(state State) error {
 }
 `
 
+	keyType, key, err := relayerWalletKey(state.l1RelayerMnemonic, state.l1Derivation)
+	if err != nil {
+		return err
+	}
+	keyType2, key2, err := relayerWalletKey(state.l2RelayerMnemonic, state.l2Derivation)
+	if err != nil {
+		return err
+	}
+
 	// Populate data for placeholders
 	data := Data{
 		ID:       state.Config["l1.chain_id"],
@@ -148,7 +178,8 @@ func createRapidRelayerConfig(state State) error {
 			Amount: state.Config["l1.gas_price.price"],
 			Denom:  state.Config["l1.gas_price.denom"],
 		},
-		Mnemonic:     state.l1RelayerMnemonic,
+		KeyType:      keyType,
+		Mnemonic:     key,
 		PacketFilter: transformToPacketFilter(state.IBCChannels, true),
 
 		ID2:       state.Config["l2.chain_id"],
@@ -158,7 +189,8 @@ func createRapidRelayerConfig(state State) error {
 			Amount: state.Config["l2.gas_price.price"],
 			Denom:  state.Config["l2.gas_price.denom"],
 		},
-		Mnemonic2:     state.l2RelayerMnemonic,
+		KeyType2:      keyType2,
+		Mnemonic2:     key2,
 		PacketFilter2: transformToPacketFilter(state.IBCChannels, false),
 	}
 
@@ -177,13 +209,18 @@ func createRapidRelayerConfig(state State) error {
 		return err
 	}
 
-	// Open the file for writing
-	outputFile, err := os.Create(outputPath)
+	// Open the file for writing, readable only by the owner since it holds the relayer mnemonics
+	outputFile, err := os.OpenFile(outputPath, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0o600)
 	if err != nil {
 		return err
 	}
 	defer outputFile.Close()
 
+	// Tighten permissions of a config written by an older version
+	if err = outputFile.Chmod(0o600); err != nil {
+		return err
+	}
+
 	// Execute the template with data
 	err = tmpl.Execute(outputFile, data)
 	if err != nil {
@@ -24,6 +24,7 @@ import (
 	"github.com/initia-labs/weave/cosmosutils"
 	"github.com/initia-labs/weave/crypto"
 	weaveio "github.com/initia-labs/weave/io"
+	"github.com/initia-labs/weave/models/keyderivation"
 	"github.com/initia-labs/weave/registry"
 	"github.com/initia-labs/weave/service"
 	"github.com/initia-labs/weave/styles"
@@ -150,6 +151,9 @@ func (m *RollupSelect) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			if err != nil {
 				return m, m.HandlePanic(err)
 			}
+			if err = minitiaConfig.SystemKeys.ValidateDerivation(); err != nil {
+				return m, m.HandlePanic(err)
+			}
 
 			state.feeWhitelistAccounts = append(state.feeWhitelistAccounts, minitiaConfig.SystemKeys.Challenger.L2Address)
 
@@ -642,7 +646,7 @@ func (m *KeysMnemonicDisplayInput) View() string {
 
 	return m.WrapView(state.weave.Render() + "\n" +
 		styles.BoldUnderlineText("Important", styles.Yellow) + "\n" +
-		styles.Text(fmt.Sprintf("Note that the mnemonic phrases for Relayer will be stored in %s. You can revisit them anytime.", common.RelayerConfigPath), styles.Yellow) + "\n\n" +
+		styles.Text(fmt.Sprintf("Note that the mnemonic phrases for Relayer will be stored unencrypted in %s, readable only by you, since the relayer reads them from there. You can revisit them anytime.", common.RelayerConfigPath), styles.Yellow) + "\n\n" +
 		mnemonicText + styles.RenderPrompt(m.GetQuestion(), []string{"`continue`"}, styles.Question) + m.TextInput.View())
 }
 
@@ -687,29 +691,9 @@ func (m *ImportL1RelayerKeyInput) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	if done {
 		state := weavecontext.PushPageAndGetState[State](m)
 
-		relayerKey, err := weaveio.RecoverKey("init", input.Text, crypto.CosmosAddressType)
-		if err != nil {
-			return m, m.HandlePanic(err)
-		}
-		state.l1RelayerMnemonic = relayerKey.Mnemonic
-		state.l1RelayerAddress = relayerKey.Address
+		state.l1RelayerMnemonic = input.Text
 		state.weave.PushPreviousResponse(styles.RenderPreviousResponse(styles.DotsSeparator, m.GetQuestion(), []string{"relayer account key", m.layerText}, styles.HiddenMnemonicText))
-
-		switch L2KeySelectOption(state.l2KeyMethod) {
-		case L2ExistingKey:
-			model := NewSettingUpRelayer(weavecontext.SetCurrentState(m.Ctx, state))
-			return model, model.Init()
-		case L2SameKey:
-			state.l2RelayerAddress = relayerKey.Address
-			state.l2RelayerMnemonic = relayerKey.Mnemonic
-			model := NewSettingUpRelayer(weavecontext.SetCurrentState(m.Ctx, state))
-			return model, model.Init()
-		case L2GenerateKey:
-			model := NewGenerateL2RelayerKeyLoading(weavecontext.SetCurrentState(m.Ctx, state))
-			return model, model.Init()
-		case L2ImportKey:
-			return NewImportL2RelayerKeyInput(weavecontext.SetCurrentState(m.Ctx, state)), nil
-		}
+		return NewRelayerKeyDerivationSelect(weavecontext.SetCurrentState(m.Ctx, state), L1RelayerKey, m.layerText), nil
 	}
 	m.TextInput = input
 	return m, cmd
@@ -754,16 +738,9 @@ func (m *ImportL2RelayerKeyInput) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	if done {
 		state := weavecontext.PushPageAndGetState[State](m)
 
-		relayerKey, err := weaveio.RecoverKey("init", input.Text, crypto.CosmosAddressType)
-		if err != nil {
-			return m, m.HandlePanic(err)
-		}
-		state.l2RelayerMnemonic = relayerKey.Mnemonic
-		state.l2RelayerAddress = relayerKey.Address
+		state.l2RelayerMnemonic = input.Text
 		state.weave.PushPreviousResponse(styles.RenderPreviousResponse(styles.DotsSeparator, m.GetQuestion(), []string{"relayer account key", "L2"}, styles.HiddenMnemonicText))
-
-		model := NewSettingUpRelayer(weavecontext.SetCurrentState(m.Ctx, state))
-		return model, model.Init()
+		return NewRelayerKeyDerivationSelect(weavecontext.SetCurrentState(m.Ctx, state), L2RelayerKey, "L2"), nil
 	}
 	m.TextInput = input
 	return m, cmd
@@ -774,6 +751,78 @@ func (m *ImportL2RelayerKeyInput) View() string {
 	return m.WrapView(state.weave.Render() + styles.RenderPrompt(m.GetQuestion(), []string{"relayer account key", "L2"}, styles.Question) + m.TextInput.View())
 }
 
+type RelayerKeyLayer string
+
+const (
+	L1RelayerKey RelayerKeyLayer = "l1"
+	L2RelayerKey RelayerKeyLayer = "l2"
+)
+
+func setRelayerKeyDerivation(state *State, layer RelayerKeyLayer, opts crypto.DerivationOptions) {
+	if layer == L2RelayerKey {
+		state.l2Derivation = opts
+	} else {
+		state.l1Derivation = opts
+	}
+}
+
+// finishImportRelayerKey recovers the imported relayer key with its derivation options and continues the flow
+func finishImportRelayerKey(ctx context.Context, layer RelayerKeyLayer) (tea.Model, tea.Cmd, error) {
+	state := weavecontext.GetCurrentState[State](ctx)
+
+	if layer == L2RelayerKey {
+		relayerKey, err := weaveio.RecoverKeyWithOptions("init", state.l2RelayerMnemonic, crypto.CosmosAddressType, state.l2Derivation)
+		if err != nil {
+			return nil, nil, err
+		}
+		state.l2RelayerAddress = relayerKey.Address
+
+		model := NewSettingUpRelayer(weavecontext.SetCurrentState(ctx, state))
+		return model, model.Init(), nil
+	}
+
+	relayerKey, err := weaveio.RecoverKeyWithOptions("init", state.l1RelayerMnemonic, crypto.CosmosAddressType, state.l1Derivation)
+	if err != nil {
+		return nil, nil, err
+	}
+	state.l1RelayerAddress = relayerKey.Address
+
+	switch L2KeySelectOption(state.l2KeyMethod) {
+	case L2SameKey:
+		state.l2RelayerAddress = relayerKey.Address
+		state.l2RelayerMnemonic = relayerKey.Mnemonic
+		state.l2Derivation = state.l1Derivation
+	case L2GenerateKey:
+		model := NewGenerateL2RelayerKeyLoading(weavecontext.SetCurrentState(ctx, state))
+		return model, model.Init(), nil
+	case L2ImportKey:
+		return NewImportL2RelayerKeyInput(weavecontext.SetCurrentState(ctx, state)), nil, nil
+	}
+
+	model := NewSettingUpRelayer(weavecontext.SetCurrentState(ctx, state))
+	return model, model.Init(), nil
+}
+
+// NewRelayerKeyDerivationSelect asks how the imported relayer key of the layer is derived before recovering it
+func NewRelayerKeyDerivationSelect(ctx context.Context, layer RelayerKeyLayer, layerText string) tea.Model {
+	return keyderivation.NewSelect(ctx, keyderivation.Flow[State]{
+		Subject:       fmt.Sprintf("relayer account key on %s", layerText),
+		DefaultHDPath: crypto.CosmosHDPath,
+		Render: func(state State) string {
+			return state.weave.Render()
+		},
+		PushPreviousResponse: func(state State, response string) State {
+			state.weave.PushPreviousResponse(response)
+			return state
+		},
+		Next: func(ctx context.Context, opts crypto.DerivationOptions) (tea.Model, tea.Cmd, error) {
+			state := weavecontext.GetCurrentState[State](ctx)
+			setRelayerKeyDerivation(&state, layer, opts)
+			return finishImportRelayerKey(weavecontext.SetCurrentState(ctx, state), layer)
+		},
+	})
+}
+
 type FetchingBalancesLoading struct {
 	ui.Loading
 	weavecontext.BaseModel
@@ -1203,6 +1252,10 @@ func broadcastDefaultPresetFromGasStation(ctx context.Context) tea.Cmd {
 		if err != nil {
 			return ui.NonRetryableErrorLoading{Err: fmt.Errorf("failed to get gas station key: %v", err)}
 		}
+		gasStationSigner, err := gasStationKey.InitiaSigner()
+		if err != nil {
+			return ui.NonRetryableErrorLoading{Err: fmt.Errorf("failed to get gas station signer: %v", err)}
+		}
 
 		if state.l1FundingAmount != "0" {
 			l1ActiveLcd, err := GetL1ActiveLcd(ctx)
@@ -1230,13 +1283,12 @@ func broadcastDefaultPresetFromGasStation(ctx context.Context) tea.Cmd {
 				return ui.NonRetryableErrorLoading{Err: err}
 			}
 			res, err := l1Tx.BroadcastMsgSend(
-				gasStationKey.Mnemonic,
+				gasStationSigner,
 				state.l1RelayerAddress,
 				fmt.Sprintf("%s%s", state.l1FundingAmount, l1GasDenom),
 				l1GasPrices,
 				l1ActiveRpc,
 				l1ChainId,
-				*gasStationKey.CoinType,
 			)
 			if err != nil {
 				return ui.NonRetryableErrorLoading{Err: err}
@@ -1270,13 +1322,12 @@ func broadcastDefaultPresetFromGasStation(ctx context.Context) tea.Cmd {
 				return ui.NonRetryableErrorLoading{Err: err}
 			}
 			res, err := l2Tx.BroadcastMsgSend(
-				gasStationKey.Mnemonic,
+				gasStationSigner,
 				state.l2RelayerAddress,
 				fmt.Sprintf("%s%s", state.l2FundingAmount, l2GasDenom),
 				l2GasPrices,
 				l2ActiveRpc,
 				l2ChainId,
-				*gasStationKey.CoinType,
 			)
 			if err != nil {
 				return ui.NonRetryableErrorLoading{Err: err}
@@ -1,6 +1,7 @@
 package relayer
 
 import (
+	"github.com/initia-labs/weave/crypto"
 	"github.com/initia-labs/weave/registry"
 	"github.com/initia-labs/weave/types"
 )
@@ -14,6 +15,7 @@ type State struct {
 	l1KeyMethod       string
 	l1RelayerAddress  string
 	l1RelayerMnemonic string
+	l1Derivation      crypto.DerivationOptions
 	l1NeedsFunding    bool
 	l1FundingAmount   string
 	l1FundingTxHash   string
@@ -21,6 +23,7 @@ type State struct {
 	l2KeyMethod       string
 	l2RelayerAddress  string
 	l2RelayerMnemonic string
+	l2Derivation      crypto.DerivationOptions
 	l2NeedsFunding    bool
 	l2FundingAmount   string
 	l2FundingTxHash   string
@@ -52,6 +55,7 @@ func (state State) Clone() State {
 		l1KeyMethod:       state.l1KeyMethod,
 		l1RelayerAddress:  state.l1RelayerAddress,
 		l1RelayerMnemonic: state.l1RelayerMnemonic,
+		l1Derivation:      state.l1Derivation,
 		l1NeedsFunding:    state.l1NeedsFunding,
 		l1FundingAmount:   state.l1FundingAmount,
 		l1FundingTxHash:   state.l1FundingTxHash,
@@ -59,6 +63,7 @@ func (state State) Clone() State {
 		l2KeyMethod:       state.l2KeyMethod,
 		l2RelayerAddress:  state.l2RelayerAddress,
 		l2RelayerMnemonic: state.l2RelayerMnemonic,
+		l2Derivation:      state.l2Derivation,
 		l2NeedsFunding:    state.l2NeedsFunding,
 		l2FundingAmount:   state.l2FundingAmount,
 		l2FundingTxHash:   state.l2FundingTxHash,
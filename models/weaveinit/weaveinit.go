@@ -2,14 +2,11 @@ package weaveinit
 
 import (
 	"context"
-	"fmt"
-	"os"
-	"path/filepath"
 
 	tea "github.com/charmbracelet/bubbletea"
 
 	"github.com/initia-labs/weave/analytics"
-	"github.com/initia-labs/weave/common"
+	"github.com/initia-labs/weave/config"
 	weavecontext "github.com/initia-labs/weave/context"
 	"github.com/initia-labs/weave/models/initia"
 	"github.com/initia-labs/weave/models/minitia"
@@ -90,9 +87,17 @@ func (m *WeaveInit) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return model, cmd
 	}
 
-	homeDir, err := os.UserHomeDir()
+	initiaHome, err := config.GetInitiaHome()
 	if err != nil {
-		return m, m.HandlePanic(fmt.Errorf("cannot get user home directory: %v", err))
+		return m, m.HandlePanic(err)
+	}
+	minitiaHome, err := config.GetMinitiaHome()
+	if err != nil {
+		return m, m.HandlePanic(err)
+	}
+	opinitHome, err := config.GetOPinitHome()
+	if err != nil {
+		return m, m.HandlePanic(err)
 	}
 
 	selected, cmd := m.Select(msg)
@@ -101,7 +106,7 @@ func (m *WeaveInit) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		switch *selected {
 		case RunL1NodeOption:
 			ctx := weavecontext.NewAppContext(initia.NewRunL1NodeState())
-			ctx = weavecontext.SetInitiaHome(ctx, filepath.Join(homeDir, common.InitiaDirectory))
+			ctx = weavecontext.SetInitiaHome(ctx, initiaHome)
 			ctx = weavecontext.SetWindowWidth(ctx, windowWidth)
 
 			analytics.AppendGlobalEventProperties(map[string]any{
@@ -117,8 +122,8 @@ func (m *WeaveInit) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return model, nil
 		case LaunchNewRollupOption:
 			ctx := weavecontext.NewAppContext(*minitia.NewLaunchState())
-			ctx = weavecontext.SetMinitiaHome(ctx, filepath.Join(homeDir, common.MinitiaDirectory))
-			ctx = weavecontext.SetOPInitHome(ctx, filepath.Join(homeDir, common.OPinitDirectory))
+			ctx = weavecontext.SetMinitiaHome(ctx, minitiaHome)
+			ctx = weavecontext.SetOPInitHome(ctx, opinitHome)
 			ctx = weavecontext.SetWindowWidth(ctx, windowWidth)
 
 			analytics.AppendGlobalEventProperties(map[string]any{
@@ -131,8 +136,8 @@ func (m *WeaveInit) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return minitiaChecker, minitiaChecker.Init()
 		case RunOPBotsOption:
 			ctx := weavecontext.NewAppContext(opinit_bots.NewOPInitBotsState())
-			ctx = weavecontext.SetMinitiaHome(ctx, filepath.Join(homeDir, common.MinitiaDirectory))
-			ctx = weavecontext.SetOPInitHome(ctx, filepath.Join(homeDir, common.OPinitDirectory))
+			ctx = weavecontext.SetMinitiaHome(ctx, minitiaHome)
+			ctx = weavecontext.SetOPInitHome(ctx, opinitHome)
 			ctx = weavecontext.SetWindowWidth(ctx, windowWidth)
 
 			analytics.AppendGlobalEventProperties(map[string]any{
@@ -150,7 +155,7 @@ func (m *WeaveInit) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return model, model.Init()
 		case RunRelayerOption:
 			ctx := weavecontext.NewAppContext(relayer.NewRelayerState())
-			ctx = weavecontext.SetMinitiaHome(ctx, filepath.Join(homeDir, common.MinitiaDirectory))
+			ctx = weavecontext.SetMinitiaHome(ctx, minitiaHome)
 			ctx = weavecontext.SetWindowWidth(ctx, windowWidth)
 
 			analytics.AppendGlobalEventProperties(map[string]any{
@@ -1,6 +1,7 @@
 package registry
 
 import (
+	"encoding/json"
 	"fmt"
 	"net/url"
 	"strconv"
@@ -8,6 +9,7 @@ import (
 	"sync"
 
 	"github.com/initia-labs/weave/client"
+	"github.com/initia-labs/weave/io"
 	"github.com/initia-labs/weave/types"
 )
 
@@ -520,11 +522,36 @@ func (cr *ChainRegistry) GetDefaultMinGasPrices() (string, error) {
 	return fmt.Sprintf("%s%s", strconv.FormatFloat(feeToken.FixedMinGasPrice, 'f', -1, 64), feeToken.Denom), nil
 }
 
-func loadChainRegistry(chainType ChainType) error {
+// fetchRegistry decodes the document at endpoint into result, falling back to the copy an installed bundle carries when endpoint cannot be reached
+func fetchRegistry(endpoint string, result interface{}) error {
 	httpClient := client.NewHTTPClient()
+	_, err := httpClient.Get(endpoint, "", nil, result)
+	if err == nil {
+		return nil
+	}
+	cached, cacheErr := io.ReadBundleCache(endpoint)
+	if cacheErr != nil {
+		return err
+	}
+	return json.Unmarshal(cached, result)
+}
+
+// RegistryEndpoints returns the URLs of the registry documents weave loads, in a fixed order
+func RegistryEndpoints() []string {
+	endpoints := make([]string, 0)
+	for _, chainType := range []ChainType{CelestiaTestnet, CelestiaMainnet, InitiaL1Testnet, InitiaL1Mainnet} {
+		endpoints = append(endpoints, GetRegistryEndpoint(chainType))
+	}
+	for _, chainType := range []ChainType{InitiaL1Testnet, InitiaL1Mainnet} {
+		endpoints = append(endpoints, ChainTypeToInitiaRegistryAPI[chainType])
+	}
+	return append(endpoints, OPInitBotsSpecEndpoint)
+}
+
+func loadChainRegistry(chainType ChainType) error {
 	endpoint := GetRegistryEndpoint(chainType)
 	LoadedChainRegistry[chainType] = &ChainRegistry{}
-	if _, err := httpClient.Get(endpoint, "", nil, LoadedChainRegistry[chainType]); err != nil {
+	if err := fetchRegistry(endpoint, LoadedChainRegistry[chainType]); err != nil {
 		return err
 	}
 	if err := replaceRpcsAndLcds(chainType, LoadedChainRegistry[chainType]); err != nil {
@@ -574,11 +601,9 @@ type ChainRegistryWithChainType struct {
 var LoadedL2Registry = make(map[string]*ChainRegistryWithChainType)
 
 func loadL2RegistryForType(chainType ChainType) error {
-	httpClient := client.NewHTTPClient()
-
 	var chains []*ChainRegistry
 	apiURL := ChainTypeToInitiaRegistryAPI[chainType]
-	if _, err := httpClient.Get(apiURL, "", nil, &chains); err != nil {
+	if err := fetchRegistry(apiURL, &chains); err != nil {
 		return fmt.Errorf("failed to fetch registry from %s: %w", apiURL, err)
 	}
 
@@ -641,8 +666,7 @@ func GetAllL2AvailableNetwork(chainType ChainType) ([]L2AvailableNetwork, error)
 var OPInitBotsSpecVersion map[string]int
 
 func loadOPInitBotsSpecVersion() error {
-	httpClient := client.NewHTTPClient()
-	if _, err := httpClient.Get(OPInitBotsSpecEndpoint, "", nil, &OPInitBotsSpecVersion); err != nil {
+	if err := fetchRegistry(OPInitBotsSpecEndpoint, &OPInitBotsSpecVersion); err != nil {
 		return fmt.Errorf("failed to load opinit spec_version: %v", err)
 	}
 	return nil
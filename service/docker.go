@@ -17,10 +17,12 @@ import (
 	"github.com/docker/go-connections/nat"
 
 	"github.com/initia-labs/weave/common"
+	"github.com/initia-labs/weave/config"
 )
 
 type Docker struct {
 	commandName    CommandName
+	profile        string
 	vmType         string
 	relayerVersion string // Cached version for relayer to ensure Create() and Start() use the same version
 }
@@ -28,6 +30,7 @@ type Docker struct {
 func NewDocker(commandName CommandName, vmType string) *Docker {
 	return &Docker{
 		commandName: commandName,
+		profile:     config.GetProfile(),
 		vmType:      vmType,
 	}
 }
@@ -376,7 +379,7 @@ func (d *Docker) GetServiceName() (string, error) {
 	if err != nil {
 		return "", err
 	}
-	return fmt.Sprintf("weave-%s", prettyName), nil
+	return fmt.Sprintf("weave-%s%s", prettyName, config.ProfileServiceSuffix(d.profile)), nil
 }
 
 func (d *Docker) GetServiceFile() (string, error) {
@@ -436,6 +439,32 @@ func (d *Docker) PruneLogs() error {
 	return nil
 }
 
+func (d *Docker) IsRunning() (bool, error) {
+	// For Rollytics, use docker compose
+	if d.commandName == Rollytics {
+		return d.isDockerComposeRunning()
+	}
+
+	cli, err := client.NewClientWithOpts(client.FromEnv, client.WithAPIVersionNegotiation())
+	if err != nil {
+		return false, err
+	}
+	defer cli.Close()
+
+	serviceName, err := d.GetServiceName()
+	if err != nil {
+		return false, err
+	}
+	containerJSON, err := cli.ContainerInspect(context.Background(), serviceName)
+	if err != nil {
+		if errdefs.IsNotFound(err) {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to inspect container: %v", err)
+	}
+	return containerJSON.State != nil && containerJSON.State.Running, nil
+}
+
 func (d *Docker) Restart() error {
 	// For Rollytics, use docker compose
 	if d.commandName == Rollytics {
@@ -244,6 +244,21 @@ func (d *Docker) stopDockerCompose() error {
 	return nil
 }
 
+func (d *Docker) isDockerComposeRunning() (bool, error) {
+	projectDir, err := d.getComposeProjectDir()
+	if err != nil {
+		return false, fmt.Errorf("failed to get compose project dir: %v", err)
+	}
+
+	cmd := exec.Command("docker", "compose", "ps", "--status", "running", "--quiet")
+	cmd.Dir = projectDir
+	output, err := cmd.Output()
+	if err != nil {
+		return false, fmt.Errorf("failed to list docker compose containers: %v", err)
+	}
+	return strings.TrimSpace(string(output)) != "", nil
+}
+
 func (d *Docker) logDockerCompose(n int) error {
 	projectDir, err := d.getComposeProjectDir()
 	if err != nil {
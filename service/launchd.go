@@ -15,6 +15,7 @@ import (
 	"time"
 
 	"github.com/initia-labs/weave/common"
+	"github.com/initia-labs/weave/config"
 	"github.com/initia-labs/weave/cosmosutils"
 	weaveio "github.com/initia-labs/weave/io"
 )
@@ -25,10 +26,11 @@ const (
 
 type Launchd struct {
 	commandName CommandName
+	profile     string
 }
 
 func NewLaunchd(commandName CommandName) *Launchd {
-	return &Launchd{commandName: commandName}
+	return &Launchd{commandName: commandName, profile: config.GetProfile()}
 }
 
 func (j *Launchd) GetCommandName() string {
@@ -36,7 +38,7 @@ func (j *Launchd) GetCommandName() string {
 }
 
 func (j *Launchd) GetServiceName() (string, error) {
-	slug, err := j.commandName.GetServiceSlug()
+	slug, err := j.commandName.getProfileServiceSlug(j.profile)
 	if err != nil {
 		return "", err
 	}
@@ -56,13 +58,19 @@ func (j *Launchd) Create(binaryVersion, appHome string) error {
 		return fmt.Errorf("failed to get binary name: %v", err)
 	}
 	var binaryPath string
-	if j.commandName == Minitia {
+	switch j.commandName {
+	case Minitia:
 		versionDir := filepath.Join(weaveDataPath, binaryVersion)
 		binaryPath, err = cosmosutils.FindBinaryDir(versionDir, binaryName)
 		if err != nil {
 			return fmt.Errorf("failed to locate %s binary: %w", binaryName, err)
 		}
-	} else {
+	case ValidatorMonitor:
+		binaryPath, err = weaveBinaryDir()
+		if err != nil {
+			return err
+		}
+	default:
 		binaryPath = filepath.Join(weaveDataPath, binaryVersion)
 	}
 	if err = os.Setenv("HOME", userHome); err != nil {
@@ -81,14 +89,27 @@ func (j *Launchd) Create(binaryVersion, appHome string) error {
 		}
 	}
 	cmd := exec.Command("tee", plistPath)
-	template := DarwinTemplateMap[j.commandName]
-	cmd.Stdin = strings.NewReader(fmt.Sprintf(string(template), binaryName, binaryPath, appHome, userHome, weaveLogPath, j.GetCommandName()))
+	cmd.Stdin = strings.NewReader(j.plistContent(binaryName, binaryPath, appHome, userHome, weaveLogPath))
 	if output, err := cmd.CombinedOutput(); err != nil {
 		return fmt.Errorf("failed to create service: %v (output: %s)", err, string(output))
 	}
 	return j.reloadService()
 }
 
+// plistContent renders the plist of the service. The validator monitor reads the config of the profile it was set up
+// with, so --profile comes before --home to be kept when Start replaces the arguments.
+func (j *Launchd) plistContent(binaryName, binaryPath, appHome, userHome, weaveLogPath string) string {
+	profileSuffix := config.ProfileServiceSuffix(j.profile)
+	var profileArgument string
+	if profileSuffix != "" {
+		profileArgument = fmt.Sprintf("\n        <string>--profile=%s</string>", j.profile)
+	}
+
+	template := DarwinTemplateMap[j.commandName]
+	return fmt.Sprintf(string(template), binaryName, escapePlistString(binaryPath), escapePlistString(appHome),
+		escapePlistString(userHome), escapePlistString(weaveLogPath), j.GetCommandName(), profileSuffix, profileArgument)
+}
+
 // func (j *Launchd) unloadService() error {
 // 	userHome, err := os.UserHomeDir()
 // 	if err != nil {
@@ -135,82 +156,92 @@ func (j *Launchd) Start(optionalArgs ...string) error {
 		if err != nil {
 			return fmt.Errorf("failed to read plist file: %w", err)
 		}
-
-		// Parse the plist XML
-		decoder := xml.NewDecoder(bytes.NewReader(content))
-		var inProgramArgs bool
-		programArgs := make([]string, 0)
-		for {
-			token, err := decoder.Token()
-			if err == io.EOF {
-				break
-			}
-			if err != nil {
-				return fmt.Errorf("failed to parse plist: %w", err)
-			}
-
-			switch t := token.(type) {
-			case xml.StartElement:
-				if t.Name.Local == "key" && inProgramArgs {
-					inProgramArgs = false
-				}
-				if t.Name.Local == "array" && inProgramArgs {
-					programArgs = []string{}
-				}
-			case xml.CharData:
-				if string(t) == "ProgramArguments" {
-					inProgramArgs = true
-				}
-				if inProgramArgs && len(strings.TrimSpace(string(t))) > 0 {
-					programArgs = append(programArgs, strings.TrimSpace(string(t)))
-				}
-			}
+		newContent, err := withProgramArguments(content, optionalArgs)
+		if err != nil {
+			return err
+		}
+		if err := os.WriteFile(plistPath, newContent, 0o644); err != nil {
+			return fmt.Errorf("failed to write plist file: %w", err)
 		}
 
-		// Create new arguments list
-		newArgs := make([]string, 0)
-
-		for i := 0; i < len(programArgs); i++ {
-			if strings.HasPrefix(programArgs[i], "--home=") {
-				newArgs = append(newArgs, programArgs[i])
-				break
-			}
-			newArgs = append(newArgs, programArgs[i])
+		// Reload and start the service
+		if err := j.reloadService(); err != nil {
+			return fmt.Errorf("failed to reload service: %w", err)
 		}
+	}
+	cmd := exec.Command("launchctl", "start", serviceName)
+	return cmd.Run()
+}
 
-		newArgs = append(newArgs, optionalArgs...)
-		// Replace program arguments in the original content
-		var oldArgsXML, newArgsXML strings.Builder
-		for _, arg := range programArgs {
-			fmt.Fprintf(&oldArgsXML, "\t\t<string>%s</string>\n", arg)
+// withProgramArguments returns the plist content with the ProgramArguments after --home replaced with optionalArgs
+func withProgramArguments(content []byte, optionalArgs []string) ([]byte, error) {
+	// Parse the plist XML
+	decoder := xml.NewDecoder(bytes.NewReader(content))
+	var inProgramArgs bool
+	programArgs := make([]string, 0)
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			break
 		}
-		for _, arg := range newArgs {
-			fmt.Fprintf(&newArgsXML, "\t\t<string>%s</string>\n", arg)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse plist: %w", err)
 		}
 
-		// Find the ProgramArguments array section and replace its content
-		startTag := "<array>"
-		endTag := "</array>"
-		arrayStart := strings.Index(string(content), startTag)
-		arrayEnd := strings.Index(string(content), endTag)
-		if arrayStart != -1 && arrayEnd != -1 {
-			arrayStart += len(startTag)
-			oldContent := string(content[arrayStart:arrayEnd])
-			newContent := strings.Replace(string(content), oldContent, "\n"+newArgsXML.String(), 1)
-
-			// Write back to file
-			if err := os.WriteFile(plistPath, []byte(newContent), 0o644); err != nil {
-				return fmt.Errorf("failed to write plist file: %w", err)
+		switch t := token.(type) {
+		case xml.StartElement:
+			if t.Name.Local == "key" && inProgramArgs {
+				inProgramArgs = false
+			}
+			if t.Name.Local == "array" && inProgramArgs {
+				programArgs = []string{}
+			}
+		case xml.CharData:
+			if string(t) == "ProgramArguments" {
+				inProgramArgs = true
+			}
+			if inProgramArgs && len(strings.TrimSpace(string(t))) > 0 {
+				programArgs = append(programArgs, strings.TrimSpace(string(t)))
 			}
 		}
+	}
 
-		// Reload and start the service
-		if err := j.reloadService(); err != nil {
-			return fmt.Errorf("failed to reload service: %w", err)
+	// Create new arguments list
+	newArgs := make([]string, 0)
+
+	for i := 0; i < len(programArgs); i++ {
+		if strings.HasPrefix(programArgs[i], "--home=") {
+			newArgs = append(newArgs, programArgs[i])
+			break
 		}
+		newArgs = append(newArgs, programArgs[i])
 	}
-	cmd := exec.Command("launchctl", "start", serviceName)
-	return cmd.Run()
+
+	newArgs = append(newArgs, optionalArgs...)
+	// The decoder unescapes the arguments, so they are escaped again when written back
+	var newArgsXML strings.Builder
+	for _, arg := range newArgs {
+		fmt.Fprintf(&newArgsXML, "\t\t<string>%s</string>\n", escapePlistString(arg))
+	}
+
+	// Find the ProgramArguments array section and replace its content
+	startTag := "<array>"
+	endTag := "</array>"
+	arrayStart := strings.Index(string(content), startTag)
+	arrayEnd := strings.Index(string(content), endTag)
+	if arrayStart == -1 || arrayEnd == -1 {
+		return content, nil
+	}
+	arrayStart += len(startTag)
+	oldContent := string(content[arrayStart:arrayEnd])
+	return []byte(strings.Replace(string(content), oldContent, "\n"+newArgsXML.String(), 1)), nil
+}
+
+// escapePlistString escapes s to be used as the content of a plist element
+func escapePlistString(s string) string {
+	var escaped bytes.Buffer
+	_ = xml.EscapeText(&escaped, []byte(s))
+	return escaped.String()
 }
 
 func (j *Launchd) Stop() error {
@@ -222,6 +253,19 @@ func (j *Launchd) Stop() error {
 	return cmd.Run()
 }
 
+func (j *Launchd) IsRunning() (bool, error) {
+	serviceName, err := j.GetServiceName()
+	if err != nil {
+		return false, fmt.Errorf("failed to get service name: %v", err)
+	}
+	// The job of a loaded service only lists a PID while its process is alive
+	output, err := exec.Command("launchctl", "list", serviceName).Output()
+	if err != nil {
+		return false, nil
+	}
+	return strings.Contains(string(output), `"PID" =`), nil
+}
+
 func (j *Launchd) Restart() error {
 	err := j.Stop()
 	if err != nil {
@@ -250,7 +294,7 @@ func (j *Launchd) PruneLogs() error {
 		return fmt.Errorf("failed to get user home directory: %v", err)
 	}
 
-	slug, err := j.commandName.GetServiceSlug()
+	slug, err := j.commandName.getProfileServiceSlug(j.profile)
 	if err != nil {
 		return fmt.Errorf("failed to get service slug: %v", err)
 	}
@@ -275,7 +319,7 @@ func (j *Launchd) streamLogsFromFiles(n int) error {
 		return fmt.Errorf("failed to get user home directory: %v", err)
 	}
 
-	slug, err := j.commandName.GetServiceSlug()
+	slug, err := j.commandName.getProfileServiceSlug(j.profile)
 	if err != nil {
 		return fmt.Errorf("failed to get service slug: %v", err)
 	}
@@ -4,9 +4,12 @@ import (
 	"fmt"
 	"os"
 	"os/signal"
+	"path/filepath"
 	"runtime"
 	"syscall"
 	"time"
+
+	"github.com/initia-labs/weave/config"
 )
 
 type Service interface {
@@ -16,21 +19,36 @@ type Service interface {
 	Stop() error
 	Restart() error
 	PruneLogs() error
+	// IsRunning reports whether the service is currently running
+	IsRunning() (bool, error)
 
 	GetServiceFile() (string, error)
 	GetServiceBinaryAndHome() (string, string, error)
 }
 
+// NewService returns the service of commandName for the active profile
 func NewService(commandName CommandName, vmType string) (Service, error) {
+	return NewServiceForProfile(commandName, vmType, config.GetProfile())
+}
+
+// NewServiceForProfile returns the service of commandName for profile, whose name carries the profile name
+// so that the services of several profiles can run side by side
+func NewServiceForProfile(commandName CommandName, vmType, profile string) (Service, error) {
 	if commandName == Relayer || commandName == Rollytics {
-		return NewDocker(commandName, vmType), nil
+		d := NewDocker(commandName, vmType)
+		d.profile = profile
+		return d, nil
 	}
 
 	switch runtime.GOOS {
 	case "linux":
-		return NewSystemd(commandName), nil
+		s := NewSystemd(commandName)
+		s.profile = profile
+		return s, nil
 	case "darwin":
-		return NewLaunchd(commandName), nil
+		l := NewLaunchd(commandName)
+		l.profile = profile
+		return l, nil
 	default:
 		return nil, fmt.Errorf("unsupported OS: %s", runtime.GOOS)
 	}
@@ -58,3 +76,16 @@ func NonDetachStart(s Service, optionalArgs ...string) error {
 	<-signalChan
 	return s.Stop()
 }
+
+// weaveBinaryDir returns the directory of the running weave binary, which services of weave itself are run from
+func weaveBinaryDir() (string, error) {
+	executable, err := os.Executable()
+	if err != nil {
+		return "", fmt.Errorf("failed to locate the weave binary: %v", err)
+	}
+	executable, err = filepath.EvalSymlinks(executable)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve the weave binary: %v", err)
+	}
+	return filepath.Dir(executable), nil
+}
@@ -0,0 +1,92 @@
+package service
+
+import (
+	"encoding/xml"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestServiceName_Profile(t *testing.T) {
+	tests := []struct {
+		name    string
+		service interface{ GetServiceName() (string, error) }
+		want    string
+	}{
+		{name: "systemd default", service: &Systemd{commandName: OPinitExecutor, profile: "default"}, want: "opinitd.executor.service"},
+		{name: "systemd profile", service: &Systemd{commandName: OPinitExecutor, profile: "testnet"}, want: "opinitd.executor-testnet.service"},
+		{name: "launchd default", service: &Launchd{commandName: UpgradableInitia, profile: "default"}, want: "com.cosmovisor.daemon"},
+		{name: "launchd profile", service: &Launchd{commandName: UpgradableInitia, profile: "testnet"}, want: "com.cosmovisor-testnet.daemon"},
+		{name: "docker default", service: &Docker{commandName: Relayer, profile: "default"}, want: "weave-relayer"},
+		{name: "docker profile", service: &Docker{commandName: Relayer, profile: "testnet"}, want: "weave-relayer-testnet"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.service.GetServiceName()
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestWithProgramArguments_Escaping(t *testing.T) {
+	content := []byte(`<plist version="1.0">
+<dict>
+    <key>ProgramArguments</key>
+    <array>
+        <string>/usr/local/bin/weave</string>
+        <string>--home=/Users/a&amp;b/.initia</string>
+        <string>--webhook-url=https://old.example.com</string>
+    </array>
+    <key>RunAtLoad</key>
+    <false/>
+</dict>
+</plist>`)
+
+	updated, err := withProgramArguments(content, []string{"--webhook-url=https://hooks.example.com/alert?a=1&b=<2>"})
+	assert.NoError(t, err)
+
+	var plist Plist
+	assert.NoError(t, xml.Unmarshal(updated, &plist), "the plist must stay valid XML")
+	assert.Equal(t, []string{
+		"/usr/local/bin/weave",
+		"--home=/Users/a&b/.initia",
+		"--webhook-url=https://hooks.example.com/alert?a=1&b=<2>",
+	}, plist.ProgramArguments)
+}
+
+func TestValidatorMonitor_StartKeepsProfile(t *testing.T) {
+	startArgs := []string{"--warn-missed-blocks", "10"}
+
+	t.Run("systemd", func(t *testing.T) {
+		j := &Systemd{commandName: ValidatorMonitor, profile: "testnet"}
+		unit := withExecStartArgs(j.serviceFileContent("weave", "/usr/local/bin", "/home/user/.initia-testnet", ""), startArgs)
+		assert.Contains(t, unit, "ExecStart=/usr/local/bin/weave initia validator monitor run --profile testnet "+
+			"--home /home/user/.initia-testnet --warn-missed-blocks 10\n")
+	})
+
+	t.Run("launchd", func(t *testing.T) {
+		j := &Launchd{commandName: ValidatorMonitor, profile: "testnet"}
+		plist, err := withProgramArguments([]byte(j.plistContent("weave", "/usr/local/bin", "/Users/user/.initia-testnet", "/Users/user", "/Users/user/.weave/log")), startArgs)
+		assert.NoError(t, err)
+
+		var parsed Plist
+		assert.NoError(t, xml.Unmarshal(plist, &parsed))
+		assert.Equal(t, []string{
+			"/usr/local/bin/weave", "initia", "validator", "monitor", "run",
+			"--profile=testnet", "--home=/Users/user/.initia-testnet", "--warn-missed-blocks", "10",
+		}, parsed.ProgramArguments)
+	})
+}
+
+func TestWithExecStartArgs_QuotesArguments(t *testing.T) {
+	j := &Systemd{commandName: ValidatorMonitor, profile: "testnet"}
+	unit := withExecStartArgs(j.serviceFileContent("weave", "/usr/local/bin", "/home/user/.initia-testnet", ""), []string{
+		"--log-file", "/home/user/validator logs/100%.log",
+		"--note", "a&b",
+		"--label", `say "hi" $USER`,
+	})
+	assert.Contains(t, unit, "--home /home/user/.initia-testnet "+
+		`--log-file "/home/user/validator logs/100%%.log" --note a&b --label "say \"hi\" $$USER"`+"\n")
+}
@@ -2,6 +2,7 @@ package service
 
 import (
 	"bufio"
+	"errors"
 	"fmt"
 	"os"
 	"os/exec"
@@ -11,6 +12,7 @@ import (
 	"strings"
 
 	"github.com/initia-labs/weave/common"
+	"github.com/initia-labs/weave/config"
 	"github.com/initia-labs/weave/cosmosutils"
 )
 
@@ -21,6 +23,7 @@ const (
 
 type Systemd struct {
 	commandName CommandName
+	profile     string
 	user        *user.User
 	userMode    bool
 }
@@ -28,9 +31,9 @@ type Systemd struct {
 func NewSystemd(commandName CommandName) *Systemd {
 	currentUser, err := user.Current()
 	if err != nil {
-		return &Systemd{commandName: commandName}
+		return &Systemd{commandName: commandName, profile: config.GetProfile()}
 	}
-	return &Systemd{commandName: commandName, user: currentUser, userMode: currentUser.Uid != "0"}
+	return &Systemd{commandName: commandName, profile: config.GetProfile(), user: currentUser, userMode: currentUser.Uid != "0"}
 }
 
 func (j *Systemd) GetCommandName() string {
@@ -38,7 +41,7 @@ func (j *Systemd) GetCommandName() string {
 }
 
 func (j *Systemd) GetServiceName() (string, error) {
-	slug, err := j.commandName.GetServiceSlug()
+	slug, err := j.commandName.getProfileServiceSlug(j.profile)
 	if err != nil {
 		return "", fmt.Errorf("failed to get service name: %v", err)
 	}
@@ -125,6 +128,11 @@ func (j *Systemd) Create(binaryVersion, appHome string) error {
 		if err != nil {
 			return fmt.Errorf("failed to locate %s binary: %w", binaryName, err)
 		}
+	case ValidatorMonitor:
+		binaryPath, err = weaveBinaryDir()
+		if err != nil {
+			return err
+		}
 	default:
 		binaryPath = filepath.Join(userHome, common.WeaveDataDirectory)
 	}
@@ -150,17 +158,13 @@ func (j *Systemd) Create(binaryVersion, appHome string) error {
 		}
 		// Remove sudo and write directly to user's directory
 		serviceFile := filepath.Join(serviceDir, serviceName)
-		template := LinuxTemplateMap[j.commandName]
-		err = os.WriteFile(serviceFile, []byte(fmt.Sprintf(string(template),
-			binaryName, binaryPath, string(j.commandName), appHome, userField)), 0o644)
+		err = os.WriteFile(serviceFile, []byte(j.serviceFileContent(binaryName, binaryPath, appHome, userField)), 0o644)
 		if err != nil {
 			return fmt.Errorf("failed to create service file: %v", err)
 		}
 	} else {
 		serviceFile := filepath.Join(j.getServiceDirPath(), serviceName)
-		template := LinuxTemplateMap[j.commandName]
-		err = os.WriteFile(serviceFile, []byte(fmt.Sprintf(string(template),
-			binaryName, binaryPath, string(j.commandName), appHome, userField)), 0o644)
+		err = os.WriteFile(serviceFile, []byte(j.serviceFileContent(binaryName, binaryPath, appHome, userField)), 0o644)
 		if err != nil {
 			return fmt.Errorf("failed to create service file: %v", err)
 		}
@@ -180,6 +184,17 @@ func (j *Systemd) Create(binaryVersion, appHome string) error {
 	return nil
 }
 
+// serviceFileContent renders the unit of the service. The validator monitor reads the config of the profile it was set
+// up with, so --profile comes before --home to be kept when Start replaces the arguments.
+func (j *Systemd) serviceFileContent(binaryName, binaryPath, appHome, userField string) string {
+	var profileFlag string
+	if config.ProfileServiceSuffix(j.profile) != "" {
+		profileFlag = fmt.Sprintf(" --profile %s", j.profile)
+	}
+	template := LinuxTemplateMap[j.commandName]
+	return fmt.Sprintf(string(template), binaryName, binaryPath, string(j.commandName), appHome, userField, profileFlag)
+}
+
 func (j *Systemd) daemonReload() error {
 	return j.systemctl("daemon-reload")
 }
@@ -274,35 +289,8 @@ func (j *Systemd) Start(optionalArgs ...string) error {
 			return fmt.Errorf("failed to read service file: %w", err)
 		}
 
-		// Parse the file line by line to find and modify ExecStart
-		lines := strings.Split(string(content), "\n")
-		for i, line := range lines {
-			if strings.HasPrefix(strings.TrimSpace(line), "ExecStart=") {
-				// Extract existing command and arguments
-				parts := strings.Fields(strings.TrimPrefix(strings.TrimSpace(line), "ExecStart="))
-
-				// Keep binary path and arguments up to --home
-				newArgs := make([]string, 0)
-
-				for j := 0; j < len(parts); j++ {
-					if strings.HasPrefix(parts[j], "--home") {
-						newArgs = append(newArgs, parts[j], parts[j+1])
-						break
-					}
-					newArgs = append(newArgs, parts[j])
-				}
-
-				// Add optional arguments
-				newArgs = append(newArgs, optionalArgs...)
-
-				// Create new ExecStart line
-				lines[i] = "ExecStart=" + strings.Join(newArgs, " ")
-				break
-			}
-		}
-
 		// Write the modified content back to the file
-		newContent := strings.Join(lines, "\n")
+		newContent := withExecStartArgs(string(content), optionalArgs)
 		if err := os.WriteFile(serviceFile, []byte(newContent), 0o644); err != nil {
 			return fmt.Errorf("failed to write service file: %w", err)
 		}
@@ -338,6 +326,70 @@ func (j *Systemd) Restart() error {
 	return j.systemctl("restart", serviceName)
 }
 
+func (j *Systemd) IsRunning() (bool, error) {
+	if err := j.ensureUserServicePrerequisites(); err != nil {
+		return false, err
+	}
+	serviceName, err := j.GetServiceName()
+	if err != nil {
+		return false, err
+	}
+	args := []string{"is-active", "--quiet", serviceName}
+	if j.userMode {
+		args = append([]string{"--user"}, args...)
+	}
+	// is-active exits with a non-zero status for every state other than active
+	err = exec.Command("systemctl", args...).Run()
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+// withExecStartArgs returns the unit content with the ExecStart arguments after --home replaced with optionalArgs
+func withExecStartArgs(content string, optionalArgs []string) string {
+	// Parse the file line by line to find and modify ExecStart
+	lines := strings.Split(content, "\n")
+	for i, line := range lines {
+		if strings.HasPrefix(strings.TrimSpace(line), "ExecStart=") {
+			// Extract existing command and arguments
+			parts := strings.Fields(strings.TrimPrefix(strings.TrimSpace(line), "ExecStart="))
+
+			// Keep binary path and arguments up to --home
+			newArgs := make([]string, 0)
+
+			for j := 0; j < len(parts); j++ {
+				if strings.HasPrefix(parts[j], "--home") {
+					newArgs = append(newArgs, parts[j], parts[j+1])
+					break
+				}
+				newArgs = append(newArgs, parts[j])
+			}
+
+			// Add optional arguments
+			for _, arg := range optionalArgs {
+				newArgs = append(newArgs, quoteExecStartArg(arg))
+			}
+
+			// Create new ExecStart line
+			lines[i] = "ExecStart=" + strings.Join(newArgs, " ")
+			break
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// quoteExecStartArg escapes arg for an ExecStart line, where systemd expands % specifiers and $ variables and splits
+// words at whitespace unless they are quoted
+func quoteExecStartArg(arg string) string {
+	arg = strings.NewReplacer("%", "%%", "$", "$$").Replace(arg)
+	if arg != "" && !strings.ContainsAny(arg, " \t\n\"'\\;") {
+		return arg
+	}
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`, "\t", `\t`).Replace(arg) + `"`
+}
+
 func (j *Systemd) GetServiceFile() (string, error) {
 	serviceName, err := j.GetServiceName()
 	if err != nil {
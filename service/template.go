@@ -2,13 +2,13 @@ package service
 
 type Template string
 
-// DarwinRunUpgradableCosmovisorTemplate should inject the arguments as follows: [1:binaryName, 2:binaryPath, 3:appHome, 4:userHome, 5:weaveLogPath, 6:serviceName]
+// DarwinRunUpgradableCosmovisorTemplate should inject the arguments as follows: [1:binaryName, 2:binaryPath, 3:appHome, 4:userHome, 5:weaveLogPath, 6:serviceName, 7:profileSuffix]
 const DarwinRunUpgradableCosmovisorTemplate Template = `<?xml version="1.0" encoding="UTF-8"?>
 <!DOCTYPE plist PUBLIC "-//Apple//DTD PLIST 1.0//EN" "http://www.apple.com/DTDs/PropertyList-1.0.dtd">
 <plist version="1.0">
 <dict>
     <key>Label</key>
-    <string>com.%[1]s.daemon</string>
+    <string>com.%[1]s%[7]s.daemon</string>
 
     <key>ProgramArguments</key>
     <array>
@@ -29,7 +29,7 @@ const DarwinRunUpgradableCosmovisorTemplate Template = `<?xml version="1.0" enco
 		<key>HOME</key>
         <string>%[4]s</string>
         <key>DYLD_LIBRARY_PATH</key>
-        <string>%[3]s/cosmovisor/dyld_lib</string>
+        <string>%[3]s/cosmovisor/current/bin:%[3]s/cosmovisor/dyld_lib</string>
         <key>DAEMON_NAME</key>
         <string>initiad</string>
         <key>DAEMON_HOME</key>
@@ -41,10 +41,10 @@ const DarwinRunUpgradableCosmovisorTemplate Template = `<?xml version="1.0" enco
     </dict>
 
     <key>StandardOutPath</key>
-    <string>%[5]s/%[1]s.stdout.log</string>
+    <string>%[5]s/%[1]s%[7]s.stdout.log</string>
 
     <key>StandardErrorPath</key>
-    <string>%[5]s/%[1]s.stderr.log</string>
+    <string>%[5]s/%[1]s%[7]s.stderr.log</string>
 
     <key>HardResourceLimits</key>
     <dict>
@@ -55,13 +55,13 @@ const DarwinRunUpgradableCosmovisorTemplate Template = `<?xml version="1.0" enco
 </plist>
 `
 
-// DarwinRunNonUpgradableCosmovisorTemplate should inject the arguments as follows: [1:binaryName, 2:binaryPath, 3:appHome, 4:userHome, 5:weaveLogPath, 6:serviceName]
+// DarwinRunNonUpgradableCosmovisorTemplate should inject the arguments as follows: [1:binaryName, 2:binaryPath, 3:appHome, 4:userHome, 5:weaveLogPath, 6:serviceName, 7:profileSuffix]
 const DarwinRunNonUpgradableCosmovisorTemplate Template = `<?xml version="1.0" encoding="UTF-8"?>
 <!DOCTYPE plist PUBLIC "-//Apple//DTD PLIST 1.0//EN" "http://www.apple.com/DTDs/PropertyList-1.0.dtd">
 <plist version="1.0">
 <dict>
     <key>Label</key>
-    <string>com.%[1]s.daemon</string>
+    <string>com.%[1]s%[7]s.daemon</string>
 
     <key>ProgramArguments</key>
     <array>
@@ -82,7 +82,7 @@ const DarwinRunNonUpgradableCosmovisorTemplate Template = `<?xml version="1.0" e
 		<key>HOME</key>
         <string>%[4]s</string>
         <key>DYLD_LIBRARY_PATH</key>
-        <string>%[3]s/cosmovisor/dyld_lib</string>
+        <string>%[3]s/cosmovisor/current/bin:%[3]s/cosmovisor/dyld_lib</string>
         <key>DAEMON_NAME</key>
         <string>initiad</string>
         <key>DAEMON_HOME</key>
@@ -94,10 +94,10 @@ const DarwinRunNonUpgradableCosmovisorTemplate Template = `<?xml version="1.0" e
     </dict>
 
     <key>StandardOutPath</key>
-    <string>%[5]s/%[1]s.stdout.log</string>
+    <string>%[5]s/%[1]s%[7]s.stdout.log</string>
 
     <key>StandardErrorPath</key>
-    <string>%[5]s/%[1]s.stderr.log</string>
+    <string>%[5]s/%[1]s%[7]s.stderr.log</string>
 
     <key>HardResourceLimits</key>
     <dict>
@@ -108,13 +108,13 @@ const DarwinRunNonUpgradableCosmovisorTemplate Template = `<?xml version="1.0" e
 </plist>
 `
 
-// DarwinRunBinaryTemplate should inject the arguments as follows: [1:binaryName, 2:binaryPath, 3:appHome, 4:userHome, 5:weaveLogPath, 6:serviceName]
+// DarwinRunBinaryTemplate should inject the arguments as follows: [1:binaryName, 2:binaryPath, 3:appHome, 4:userHome, 5:weaveLogPath, 6:serviceName, 7:profileSuffix]
 const DarwinRunBinaryTemplate Template = `<?xml version="1.0" encoding="UTF-8"?>
 <!DOCTYPE plist PUBLIC "-//Apple//DTD PLIST 1.0//EN" "http://www.apple.com/DTDs/PropertyList-1.0.dtd">
 <plist version="1.0">
 <dict>
     <key>Label</key>
-    <string>com.%[1]s.daemon</string>
+    <string>com.%[1]s%[7]s.daemon</string>
 
     <key>ProgramArguments</key>
     <array>
@@ -139,10 +139,10 @@ const DarwinRunBinaryTemplate Template = `<?xml version="1.0" encoding="UTF-8"?>
     </dict>
 
     <key>StandardOutPath</key>
-    <string>%[5]s/%[1]s.stdout.log</string>
+    <string>%[5]s/%[1]s%[7]s.stdout.log</string>
 
     <key>StandardErrorPath</key>
-    <string>%[5]s/%[1]s.stderr.log</string>
+    <string>%[5]s/%[1]s%[7]s.stderr.log</string>
 
     <key>HardResourceLimits</key>
     <dict>
@@ -153,13 +153,13 @@ const DarwinRunBinaryTemplate Template = `<?xml version="1.0" encoding="UTF-8"?>
 </plist>
 `
 
-// DarwinOPinitBotTemplate should inject the arguments as follows: [binaryName, binaryPath, appHome, userHome, weaveLogPath, serviceName]
+// DarwinOPinitBotTemplate should inject the arguments as follows: [binaryName, binaryPath, appHome, userHome, weaveLogPath, serviceName, profileSuffix]
 const DarwinOPinitBotTemplate Template = `<?xml version="1.0" encoding="UTF-8"?>
 <!DOCTYPE plist PUBLIC "-//Apple//DTD PLIST 1.0//EN" "http://www.apple.com/DTDs/PropertyList-1.0.dtd">
 <plist version="1.0">
 <dict>
     <key>Label</key>
-    <string>com.%[1]s.%[6]s.daemon</string>
+    <string>com.%[1]s.%[6]s%[7]s.daemon</string>
 
     <key>ProgramArguments</key>
     <array>
@@ -183,10 +183,10 @@ const DarwinOPinitBotTemplate Template = `<?xml version="1.0" encoding="UTF-8"?>
     </dict>
 
     <key>StandardOutPath</key>
-    <string>%[5]s/%[1]s.%[6]s.stdout.log</string>
+    <string>%[5]s/%[1]s.%[6]s%[7]s.stdout.log</string>
 
     <key>StandardErrorPath</key>
-    <string>%[5]s/%[1]s.%[6]s.stderr.log</string>
+    <string>%[5]s/%[1]s.%[6]s%[7]s.stderr.log</string>
 
     <key>HardResourceLimits</key>
     <dict>
@@ -197,13 +197,13 @@ const DarwinOPinitBotTemplate Template = `<?xml version="1.0" encoding="UTF-8"?>
 </plist>
 `
 
-// DarwinRelayerTemplate should inject the arguments as follows: [binaryName, binaryPath, appHome, userHome, weaveLogPath, serviceName]
+// DarwinRelayerTemplate should inject the arguments as follows: [binaryName, binaryPath, appHome, userHome, weaveLogPath, serviceName, profileSuffix]
 const DarwinRelayerTemplate Template = `<?xml version="1.0" encoding="UTF-8"?>
 <!DOCTYPE plist PUBLIC "-//Apple//DTD PLIST 1.0//EN" "http://www.apple.com/DTDs/PropertyList-1.0.dtd">
 <plist version="1.0">
 <dict>
     <key>Label</key>
-    <string>com.%[1]s.daemon</string>
+    <string>com.%[1]s%[7]s.daemon</string>
 
     <key>ProgramArguments</key>
     <array>
@@ -225,10 +225,10 @@ const DarwinRelayerTemplate Template = `<?xml version="1.0" encoding="UTF-8"?>
     </dict>
 
     <key>StandardOutPath</key>
-    <string>%[5]s/%[1]s.stdout.log</string>
+    <string>%[5]s/%[1]s%[7]s.stdout.log</string>
 
     <key>StandardErrorPath</key>
-    <string>%[5]s/%[1]s.stderr.log</string>
+    <string>%[5]s/%[1]s%[7]s.stderr.log</string>
 
     <key>HardResourceLimits</key>
     <dict>
@@ -249,7 +249,7 @@ After=network.target
 Type=exec
 %[5]sExecStart=%[2]s/%[1]s run start
 KillSignal=SIGINT
-Environment="LD_LIBRARY_PATH=%[4]s/cosmovisor/dyld_lib"
+Environment="LD_LIBRARY_PATH=%[4]s/cosmovisor/current/bin:%[4]s/cosmovisor/dyld_lib"
 Environment="DAEMON_NAME=initiad"
 Environment="DAEMON_HOME=%[4]s"
 Environment="DAEMON_ALLOW_DOWNLOAD_BINARIES=true"
@@ -270,7 +270,7 @@ After=network.target
 Type=exec
 %[5]sExecStart=%[2]s/%[1]s run start
 KillSignal=SIGINT
-Environment="LD_LIBRARY_PATH=%[4]s/cosmovisor/dyld_lib"
+Environment="LD_LIBRARY_PATH=%[4]s/cosmovisor/current/bin:%[4]s/cosmovisor/dyld_lib"
 Environment="DAEMON_NAME=initiad"
 Environment="DAEMON_HOME=%[4]s"
 Environment="DAEMON_ALLOW_DOWNLOAD_BINARIES=false"
@@ -331,6 +331,66 @@ LimitNOFILE=65535
 WantedBy=multi-user.target
 `
 
+// DarwinValidatorMonitorTemplate should inject the arguments as follows: [binaryName, binaryPath, appHome, userHome, weaveLogPath, serviceName, profileSuffix, profileArgument]
+const DarwinValidatorMonitorTemplate Template = `<?xml version="1.0" encoding="UTF-8"?>
+<!DOCTYPE plist PUBLIC "-//Apple//DTD PLIST 1.0//EN" "http://www.apple.com/DTDs/PropertyList-1.0.dtd">
+<plist version="1.0">
+<dict>
+    <key>Label</key>
+    <string>com.%[1]s.%[6]s%[7]s.daemon</string>
+
+    <key>ProgramArguments</key>
+    <array>
+        <string>%[2]s/%[1]s</string>
+        <string>initia</string>
+        <string>validator</string>
+        <string>monitor</string>
+        <string>run</string>%[8]s
+        <string>--home=%[3]s</string>
+    </array>
+
+    <key>RunAtLoad</key>
+    <false/>
+
+    <key>KeepAlive</key>
+    <dict>
+        <key>SuccessfulExit</key>
+        <false/>
+    </dict>
+
+    <!-- Adding the environment variable -->
+    <key>EnvironmentVariables</key>
+    <dict>
+		<key>HOME</key>
+        <string>%[4]s</string>
+    </dict>
+
+    <key>StandardOutPath</key>
+    <string>%[5]s/%[1]s.%[6]s%[7]s.stdout.log</string>
+
+    <key>StandardErrorPath</key>
+    <string>%[5]s/%[1]s.%[6]s%[7]s.stderr.log</string>
+</dict>
+</plist>
+`
+
+// LinuxValidatorMonitorTemplate should inject the arguments as follows: [binaryName, binaryPath, serviceName, appHome, UserField, profileFlag]
+const LinuxValidatorMonitorTemplate Template = `
+[Unit]
+Description=%[1]s %[3]s
+After=network.target
+
+[Service]
+Type=exec
+%[5]sExecStart=%[2]s/%[1]s initia validator monitor run%[6]s --home %[4]s
+KillSignal=SIGINT
+Restart=on-failure
+RestartSec=30
+
+[Install]
+WantedBy=multi-user.target
+`
+
 var (
 	LinuxTemplateMap = map[CommandName]Template{
 		UpgradableInitia:    LinuxRunUpgradableCosmovisorTemplate,
@@ -339,6 +399,7 @@ var (
 		OPinitExecutor:      LinuxOPinitBotTemplate,
 		OPinitChallenger:    LinuxOPinitBotTemplate,
 		Relayer:             LinuxRelayerTemplate,
+		ValidatorMonitor:    LinuxValidatorMonitorTemplate,
 	}
 	DarwinTemplateMap = map[CommandName]Template{
 		UpgradableInitia:    DarwinRunUpgradableCosmovisorTemplate,
@@ -347,5 +408,6 @@ var (
 		OPinitExecutor:      DarwinOPinitBotTemplate,
 		OPinitChallenger:    DarwinOPinitBotTemplate,
 		Relayer:             DarwinRelayerTemplate,
+		ValidatorMonitor:    DarwinValidatorMonitorTemplate,
 	}
 )
@@ -3,6 +3,7 @@ package service
 import (
 	"fmt"
 
+	"github.com/initia-labs/weave/config"
 	"github.com/initia-labs/weave/cosmosutils"
 )
 
@@ -16,6 +17,7 @@ const (
 	OPinitChallenger    CommandName = "challenger"
 	Relayer             CommandName = "relayer"
 	Rollytics           CommandName = "rollytics"
+	ValidatorMonitor    CommandName = "validator_monitor"
 )
 
 // RapidRelayerVersionFallback is the fallback docker image tag used for the rapid relayer
@@ -43,6 +45,8 @@ func (cmd CommandName) GetPrettyName() (string, error) {
 		return "opinit", nil
 	case Relayer:
 		return "relayer", nil
+	case ValidatorMonitor:
+		return "validator monitor", nil
 	default:
 		return "", fmt.Errorf("unsupported command %s", cmd)
 	}
@@ -60,6 +64,8 @@ func (cmd CommandName) GetInitCommand() (string, error) {
 		return "relayer init", nil
 	case Rollytics:
 		return "", nil
+	case ValidatorMonitor:
+		return "initia validator monitor start", nil
 	default:
 		return "", fmt.Errorf("unsupported command %s", cmd)
 	}
@@ -73,11 +79,22 @@ func (cmd CommandName) GetBinaryName() (string, error) {
 		return "minitiad", nil
 	case OPinitExecutor, OPinitChallenger:
 		return "opinitd", nil
+	case ValidatorMonitor:
+		return "weave", nil
 	default:
 		return "", fmt.Errorf("unsupported command: %v", cmd)
 	}
 }
 
+// getProfileServiceSlug returns the service slug used by profile, which carries the profile name unless it is the default one
+func (cmd CommandName) getProfileServiceSlug(profile string) (string, error) {
+	slug, err := cmd.GetServiceSlug()
+	if err != nil {
+		return "", err
+	}
+	return slug + config.ProfileServiceSuffix(profile), nil
+}
+
 func (cmd CommandName) GetServiceSlug() (string, error) {
 	switch cmd {
 	case UpgradableInitia:
@@ -90,6 +107,8 @@ func (cmd CommandName) GetServiceSlug() (string, error) {
 		return "opinitd.executor", nil
 	case OPinitChallenger:
 		return "opinitd.challenger", nil
+	case ValidatorMonitor:
+		return "weave.validator_monitor", nil
 	default:
 		return "", fmt.Errorf("unsupported command: %v", cmd)
 	}
@@ -10,9 +10,10 @@ import (
 )
 
 var (
-	FooterLine         = BoldText("│ ", Gray)
-	FooterCommands     = []string{"Enter", "Ctrl+c", "q", "Ctrl+z", "Ctrl+t", "Space", "arrow-keys"}
-	HiddenMnemonicText = Text("*Mnemonic has been entered and is now hidden for security purposes.*", Ivory)
+	FooterLine           = BoldText("│ ", Gray)
+	FooterCommands       = []string{"Enter", "Ctrl+c", "q", "Ctrl+z", "Ctrl+t", "Space", "arrow-keys"}
+	HiddenMnemonicText   = Text("*Mnemonic has been entered and is now hidden for security purposes.*", Ivory)
+	HiddenPassphraseText = Text("*Passphrase has been entered and is now hidden for security purposes.*", Ivory)
 )
 
 func SetColor(text string, color HexColor) string {
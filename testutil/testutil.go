@@ -205,6 +205,7 @@ func SetupGasStation(t *testing.T) tea.Model {
 	steps := Steps{
 		TypeText(GasStationMnemonic), // type in the mnemonic
 		PressEnter,                   // press enter to confirm
+		PressEnter,                   // press enter to use the default derivation
 	}
 
 	return RunProgramWithSteps(t, firstModel, steps)
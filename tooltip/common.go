@@ -4,4 +4,8 @@ import "github.com/initia-labs/weave/ui"
 
 var (
 	MonikerTooltip = ui.NewTooltip("Moniker", "A unique identifier among nodes in a network.", "", []string{}, []string{}, []string{})
+
+	KeyDerivationTooltip   = ui.NewTooltip("Key derivation", "By default, keys are derived from the mnemonic at the standard BIP44 path of their coin type, without a BIP39 passphrase. Only customize this if the mnemonic was set up with a passphrase or a non-standard HD path, for example by a hardware wallet.", "", []string{}, []string{}, []string{})
+	BIP39PassphraseTooltip = ui.NewTooltip("BIP39 passphrase", "An optional passphrase combined with the mnemonic to derive the seed, sometimes called the 25th word. A different passphrase derives an entirely different account.", "", []string{}, []string{}, []string{})
+	HDPathTooltip          = ui.NewTooltip("HD path", "The BIP44 derivation path of the key, such as m/44'/118'/0'/0/0. Indices ending with ' are hardened.", "", []string{}, []string{}, []string{})
 )
@@ -25,6 +25,13 @@ var (
 	L1StateSyncTooltip    = ui.NewTooltip("State Sync", "Retrieves the latest blockchain state from peers without downloading the entire history. It's faster than syncing from genesis but may miss some historical data.\n\nThis is necessary to participate in an existing network.", "", []string{}, []string{}, []string{})
 	L1NoSyncTooltip       = ui.NewTooltip("No Sync", "The node will not download data from any sources to replace the existing (if any). The node will start syncing from its current state, potentially genesis state if this is the first run.\n\nThis is best for local development / testing.", "", []string{}, []string{}, []string{})
 
+	// Node Role Tooltips
+	L1PublicRPCRoleTooltip = ui.NewTooltip("Public RPC node", "Serves RPC, REST and gRPC queries to everyone. Every endpoint listens on all interfaces, transactions are indexed and the mempool and inbound peer limits are raised.", "", []string{}, []string{}, []string{})
+	L1ArchiveRoleTooltip   = ui.NewTooltip("Archive node", "Keeps every historical state and indexes transactions so that queries at any height can be answered. This mode consumes the highest disk usage.", "", []string{}, []string{"highest disk usage"}, []string{})
+	L1SentryRoleTooltip    = ui.NewTooltip("Sentry node", "Shields a validator from the public network. Query endpoints stay closed, old states are pruned and private addresses are accepted so that the validator behind it can connect.", "", []string{}, []string{}, []string{})
+	L1ValidatorRoleTooltip = ui.NewTooltip("Private validator", "Signs blocks behind sentry nodes. Peer exchange is disabled, so the node only connects to its persistent peers, which should be your sentry nodes.", "", []string{}, []string{"persistent peers"}, []string{})
+	L1CustomRoleTooltip    = ui.NewTooltip("Custom", "Choose the enabled features and the pruning strategy yourself and keep the rest of the initiad defaults.", "", []string{}, []string{}, []string{})
+
 	// Cosmovisor Tooltips
 	L1CosmovisorAutoUpgradeEnableTooltip  = ui.NewTooltip("Enable", "Enable automatic downloading of new binaries and upgrades via Cosmovisor. \nSee more: https://docs.initia.xyz/run-initia-node/automating-software-updates-with-cosmovisor", "", []string{}, []string{}, []string{})
 	L1CosmovisorAutoUpgradeDisableTooltip = ui.NewTooltip("Disable", "Disable automatic downloading of new binaries and upgrades via Cosmovisor. You will need to manually upgrade the binaries and restart the node to apply the upgrades.", "", []string{}, []string{}, []string{})
@@ -4,6 +4,9 @@ import (
 	"github.com/initia-labs/weave/ui"
 )
 
+// SystemKeyDerivationWarning explains that imported system keys are always derived at the default path
+const SystemKeyDerivationWarning = "Only keys at the default derivation path of the mnemonic can be imported. Keys set up with a BIP39 passphrase or a custom HD path are not supported as system keys."
+
 var (
 	RollupChainIdTooltip      = ui.NewTooltip("Rollup chain ID", ChainIDDescription("rollup"), "", []string{}, []string{}, []string{})
 	RollupRPCEndpointTooltip  = ui.NewTooltip("Rollup RPC endpoint", RPCEndpointDescription("rollup"), "", []string{}, []string{}, []string{})
@@ -18,11 +21,11 @@ var (
 	EnableOracleTooltip                     = ui.NewTooltip("Oracle", "Enabling the Oracle feature allows the rollup and contracts deployed on the rollup to access asset price data relayed from the Initia L1.", "", []string{}, []string{}, []string{})
 
 	// System Key Tooltips
-	SystemKeyOperatorMnemonicTooltip        = ui.NewTooltip("Rollup Operator", "The operator, also known as Sequencer, is responsible for creating blocks, ordering and including transactions within each block, and maintaining the operation of the rollup network.", "", []string{}, []string{}, []string{})
-	SystemKeyBridgeExecutorMnemonicTooltip  = ui.NewTooltip("Bridge Executor", "The executor monitors the L1 and rollup transactions, facilitates token bridging and withdrawals between the rollup and Initia L1 chain, and also relays oracle price feed to rollup.", "", []string{}, []string{}, []string{})
-	SystemKeyOutputSubmitterMnemonicTooltip = ui.NewTooltip("Output Submitter", "The submitter submits rollup output roots to L1 for verification and potential challenges. If the submitted output remains unchallenged beyond the output finalization period, it is considered finalized and immutable.", "", []string{}, []string{}, []string{})
-	SystemKeyBatchSubmitterMnemonicTooltip  = ui.NewTooltip("Batch Submitter", "The batch submitter submits block and transactions data in batches into a chain to ensure Data Availability. Currently, submissions can be made to Initia L1 or Celestia.", "", []string{}, []string{}, []string{})
-	SystemKeyChallengerMnemonicTooltip      = ui.NewTooltip("Challenger", "The challenger prevents misconduct and invalid rollup state submissions by monitoring for output proposals and challenging any that are invalid.", "", []string{}, []string{}, []string{})
+	SystemKeyOperatorMnemonicTooltip        = ui.NewTooltip("Rollup Operator", "The operator, also known as Sequencer, is responsible for creating blocks, ordering and including transactions within each block, and maintaining the operation of the rollup network.", SystemKeyDerivationWarning, []string{}, []string{}, []string{})
+	SystemKeyBridgeExecutorMnemonicTooltip  = ui.NewTooltip("Bridge Executor", "The executor monitors the L1 and rollup transactions, facilitates token bridging and withdrawals between the rollup and Initia L1 chain, and also relays oracle price feed to rollup.", SystemKeyDerivationWarning, []string{}, []string{}, []string{})
+	SystemKeyOutputSubmitterMnemonicTooltip = ui.NewTooltip("Output Submitter", "The submitter submits rollup output roots to L1 for verification and potential challenges. If the submitted output remains unchallenged beyond the output finalization period, it is considered finalized and immutable.", SystemKeyDerivationWarning, []string{}, []string{}, []string{})
+	SystemKeyBatchSubmitterMnemonicTooltip  = ui.NewTooltip("Batch Submitter", "The batch submitter submits block and transactions data in batches into a chain to ensure Data Availability. Currently, submissions can be made to Initia L1 or Celestia.", SystemKeyDerivationWarning, []string{}, []string{}, []string{})
+	SystemKeyChallengerMnemonicTooltip      = ui.NewTooltip("Challenger", "The challenger prevents misconduct and invalid rollup state submissions by monitoring for output proposals and challenging any that are invalid.", SystemKeyDerivationWarning, []string{}, []string{}, []string{})
 
 	// System Accounts funding
 	SystemAccountsFundingPresetTooltip = ui.NewTooltip(
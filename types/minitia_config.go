@@ -1,5 +1,11 @@
 package types
 
+import (
+	"fmt"
+
+	"github.com/initia-labs/weave/crypto"
+)
+
 type MinitiaConfig struct {
 	L1Config        *L1Config        `json:"l1_config,omitempty"`
 	L2Config        *L2Config        `json:"l2_config,omitempty"`
@@ -34,6 +40,10 @@ type SystemAccount struct {
 	L2Address string `json:"l2_address,omitempty"`
 	DAAddress string `json:"da_address,omitempty"`
 	Mnemonic  string `json:"mnemonic,omitempty"`
+
+	// Derivation was written by older versions of weave for keys imported with a BIP39 passphrase or a custom HD path.
+	// It is only read so that such keys are rejected, see SystemKeys.ValidateDerivation.
+	Derivation *crypto.DerivationOptions `json:"derivation,omitempty"`
 }
 
 func NewSystemAccount(mnemonic, addresses string) *SystemAccount {
@@ -70,6 +80,31 @@ type SystemKeys struct {
 	Challenger      *SystemAccount `json:"challenger,omitempty"`
 }
 
+// ValidateDerivation rejects system keys derived with a BIP39 passphrase or a custom HD path. minitiad, the OPinit bots
+// and the relayer only derive keys from a mnemonic at the default path, so such keys would end up as other accounts.
+func (s *SystemKeys) ValidateDerivation() error {
+	if s == nil {
+		return nil
+	}
+	accounts := []struct {
+		name    string
+		account *SystemAccount
+	}{
+		{"validator", s.Validator},
+		{"bridge executor", s.BridgeExecutor},
+		{"output submitter", s.OutputSubmitter},
+		{"batch submitter", s.BatchSubmitter},
+		{"challenger", s.Challenger},
+	}
+	for _, a := range accounts {
+		if a.account != nil && a.account.Derivation != nil && !a.account.Derivation.IsDefault() {
+			return fmt.Errorf("the %s system key uses a custom BIP39 passphrase or HD path, which rollup system keys do not support: "+
+				"use a mnemonic whose key is at the default derivation path", a.name)
+		}
+	}
+	return nil
+}
+
 // Artifacts define the structure for the JSON data
 type Artifacts struct {
 	BridgeID                string `json:"BRIDGE_ID"`
@@ -140,10 +175,15 @@ func cloneSystemAccount(acc *SystemAccount) *SystemAccount {
 	if acc == nil {
 		return nil
 	}
-	return &SystemAccount{
+	clone := &SystemAccount{
 		L1Address: acc.L1Address,
 		L2Address: acc.L2Address,
 		DAAddress: acc.DAAddress,
 		Mnemonic:  acc.Mnemonic,
 	}
+	if acc.Derivation != nil {
+		derivation := *acc.Derivation
+		clone.Derivation = &derivation
+	}
+	return clone
 }
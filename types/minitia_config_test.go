@@ -4,6 +4,8 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+
+	"github.com/initia-labs/weave/crypto"
 )
 
 // Test the creation of a new SystemAccount using NewSystemAccount function
@@ -42,3 +44,21 @@ func TestGenesisAccounts(t *testing.T) {
 	assert.Equal(t, "address2", accounts[1].Address, "Expected second account address to be 'address2'")
 	assert.Equal(t, "200coins", accounts[1].Coins, "Expected second account coins to be '200coins'")
 }
+
+func TestSystemKeysValidateDerivation(t *testing.T) {
+	keys := &SystemKeys{
+		Validator:  NewSystemAccount("mnemonic", "init1validator"),
+		Challenger: NewSystemAccount("mnemonic", "init1challenger"),
+	}
+	assert.NoError(t, keys.ValidateDerivation())
+
+	keys.Challenger.Derivation = &crypto.DerivationOptions{}
+	assert.NoError(t, keys.ValidateDerivation())
+
+	keys.Challenger.Derivation = &crypto.DerivationOptions{HDPath: crypto.HDPath(118, 0, 1)}
+	err := keys.ValidateDerivation()
+	assert.ErrorContains(t, err, "challenger system key")
+
+	var missing *SystemKeys
+	assert.NoError(t, missing.ValidateDerivation())
+}
@@ -0,0 +1,285 @@
+package types
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+type NodeRole string
+
+const (
+	NodeRolePublicRPC NodeRole = "rpc"
+	NodeRoleArchive   NodeRole = "archive"
+	NodeRoleSentry    NodeRole = "sentry"
+	NodeRoleValidator NodeRole = "validator"
+)
+
+var NodeRoles = []NodeRole{NodeRolePublicRPC, NodeRoleArchive, NodeRoleSentry, NodeRoleValidator}
+
+func (r NodeRole) DisplayName() string {
+	switch r {
+	case NodeRolePublicRPC:
+		return "Public RPC node"
+	case NodeRoleArchive:
+		return "Archive node"
+	case NodeRoleSentry:
+		return "Sentry node"
+	case NodeRoleValidator:
+		return "Private validator"
+	}
+	return string(r)
+}
+
+const (
+	ConfigToml = "config.toml"
+	AppToml    = "app.toml"
+
+	localRPCListenAddress  = "tcp://127.0.0.1:26657"
+	publicRPCListenAddress = "tcp://0.0.0.0:26657"
+	localAPIAddress        = "tcp://localhost:1317"
+	publicAPIAddress       = "tcp://0.0.0.0:1317"
+	localGRPCAddress       = "localhost:9090"
+	publicGRPCAddress      = "0.0.0.0:9090"
+)
+
+// NodePreset is a bundle of config.toml and app.toml settings that suits a node role
+type NodePreset struct {
+	Role             NodeRole `json:"role"`
+	Pruning          string   `json:"pruning"`
+	TxIndexer        string   `json:"tx_indexer"`
+	RPCListenAddress string   `json:"rpc_laddr"`
+	EnableAPI        bool     `json:"enable_api"`
+	APIAddress       string   `json:"api_address"`
+	EnableGRPC       bool     `json:"enable_grpc"`
+	GRPCAddress      string   `json:"grpc_address"`
+	MempoolSize      int      `json:"mempool_size"`
+	MaxInboundPeers  int      `json:"max_num_inbound_peers"`
+	MaxOutboundPeers int      `json:"max_num_outbound_peers"`
+	Pex              bool     `json:"pex"`
+	AddrBookStrict   bool     `json:"addr_book_strict"`
+}
+
+// NodePresetSetting is a single value a NodePreset writes, keyed the way config.UpdateTomlValue expects
+type NodePresetSetting struct {
+	File  string
+	Key   string
+	Value string
+}
+
+// NewNodePreset returns the default settings of role
+func NewNodePreset(role NodeRole) (*NodePreset, error) {
+	switch role {
+	case NodeRolePublicRPC:
+		// Serves queries to everyone, so every endpoint is exposed and transactions are indexed
+		return &NodePreset{
+			Role:             role,
+			Pruning:          "default",
+			TxIndexer:        "kv",
+			RPCListenAddress: publicRPCListenAddress,
+			EnableAPI:        true,
+			APIAddress:       publicAPIAddress,
+			EnableGRPC:       true,
+			GRPCAddress:      publicGRPCAddress,
+			MempoolSize:      10000,
+			MaxInboundPeers:  100,
+			MaxOutboundPeers: 20,
+			Pex:              true,
+			AddrBookStrict:   true,
+		}, nil
+	case NodeRoleArchive:
+		// Keeps every state so that historical queries can be answered
+		return &NodePreset{
+			Role:             role,
+			Pruning:          "nothing",
+			TxIndexer:        "kv",
+			RPCListenAddress: publicRPCListenAddress,
+			EnableAPI:        true,
+			APIAddress:       publicAPIAddress,
+			EnableGRPC:       true,
+			GRPCAddress:      publicGRPCAddress,
+			MempoolSize:      5000,
+			MaxInboundPeers:  40,
+			MaxOutboundPeers: 10,
+			Pex:              true,
+			AddrBookStrict:   true,
+		}, nil
+	case NodeRoleSentry:
+		// Relays for a validator on a private network, so private addresses have to be accepted
+		return &NodePreset{
+			Role:             role,
+			Pruning:          "everything",
+			TxIndexer:        "null",
+			RPCListenAddress: localRPCListenAddress,
+			EnableAPI:        false,
+			APIAddress:       localAPIAddress,
+			EnableGRPC:       false,
+			GRPCAddress:      localGRPCAddress,
+			MempoolSize:      5000,
+			MaxInboundPeers:  100,
+			MaxOutboundPeers: 30,
+			Pex:              true,
+			AddrBookStrict:   false,
+		}, nil
+	case NodeRoleValidator:
+		// Only talks to its persistent peers, which should be its sentry nodes
+		return &NodePreset{
+			Role:             role,
+			Pruning:          "default",
+			TxIndexer:        "null",
+			RPCListenAddress: localRPCListenAddress,
+			EnableAPI:        false,
+			APIAddress:       localAPIAddress,
+			EnableGRPC:       false,
+			GRPCAddress:      localGRPCAddress,
+			MempoolSize:      5000,
+			MaxInboundPeers:  10,
+			MaxOutboundPeers: 10,
+			Pex:              false,
+			AddrBookStrict:   false,
+		}, nil
+	}
+	return nil, fmt.Errorf("unknown node role %q: expected one of %s", role, JoinNodeRoles())
+}
+
+// LoadNodePreset parses a preset file. The defaults of its role are used for every setting the file leaves out.
+func LoadNodePreset(data []byte) (*NodePreset, error) {
+	var header struct {
+		Role NodeRole `json:"role"`
+	}
+	if err := json.Unmarshal(data, &header); err != nil {
+		return nil, fmt.Errorf("failed to parse node preset: %w", err)
+	}
+	if header.Role == "" {
+		return nil, fmt.Errorf("node preset is missing a role: expected one of %s", JoinNodeRoles())
+	}
+
+	preset, err := NewNodePreset(header.Role)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, preset); err != nil {
+		return nil, fmt.Errorf("failed to parse node preset: %w", err)
+	}
+	if err := preset.Validate(); err != nil {
+		return nil, err
+	}
+	return preset, nil
+}
+
+// Settings lists the values the preset writes, in the order they are shown to the user
+func (p *NodePreset) Settings() []NodePresetSetting {
+	return []NodePresetSetting{
+		{File: AppToml, Key: "pruning", Value: p.Pruning},
+		{File: ConfigToml, Key: "tx_index.indexer", Value: p.TxIndexer},
+		{File: ConfigToml, Key: "rpc.laddr", Value: p.RPCListenAddress},
+		{File: AppToml, Key: "api.enable", Value: strconv.FormatBool(p.EnableAPI)},
+		{File: AppToml, Key: "api.address", Value: p.APIAddress},
+		{File: AppToml, Key: "grpc.enable", Value: strconv.FormatBool(p.EnableGRPC)},
+		{File: AppToml, Key: "grpc.address", Value: p.GRPCAddress},
+		{File: ConfigToml, Key: "mempool.size", Value: strconv.Itoa(p.MempoolSize)},
+		{File: ConfigToml, Key: "p2p.max_num_inbound_peers", Value: strconv.Itoa(p.MaxInboundPeers)},
+		{File: ConfigToml, Key: "p2p.max_num_outbound_peers", Value: strconv.Itoa(p.MaxOutboundPeers)},
+		{File: ConfigToml, Key: "p2p.pex", Value: strconv.FormatBool(p.Pex)},
+		{File: ConfigToml, Key: "p2p.addr_book_strict", Value: strconv.FormatBool(p.AddrBookStrict)},
+	}
+}
+
+// Set changes the setting with the given key, as listed by Settings
+func (p *NodePreset) Set(key, value string) error {
+	value = strings.TrimSpace(value)
+	var err error
+	switch key {
+	case "pruning":
+		p.Pruning = value
+	case "tx_index.indexer":
+		p.TxIndexer = value
+	case "rpc.laddr":
+		p.RPCListenAddress = value
+	case "api.enable":
+		err = setBool(&p.EnableAPI, key, value)
+	case "api.address":
+		p.APIAddress = value
+	case "grpc.enable":
+		err = setBool(&p.EnableGRPC, key, value)
+	case "grpc.address":
+		p.GRPCAddress = value
+	case "mempool.size":
+		err = setCount(&p.MempoolSize, key, value)
+	case "p2p.max_num_inbound_peers":
+		err = setCount(&p.MaxInboundPeers, key, value)
+	case "p2p.max_num_outbound_peers":
+		err = setCount(&p.MaxOutboundPeers, key, value)
+	case "p2p.pex":
+		err = setBool(&p.Pex, key, value)
+	case "p2p.addr_book_strict":
+		err = setBool(&p.AddrBookStrict, key, value)
+	default:
+		return fmt.Errorf("unknown setting %s", key)
+	}
+	if err != nil {
+		return err
+	}
+	return p.Validate()
+}
+
+// Validate checks the values that initiad would otherwise reject on start
+func (p *NodePreset) Validate() error {
+	switch p.Pruning {
+	case "default", "nothing", "everything":
+	default:
+		return fmt.Errorf("invalid pruning %q: expected default, nothing or everything", p.Pruning)
+	}
+	switch p.TxIndexer {
+	case "kv", "null":
+	default:
+		return fmt.Errorf("invalid tx_index.indexer %q: expected kv or null", p.TxIndexer)
+	}
+	if p.RPCListenAddress == "" || p.APIAddress == "" || p.GRPCAddress == "" {
+		return fmt.Errorf("rpc.laddr, api.address and grpc.address cannot be empty")
+	}
+	if p.MempoolSize <= 0 {
+		return fmt.Errorf("mempool.size must be positive")
+	}
+	if p.MaxInboundPeers < 0 || p.MaxOutboundPeers < 0 {
+		return fmt.Errorf("peer limits cannot be negative")
+	}
+	return nil
+}
+
+// Clone returns a copy of the preset, or nil if the receiver is nil
+func (p *NodePreset) Clone() *NodePreset {
+	if p == nil {
+		return nil
+	}
+	clone := *p
+	return &clone
+}
+
+func setBool(field *bool, key, value string) error {
+	parsed, err := strconv.ParseBool(value)
+	if err != nil {
+		return fmt.Errorf("invalid %s %q: expected true or false", key, value)
+	}
+	*field = parsed
+	return nil
+}
+
+func setCount(field *int, key, value string) error {
+	parsed, err := strconv.Atoi(value)
+	if err != nil || parsed < 0 {
+		return fmt.Errorf("invalid %s %q: expected a non-negative number", key, value)
+	}
+	*field = parsed
+	return nil
+}
+
+// JoinNodeRoles returns the known node roles as a comma-separated list
+func JoinNodeRoles() string {
+	roles := make([]string, len(NodeRoles))
+	for i, role := range NodeRoles {
+		roles[i] = string(role)
+	}
+	return strings.Join(roles, ", ")
+}
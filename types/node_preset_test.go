@@ -0,0 +1,64 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewNodePreset(t *testing.T) {
+	for _, role := range NodeRoles {
+		preset, err := NewNodePreset(role)
+		assert.NoError(t, err)
+		assert.Equal(t, role, preset.Role)
+		assert.NoError(t, preset.Validate(), "the %s preset should be valid", role)
+	}
+
+	_, err := NewNodePreset("miner")
+	assert.Error(t, err)
+}
+
+func TestLoadNodePreset(t *testing.T) {
+	preset, err := LoadNodePreset([]byte(`{"role": "validator", "mempool_size": 8000, "pex": true}`))
+	assert.NoError(t, err)
+	assert.Equal(t, NodeRoleValidator, preset.Role)
+	assert.Equal(t, 8000, preset.MempoolSize)
+	assert.True(t, preset.Pex)
+	// Settings left out of the file keep the defaults of the role
+	assert.Equal(t, "null", preset.TxIndexer)
+	assert.False(t, preset.AddrBookStrict)
+
+	_, err = LoadNodePreset([]byte(`{"mempool_size": 8000}`))
+	assert.Error(t, err)
+
+	_, err = LoadNodePreset([]byte(`{"role": "archive", "pruning": "custom"}`))
+	assert.Error(t, err)
+}
+
+func TestNodePresetSet(t *testing.T) {
+	preset, _ := NewNodePreset(NodeRolePublicRPC)
+
+	for _, setting := range preset.Settings() {
+		assert.NoError(t, preset.Set(setting.Key, setting.Value), "setting %s to its own value should succeed", setting.Key)
+	}
+
+	assert.NoError(t, preset.Set("p2p.max_num_inbound_peers", " 50 "))
+	assert.Equal(t, 50, preset.MaxInboundPeers)
+	assert.NoError(t, preset.Set("api.enable", "false"))
+	assert.False(t, preset.EnableAPI)
+
+	assert.Error(t, preset.Set("mempool.size", "0"))
+	assert.Error(t, preset.Set("p2p.pex", "maybe"))
+	assert.Error(t, preset.Set("tx_index.indexer", "psql"))
+	assert.Error(t, preset.Set("consensus.timeout_commit", "1s"))
+}
+
+func TestNodePresetClone(t *testing.T) {
+	var nilPreset *NodePreset
+	assert.Nil(t, nilPreset.Clone())
+
+	preset, _ := NewNodePreset(NodeRoleSentry)
+	clone := preset.Clone()
+	assert.NoError(t, clone.Set("mempool.size", "1"))
+	assert.Equal(t, 5000, preset.MempoolSize)
+}
@@ -2,6 +2,8 @@ package ui
 
 import (
 	"fmt"
+	"io"
+	"sync/atomic"
 	"time"
 
 	"github.com/charmbracelet/bubbles/progress"
@@ -22,6 +24,10 @@ type Downloader struct {
 	done       bool
 	err        error
 	validateFn func(string) error
+	options    client.DownloadOptions
+	resumed    int64
+	consumeFn  func(io.Reader) error
+	finalizeFn func(error) error
 }
 
 func NewDownloader(text, url, dest string, validateFn func(string) error) *Downloader {
@@ -36,6 +42,28 @@ func NewDownloader(text, url, dest string, validateFn func(string) error) *Downl
 	}
 }
 
+// NewDownloaderWithOptions creates a downloader that resumes, parallelizes, throttles or verifies the download as configured
+func NewDownloaderWithOptions(text, url, dest string, validateFn func(string) error, options client.DownloadOptions) *Downloader {
+	downloader := NewDownloader(text, url, dest, validateFn)
+	downloader.options = options
+	return downloader
+}
+
+// NewStreamingDownloader creates a downloader that hands the download to consumeFn as it arrives instead of saving it
+func NewStreamingDownloader(text, url string, consumeFn func(io.Reader) error, options client.DownloadOptions) *Downloader {
+	downloader := NewDownloaderWithOptions(text, url, "", nil, options)
+	downloader.consumeFn = consumeFn
+	return downloader
+}
+
+// WithFinalizer sets a function called once a streaming download ends, with the error of the download or of its
+// verification. The consumer sees the data before it is verified, so finalizeFn commits what it staged when the error
+// is nil and discards it otherwise.
+func (m *Downloader) WithFinalizer(finalizeFn func(error) error) *Downloader {
+	m.finalizeFn = finalizeFn
+	return m
+}
+
 func (m *Downloader) GetError() error {
 	return m.err
 }
@@ -43,7 +71,20 @@ func (m *Downloader) GetError() error {
 func (m *Downloader) startDownload() tea.Cmd {
 	return func() tea.Msg {
 		httpClient := client.NewHTTPClient()
-		if err := httpClient.DownloadAndValidateFile(m.url, m.dest, &m.current, &m.total, m.validateFn); err != nil {
+		options := m.options
+		options.Resumed = &m.resumed
+		var err error
+		if m.consumeFn != nil {
+			err = httpClient.StreamFileWithOptions(m.url, &m.current, &m.total, options, m.consumeFn)
+			if m.finalizeFn != nil {
+				if finalizeErr := m.finalizeFn(err); err == nil {
+					err = finalizeErr
+				}
+			}
+		} else {
+			err = httpClient.DownloadAndValidateFileWithOptions(m.url, m.dest, &m.current, &m.total, m.validateFn, options)
+		}
+		if err != nil {
 			m.SetError(err)
 			return nil
 		}
@@ -66,6 +107,7 @@ func (m *Downloader) Update(msg tea.Msg) (*Downloader, tea.Cmd) {
 	case tea.KeyMsg:
 		if msg.String() == "q" || msg.String() == "ctrl+c" {
 			analytics.TrackEvent(analytics.Interrupted, analytics.NewEmptyEvent())
+			client.CancelRequests()
 			return m, tea.Quit
 		}
 	}
@@ -83,8 +125,13 @@ func (m *Downloader) View() string {
 	if m.done {
 		return fmt.Sprintf("%sDownload Complete!\nTotal Size: %d bytes\n", styles.CorrectMark, m.total)
 	}
-	percentage := float64(m.current) / float64(m.total)
-	return fmt.Sprintf("\n %s: %s / %s \n %s", m.text, ByteCountSI(m.current), ByteCountSI(m.total), m.progress.ViewAs(percentage))
+	current, total, resumed := atomic.LoadInt64(&m.current), atomic.LoadInt64(&m.total), atomic.LoadInt64(&m.resumed)
+	percentage := float64(current) / float64(total)
+	var resumedText string
+	if resumed > 0 {
+		resumedText = fmt.Sprintf(" (resumed from %s)", ByteCountSI(resumed))
+	}
+	return fmt.Sprintf("\n %s: %s / %s%s \n %s", m.text, ByteCountSI(current), ByteCountSI(total), resumedText, m.progress.ViewAs(percentage))
 }
 
 func (m *Downloader) GetCompletion() bool {
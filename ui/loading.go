@@ -13,6 +13,7 @@ import (
 	"github.com/charmbracelet/lipgloss"
 
 	"github.com/initia-labs/weave/analytics"
+	"github.com/initia-labs/weave/client"
 	"github.com/initia-labs/weave/styles"
 )
 
@@ -93,6 +94,8 @@ func (m Loading) Update(msg tea.Msg) (Loading, tea.Cmd) {
 		switch msg.String() {
 		case "ctrl+c":
 			analytics.TrackEvent(analytics.Interrupted, analytics.NewEmptyEvent())
+			// Stop the work running in the background along with the screen
+			client.CancelRequests()
 			m.quitting = true
 			return m, tea.Quit
 		default: